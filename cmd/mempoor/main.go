@@ -15,6 +15,10 @@ func main() {
 	subcommands.Register(&cmd.NodeArgs{}, "")
 	subcommands.Register(&cmd.TxArgs{}, "")
 	subcommands.Register(&cmd.BlockArgs{}, "")
+	subcommands.Register(&cmd.AccountArgs{}, "")
+	subcommands.Register(&cmd.StatusArgs{}, "")
+	subcommands.Register(&cmd.PeerArgs{}, "")
+	subcommands.Register(&cmd.LightArgs{}, "")
 
 	flag.Parse()
 	os.Exit(int(subcommands.Execute(context.Background())))
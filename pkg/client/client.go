@@ -0,0 +1,190 @@
+// Package client is a typed Go client for a mempoor node's RPC API.
+//
+// The methods and types in client_gen.go are generated from the node's
+// OpenAPI document (GET /openapi.json); run go generate here after
+// changing an RPC method's params or result.
+package client
+
+//go:generate go run ./internal/gen -out client_gen.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Client calls the RPC API of one node.
+type Client struct {
+	// URL is the node's base URL, such as http://localhost:8080.
+	URL string
+
+	// Token is sent as "Authorization: Bearer <token>" when set; the
+	// node checks it for admin methods.
+	Token string
+
+	// HTTPClient makes the requests; nil = http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// New returns a client for the node whose RPC server listens on addr,
+// a host:port or a URL.
+func New(addr string) *Client {
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	return &Client{URL: strings.TrimSuffix(addr, "/")}
+}
+
+// Error is a call the node failed or refused.
+//
+// Code is the HTTP status of a failed call: a bad request, a missing
+// admin token, and so on. A refusal, such as tx.update of an unknown
+// tx, is answered with 200 and a result holding only an error; Code is
+// 200 then.
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return "RPC error: " + e.Message
+}
+
+// Request is one call of a batch.
+type Request struct {
+	Method string `json:"method"`
+	Params any    `json:"params"`
+}
+
+// BatchResult is the answer to one call of a batch, as the node sends
+// it: Code is the call's HTTP status.
+type BatchResult struct {
+	Code   int             `json:"code"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// response is the node's answer to one call.
+type response struct {
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// Call calls method with params and decodes its result into out, unless
+// out is nil. The generated methods use it; it also reaches methods this
+// client does not know yet.
+func (c *Client) Call(ctx context.Context, method string, params, out any) error {
+	resp, err := c.post(ctx, Request{Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var body response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return &Error{Code: resp.StatusCode, Message: resp.Status}
+		}
+		return fmt.Errorf("failed to decode RPC response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &Error{Code: resp.StatusCode, Message: body.Error}
+	}
+	if msg, ok := refusal(body.Result); ok {
+		return &Error{Code: http.StatusOK, Message: msg}
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(body.Result, out); err != nil {
+			return fmt.Errorf("failed to decode RPC result: %w", err)
+		}
+	}
+	return nil
+}
+
+// refusal reports whether result is an object holding only an error,
+// and returns the error.
+func refusal(result json.RawMessage) (string, bool) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(result, &fields) != nil || len(fields) != 1 {
+		return "", false
+	}
+	var msg string
+	if json.Unmarshal(fields["error"], &msg) != nil || msg == "" {
+		return "", false
+	}
+	return msg, true
+}
+
+// Stream calls a method that answers with a stream, such as
+// block.export, and returns the body for the caller to read and close.
+func (c *Client) Stream(ctx context.Context, method string, params any) (io.ReadCloser, error) {
+	resp, err := c.post(ctx, Request{Method: method, Params: params})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var body response
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return nil, &Error{Code: resp.StatusCode, Message: resp.Status}
+		}
+		return nil, &Error{Code: resp.StatusCode, Message: body.Error}
+	}
+	return resp.Body, nil
+}
+
+// Batch sends reqs in one HTTP request and returns their answers, in
+// order; see "Batch semantics" in pkg/mempoor. At most
+// mempoor.MaxRPCBatch calls fit in one batch.
+func (c *Client) Batch(ctx context.Context, reqs []Request) ([]BatchResult, error) {
+	resp, err := c.post(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body response
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return nil, &Error{Code: resp.StatusCode, Message: resp.Status}
+		}
+		return nil, &Error{Code: resp.StatusCode, Message: body.Error}
+	}
+
+	var results []BatchResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode RPC batch: %w", err)
+	}
+	return results, nil
+}
+
+// post sends body to the node's /rpc endpoint.
+func (c *Client) post(ctx context.Context, body any) (*http.Response, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode RPC request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL+"/rpc", bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC call error: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,865 @@
+// Code generated by mempoor/pkg/client/internal/gen from the node's OpenAPI document. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// TxAdd calls tx.add, which adds one tx to a pool.
+func (c *Client) TxAdd(ctx context.Context, params AddTxParams) (*AddTxResult, error) {
+	var out AddTxResult
+	if err := c.Call(ctx, "tx.add", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxAddBatch calls tx.addBatch, which adds many txs, each succeeding or failing alone.
+func (c *Client) TxAddBatch(ctx context.Context, params AddBatchParams) (*AddBatchResult, error) {
+	var out AddBatchResult
+	if err := c.Call(ctx, "tx.addBatch", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxAddBundle calls tx.addBundle, which adds txs as one atomic bundle.
+func (c *Client) TxAddBundle(ctx context.Context, params AddBundleParams) (*AddBundleResult, error) {
+	var out AddBundleResult
+	if err := c.Call(ctx, "tx.addBundle", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxUpdate calls tx.update, which replaces a pending tx's fee.
+func (c *Client) TxUpdate(ctx context.Context, params UpdateTxParams) (*OKResult, error) {
+	var out OKResult
+	if err := c.Call(ctx, "tx.update", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxRemove calls tx.remove, which removes a pending tx.
+func (c *Client) TxRemove(ctx context.Context, params RemoveTxParams) (*OKResult, error) {
+	var out OKResult
+	if err := c.Call(ctx, "tx.remove", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxHistory calls tx.history, which returns a pending tx and its earlier fees.
+func (c *Client) TxHistory(ctx context.Context, params TxHistoryParams) (*TxHistoryResult, error) {
+	var out TxHistoryResult
+	if err := c.Call(ctx, "tx.history", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxStatus calls tx.status, which returns the last known lifecycle state of a tx.
+func (c *Client) TxStatus(ctx context.Context, params TxStatusParams) (*TxStatus, error) {
+	var out TxStatus
+	if err := c.Call(ctx, "tx.status", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxGet calls tx.get, which returns a pending tx and its position in the priority order.
+func (c *Client) TxGet(ctx context.Context, params TxGetParams) (*TxGetResult, error) {
+	var out TxGetResult
+	if err := c.Call(ctx, "tx.get", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxReceipt calls tx.receipt, which returns the receipt of an included tx.
+func (c *Client) TxReceipt(ctx context.Context, params TxReceiptParams) (*Receipt, error) {
+	var out Receipt
+	if err := c.Call(ctx, "tx.receipt", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxScheduled calls tx.scheduled, which lists txs waiting for their NotBefore.
+func (c *Client) TxScheduled(ctx context.Context, params ScheduledParams) (*ListTxResult, error) {
+	var out ListTxResult
+	if err := c.Call(ctx, "tx.scheduled", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxList calls tx.list, which lists a pool's pending txs, filtered, sorted and paged.
+func (c *Client) TxList(ctx context.Context, params ListTxParams) (*ListTxResult, error) {
+	var out ListTxResult
+	if err := c.Call(ctx, "tx.list", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxTop calls tx.top, which lists the next txs a block would select.
+func (c *Client) TxTop(ctx context.Context, params TopTxParams) (*ListTxResult, error) {
+	var out ListTxResult
+	if err := c.Call(ctx, "tx.top", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BlockList calls block.list, which lists the chain's blocks.
+func (c *Client) BlockList(ctx context.Context, params ListBlocksParams) (*ListBlocksResult, error) {
+	var out ListBlocksResult
+	if err := c.Call(ctx, "block.list", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BlockGet calls block.get, which returns one block by height or hash.
+func (c *Client) BlockGet(ctx context.Context, params BlockGetParams) (*GetBlockResult, error) {
+	var out GetBlockResult
+	if err := c.Call(ctx, "block.get", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BlockHeaders calls block.headers, which returns headers for light clients.
+func (c *Client) BlockHeaders(ctx context.Context, params HeadersParams) (*HeadersResult, error) {
+	var out HeadersResult
+	if err := c.Call(ctx, "block.headers", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BlockTemplate calls block.template, which builds the next block without committing it.
+func (c *Client) BlockTemplate(ctx context.Context) (*GetBlockResult, error) {
+	var out GetBlockResult
+	if err := c.Call(ctx, "block.template", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BlockMetrics calls block.metrics, which returns the block builder's metrics.
+func (c *Client) BlockMetrics(ctx context.Context) (*BuilderMetrics, error) {
+	var out BuilderMetrics
+	if err := c.Call(ctx, "block.metrics", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BlockPropose calls block.propose, which offers an externally built block for the tip.
+func (c *Client) BlockPropose(ctx context.Context, params ProposeParams) (*ProposeResult, error) {
+	var out ProposeResult
+	if err := c.Call(ctx, "block.propose", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BlockReorg calls block.reorg, which replaces the tip with a competing block.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) BlockReorg(ctx context.Context, params ReorgParams) (*ReorgResult, error) {
+	var out ReorgResult
+	if err := c.Call(ctx, "block.reorg", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BlockExport calls block.export, which streams the chain as one JSON block per line.
+// It is an admin method: set Token if the node has an admin token.
+// The body (application/x-ndjson) is returned for the caller to read and close.
+func (c *Client) BlockExport(ctx context.Context) (io.ReadCloser, error) {
+	return c.Stream(ctx, "block.export", nil)
+}
+
+// BlockImport calls block.import, which adopts an exported chain.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) BlockImport(ctx context.Context, params ImportParams) (*ImportResult, error) {
+	var out ImportResult
+	if err := c.Call(ctx, "block.import", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// NodeStatus calls node.status, which returns the node's status.
+func (c *Client) NodeStatus(ctx context.Context) (*NodeStatus, error) {
+	var out NodeStatus
+	if err := c.Call(ctx, "node.status", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PeerAdd calls peer.add, which adds a peer and returns the peers.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) PeerAdd(ctx context.Context, params PeerParams) (*PeerListResult, error) {
+	var out PeerListResult
+	if err := c.Call(ctx, "peer.add", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PeerRemove calls peer.remove, which removes a peer and returns the peers.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) PeerRemove(ctx context.Context, params PeerParams) (*PeerListResult, error) {
+	var out PeerListResult
+	if err := c.Call(ctx, "peer.remove", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PeerList calls peer.list, which returns the peers.
+func (c *Client) PeerList(ctx context.Context) (*PeerListResult, error) {
+	var out PeerListResult
+	if err := c.Call(ctx, "peer.list", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DebugMine calls debug.mine, which produces blocks on demand.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) DebugMine(ctx context.Context, params MineParams) (*MineResult, error) {
+	var out MineResult
+	if err := c.Call(ctx, "debug.mine", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AccountGet calls account.get, which returns an account's balance and next nonce.
+func (c *Client) AccountGet(ctx context.Context, params AccountGetParams) (*AccountResult, error) {
+	var out AccountResult
+	if err := c.Call(ctx, "account.get", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// FeeEstimate calls fee.estimate, which returns the fee oracle's percentiles.
+func (c *Client) FeeEstimate(ctx context.Context) (*FeeEstimate, error) {
+	var out FeeEstimate
+	if err := c.Call(ctx, "fee.estimate", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// FeeFloor calls fee.floor, which returns the pool's admission fee floor.
+func (c *Client) FeeFloor(ctx context.Context) (*FeeFloorResult, error) {
+	var out FeeFloorResult
+	if err := c.Call(ctx, "fee.floor", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminSetSenderAccess calls admin.setSenderAccess, which allows, denies or resets a sender.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminSetSenderAccess(ctx context.Context, params SetSenderAccessParams) (*OKResult, error) {
+	var out OKResult
+	if err := c.Call(ctx, "admin.setSenderAccess", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminSenderACL calls admin.senderACL, which returns the sender allow and deny lists.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminSenderACL(ctx context.Context) (*SenderACL, error) {
+	var out SenderACL
+	if err := c.Call(ctx, "admin.senderACL", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminPurgeSender calls admin.purgeSender, which removes every pending tx of a sender.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminPurgeSender(ctx context.Context, params PurgeSenderParams) (*PurgeSenderResult, error) {
+	var out PurgeSenderResult
+	if err := c.Call(ctx, "admin.purgeSender", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminClear calls admin.clear, which removes every pending tx.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminClear(ctx context.Context, params ClearParams) (*ClearResult, error) {
+	var out ClearResult
+	if err := c.Call(ctx, "admin.clear", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminPin calls admin.pin, which pins or unpins a pending tx.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminPin(ctx context.Context, params PinParams) (*OKResult, error) {
+	var out OKResult
+	if err := c.Call(ctx, "admin.pin", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminPinned calls admin.pinned, which lists the pinned txs.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminPinned(ctx context.Context) (*PinnedResult, error) {
+	var out PinnedResult
+	if err := c.Call(ctx, "admin.pinned", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminRequire calls admin.require, which requires a tx in the next block, or cancels that.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminRequire(ctx context.Context, params RequireParams) (*OKResult, error) {
+	var out OKResult
+	if err := c.Call(ctx, "admin.require", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminRequired calls admin.required, which lists the required txs.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminRequired(ctx context.Context) (*RequiredResult, error) {
+	var out RequiredResult
+	if err := c.Call(ctx, "admin.required", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminSetBlockLimits calls admin.setBlockLimits, which changes the live block limits.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminSetBlockLimits(ctx context.Context, params LimitsUpdate) (*BlockLimitsResult, error) {
+	var out BlockLimitsResult
+	if err := c.Call(ctx, "admin.setBlockLimits", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminBlockLimits calls admin.blockLimits, which returns the live block limits.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminBlockLimits(ctx context.Context) (*BlockLimitsResult, error) {
+	var out BlockLimitsResult
+	if err := c.Call(ctx, "admin.blockLimits", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminCompact calls admin.compact, which compacts the pool's indexes.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminCompact(ctx context.Context) (*OKResult, error) {
+	var out OKResult
+	if err := c.Call(ctx, "admin.compact", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminCheckInvariants calls admin.checkInvariants, which checks the pool's internal invariants.
+// It is an admin method: set Token if the node has an admin token.
+func (c *Client) AdminCheckInvariants(ctx context.Context) (*CheckInvariantsResult, error) {
+	var out CheckInvariantsResult
+	if err := c.Call(ctx, "admin.checkInvariants", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AccountGetParams is the node's AccountGetParams schema.
+type AccountGetParams struct {
+	Address string `json:"address"`
+}
+
+// AccountResult is the node's AccountResult schema.
+type AccountResult struct {
+	Address string `json:"address"`
+	Balance uint64 `json:"balance"`
+	Nonce   uint64 `json:"nonce"`
+}
+
+// AddBatchParams is the node's AddBatchParams schema.
+type AddBatchParams struct {
+	Txs []AddTxParams `json:"txs"`
+}
+
+// AddBatchResult is the node's AddBatchResult schema.
+type AddBatchResult struct {
+	Results []AddBatchItem `json:"results"`
+}
+
+// AddBundleParams is the node's AddBundleParams schema.
+type AddBundleParams struct {
+	BundleID string        `json:"bundleId"`
+	Pool     string        `json:"pool"`
+	Txs      []AddTxParams `json:"txs"`
+}
+
+// AddBundleResult is the node's AddBundleResult schema.
+type AddBundleResult struct {
+	BundleID string   `json:"bundleId"`
+	TxIDs    []string `json:"txIDs"`
+	Evicted  []string `json:"evicted,omitempty"`
+}
+
+// AddTxParams is the node's AddTxParams schema.
+type AddTxParams struct {
+	Sender    string    `json:"sender"`
+	Recipient string    `json:"recipient"`
+	Payload   string    `json:"payload"`
+	Nonce     uint64    `json:"nonce"`
+	Fee       uint64    `json:"fee"`
+	MaxFee    uint64    `json:"maxFee,omitempty"`
+	Tip       uint64    `json:"tip,omitempty"`
+	Gas       uint64    `json:"gas"`
+	Amount    uint64    `json:"amount,omitempty"`
+	ChainID   string    `json:"chainId,omitempty"`
+	ParentID  string    `json:"parentID,omitempty"`
+	DependsOn []string  `json:"dependsOn,omitempty"`
+	NotBefore time.Time `json:"notBefore"`
+	Lane      string    `json:"lane"`
+	Pool      string    `json:"pool,omitempty"`
+}
+
+// AddTxResult is the node's AddTxResult schema.
+type AddTxResult struct {
+	TxID      string   `json:"txID"`
+	Orphan    bool     `json:"orphan,omitempty"`
+	Scheduled bool     `json:"scheduled,omitempty"`
+	Merged    bool     `json:"merged,omitempty"`
+	Evicted   []string `json:"evicted,omitempty"`
+}
+
+// BlockGetParams is the node's BlockGetParams schema.
+type BlockGetParams struct {
+	Height      *uint64 `json:"height,omitempty"`
+	Hash        string  `json:"hash,omitempty"`
+	Compression string  `json:"compression"`
+}
+
+// BlockLimitsResult is the node's BlockLimitsResult schema.
+type BlockLimitsResult struct {
+	Limits  BlockLimits `json:"limits"`
+	Version uint64      `json:"version"`
+}
+
+// BuilderMetrics is the node's BuilderMetrics schema.
+type BuilderMetrics struct {
+	Builds            uint64  `json:"builds"`
+	EmptyBuilds       uint64  `json:"emptyBuilds"`
+	InterruptedBuilds uint64  `json:"interruptedBuilds"`
+	LastSelectionMs   float64 `json:"lastSelectionMs"`
+	AvgSelectionMs    float64 `json:"avgSelectionMs"`
+	MaxSelectionMs    float64 `json:"maxSelectionMs"`
+	LastTxFill        float64 `json:"lastTxFill"`
+	AvgTxFill         float64 `json:"avgTxFill"`
+	LastGasFill       float64 `json:"lastGasFill"`
+	AvgGasFill        float64 `json:"avgGasFill"`
+	LastFees          uint64  `json:"lastFees"`
+	TotalFees         uint64  `json:"totalFees"`
+}
+
+// CheckInvariantsResult is the node's CheckInvariantsResult schema.
+type CheckInvariantsResult struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// ClearParams is the node's ClearParams schema.
+type ClearParams struct {
+	ReturnTxs bool `json:"returnTxs"`
+}
+
+// ClearResult is the node's ClearResult schema.
+type ClearResult struct {
+	Cleared int  `json:"cleared"`
+	Txs     []Tx `json:"txs,omitempty"`
+}
+
+// FeeEstimate is the node's FeeEstimate schema.
+type FeeEstimate struct {
+	Pending  FeePercentiles `json:"pending"`
+	Included FeePercentiles `json:"included"`
+	Window   int            `json:"window"`
+}
+
+// FeeFloorResult is the node's FeeFloorResult schema.
+type FeeFloorResult struct {
+	Floor uint64 `json:"floor"`
+}
+
+// GetBlockResult is the node's GetBlockResult schema.
+type GetBlockResult struct {
+	Block Block `json:"block"`
+}
+
+// HeadersParams is the node's HeadersParams schema.
+type HeadersParams struct {
+	From  uint64 `json:"from"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// HeadersResult is the node's HeadersResult schema.
+type HeadersResult struct {
+	Headers []Block `json:"headers"`
+}
+
+// ImportParams is the node's ImportParams schema.
+type ImportParams struct {
+	Blocks []Block `json:"blocks"`
+}
+
+// ImportResult is the node's ImportResult schema.
+type ImportResult struct {
+	Imported   int `json:"imported"`
+	Displaced  int `json:"displaced"`
+	Reinserted int `json:"reinserted"`
+}
+
+// LimitsUpdate is the node's LimitsUpdate schema.
+type LimitsUpdate struct {
+	GasLimit      *uint64 `json:"gasLimit"`
+	MaxTxPerBlock *int    `json:"maxTxPerBlock"`
+	MinFee        *uint64 `json:"minFee"`
+}
+
+// ListBlocksParams is the node's ListBlocksParams schema.
+type ListBlocksParams struct {
+	Compression string `json:"compression"`
+}
+
+// ListBlocksResult is the node's ListBlocksResult schema.
+type ListBlocksResult struct {
+	Blocks []Block `json:"blocks"`
+}
+
+// ListTxParams is the node's ListTxParams schema.
+type ListTxParams struct {
+	Offset    int    `json:"offset"`
+	Limit     int    `json:"limit"`
+	Sort      string `json:"sort"`
+	Pool      string `json:"pool"`
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+	MinFee    uint64 `json:"minFee"`
+	MaxFee    uint64 `json:"maxFee"`
+	MinGas    uint64 `json:"minGas"`
+}
+
+// ListTxResult is the node's ListTxResult schema.
+type ListTxResult struct {
+	Transactions []Tx `json:"transactions"`
+	Total        int  `json:"total"`
+}
+
+// MineParams is the node's MineParams schema.
+type MineParams struct {
+	Count int `json:"count"`
+}
+
+// MineResult is the node's MineResult schema.
+type MineResult struct {
+	Blocks []Block `json:"blocks"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// NodeStatus is the node's NodeStatus schema.
+type NodeStatus struct {
+	Version    string         `json:"version"`
+	ChainID    string         `json:"chainId,omitempty"`
+	StartedAt  time.Time      `json:"startedAt"`
+	Uptime     string         `json:"uptime"`
+	Height     uint64         `json:"height"`
+	TipHash    string         `json:"tipHash"`
+	PendingTxs int            `json:"pendingTxs"`
+	PendingGas uint64         `json:"pendingGas"`
+	Limits     StatusLimits   `json:"limits"`
+	Production BuilderMetrics `json:"production"`
+}
+
+// OKResult is the node's OKResult schema.
+type OKResult struct {
+	OK bool `json:"ok"`
+}
+
+// PeerListResult is the node's PeerListResult schema.
+type PeerListResult struct {
+	Peers []PeerInfo `json:"peers"`
+}
+
+// PeerParams is the node's PeerParams schema.
+type PeerParams struct {
+	Address string `json:"address"`
+}
+
+// PinParams is the node's PinParams schema.
+type PinParams struct {
+	ID    string `json:"id"`
+	Unpin bool   `json:"unpin"`
+}
+
+// PinnedResult is the node's PinnedResult schema.
+type PinnedResult struct {
+	Pinned []string `json:"pinned"`
+}
+
+// ProposeParams is the node's ProposeParams schema.
+type ProposeParams struct {
+	Block Block `json:"block"`
+}
+
+// ProposeResult is the node's ProposeResult schema.
+type ProposeResult struct {
+	Accepted      bool   `json:"accepted"`
+	Block         Block  `json:"block"`
+	ProposedFees  uint64 `json:"proposedFees"`
+	CandidateFees uint64 `json:"candidateFees"`
+}
+
+// PurgeSenderParams is the node's PurgeSenderParams schema.
+type PurgeSenderParams struct {
+	Sender string `json:"sender"`
+}
+
+// PurgeSenderResult is the node's PurgeSenderResult schema.
+type PurgeSenderResult struct {
+	Removed []string `json:"removed"`
+}
+
+// Receipt is the node's Receipt schema.
+type Receipt struct {
+	TxID          string `json:"txID"`
+	Status        string `json:"status"`
+	BlockHeight   uint64 `json:"blockHeight"`
+	BlockHash     string `json:"blockHash"`
+	Index         int    `json:"index"`
+	Gas           uint64 `json:"gas"`
+	Fee           uint64 `json:"fee,omitempty"`
+	Confirmations uint64 `json:"confirmations"`
+	Pruned        bool   `json:"pruned,omitempty"`
+}
+
+// RemoveTxParams is the node's RemoveTxParams schema.
+type RemoveTxParams struct {
+	ID string `json:"id"`
+}
+
+// ReorgParams is the node's ReorgParams schema.
+type ReorgParams struct {
+	Block Block `json:"block"`
+}
+
+// ReorgResult is the node's ReorgResult schema.
+type ReorgResult struct {
+	Block      Block    `json:"block"`
+	Displaced  []string `json:"displaced"`
+	Reinserted int      `json:"reinserted"`
+}
+
+// RequireParams is the node's RequireParams schema.
+type RequireParams struct {
+	ID     string `json:"id"`
+	Cancel bool   `json:"cancel"`
+}
+
+// RequiredResult is the node's RequiredResult schema.
+type RequiredResult struct {
+	Required []string `json:"required"`
+}
+
+// ScheduledParams is the node's ScheduledParams schema.
+type ScheduledParams struct {
+	Pool string `json:"pool"`
+}
+
+// SenderACL is the node's SenderACL schema.
+type SenderACL struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+// SetSenderAccessParams is the node's SetSenderAccessParams schema.
+type SetSenderAccessParams struct {
+	Sender string `json:"sender"`
+	Rule   string `json:"rule"`
+}
+
+// TopTxParams is the node's TopTxParams schema.
+type TopTxParams struct {
+	N    int    `json:"n"`
+	Pool string `json:"pool"`
+}
+
+// TxGetParams is the node's TxGetParams schema.
+type TxGetParams struct {
+	ID string `json:"id"`
+}
+
+// TxGetResult is the node's TxGetResult schema.
+type TxGetResult struct {
+	Tx           *Tx `json:"tx"`
+	Position     int `json:"position"`
+	LanePosition int `json:"lanePosition"`
+}
+
+// TxHistoryParams is the node's TxHistoryParams schema.
+type TxHistoryParams struct {
+	ID string `json:"id"`
+}
+
+// TxHistoryResult is the node's TxHistoryResult schema.
+type TxHistoryResult struct {
+	Current *Tx         `json:"current"`
+	History []TxVersion `json:"history"`
+}
+
+// TxReceiptParams is the node's TxReceiptParams schema.
+type TxReceiptParams struct {
+	ID string `json:"id"`
+}
+
+// TxStatus is the node's TxStatus schema.
+type TxStatus struct {
+	ID        string    `json:"id"`
+	State     string    `json:"state"`
+	Height    uint64    `json:"height,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TxStatusParams is the node's TxStatusParams schema.
+type TxStatusParams struct {
+	ID string `json:"id"`
+}
+
+// UpdateTxParams is the node's UpdateTxParams schema.
+type UpdateTxParams struct {
+	ID  string  `json:"id"`
+	Fee uint64  `json:"fee"`
+	Tip *uint64 `json:"tip,omitempty"`
+}
+
+// AddBatchItem is the node's AddBatchItem schema.
+type AddBatchItem struct {
+	TxID      string `json:"txID,omitempty"`
+	Orphan    bool   `json:"orphan,omitempty"`
+	Scheduled bool   `json:"scheduled,omitempty"`
+	Merged    bool   `json:"merged,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Block is the node's Block schema.
+type Block struct {
+	Height       uint64            `json:"height"`
+	PrevHash     string            `json:"prevHash"`
+	Timestamp    time.Time         `json:"timestamp"`
+	TxCount      int               `json:"txCount"`
+	GasUsed      uint64            `json:"gasUsed"`
+	Hash         string            `json:"hash"`
+	Burned       uint64            `json:"burned,omitempty"`
+	Tipped       uint64            `json:"tipped,omitempty"`
+	TotalFees    uint64            `json:"totalFees"`
+	AvgFeePerGas uint64            `json:"avgFeePerGas"`
+	Proposer     string            `json:"proposer,omitempty"`
+	ExtraData    string            `json:"extraData,omitempty"`
+	Extra        map[string]string `json:"extra,omitempty"`
+	ChainID      string            `json:"chainId,omitempty"`
+	Transactions []Tx              `json:"transactions"`
+	Groups       [][]int           `json:"groups,omitempty"`
+	Compression  string            `json:"compression,omitempty"`
+	Signer       string            `json:"signer,omitempty"`
+	Signature    string            `json:"signature,omitempty"`
+	Pruned       bool              `json:"pruned,omitempty"`
+}
+
+// BlockLimits is the node's BlockLimits schema.
+type BlockLimits struct {
+	GasLimit      uint64 `json:"gasLimit"`
+	MaxTxPerBlock int    `json:"maxTxPerBlock"`
+	MinFee        uint64 `json:"minFee"`
+}
+
+// FeePercentiles is the node's FeePercentiles schema.
+type FeePercentiles struct {
+	Count int    `json:"count"`
+	P25   uint64 `json:"p25"`
+	P50   uint64 `json:"p50"`
+	P90   uint64 `json:"p90"`
+}
+
+// PeerInfo is the node's PeerInfo schema.
+type PeerInfo struct {
+	Address        string    `json:"address"`
+	State          string    `json:"state"`
+	LastSeenHeight uint64    `json:"lastSeenHeight"`
+	LastSeen       time.Time `json:"lastSeen,omitzero"`
+	LastError      string    `json:"lastError,omitempty"`
+	Sent           uint64    `json:"sent"`
+	Received       uint64    `json:"received"`
+	Failures       uint64    `json:"failures"`
+}
+
+// StatusLimits is the node's StatusLimits schema.
+type StatusLimits struct {
+	BlockInterval string `json:"blockInterval"`
+	GasLimit      uint64 `json:"gasLimit"`
+	MaxTxPerBlock int    `json:"maxTxPerBlock"`
+	MinFee        uint64 `json:"minFee"`
+	Version       uint64 `json:"version"`
+}
+
+// Tx is the node's Tx schema.
+type Tx struct {
+	ID          string    `json:"ID"`
+	Sender      string    `json:"Sender"`
+	Recipient   string    `json:"Recipient"`
+	Fee         uint64    `json:"Fee"`
+	Gas         uint64    `json:"Gas"`
+	Payload     string    `json:"Payload"`
+	Amount      uint64    `json:"Amount"`
+	ChainID     string    `json:"ChainID,omitempty"`
+	MaxFee      uint64    `json:"MaxFee"`
+	Tip         uint64    `json:"Tip"`
+	Nonce       uint64    `json:"Nonce"`
+	ParentID    string    `json:"ParentID"`
+	DependsOn   []string  `json:"DependsOn"`
+	NotBefore   time.Time `json:"NotBefore"`
+	Lane        string    `json:"Lane"`
+	Pool        string    `json:"Pool"`
+	BundleID    string    `json:"BundleID"`
+	BundleIndex int       `json:"BundleIndex"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	Timestamp   time.Time `json:"Timestamp"`
+}
+
+// TxVersion is the node's TxVersion schema.
+type TxVersion struct {
+	Fee       uint64    `json:"fee"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeNode answers every call with code and body.
+func fakeNode(t *testing.T, code int, body string) (*Client, *http.Request) {
+	t.Helper()
+	var last http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = *r
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return New(srv.URL), &last
+}
+
+func TestCallErrors(t *testing.T) {
+	ctx := context.Background()
+	var rpcErr *Error
+
+	c, _ := fakeNode(t, http.StatusOK, `{"result":{"error":"transaction not found"}}`)
+	if _, err := c.TxRemove(ctx, RemoveTxParams{ID: "x"}); !errors.As(err, &rpcErr) || rpcErr.Code != http.StatusOK || rpcErr.Message != "transaction not found" {
+		t.Fatalf("expected a refusal, got %v", err)
+	}
+
+	// A result with more than an error is a result.
+	c, _ = fakeNode(t, http.StatusOK, `{"result":{"blocks":[],"error":"stopped early"}}`)
+	if mined, err := c.DebugMine(ctx, MineParams{Count: 2}); err != nil || mined.Error != "stopped early" {
+		t.Fatalf("expected a partial result, got %+v, %v", mined, err)
+	}
+
+	c, _ = fakeNode(t, http.StatusUnauthorized, `{"error":"invalid or missing admin token"}`)
+	if _, err := c.AdminCompact(ctx); !errors.As(err, &rpcErr) || rpcErr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %v", err)
+	}
+	if _, err := c.BlockExport(ctx); !errors.As(err, &rpcErr) || rpcErr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for the stream, got %v", err)
+	}
+}
+
+func TestCallSendsToken(t *testing.T) {
+	c, last := fakeNode(t, http.StatusOK, `{"result":{"ok":true}}`)
+	c.Token = "s3cret"
+	if res, err := c.AdminCompact(context.Background()); err != nil || !res.OK {
+		t.Fatalf("compact: %+v, %v", res, err)
+	}
+	if got := last.Header.Get("Authorization"); got != "Bearer s3cret" || last.URL.Path != "/rpc" {
+		t.Fatalf("expected the token sent to /rpc, got %q at %s", got, last.URL.Path)
+	}
+}
+
+func TestNewAddsScheme(t *testing.T) {
+	if c := New("localhost:8080"); c.URL != "http://localhost:8080" {
+		t.Fatalf("expected an http URL, got %q", c.URL)
+	}
+	if c := New("https://node.example/"); c.URL != "https://node.example" {
+		t.Fatalf("expected the URL kept, got %q", c.URL)
+	}
+}
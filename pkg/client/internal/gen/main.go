@@ -0,0 +1,306 @@
+// Command gen writes pkg/client's typed methods and types from the
+// node's OpenAPI document; see "OpenAPI semantics" in pkg/mempoor.
+//
+//	go run ./internal/gen -out client_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"mempoor/pkg/mempoor"
+)
+
+// schema is the subset of JSON Schema the node's document uses.
+type schema struct {
+	Ref                  string      `json:"$ref"`
+	Type                 any         `json:"type"` // a name, or [name, "null"]
+	Format               string      `json:"format"`
+	Items                *schema     `json:"items"`
+	AdditionalProperties *schema     `json:"additionalProperties"`
+	Properties           *properties `json:"properties"`
+	Required             []string    `json:"required"`
+	AnyOf                []*schema   `json:"anyOf"`
+}
+
+// properties are an object schema's properties in document order.
+type properties struct {
+	names  []string
+	byName map[string]*schema
+}
+
+func (p *properties) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil { // {
+		return err
+	}
+	p.byName = make(map[string]*schema)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+		var s schema
+		if err := dec.Decode(&s); err != nil {
+			return err
+		}
+		p.names = append(p.names, name)
+		p.byName[name] = &s
+	}
+	return nil
+}
+
+// method is one entry of x-rpc-methods.
+type method struct {
+	Name    string  `json:"name"`
+	Summary string  `json:"summary"`
+	Admin   bool    `json:"admin"`
+	Params  *schema `json:"params"`
+	Result  *schema `json:"result"`
+	Stream  string  `json:"stream"`
+}
+
+type document struct {
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+	Methods []method `json:"x-rpc-methods"`
+}
+
+func main() {
+	out := flag.String("out", "client_gen.go", "file to write")
+	flag.Parse()
+
+	src, err := generate(mempoor.OpenAPI())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted Go source for the document in raw.
+func generate(raw []byte) ([]byte, error) {
+	var doc document
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("decoding the document: %w", err)
+	}
+	g := &generator{
+		schemas: doc.Components.Schemas,
+		used:    make(map[string]bool),
+		imports: map[string]bool{"context": true},
+	}
+
+	var methods bytes.Buffer
+	for _, m := range doc.Methods {
+		if err := g.method(&methods, m); err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+	}
+
+	var types bytes.Buffer
+	for done := make(map[string]bool); len(done) < len(g.used); {
+		names := make([]string, 0, len(g.used))
+		for name := range g.used {
+			if !done[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			done[name] = true
+			if err := g.typeDecl(&types, name); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by mempoor/pkg/client/internal/gen from the node's OpenAPI document. DO NOT EDIT.\n\n")
+	buf.WriteString("package client\n\nimport (\n")
+	for _, pkg := range slices.Sorted(maps.Keys(g.imports)) {
+		fmt.Fprintf(&buf, "%q\n", pkg)
+	}
+	buf.WriteString(")\n\n")
+	buf.Write(methods.Bytes())
+	buf.Write(types.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+// generator tracks which schemas and packages the methods need.
+type generator struct {
+	schemas map[string]*schema
+	used    map[string]bool
+	imports map[string]bool
+}
+
+// method writes the Client method calling m.
+func (g *generator) method(w *bytes.Buffer, m method) error {
+	name := goName(strings.ReplaceAll(m.Name, ".", "_"))
+	fmt.Fprintf(w, "// %s calls %s, which %s.\n", name, m.Name, m.Summary)
+	if m.Admin {
+		w.WriteString("// It is an admin method: set Token if the node has an admin token.\n")
+	}
+
+	args, params := "ctx context.Context", "nil"
+	if m.Params != nil {
+		typ, err := g.goType(m.Params)
+		if err != nil {
+			return err
+		}
+		args += ", params " + typ
+		params = "params"
+	}
+
+	if m.Stream != "" {
+		g.imports["io"] = true
+		fmt.Fprintf(w, "// The body (%s) is returned for the caller to read and close.\n", m.Stream)
+		fmt.Fprintf(w, "func (c *Client) %s(%s) (io.ReadCloser, error) {\n", name, args)
+		fmt.Fprintf(w, "return c.Stream(ctx, %q, %s)\n}\n\n", m.Name, params)
+		return nil
+	}
+	if m.Result == nil {
+		return fmt.Errorf("no result and no stream")
+	}
+	result, err := g.goType(m.Result)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "func (c *Client) %s(%s) (*%s, error) {\n", name, args, result)
+	fmt.Fprintf(w, "var out %s\n", result)
+	fmt.Fprintf(w, "if err := c.Call(ctx, %q, %s, &out); err != nil {\nreturn nil, err\n}\n", m.Name, params)
+	w.WriteString("return &out, nil\n}\n\n")
+	return nil
+}
+
+// typeDecl writes the struct type for the named object schema.
+func (g *generator) typeDecl(w *bytes.Buffer, name string) error {
+	s := g.schemas[name]
+	if s == nil || s.Properties == nil {
+		return fmt.Errorf("not an object schema")
+	}
+	fmt.Fprintf(w, "// %s is the node's %s schema.\n", name, name)
+	fmt.Fprintf(w, "type %s struct {\n", name)
+
+	for _, prop := range s.Properties.names {
+		p := s.Properties.byName[prop]
+		typ, err := g.goType(p)
+		if err != nil {
+			return fmt.Errorf("%s: %w", prop, err)
+		}
+		tag := prop
+		if !slices.Contains(s.Required, prop) {
+			if typ == "time.Time" || p.Ref != "" {
+				tag += ",omitzero"
+			} else {
+				tag += ",omitempty"
+			}
+		}
+		fmt.Fprintf(w, "%s %s `json:%q`\n", goName(prop), typ, tag)
+	}
+	w.WriteString("}\n\n")
+	return nil
+}
+
+// goType returns the Go type for s, marking referenced schemas used.
+func (g *generator) goType(s *schema) (string, error) {
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		if !ok || g.schemas[name] == nil {
+			return "", fmt.Errorf("unknown reference %q", s.Ref)
+		}
+		g.used[name] = true
+		return name, nil
+	}
+	if len(s.AnyOf) == 2 && s.AnyOf[1].Type == "null" {
+		typ, err := g.goType(s.AnyOf[0])
+		return "*" + typ, err
+	}
+
+	typ, nullable := s.Type, false
+	if types, ok := typ.([]any); ok && len(types) == 2 && types[1] == "null" {
+		typ, nullable = types[0], true
+	}
+	var out string
+	switch typ {
+	case nil:
+		g.imports["encoding/json"] = true
+		return "json.RawMessage", nil
+	case "string":
+		switch s.Format {
+		case "date-time":
+			g.imports["time"] = true
+			out = "time.Time"
+		case "byte":
+			out = "[]byte"
+		default:
+			out = "string"
+		}
+	case "boolean":
+		out = "bool"
+	case "number":
+		out = "float64"
+	case "integer":
+		out = "int"
+		if s.Format != "" {
+			out = s.Format
+		}
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		elem, err := g.goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		out = "[]" + elem
+	case "object":
+		if s.AdditionalProperties == nil {
+			return "", fmt.Errorf("inline object")
+		}
+		elem, err := g.goType(s.AdditionalProperties)
+		if err != nil {
+			return "", err
+		}
+		out = "map[string]" + elem
+	default:
+		return "", fmt.Errorf("unsupported type %v", typ)
+	}
+	if nullable {
+		out = "*" + out
+	}
+	return out, nil
+}
+
+// goName turns a JSON name into an exported Go name: txID is TxID,
+// chainId is ChainID, tx_addBatch is TxAddBatch.
+func goName(s string) string {
+	var sb strings.Builder
+	for part := range strings.SplitSeq(s, "_") {
+		switch {
+		case part == "id" || part == "ok":
+			part = strings.ToUpper(part)
+		case strings.HasSuffix(part, "Id"):
+			part = strings.TrimSuffix(part, "Id") + "ID"
+		case strings.HasSuffix(part, "Ids"):
+			part = strings.TrimSuffix(part, "Ids") + "IDs"
+		}
+		r, size := utf8.DecodeRuneInString(part)
+		sb.WriteRune(unicode.ToUpper(r))
+		sb.WriteString(part[size:])
+	}
+	return sb.String()
+}
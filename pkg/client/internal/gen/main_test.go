@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"mempoor/pkg/mempoor"
+)
+
+func TestClientIsGenerated(t *testing.T) {
+	want, err := generate(mempoor.OpenAPI())
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	got, err := os.ReadFile("../../client_gen.go")
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("client_gen.go is stale: run go generate in pkg/client")
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for in, want := range map[string]string{
+		"txID":            "TxID",
+		"chainId":         "ChainID",
+		"txIDs":           "TxIDs",
+		"id":              "ID",
+		"ok":              "OK",
+		"ID":              "ID",
+		"tx_addBatch":     "TxAddBatch",
+		"admin_senderACL": "AdminSenderACL",
+	} {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
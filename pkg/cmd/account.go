@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"mempoor/pkg/client"
+
+	"github.com/google/subcommands"
+)
+
+type AccountArgs struct {
+	NodeAddr string
+}
+
+func (*AccountArgs) Name() string     { return "account" }
+func (*AccountArgs) Synopsis() string { return "account balances and nonces" }
+func (*AccountArgs) Usage() string {
+	return `account <command> [--flags]
+
+Account commands. Balances are tracked once the node's genesis block
+allocates some (mempoor start --genesis-balances); included txs debit
+their sender the fee paid plus --amount and credit the recipient, and
+advance its nonce past theirs.
+
+Commands:
+    get     Show an address's balance and next unused nonce
+
+Examples:
+    mempoor account get --address alice
+`
+}
+
+func (a *AccountArgs) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&a.NodeAddr, "addr", "localhost:8080", "address of running mempoor node")
+}
+
+func (a *AccountArgs) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() == 0 {
+		fmt.Println(a.Usage())
+		return subcommands.ExitUsageError
+	}
+
+	switch f.Arg(0) {
+	case "get":
+		return a.get(ctx, f.Args()[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown account command: %s\n", f.Arg(0))
+		return subcommands.ExitUsageError
+	}
+}
+
+func (a *AccountArgs) get(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("account get", flag.ExitOnError)
+
+	var address string
+	fs.StringVar(&address, "address", "", "account address")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+	if address == "" {
+		fmt.Fprintln(os.Stderr, "--address is required")
+		return subcommands.ExitUsageError
+	}
+
+	result, err := client.New(a.NodeAddr).AccountGet(ctx, client.AccountGetParams{Address: address})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	fmt.Printf("%s balance=%d nonce=%d\n", result.Address, result.Balance, result.Nonce)
+	return subcommands.ExitSuccess
+}
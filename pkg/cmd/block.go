@@ -5,7 +5,12 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+
+	"mempoor/pkg/client"
+	"mempoor/pkg/mempoor"
 
 	"github.com/google/subcommands"
 )
@@ -27,7 +32,15 @@ transaction is included in a block, it is removed from the mempool.
 
 Commands:
     list        List all produced blocks (chain view)
-    get         Get a specific block by height
+    get         Get a specific block by height or hash
+    template    Preview the next block without producing it
+    metrics     Show builder latency, block fill, and fee metrics
+    propose     Submit an externally built block for the next height
+    reorg       Submit a competing block for an existing height
+    export      Write the whole chain to a JSONL file
+    import      Adopt a chain exported by "block export"
+    mine        Produce blocks now instead of waiting for the ticker
+    replay      Re-derive blocks from a node's --replay-log (offline)
 
 Examples:
     # View all produced blocks (finalized chain view)
@@ -35,6 +48,31 @@ Examples:
 
     # View a specific block
     mempoor block get --height 0
+    mempoor block get --hash 9f86d081884c7d65...
+
+    # Preview what the next block would contain
+    mempoor block template
+
+    # Check how full blocks are and how long selection takes
+    mempoor block metrics
+
+    # Offer a block built elsewhere (JSON shaped like "block get")
+    mempoor block propose --file ./block.json
+
+    # Switch to a competing fork that pays more (complete block JSON)
+    mempoor block reorg --file ./fork.json --token $TOKEN
+
+    # Archive the chain, one JSON block per line
+    mempoor block export --out ./chain.jsonl
+
+    # Load it into another node (same genesis), extending or replacing
+    mempoor block import --file ./chain.jsonl --token $TOKEN
+
+    # Produce up to 5 blocks right away (stops when nothing is pending)
+    mempoor block mine --count 5
+
+    # Check that recorded blocks are reproducible
+    mempoor block replay --log ./replay.log
 `
 }
 
@@ -53,6 +91,22 @@ func (b *BlockArgs) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface
 		return b.list(ctx)
 	case "get":
 		return b.get(ctx, f.Args()[1:])
+	case "template":
+		return b.template(ctx)
+	case "metrics":
+		return b.metrics(ctx)
+	case "propose":
+		return b.submit(ctx, "block.propose", f.Args()[1:])
+	case "reorg":
+		return b.submit(ctx, "block.reorg", f.Args()[1:])
+	case "export":
+		return b.export(ctx, f.Args()[1:])
+	case "import":
+		return b.importChain(ctx, f.Args()[1:])
+	case "mine":
+		return b.mine(ctx, f.Args()[1:])
+	case "replay":
+		return b.replay(f.Args()[1:])
 	default:
 		fmt.Fprintf(os.Stderr, "unknown block command: %s\n", f.Arg(0))
 		return subcommands.ExitUsageError
@@ -60,18 +114,13 @@ func (b *BlockArgs) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface
 }
 
 func (b *BlockArgs) list(ctx context.Context) subcommands.ExitStatus {
-	params := map[string]interface{}{}
-
-	var result struct {
-		Blocks json.RawMessage `json:"blocks"`
-	}
-
-	if err := callRPC(b.NodeAddr, "block.list", params, &result); err != nil {
+	result, err := client.New(b.NodeAddr).BlockList(ctx, client.ListBlocksParams{})
+	if err != nil {
 		fmt.Println("error:", err)
 		return subcommands.ExitFailure
 	}
 
-	fmt.Println(string(result.Blocks))
+	printJSON(result.Blocks)
 	return subcommands.ExitSuccess
 }
 
@@ -79,26 +128,247 @@ func (b *BlockArgs) get(ctx context.Context, args []string) subcommands.ExitStat
 	fs := flag.NewFlagSet("block get", flag.ExitOnError)
 
 	var height uint64
+	var hash string
 	fs.Uint64Var(&height, "height", 0, "block height")
+	fs.StringVar(&hash, "hash", "", "block hash (hex), instead of --height")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+
+	params := client.BlockGetParams{Hash: hash}
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "height" {
+			params.Height = &height
+		}
+	})
+	if params.Height != nil && hash != "" {
+		fmt.Fprintln(os.Stderr, "--height and --hash are mutually exclusive")
+		return subcommands.ExitUsageError
+	}
+
+	result, err := client.New(b.NodeAddr).BlockGet(ctx, params)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result.Block)
+	return subcommands.ExitSuccess
+}
+
+func (b *BlockArgs) template(ctx context.Context) subcommands.ExitStatus {
+	result, err := client.New(b.NodeAddr).BlockTemplate(ctx)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result.Block)
+	return subcommands.ExitSuccess
+}
+
+func (b *BlockArgs) metrics(ctx context.Context) subcommands.ExitStatus {
+	result, err := client.New(b.NodeAddr).BlockMetrics(ctx)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result)
+	return subcommands.ExitSuccess
+}
+
+// submit sends the JSON block in --file to method, block.propose or
+// block.reorg.
+func (b *BlockArgs) submit(ctx context.Context, method string, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet(strings.Replace(method, ".", " ", 1), flag.ExitOnError)
+
+	var path, token string
+	fs.StringVar(&path, "file", "", "JSON block to submit, shaped like block get output")
+	fs.StringVar(&token, "token", "", "admin token, required by block reorg")
 
 	if err := fs.Parse(args); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return subcommands.ExitUsageError
 	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "--file is required")
+		return subcommands.ExitUsageError
+	}
 
-	params := map[string]interface{}{
-		"height": height,
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
 	}
 
-	var result struct {
-		Block json.RawMessage `json:"block"`
+	var block client.Block
+	if err := json.Unmarshal(raw, &block); err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
 	}
 
-	if err := callRPC(b.NodeAddr, "block.get", params, &result); err != nil {
+	c := client.New(b.NodeAddr)
+	c.Token = token
+	var result any
+	if method == "block.propose" {
+		result, err = c.BlockPropose(ctx, client.ProposeParams{Block: block})
+	} else {
+		result, err = c.BlockReorg(ctx, client.ReorgParams{Block: block})
+	}
+	if err != nil {
 		fmt.Println("error:", err)
 		return subcommands.ExitFailure
 	}
 
-	fmt.Println(string(result.Block))
+	printJSON(result)
+	return subcommands.ExitSuccess
+}
+
+func (b *BlockArgs) export(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("block export", flag.ExitOnError)
+
+	var path string
+	fs.StringVar(&path, "out", "", "file to write the chain to (empty = stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+
+	out := os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			fmt.Println("error:", err)
+			return subcommands.ExitFailure
+		}
+		defer f.Close()
+		out = f
+	}
+
+	stream, err := client.New(b.NodeAddr).BlockExport(ctx)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+	defer stream.Close()
+
+	if _, err := io.Copy(out, stream); err != nil {
+		fmt.Println("error: failed to read RPC stream:", err)
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+func (b *BlockArgs) importChain(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("block import", flag.ExitOnError)
+
+	var path, token string
+	fs.StringVar(&path, "file", "", "JSONL chain written by block export")
+	fs.StringVar(&token, "token", "", "admin token, required by block import")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "--file is required")
+		return subcommands.ExitUsageError
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	var blocks []client.Block
+	for i, line := range strings.Split(string(raw), "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		var block client.Block
+		if err := json.Unmarshal([]byte(line), &block); err != nil {
+			fmt.Printf("error: line %d: %v\n", i+1, err)
+			return subcommands.ExitFailure
+		}
+		blocks = append(blocks, block)
+	}
+
+	c := client.New(b.NodeAddr)
+	c.Token = token
+	result, err := c.BlockImport(ctx, client.ImportParams{Blocks: blocks})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result)
+	return subcommands.ExitSuccess
+}
+
+func (b *BlockArgs) mine(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("block mine", flag.ExitOnError)
+
+	var count int
+	fs.IntVar(&count, "count", 1, "most blocks to produce")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+
+	result, err := client.New(b.NodeAddr).DebugMine(ctx, client.MineParams{Count: count})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result)
+	return subcommands.ExitSuccess
+}
+
+func (b *BlockArgs) replay(args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("block replay", flag.ExitOnError)
+
+	var path string
+	fs.StringVar(&path, "log", "", "replay log written by mempoor start --replay-log")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "--log is required")
+		return subcommands.ExitUsageError
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+	defer f.Close()
+
+	report, err := mempoor.Replay(f, mempoor.MempoolConfig{})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	for _, mm := range report.Mismatches {
+		if mm.Err != nil {
+			fmt.Printf("MISMATCH height=%d want=%s error=%v\n", mm.Height, mm.Want, mm.Err)
+			continue
+		}
+		fmt.Printf("MISMATCH height=%d want=%s got=%s\n", mm.Height, mm.Want, mm.Got)
+	}
+	fmt.Printf("replayed %d blocks, %d mismatches\n", report.Blocks, len(report.Mismatches))
+	if len(report.Mismatches) > 0 {
+		return subcommands.ExitFailure
+	}
 	return subcommands.ExitSuccess
 }
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"mempoor/pkg/mempoor"
+
+	"github.com/google/subcommands"
+)
+
+type LightArgs struct {
+	peer          string
+	chainID       string
+	genesisHash   string
+	requireSigned bool
+	interval      time.Duration
+	logLevel      string
+	logFormat     string
+}
+
+func (*LightArgs) Name() string     { return "light" }
+func (*LightArgs) Synopsis() string { return "runs a header-only light client against a node" }
+func (*LightArgs) Usage() string {
+	return `light --peer <host:port> [--flags]
+
+Syncs block headers only from a full node (its block.headers RPC),
+without tx payloads, mempool, or block production. Every header is
+checked against the one before it: height, prevHash, timestamp, chain
+ID, tx count and gas, fee summary, and signature. Each header is kept
+with its hash and a root over its tx IDs. The client logs every batch
+it syncs; a header that fails is logged, and nothing from it on is
+kept.
+
+--chain-id must match the peer's genesis block; --genesis-hash pins the
+genesis block itself, so a peer on another chain is refused. With
+--require-signed every header above genesis must be signed.
+
+Examples:
+    mempoor light --peer localhost:8080
+    mempoor light --peer 10.0.0.2:8080 --chain-id testnet-1 --require-signed
+`
+}
+
+func (args *LightArgs) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&args.peer, "peer", "localhost:8080", "RPC address (host:port) of the full node to sync from")
+	fs.StringVar(&args.chainID, "chain-id", "", "chain ID the peer's genesis block must carry")
+	fs.StringVar(&args.genesisHash, "genesis-hash", "", "hex hash the peer's genesis block must have (empty = any)")
+	fs.BoolVar(&args.requireSigned, "require-signed", false, "reject headers above genesis without a signature")
+	fs.DurationVar(&args.interval, "interval", mempoor.DefaultPeerInterval, "time between syncs")
+	fs.StringVar(&args.logLevel, "log-level", "info", "least severe log level written: debug, info, warn, or error")
+	fs.StringVar(&args.logFormat, "log-format", "text", "log output: text (key=value) or json")
+}
+
+func (args *LightArgs) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	cfg := mempoor.LightConfig{
+		Peer:          args.peer,
+		ChainID:       args.chainID,
+		RequireSigned: args.requireSigned,
+		Interval:      args.interval,
+	}
+	if args.genesisHash != "" {
+		raw, err := hex.DecodeString(args.genesisHash)
+		if err != nil || len(raw) != len(cfg.GenesisHash) {
+			fmt.Fprintf(os.Stderr, "bad --genesis-hash %q\n", args.genesisHash)
+			return subcommands.ExitUsageError
+		}
+		copy(cfg.GenesisHash[:], raw)
+	}
+	level, err := mempoor.ParseLogLevel(args.logLevel)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+	format, err := mempoor.ParseLogFormat(args.logFormat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+	cfg.Logger = mempoor.NewLogger(os.Stdout, format, level)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	cfg.Logger.Info("started light client", "peer", args.peer)
+	mempoor.NewLightClient(cfg).Run(ctx)
+	return subcommands.ExitSuccess
+}
@@ -6,12 +6,47 @@ import (
 	"fmt"
 	"mempoor/pkg/mempoor"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/google/subcommands"
 )
 
 type NodeArgs struct {
 	listenAddr string
+	grpcListen string
+	dataDir    string
+	strategy   string
+	forkChoice string
+	bestOf     bool
+	replayLog  string
+	emptyBlks  bool
+	proposer   string
+	extraData  string
+	buildTO    time.Duration
+	baseFee    uint64
+	fillTarget int
+	compress   string
+	limitsFile string
+	genExtra   string
+	genBalance string
+	keyFile    string
+	retainBlks int
+	retainAge  time.Duration
+	drain      bool
+	config     string
+	interval   time.Duration
+	gasLimit   uint64
+	maxTx      int
+	minFee     uint64
+	chainID    string
+	logLevel   string
+	logFormat  string
+	peers      string
+	peerEvery  time.Duration
+	ckptEvery  int
 }
 
 func (*NodeArgs) Name() string { return "start" }
@@ -27,19 +62,306 @@ Starts the mempoor node, which runs:
   • Block builder (produces finalized blocks)
   • RPC server  (accepts CLI commands)
 
+Pending transactions are journaled to --data-dir as they change and
+reloaded on the next start, even after a crash; produced blocks are
+stored there too, in chain.db. Without --data-dir the mempool and the
+chain are in-memory only.
+
+--config reads node settings from a YAML file (JSON works too), so
+block parameters can change without a rebuild; flags given on the
+command line override it. Keys (all optional): listen, grpcListen,
+dataDir, chainId, peers, peerInterval, blockInterval, gasLimit,
+maxTxPerBlock, minFee, baseFee, maxMempoolTxs, strategy, bestOf,
+emptyBlocks, fillTarget, buildTimeout, forkChoice, proposer,
+extraData, blockCompression, keyFile, limitsFile, replayLog,
+retainBlocks, retainAge, drainOnShutdown, checkpointEvery, and genesis
+(extraData, balances). --block-interval, --gas-limit, --max-tx-per-block, and
+--min-fee set the block parameters directly.
+
+On SIGHUP the node re-reads --config and applies gasLimit,
+maxTxPerBlock, minFee, and blockInterval in one step, logging each
+change; other changed settings are logged as needing a restart. The
+mempool and chain are kept.
+
+--strategy picks how blocks are filled: "priority" (highest fee first,
+the default), "fifo" (arrival order), or "fair" (round-robin by sender,
+so no single sender can fill a block while others wait). With
+--best-of, every block is built under all strategies concurrently and
+the one with the highest total fee is produced.
+
+--fork-choice picks between competing blocks for the same height, from
+block.reorg or block.propose: "most-fees" (the branch paying more total
+fees, the default) or "longest-chain" (the longer branch; a tie keeps
+the block seen first).
+
+By default a tick with nothing to include produces no block; with
+--empty-blocks the node emits a zero-tx block instead, so the chain
+height and timestamps keep advancing.
+
+--base-fee turns on the fee market: every block burns that much per unit
+of gas, and txs are ordered by the tip left over for the proposer.
+
+--fill-target also produces a block as soon as pending gas reaches that
+percentage of the block gas limit, or a full block's worth of txs is
+waiting, instead of only on the tick; the tick remains the fallback.
+
+--build-timeout bounds how long selection may run for each block (the
+default is the block interval); a block whose selection runs out of
+time is produced with the txs selected so far.
+
+--chain-id names the network: it is recorded in the genesis block and
+every block header, and the node rejects txs for any other chain ID, so
+nodes of different networks never share txs or blocks. A data dir
+keeps the chain ID it was started with.
+
+--peers lists other nodes' RPC addresses to watch; their state and tip
+height are polled every --peer-interval and shown by "mempoor peer
+list".
+
+--proposer and --extra-data are stamped into every block header (and
+its hash) so blocks can be attributed; extra data is capped at 32 bytes.
+
+--key-file holds the node's ed25519 key, which signs every block it
+publishes; it is created on the first start. Without it the node signs
+with a fresh key each start.
+
+--checkpoint-every writes the chain tip, account balances and nonces,
+and a mempool snapshot to --data-dir every N blocks, so a restart
+replays account state from the last checkpoint instead of from genesis.
+
+--retain-blocks and --retain-age bound the blocks kept in full: a block
+outside both the last N blocks and the age window is pruned to its
+header (and tx IDs) in memory and on disk, so a long-running node does
+not grow without bound. Pruning cannot be combined with
+--genesis-balances.
+
+On SIGINT or SIGTERM the node stops taking RPCs, lets in-flight ones
+finish, and persists the mempool before exiting. With
+--drain-on-shutdown it first builds one final block from whatever is
+pending.
+
+--block-compression gzip keeps the tx payloads of stored blocks
+compressed, which pays off when payloads carry bulk data; blocks are
+decompressed transparently when read.
+
+--limits-file names a JSON file with any of gasLimit, maxTxPerBlock, and
+minFee; on SIGHUP the node re-reads it and applies it from the next
+block, without a restart. admin.setBlockLimits does the same over RPC.
+
+--replay-log appends every pool change and block to a file that
+"mempoor block replay" can re-derive the blocks from, for debugging.
+
+Logs are structured (log/slog): every line carries a component (node,
+rpc, mempool, builder, or chain). --log-format json writes one JSON
+object per line for log pipelines; --log-level debug adds a line per
+RPC call.
+
 Examples:
     mempoor start --listen 127.0.0.1:8080
+    mempoor start --listen 127.0.0.1:8080 --data-dir ./data
+    mempoor start --grpc-listen 127.0.0.1:9090
+    mempoor start --strategy fair
+    mempoor start --best-of
+    mempoor start --empty-blocks
+    mempoor start --fill-target 80
+    mempoor start --genesis-balances alice=1000,bob=500
+    mempoor start --config ./node.yaml --min-fee 5
+    mempoor start --key-file ./node.key
+    mempoor start --data-dir ./data --retain-blocks 10000 --retain-age 24h
 `
 }
 
 func (args *NodeArgs) SetFlags(fs *flag.FlagSet) {
 	fs.StringVar(&args.listenAddr, "listen", "127.0.0.1:8080", "address for the node to listen on")
+	fs.StringVar(&args.grpcListen, "grpc-listen", "", "address to serve the gRPC API on (empty = no gRPC server)")
+	fs.StringVar(&args.dataDir, "data-dir", "", "directory for persisted node state (empty = in-memory only)")
+	fs.StringVar(&args.config, "config", "", "YAML (or JSON) file with node settings; flags given here override it")
+	fs.DurationVar(&args.interval, "block-interval", 2*time.Second, "time between blocks")
+	fs.Uint64Var(&args.gasLimit, "gas-limit", 1_000_000, "max gas per block (0 = unlimited)")
+	fs.IntVar(&args.maxTx, "max-tx-per-block", 1000, "max txs per block")
+	fs.Uint64Var(&args.minFee, "min-fee", 0, "min fee for a tx to be selected")
+	fs.StringVar(&args.strategy, "strategy", "priority", "block fill order: priority, fifo, or fair")
+	fs.BoolVar(&args.bestOf, "best-of", false, "build candidate blocks under every strategy and keep the highest-fee one")
+	fs.StringVar(&args.forkChoice, "fork-choice", "most-fees", "rule between competing blocks: most-fees or longest-chain")
+	fs.BoolVar(&args.emptyBlks, "empty-blocks", false, "produce a zero-tx block on ticks with nothing to include")
+	fs.Uint64Var(&args.baseFee, "base-fee", 0, "fee burned per unit of gas in every block (0 = no fee market)")
+	fs.IntVar(&args.fillTarget, "fill-target", 0, "also build once pending gas reaches this % of the gas limit (0 = ticker only)")
+	fs.DurationVar(&args.buildTO, "build-timeout", 0, "max selection time per block (0 = block interval)")
+	fs.StringVar(&args.chainID, "chain-id", "", "network name recorded in every block; txs must carry it (empty = none)")
+	fs.StringVar(&args.peers, "peers", "", "comma-separated RPC addresses (host:port) of peer nodes to watch")
+	fs.DurationVar(&args.peerEvery, "peer-interval", mempoor.DefaultPeerInterval, "how often peers are polled")
+	fs.StringVar(&args.proposer, "proposer", "", "proposer identity recorded in every block header")
+	fs.StringVar(&args.extraData, "extra-data", "", "free-form data (max 32 bytes) recorded in every block header")
+	fs.StringVar(&args.genExtra, "genesis-extra-data", "", "free-form data (max 32 bytes) recorded in the genesis block")
+	fs.StringVar(&args.genBalance, "genesis-balances", "", "initial balances in the genesis block, e.g. alice=1000,bob=500")
+	fs.StringVar(&args.keyFile, "key-file", "", "file holding the node's block signing key; created if missing (empty = fresh key each start)")
+	fs.IntVar(&args.retainBlks, "retain-blocks", 0, "keep the last N blocks in full, pruning older ones to headers (0 = no block-count window)")
+	fs.DurationVar(&args.retainAge, "retain-age", 0, "keep blocks younger than this in full (0 = no age window)")
+	fs.IntVar(&args.ckptEvery, "checkpoint-every", 0, "checkpoint the chain tip, account state, and mempool every N blocks (0 = never; needs --data-dir)")
+	fs.BoolVar(&args.drain, "drain-on-shutdown", false, "build one final block from pending txs before exiting")
+	fs.StringVar(&args.compress, "block-compression", "none", "compress tx payloads of stored blocks: none or gzip")
+	fs.StringVar(&args.limitsFile, "limits-file", "", "JSON block limits re-read on SIGHUP (gasLimit, maxTxPerBlock, minFee)")
+	fs.StringVar(&args.logLevel, "log-level", "info", "least severe log level written: debug, info, warn, or error")
+	fs.StringVar(&args.logFormat, "log-format", "text", "log output: text (key=value) or json")
+	fs.StringVar(&args.replayLog, "replay-log", "", "file to record pool changes and blocks to, for block replay")
 }
 
 func (args *NodeArgs) Execute(ctx context.Context, flagSet *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
-	if err := mempoor.StartNode(ctx, args.listenAddr); err != nil {
+	cfg := mempoor.DefaultNodeConfig(args.listenAddr, args.dataDir)
+	cfg.ConfigFile = args.config
+	if args.config != "" {
+		file, err := mempoor.ReadConfigFile(args.config)
+		if err == nil {
+			err = file.Apply(&cfg)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return subcommands.ExitUsageError
+		}
+	}
+
+	// Flags given on the command line override the config file.
+	if err := args.override(&cfg, flagSet); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := mempoor.RunNode(ctx, cfg); err != nil {
 		fmt.Fprintf(os.Stderr, "node error: %v\n", err)
 		return subcommands.ExitFailure
 	}
 	return subcommands.ExitSuccess
 }
+
+// override sets the fields of cfg whose flags were given in flagSet.
+func (args *NodeArgs) override(cfg *mempoor.NodeConfig, flagSet *flag.FlagSet) error {
+	given := make(map[string]bool)
+	flagSet.Visit(func(f *flag.Flag) { given[f.Name] = true })
+
+	if given["listen"] {
+		cfg.ListenAddr = args.listenAddr
+	}
+	if given["grpc-listen"] {
+		cfg.GRPCListenAddr = args.grpcListen
+	}
+	if given["data-dir"] {
+		cfg.DataDir = args.dataDir
+	}
+	if given["block-interval"] {
+		cfg.BlockInterval = args.interval
+	}
+	if given["gas-limit"] {
+		cfg.GasLimit = args.gasLimit
+	}
+	if given["max-tx-per-block"] {
+		cfg.MaxTxPerBlock = args.maxTx
+	}
+	if given["min-fee"] {
+		cfg.MinFee = args.minFee
+	}
+	if given["strategy"] {
+		strategy, err := mempoor.ParseSelectionStrategy(args.strategy)
+		if err != nil {
+			return err
+		}
+		cfg.Strategy = strategy
+	}
+	if given["fork-choice"] {
+		forkChoice, err := mempoor.ParseForkChoice(args.forkChoice)
+		if err != nil {
+			return err
+		}
+		cfg.ForkChoice = forkChoice
+	}
+	if given["best-of"] {
+		cfg.Candidates = nil
+		if args.bestOf {
+			cfg.Candidates = mempoor.DefaultBlockCandidates
+		}
+	}
+	if given["empty-blocks"] {
+		cfg.ProduceEmptyBlocks = args.emptyBlks
+	}
+	if given["build-timeout"] {
+		cfg.BuildTimeout = args.buildTO
+	}
+	if given["fill-target"] {
+		cfg.FillTarget = args.fillTarget
+	}
+	if given["block-compression"] {
+		compression, err := mempoor.ParsePayloadCompression(args.compress)
+		if err != nil {
+			return err
+		}
+		cfg.BlockCompression = compression
+	}
+	if given["limits-file"] {
+		cfg.LimitsFile = args.limitsFile
+	}
+	if given["base-fee"] {
+		cfg.BaseFee = args.baseFee
+	}
+	if given["log-level"] {
+		level, err := mempoor.ParseLogLevel(args.logLevel)
+		if err != nil {
+			return err
+		}
+		cfg.LogLevel = level
+	}
+	if given["log-format"] {
+		format, err := mempoor.ParseLogFormat(args.logFormat)
+		if err != nil {
+			return err
+		}
+		cfg.LogFormat = format
+	}
+	if given["chain-id"] {
+		cfg.ChainID = args.chainID
+	}
+	if given["peers"] {
+		cfg.Peers = nil
+		if args.peers != "" {
+			cfg.Peers = strings.Split(args.peers, ",")
+		}
+	}
+	if given["peer-interval"] {
+		cfg.PeerInterval = args.peerEvery
+	}
+	if given["proposer"] {
+		cfg.Proposer = args.proposer
+	}
+	if given["extra-data"] {
+		cfg.ExtraData = []byte(args.extraData)
+	}
+	if given["genesis-balances"] {
+		balances, err := mempoor.ParseGenesisBalances(args.genBalance)
+		if err != nil {
+			return err
+		}
+		cfg.Genesis.Balances = balances
+	}
+	if given["genesis-extra-data"] {
+		cfg.Genesis.ExtraData = []byte(args.genExtra)
+	}
+	if given["replay-log"] {
+		cfg.ReplayLog = args.replayLog
+	}
+	if given["key-file"] {
+		cfg.KeyFile = args.keyFile
+	}
+	if given["retain-blocks"] {
+		cfg.Retention.Blocks = args.retainBlks
+	}
+	if given["retain-age"] {
+		cfg.Retention.Age = args.retainAge
+	}
+	if given["drain-on-shutdown"] {
+		cfg.DrainOnShutdown = args.drain
+	}
+	if given["checkpoint-every"] {
+		cfg.CheckpointEvery = args.ckptEvery
+	}
+	return nil
+}
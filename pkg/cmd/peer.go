@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"mempoor/pkg/client"
+
+	"github.com/google/subcommands"
+)
+
+type PeerArgs struct {
+	NodeAddr string
+}
+
+func (*PeerArgs) Name() string     { return "peer" }
+func (*PeerArgs) Synopsis() string { return "manage the peer nodes a node watches" }
+func (*PeerArgs) Usage() string {
+	return `peer <command> [--flags]
+
+Peer commands. A peer is another mempoor node, named by its RPC address
+(host:port). The node polls every peer's status and reports whether it
+answers, its last seen chain height, and how many requests it sent and
+got answered. Peers added here last until the node restarts; use
+"mempoor start --peers" for peers that should always be there.
+
+Commands:
+    add       Start watching a peer
+    remove    Stop watching a peer
+    list      Show every peer with its state, height, and counters
+
+Examples:
+    mempoor peer add --address 10.0.0.2:8080 --token $TOKEN
+    mempoor peer list
+    mempoor peer remove --address 10.0.0.2:8080 --token $TOKEN
+`
+}
+
+func (p *PeerArgs) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&p.NodeAddr, "addr", "localhost:8080", "address of running mempoor node")
+}
+
+func (p *PeerArgs) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() == 0 {
+		fmt.Println(p.Usage())
+		return subcommands.ExitUsageError
+	}
+
+	switch f.Arg(0) {
+	case "add":
+		return p.change(ctx, "peer.add", f.Args()[1:])
+	case "remove":
+		return p.change(ctx, "peer.remove", f.Args()[1:])
+	case "list":
+		return p.list(ctx)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown peer command: %s\n", f.Arg(0))
+		return subcommands.ExitUsageError
+	}
+}
+
+func (p *PeerArgs) change(ctx context.Context, method string, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet(method, flag.ExitOnError)
+
+	var address, token string
+	fs.StringVar(&address, "address", "", "peer RPC address (host:port)")
+	fs.StringVar(&token, "token", "", "admin token, required by peer add and remove")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+	if address == "" {
+		fmt.Fprintln(os.Stderr, "--address is required")
+		return subcommands.ExitUsageError
+	}
+
+	c := client.New(p.NodeAddr)
+	c.Token = token
+	change := c.PeerAdd
+	if method == "peer.remove" {
+		change = c.PeerRemove
+	}
+	result, err := change(ctx, client.PeerParams{Address: address})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+	printPeers(result.Peers)
+	return subcommands.ExitSuccess
+}
+
+func (p *PeerArgs) list(ctx context.Context) subcommands.ExitStatus {
+	result, err := client.New(p.NodeAddr).PeerList(ctx)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+	printPeers(result.Peers)
+	return subcommands.ExitSuccess
+}
+
+func printPeers(peers []client.PeerInfo) {
+	if len(peers) == 0 {
+		fmt.Println("no peers")
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ADDRESS\tSTATE\tHEIGHT\tLAST SEEN\tSENT\tRECEIVED\tFAILURES\tERROR")
+	for _, peer := range peers {
+		seen := "-"
+		if !peer.LastSeen.IsZero() {
+			seen = peer.LastSeen.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%d\t%d\t%d\t%s\n", peer.Address, peer.State, peer.LastSeenHeight,
+			seen, peer.Sent, peer.Received, peer.Failures, peer.LastError)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"mempoor/pkg/client"
+
+	"github.com/google/subcommands"
+)
+
+type StatusArgs struct {
+	NodeAddr string
+	JSON     bool
+}
+
+func (*StatusArgs) Name() string     { return "status" }
+func (*StatusArgs) Synopsis() string { return "show a running node's status" }
+func (*StatusArgs) Usage() string {
+	return `status [--flags]
+
+Shows the node's version, uptime, chain tip, pending txs and gas, live
+block limits, and block production stats.
+
+Examples:
+    mempoor status
+    mempoor status --json
+`
+}
+
+func (s *StatusArgs) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&s.NodeAddr, "addr", "localhost:8080", "address of running mempoor node")
+	fs.BoolVar(&s.JSON, "json", false, "print the raw node.status result")
+}
+
+func (s *StatusArgs) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	st, err := client.New(s.NodeAddr).NodeStatus(ctx)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+	if s.JSON {
+		printJSON(st)
+		return subcommands.ExitSuccess
+	}
+
+	chainID := st.ChainID
+	if chainID == "" {
+		chainID = "(none)"
+	}
+	fmt.Printf("version     %s\n", st.Version)
+	fmt.Printf("chain       %s\n", chainID)
+	fmt.Printf("uptime      %s (since %s)\n", st.Uptime, st.StartedAt.Format("2006-01-02 15:04:05Z07:00"))
+	fmt.Printf("tip         height=%d hash=%s\n", st.Height, st.TipHash)
+	fmt.Printf("mempool     txs=%d gas=%d\n", st.PendingTxs, st.PendingGas)
+	fmt.Printf("limits      interval=%s gasLimit=%d maxTxPerBlock=%d minFee=%d (version %d)\n",
+		st.Limits.BlockInterval, st.Limits.GasLimit, st.Limits.MaxTxPerBlock, st.Limits.MinFee, st.Limits.Version)
+	p := st.Production
+	fmt.Printf("production  builds=%d empty=%d interrupted=%d avgSelection=%.2fms avgGasFill=%.1f%% totalFees=%d\n",
+		p.Builds, p.EmptyBuilds, p.InterruptedBuilds, p.AvgSelectionMs, p.AvgGasFill*100, p.TotalFees)
+	return subcommands.ExitSuccess
+}
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
+
+	"mempoor/pkg/client"
+	"mempoor/pkg/mempoor"
 
 	"github.com/google/subcommands"
 )
@@ -15,7 +21,7 @@ type TxArgs struct {
 }
 
 func (*TxArgs) Name() string     { return "tx" }
-func (*TxArgs) Synopsis() string { return "transaction operations: add, update, remove, list" }
+func (*TxArgs) Synopsis() string { return "transaction operations and fee estimates" }
 func (*TxArgs) Usage() string {
 	return `tx <command> [--flags]
 
@@ -26,10 +32,18 @@ in a block. "mempoor tx list" shows these transactions in PRIORITY ORDER,
 i.e., the order in which the next block would include them.
 
 Commands:
-    add        Add a new transaction to the mempool
-    update     Update the fee of an existing transaction
-    remove     Remove a transaction from the mempool
-    list       List current mempool transactions (priority-ordered)
+    add           Add a new transaction to the mempool
+    update        Update the fee of an existing transaction
+    remove        Remove a transaction, or all of a sender's, from the mempool
+    get           Show a pending transaction and its place in the priority order
+    list          List current mempool transactions (priority-ordered)
+    top           Show the N highest-priority pending transactions
+    history       Show prior fee versions of a fee-bumped transaction
+    scheduled     List transactions waiting for their --delay to pass
+    status        Show where a transaction is: pending, selected, included, or dropped
+    receipt       Confirm a transaction's inclusion: block, position, gas, and fee paid
+    fee-estimate  Suggest fees from pending and recently included txs
+    batch         Send many RPC calls from a JSONL file in one round-trip
 
 Examples:
     # Add a transaction (pending in mempool)
@@ -38,11 +52,52 @@ Examples:
     # View pending transactions (mempool view)
     mempoor tx list
 
+    # Page through pending transactions, oldest first
+    mempoor tx list --sort age --offset 0 --limit 20
+
+    # Inspect one sender's traffic above a fee floor
+    mempoor tx list --sender alice --min-fee 10
+
+    # What ranks highest right now
+    mempoor tx top --n 5
+
+    # Add to, and list, a named mempool partition
+    mempoor tx add --sender alice --recipient bob --fee 10 --gas 500 --pool data
+    mempoor tx list --pool data
+
     # Update fee (RBF-like behavior)
     mempoor tx update --id <txid> --fee 100
 
     # Remove a pending tx
     mempoor tx remove --id <txid>
+
+    # Clear every pending tx from a misbehaving account
+    mempoor tx remove --sender mallory
+
+    # Inspect one pending tx: position 0 is next in line
+    mempoor tx get --id <txid>
+
+    # Audit fee bumps of a pending tx
+    mempoor tx history --id <txid>
+
+    # Offer at most 900 in total, of which at most 50 tips the proposer
+    mempoor tx add --sender alice --recipient bob --max-fee 900 --tip 50 --gas 500
+
+    # Hold a tx for a minute before it can be mined
+    mempoor tx add --sender alice --recipient bob --fee 10 --gas 500 --delay 1m
+
+    # Follow a tx after it leaves the mempool
+    mempoor tx status --id <txid>
+
+    # Confirm it made it into the chain, and what it paid
+    mempoor tx receipt --id <txid>
+
+    # Pick a fee likely to make the next few blocks
+    mempoor tx fee-estimate
+
+    # Add and bump dozens of txs per request: one {"method", "params"}
+    # object per line, answered in order
+    mempoor tx batch --file ./calls.jsonl
 `
 }
 
@@ -64,7 +119,23 @@ func (t *TxArgs) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{})
 	case "remove":
 		return t.remove(ctx, f.Args()[1:])
 	case "list":
-		return t.list(ctx)
+		return t.list(ctx, f.Args()[1:])
+	case "top":
+		return t.top(ctx, f.Args()[1:])
+	case "get":
+		return t.get(ctx, f.Args()[1:])
+	case "history":
+		return t.history(ctx, f.Args()[1:])
+	case "scheduled":
+		return t.scheduled(ctx)
+	case "status":
+		return t.status(ctx, f.Args()[1:])
+	case "receipt":
+		return t.receipt(ctx, f.Args()[1:])
+	case "fee-estimate":
+		return t.feeEstimate(ctx)
+	case "batch":
+		return t.batch(ctx, f.Args()[1:])
 	default:
 		fmt.Fprintf(os.Stderr, "unknown tx command: %s\n", f.Arg(0))
 		return subcommands.ExitUsageError
@@ -74,38 +145,74 @@ func (t *TxArgs) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{})
 func (t *TxArgs) add(ctx context.Context, args []string) subcommands.ExitStatus {
 	fs := flag.NewFlagSet("tx add", flag.ExitOnError)
 
-	var sender, recipient, payload string
-	var fee, gas uint64
+	var sender, recipient, payload, parent, dependsOn, lane, pool, chainID string
+	var nonce, fee, maxFee, tip, gas, amount uint64
+	var delay time.Duration
 
 	fs.StringVar(&sender, "sender", "", "sender address")
 	fs.StringVar(&recipient, "recipient", "", "recipient address")
 	fs.StringVar(&payload, "payload", "", "payload")
+	fs.Uint64Var(&nonce, "nonce", 0, "sender nonce (txs from a sender are included in nonce order)")
 	fs.Uint64Var(&fee, "fee", 0, "transaction fee")
+	fs.Uint64Var(&maxFee, "max-fee", 0, "dynamic fee: most the tx pays in total, base fee included")
+	fs.Uint64Var(&tip, "tip", 0, "dynamic fee: most of --max-fee offered to the proposer")
 	fs.Uint64Var(&gas, "gas", 0, "gas limit for transaction")
+	fs.Uint64Var(&amount, "amount", 0, "optional value moved from sender to recipient")
+	fs.StringVar(&parent, "parent", "", "optional parent tx ID that must be pending first")
+	fs.StringVar(&dependsOn, "depends-on", "", "optional comma-separated tx IDs that must be in a block first")
+	fs.StringVar(&lane, "lane", "normal", "priority lane: urgent, normal, or low")
+	fs.DurationVar(&delay, "delay", 0, "optional delay before the tx becomes eligible, e.g. 30s")
+	fs.StringVar(&pool, "pool", "", "optional named mempool to add the tx to (default pool if empty)")
+	fs.StringVar(&chainID, "chain-id", "", "chain ID of the network the tx is for; must match the node's")
 
 	if err := fs.Parse(args); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return subcommands.ExitUsageError
 	}
 
-	params := map[string]interface{}{
-		"sender":    sender,
-		"recipient": recipient,
-		"payload":   payload,
-		"fee":       fee,
-		"gas":       gas,
+	params := client.AddTxParams{
+		Sender:    sender,
+		Recipient: recipient,
+		Payload:   payload,
+		Nonce:     nonce,
+		Fee:       fee,
+		Gas:       gas,
+		Amount:    amount,
+		ChainID:   chainID,
+		ParentID:  parent,
+		Lane:      lane,
+		Pool:      pool,
 	}
-
-	var result struct {
-		TxID string `json:"txID"`
+	if dependsOn != "" {
+		params.DependsOn = strings.Split(dependsOn, ",")
+	}
+	if maxFee > 0 {
+		params.MaxFee = maxFee
+		params.Tip = tip
+	}
+	if delay > 0 {
+		params.NotBefore = time.Now().Add(delay)
 	}
 
-	if err := callRPC(t.NodeAddr, "tx.add", params, &result); err != nil {
+	result, err := client.New(t.NodeAddr).TxAdd(ctx, params)
+	if err != nil {
 		fmt.Println("error:", err)
 		return subcommands.ExitFailure
 	}
 
 	fmt.Println("tx added:", result.TxID)
+	if result.Orphan {
+		fmt.Println("tx is orphaned until its dependency arrives")
+	}
+	if result.Scheduled {
+		fmt.Println("tx is scheduled and waits until its delay has passed")
+	}
+	if result.Merged {
+		fmt.Println("tx merged into an identical pending tx")
+	}
+	for _, id := range result.Evicted {
+		fmt.Println("tx evicted:", id)
+	}
 	return subcommands.ExitSuccess
 }
 
@@ -114,25 +221,24 @@ func (t *TxArgs) update(ctx context.Context, args []string) subcommands.ExitStat
 
 	var id string
 	var fee uint64
+	var tip int64
 
 	fs.StringVar(&id, "id", "", "transaction ID")
-	fs.Uint64Var(&fee, "fee", 0, "new fee")
+	fs.Uint64Var(&fee, "fee", 0, "new fee (the new max fee for a dynamic-fee tx)")
+	fs.Int64Var(&tip, "tip", -1, "new tip for a dynamic-fee tx (-1 = keep)")
 
 	if err := fs.Parse(args); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return subcommands.ExitUsageError
 	}
 
-	params := map[string]interface{}{
-		"id":  id,
-		"fee": fee,
+	params := client.UpdateTxParams{ID: id, Fee: fee}
+	if tip >= 0 {
+		newTip := uint64(tip)
+		params.Tip = &newTip
 	}
 
-	var ok struct {
-		OK bool `json:"ok"`
-	}
-
-	if err := callRPC(t.NodeAddr, "tx.update", params, &ok); err != nil {
+	if _, err := client.New(t.NodeAddr).TxUpdate(ctx, params); err != nil {
 		fmt.Println("error:", err)
 		return subcommands.ExitFailure
 	}
@@ -144,6 +250,115 @@ func (t *TxArgs) update(ctx context.Context, args []string) subcommands.ExitStat
 func (t *TxArgs) remove(ctx context.Context, args []string) subcommands.ExitStatus {
 	fs := flag.NewFlagSet("tx remove", flag.ExitOnError)
 
+	var id, sender, token string
+	fs.StringVar(&id, "id", "", "transaction ID")
+	fs.StringVar(&sender, "sender", "", "remove every pending transaction from this sender instead")
+	fs.StringVar(&token, "token", "", "admin token, required by --sender once the node has one")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+
+	if sender != "" {
+		return t.removeBySender(ctx, sender, token)
+	}
+
+	if _, err := client.New(t.NodeAddr).TxRemove(ctx, client.RemoveTxParams{ID: id}); err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	fmt.Println("tx removed")
+	return subcommands.ExitSuccess
+}
+
+// removeBySender removes sender's pending txs through admin.purgeSender.
+func (t *TxArgs) removeBySender(ctx context.Context, sender, token string) subcommands.ExitStatus {
+	c := client.New(t.NodeAddr)
+	c.Token = token
+	result, err := c.AdminPurgeSender(ctx, client.PurgeSenderParams{Sender: sender})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	fmt.Printf("%d txs removed\n", len(result.Removed))
+	return subcommands.ExitSuccess
+}
+
+func (t *TxArgs) list(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("tx list", flag.ExitOnError)
+
+	var offset, limit int
+	var order, sender, recipient, pool string
+	var minFee, maxFee, minGas uint64
+
+	fs.IntVar(&offset, "offset", 0, "number of txs to skip")
+	fs.IntVar(&limit, "limit", 0, "max txs to return (0 = all)")
+	fs.StringVar(&order, "sort", "priority", "sort order: priority, fee, age, sender")
+	fs.StringVar(&sender, "sender", "", "only txs from this sender")
+	fs.StringVar(&recipient, "recipient", "", "only txs to this recipient")
+	fs.Uint64Var(&minFee, "min-fee", 0, "only txs with fee >= min-fee")
+	fs.Uint64Var(&maxFee, "max-fee", 0, "only txs with fee <= max-fee (0 = no max)")
+	fs.Uint64Var(&minGas, "min-gas", 0, "only txs with gas >= min-gas")
+	fs.StringVar(&pool, "pool", "", "named mempool to list (default pool if empty)")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+
+	result, err := client.New(t.NodeAddr).TxList(ctx, client.ListTxParams{
+		Offset:    offset,
+		Limit:     limit,
+		Sort:      order,
+		Pool:      pool,
+		Sender:    sender,
+		Recipient: recipient,
+		MinFee:    minFee,
+		MaxFee:    maxFee,
+		MinGas:    minGas,
+	})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result.Transactions)
+	if n := len(result.Transactions); n < result.Total {
+		// On stderr, so stdout stays a JSON array for scripts.
+		fmt.Fprintf(os.Stderr, "%d of %d matching txs (offset %d)\n", n, result.Total, offset)
+	}
+	return subcommands.ExitSuccess
+}
+
+func (t *TxArgs) top(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("tx top", flag.ExitOnError)
+
+	var n int
+	var pool string
+	fs.IntVar(&n, "n", 10, "number of txs to show")
+	fs.StringVar(&pool, "pool", "", "named mempool to show (default pool if empty)")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+
+	result, err := client.New(t.NodeAddr).TxTop(ctx, client.TopTxParams{N: n, Pool: pool})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result.Transactions)
+	return subcommands.ExitSuccess
+}
+
+func (t *TxArgs) get(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("tx get", flag.ExitOnError)
+
 	var id string
 	fs.StringVar(&id, "id", "", "transaction ID")
 
@@ -152,33 +367,174 @@ func (t *TxArgs) remove(ctx context.Context, args []string) subcommands.ExitStat
 		return subcommands.ExitUsageError
 	}
 
-	params := map[string]interface{}{"id": id}
+	result, err := client.New(t.NodeAddr).TxGet(ctx, client.TxGetParams{ID: id})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result)
+	return subcommands.ExitSuccess
+}
 
-	var ok struct {
-		OK bool `json:"ok"`
+func (t *TxArgs) history(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("tx history", flag.ExitOnError)
+
+	var id string
+	fs.StringVar(&id, "id", "", "transaction ID")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
 	}
 
-	if err := callRPC(t.NodeAddr, "tx.remove", params, &ok); err != nil {
+	result, err := client.New(t.NodeAddr).TxHistory(ctx, client.TxHistoryParams{ID: id})
+	if err != nil {
 		fmt.Println("error:", err)
 		return subcommands.ExitFailure
 	}
 
-	fmt.Println("tx removed")
+	printJSON(result)
+	return subcommands.ExitSuccess
+}
+
+func (t *TxArgs) status(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("tx status", flag.ExitOnError)
+
+	var id string
+	fs.StringVar(&id, "id", "", "transaction ID")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+
+	result, err := client.New(t.NodeAddr).TxStatus(ctx, client.TxStatusParams{ID: id})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result)
 	return subcommands.ExitSuccess
 }
 
-func (t *TxArgs) list(ctx context.Context) subcommands.ExitStatus {
-	params := map[string]interface{}{}
+func (t *TxArgs) receipt(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("tx receipt", flag.ExitOnError)
+
+	var id string
+	fs.StringVar(&id, "id", "", "transaction ID")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+
+	result, err := client.New(t.NodeAddr).TxReceipt(ctx, client.TxReceiptParams{ID: id})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	printJSON(result)
+	return subcommands.ExitSuccess
+}
 
-	var result struct {
-		Transactions json.RawMessage `json:"transactions"`
+func (t *TxArgs) scheduled(ctx context.Context) subcommands.ExitStatus {
+	result, err := client.New(t.NodeAddr).TxScheduled(ctx, client.ScheduledParams{})
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
 	}
 
-	if err := callRPC(t.NodeAddr, "tx.list", params, &result); err != nil {
+	printJSON(result)
+	return subcommands.ExitSuccess
+}
+
+func (t *TxArgs) feeEstimate(ctx context.Context) subcommands.ExitStatus {
+	result, err := client.New(t.NodeAddr).FeeEstimate(ctx)
+	if err != nil {
 		fmt.Println("error:", err)
 		return subcommands.ExitFailure
 	}
 
-	fmt.Println(string(result.Transactions))
+	printJSON(result)
 	return subcommands.ExitSuccess
 }
+
+func (t *TxArgs) batch(ctx context.Context, args []string) subcommands.ExitStatus {
+	fs := flag.NewFlagSet("tx batch", flag.ExitOnError)
+
+	var path string
+	fs.StringVar(&path, "file", "", `JSONL file of RPC calls, one {"method": ..., "params": ...} per line`)
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return subcommands.ExitUsageError
+	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "--file is required")
+		return subcommands.ExitUsageError
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+	defer f.Close()
+
+	var reqs []client.Request
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 16<<20)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			fmt.Printf("error: line %d: %v\n", len(reqs)+1, err)
+			return subcommands.ExitFailure
+		}
+		reqs = append(reqs, client.Request{Method: req.Method, Params: req.Params})
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Println("error:", err)
+		return subcommands.ExitFailure
+	}
+
+	// One round-trip per mempoor.MaxRPCBatch calls.
+	c := client.New(t.NodeAddr)
+	status := subcommands.ExitSuccess
+	for start := 0; start < len(reqs); start += mempoor.MaxRPCBatch {
+		chunk := reqs[start:min(start+mempoor.MaxRPCBatch, len(reqs))]
+		results, err := c.Batch(ctx, chunk)
+		if err != nil {
+			fmt.Println("error:", err)
+			return subcommands.ExitFailure
+		}
+		for i, res := range results {
+			if res.Error != "" {
+				status = subcommands.ExitFailure
+				fmt.Printf("%d %s error: %s\n", start+i+1, chunk[i].Method, res.Error)
+				continue
+			}
+			fmt.Printf("%d %s %s\n", start+i+1, chunk[i].Method, res.Result)
+		}
+	}
+	return status
+}
+
+// printJSON prints v as one line of JSON.
+func printJSON(v any) {
+	out, err := json.Marshal(v)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println(string(out))
+}
@@ -0,0 +1,102 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrSenderDenied is returned by Add for a sender the ACL rejects.
+var ErrSenderDenied = errors.New("mempool: sender not permitted")
+
+// SenderACL lists senders explicitly allowed or denied at admission.
+//
+// ACL semantics:
+//   - A denied sender is always rejected.
+//   - If Allow is non-empty, only allowed senders are admitted.
+//   - Only Add/AddBatch are checked; txs already pending stay pending.
+type SenderACL struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+// AccessRule is the per-sender entry set by SetSenderAccess.
+type AccessRule int
+
+const (
+	AccessDefault AccessRule = iota // on neither list
+	AccessAllow                     // on the allowlist
+	AccessDeny                      // on the denylist
+)
+
+func (r AccessRule) String() string {
+	switch r {
+	case AccessDefault:
+		return "default"
+	case AccessAllow:
+		return "allow"
+	case AccessDeny:
+		return "deny"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseAccessRule maps "default", "allow", or "deny" to an AccessRule.
+func ParseAccessRule(name string) (AccessRule, error) {
+	switch name {
+	case "default":
+		return AccessDefault, nil
+	case "allow":
+		return AccessAllow, nil
+	case "deny":
+		return AccessDeny, nil
+	default:
+		return 0, fmt.Errorf("mempool: unknown access rule %q", name)
+	}
+}
+
+// senderPermitted applies the ACL to sender. Caller must hold the lock.
+func (m *mempool) senderPermitted(sender string) bool {
+	if _, denied := m.deny[sender]; denied {
+		return false
+	}
+	if len(m.allow) == 0 {
+		return true
+	}
+	_, allowed := m.allow[sender]
+	return allowed
+}
+
+// SetSenderAccess moves sender onto the allowlist, the denylist, or off
+// both. It takes effect for the next Add.
+func (m *mempool) SetSenderAccess(sender string, rule AccessRule) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.allow, sender)
+	delete(m.deny, sender)
+	switch rule {
+	case AccessAllow:
+		m.allow[sender] = struct{}{}
+	case AccessDeny:
+		m.deny[sender] = struct{}{}
+	}
+}
+
+// SenderACL returns the current lists, each sorted.
+func (m *mempool) SenderACL() SenderACL {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return SenderACL{Allow: sortedKeys(m.allow), Deny: sortedKeys(m.deny)}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
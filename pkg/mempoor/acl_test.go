@@ -0,0 +1,43 @@
+package mempoor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSenderACLDenylist(t *testing.T) {
+	mp := NewMempool(MempoolConfig{SenderACL: SenderACL{Deny: []string{"mallory"}}})
+
+	if _, err := mp.Add(newTx("mallory", 10, 10)); !errors.Is(err, ErrSenderDenied) {
+		t.Fatalf("expected ErrSenderDenied, got %v", err)
+	}
+	if _, err := mp.Add(newTx("alice", 10, 10)); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+
+	mp.SetSenderAccess("mallory", AccessDefault)
+	if _, err := mp.Add(newTx("mallory", 10, 10)); err != nil {
+		t.Fatalf("expected mallory admitted after un-deny, got %v", err)
+	}
+}
+
+func TestSenderACLAllowlist(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	mp.SetSenderAccess("alice", AccessAllow)
+
+	if _, err := mp.Add(newTx("bob", 10, 10)); !errors.Is(err, ErrSenderDenied) {
+		t.Fatalf("expected bob rejected by allowlist, got %v", err)
+	}
+	if _, err := mp.Add(newTx("alice", 10, 10)); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+
+	// Deny wins over a previous allow for the same sender.
+	mp.SetSenderAccess("alice", AccessDeny)
+	mp.SetSenderAccess("carol", AccessAllow)
+	want := SenderACL{Allow: []string{"carol"}, Deny: []string{"alice"}}
+	if got := mp.SenderACL(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected ACL: got %+v want %+v", got, want)
+	}
+}
@@ -0,0 +1,65 @@
+package mempoor
+
+import (
+	"math/bits"
+	"time"
+)
+
+// AgingPolicy returns a FeeFirst variant in which a tx's effective fee
+// grows by boost for every interval it has waited since CreatedAt:
+//
+//	effective = Fee + boost * age / interval
+//
+// A low-fee tx therefore overtakes any newer tx paying less than its
+// effective fee, which bounds inclusion latency: a tx paying f waits at
+// most (F-f)*interval/boost behind a steady stream of txs paying F.
+//
+// Aging semantics:
+//   - Age is measured from CreatedAt, so a fee bump does not reset it.
+//   - Every tx ages at the same rate, so the relative order of two txs
+//     never changes over time. That keeps the heap valid without
+//     periodic re-sorting; "now" cancels out of every comparison.
+//   - Ties fall back to Timestamp ASC, ID ASC like FeeFirst.
+//
+// boost == 0 or interval <= 0 yields plain FeeFirst.
+func AgingPolicy(boost uint64, interval time.Duration) PriorityPolicy {
+	if boost == 0 || interval <= 0 {
+		return FeeFirst
+	}
+	step := uint64(interval)
+
+	return PriorityFunc(func(ti, tj *Tx) bool {
+		// ti before tj  ⇔  Fi*I + B*Cj > Fj*I + B*Ci, in 128 bits.
+		hi1, lo1 := mulAdd128(ti.Fee, step, boost, unixNanos(tj.CreatedAt))
+		hi2, lo2 := mulAdd128(tj.Fee, step, boost, unixNanos(ti.CreatedAt))
+		if hi1 != hi2 {
+			return hi1 > hi2
+		}
+		if lo1 != lo2 {
+			return lo1 > lo2
+		}
+
+		if !ti.Timestamp.Equal(tj.Timestamp) {
+			return ti.Timestamp.Before(tj.Timestamp)
+		}
+		return ti.ID < tj.ID
+	})
+}
+
+// mulAdd128 returns a*b + c*d as a 128-bit (hi, lo) pair. Inputs used
+// here keep each product below 2^127, so the sum cannot overflow.
+func mulAdd128(a, b, c, d uint64) (hi, lo uint64) {
+	h1, l1 := bits.Mul64(a, b)
+	h2, l2 := bits.Mul64(c, d)
+	lo, carry := bits.Add64(l1, l2, 0)
+	hi, _ = bits.Add64(h1, h2, carry)
+	return hi, lo
+}
+
+// unixNanos clamps pre-1970 times to 0 so they fit in a uint64.
+func unixNanos(t time.Time) uint64 {
+	if ns := t.UnixNano(); ns > 0 {
+		return uint64(ns)
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package mempoor
+
+import (
+	"testing"
+	"time"
+)
+
+func agedTx(id TxID, fee uint64, created time.Time) *Tx {
+	return &Tx{ID: id, Fee: fee, CreatedAt: created, Timestamp: created}
+}
+
+func TestAgingPolicyOldTxOvertakes(t *testing.T) {
+	p := AgingPolicy(1, time.Second)
+	t0 := time.Unix(1_700_000_000, 0)
+
+	old := agedTx("old", 10, t0)
+	fresh := agedTx("fresh", 15, t0.Add(10*time.Second))
+	if !p.Less(old, fresh) {
+		t.Fatalf("10 + 10s of aging should beat a fresh 15")
+	}
+
+	// 10 + 10 == 20: equal effective fee falls back to Timestamp ASC.
+	tie := agedTx("tie", 20, t0.Add(10*time.Second))
+	if !p.Less(old, tie) || p.Less(tie, old) {
+		t.Fatalf("equal effective fee must fall back to Timestamp ASC")
+	}
+
+	richer := agedTx("richer", 21, t0.Add(10*time.Second))
+	if p.Less(old, richer) {
+		t.Fatalf("a fresh tx paying more than the aged fee must win")
+	}
+}
+
+func TestAgingPolicyBoundsLatencyInMempool(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	mp := NewMempool(MempoolConfig{Priority: AgingPolicy(10, time.Second)})
+
+	cheap := NewUnsignedTxWithClock(clock, "cheap", "bob", "x", 0, 1, 10)
+	_, _ = mp.Add(cheap)
+
+	// A steady stream of fee-50 txs, one block per second.
+	for i := 0; i < 10; i++ {
+		clock.Advance(time.Second)
+		_, _ = mp.Add(NewUnsignedTxWithClock(clock, "whale", "bob", string(rune('a'+i)), uint64(i), 50, 10))
+
+		res := mp.SelectTransactions(BlockConstraints{MaxTx: 1})
+		if len(res.Transactions) == 1 && res.Transactions[0].ID == cheap.ID {
+			return // 1 + 10/s aging passes 50 within ~5 blocks
+		}
+	}
+	t.Fatalf("aged low-fee tx was never selected")
+}
+
+func TestAgingPolicyZeroIsFeeFirst(t *testing.T) {
+	t0 := time.Unix(1_700_000_000, 0)
+	old := agedTx("old", 10, t0)
+	fresh := agedTx("fresh", 11, t0.Add(time.Hour))
+
+	if AgingPolicy(0, time.Second).Less(old, fresh) {
+		t.Fatalf("zero boost must order like FeeFirst")
+	}
+}
@@ -0,0 +1,88 @@
+package mempoor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// MaxRPCBatch caps how many requests one batch may hold.
+const MaxRPCBatch = 256
+
+// Batch semantics (POST /rpc with a JSON array):
+//   - Each element is a request, as sent on its own. They run one after
+//     another, in order, and the response is an array of the same
+//     length: element i answers request i.
+//   - Each response carries the HTTP status its request would have got
+//     alone as "code", beside "result" and "error". The batch itself is
+//     200 unless it is malformed: not an array of requests, empty, or
+//     over MaxRPCBatch (400).
+//   - Requests do not share a fate: one failing does not stop or undo
+//     the others. Admin methods check the batch's Authorization header
+//     like a single call.
+//   - block.export, which streams, cannot be batched and fails with 400
+//     in its slot.
+//   - Metrics and logs count every request of a batch as its own call.
+
+// batchResponse is one element of a batch response.
+type batchResponse struct {
+	Code   int             `json:"code"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// isBatch reports whether body is a JSON array.
+func isBatch(body json.RawMessage) bool {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// handleRPCBatch runs every request of a batch; see "Batch semantics".
+func (n *Node) handleRPCBatch(w http.ResponseWriter, r *http.Request, body json.RawMessage) {
+	var reqs []rpcRequest
+	if err := json.Unmarshal(body, &reqs); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid JSON batch")
+		return
+	}
+	if len(reqs) == 0 || len(reqs) > MaxRPCBatch {
+		writeRPCError(w, http.StatusBadRequest, fmt.Sprintf("a batch holds 1 to %d requests", MaxRPCBatch))
+		return
+	}
+
+	out := make([]batchResponse, len(reqs))
+	for i, req := range reqs {
+		if req.Method == "block.export" {
+			out[i] = batchResponse{Code: http.StatusBadRequest, Error: "block.export cannot be batched"}
+			continue
+		}
+		rec := &bufferedResponse{header: make(http.Header), code: http.StatusOK}
+		n.serveRPC(rec, r, req)
+
+		out[i].Code = rec.code
+		var resp struct {
+			Result json.RawMessage `json:"result"`
+			Error  string          `json:"error"`
+		}
+		if err := json.Unmarshal(rec.body.Bytes(), &resp); err != nil {
+			out[i].Code, out[i].Error = http.StatusInternalServerError, "invalid response"
+			continue
+		}
+		out[i].Result, out[i].Error = resp.Result, resp.Error
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// bufferedResponse holds one batched request's response.
+type bufferedResponse struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header         { return b.header }
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+func (b *bufferedResponse) WriteHeader(code int)        { b.code = code }
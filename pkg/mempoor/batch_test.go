@@ -0,0 +1,84 @@
+package mempoor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRPCBatchAnswersInOrder(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	rec := callRPC(n, `[
+		{"method":"tx.add","params":{"sender":"alice","recipient":"bob","fee":5,"gas":1}},
+		{"method":"nope"},
+		{"method":"tx.add","params":{"sender":"bob","recipient":"alice","fee":7,"gas":1}},
+		{"method":"block.export"},
+		{"method":"node.status"}
+	]`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for the batch, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var out []batchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	codes := []int{http.StatusOK, http.StatusBadRequest, http.StatusOK, http.StatusBadRequest, http.StatusOK}
+	if len(out) != len(codes) {
+		t.Fatalf("expected %d responses, got %d", len(codes), len(out))
+	}
+	for i, want := range codes {
+		if out[i].Code != want {
+			t.Fatalf("response %d: expected %d, got %d (%s)", i, want, out[i].Code, out[i].Error)
+		}
+	}
+	if len(out[0].Result) == 0 || out[1].Error == "" || out[3].Error == "" {
+		t.Fatalf("expected results and errors in their slots, got %+v", out)
+	}
+	if n.mempool.Count() != 2 {
+		t.Fatalf("expected both adds to land despite the failures between them, got %d", n.mempool.Count())
+	}
+}
+
+func TestRPCBatchRejectsMalformed(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	over := "[" + strings.Repeat(`{"method":"node.status"},`, MaxRPCBatch) + `{"method":"node.status"}]`
+	for name, body := range map[string]string{
+		"empty":    `[]`,
+		"not reqs": `[1, 2]`,
+		"too big":  over,
+	} {
+		if rec := callRPC(n, body); rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", name, rec.Code)
+		}
+	}
+}
+
+func TestRPCBatchChecksAdminToken(t *testing.T) {
+	n := NewNode(NodeConfig{AdminToken: "s3cret"})
+	_, _ = n.mempool.Add(newTx("alice", 10, 1))
+
+	body := `[{"method":"node.status"},{"method":"admin.clear"}]`
+	for _, tc := range []struct {
+		token string
+		code  int
+	}{{"", http.StatusUnauthorized}, {"s3cret", http.StatusOK}} {
+		req := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(body))
+		if tc.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		rec := httptest.NewRecorder()
+		n.handleRPC(rec, req)
+		var out []batchResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(out) != 2 || out[0].Code != http.StatusOK || out[1].Code != tc.code {
+			t.Fatalf("token %q: expected [200 %d], got %+v", tc.token, tc.code, out)
+		}
+	}
+	if n.mempool.Count() != 0 {
+		t.Fatalf("expected admin.clear to run with the token")
+	}
+}
@@ -2,10 +2,22 @@ package mempoor
 
 import (
 	"crypto/sha256"
+	"encoding/hex"
+	"sort"
 	"strconv"
 	"time"
 )
 
+// feeSummary returns BlockHeader.TotalFees and AvgFeePerGas for a block
+// burning burned, tipping tipped, and using gas.
+func feeSummary(burned, tipped, gas uint64) (total, perGas uint64) {
+	total = burned + tipped
+	if gas > 0 {
+		perGas = total / gas
+	}
+	return total, perGas
+}
+
 // Hash computes a deterministic block hash.
 // See explanation above.
 func (b *Block) Hash() [32]byte {
@@ -20,6 +32,31 @@ func (b *Block) Hash() [32]byte {
 
 	h.Write(b.Header.PrevHash[:])
 
+	if b.Header.Burned != 0 || b.Header.Tipped != 0 {
+		h.Write([]byte("|burned=" + strconv.FormatUint(b.Header.Burned, 10) +
+			"|tipped=" + strconv.FormatUint(b.Header.Tipped, 10)))
+	}
+	if b.Header.Proposer != "" {
+		h.Write([]byte("|proposer=" + strconv.Quote(b.Header.Proposer)))
+	}
+	if len(b.Header.ExtraData) > 0 {
+		h.Write([]byte("|extradata=" + hex.EncodeToString(b.Header.ExtraData)))
+	}
+	if b.Header.ChainID != "" {
+		h.Write([]byte("|chainid=" + strconv.Quote(b.Header.ChainID)))
+	}
+
+	if len(b.Header.Extra) > 0 {
+		keys := make([]string, 0, len(b.Header.Extra))
+		for k := range b.Header.Extra {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			h.Write([]byte("|" + strconv.Quote(k) + "=" + strconv.Quote(b.Header.Extra[k])))
+		}
+	}
+
 	for _, tx := range b.Transactions {
 		h.Write([]byte(tx.ID))
 	}
@@ -0,0 +1,267 @@
+package mempoor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrBlockNotFound is returned by a BlockStore for a height or hash it
+// does not hold.
+var ErrBlockNotFound = errors.New("mempool: block not found")
+
+// chainFile is the name of the block store inside NodeConfig.DataDir.
+const chainFile = "chain.db"
+
+// BlockStore persists a node's chain.
+//
+// Chain storage semantics (NodeConfig.BlockStore, DataDir):
+//   - The node puts each block after every BlockSink accepted it and
+//     before committing its txs. If Put fails, the block is discarded and
+//     its txs return to the mempool, as for a failing sink.
+//   - Blocks are stored as the node keeps them, compressed under
+//     BlockCompression if set, and keyed by both height and hash.
+//   - On startup the node reloads the whole chain, validates each block
+//     against its parent (see validate.go), and builds on its tip.
+//     Put of an existing height replaces that block and drops every
+//     block above it in the same write, which is how a reorg switches
+//     the stored chain (see reorg.go).
+//   - With DataDir set and no BlockStore, the node opens a BoltBlockStore
+//     in DataDir and closes it on shutdown. A BlockStore passed in the
+//     config is owned by the caller.
+type BlockStore interface {
+	// Put stores b under its height and hash. If the height is taken, b
+	// replaces that block and every block above it, atomically.
+	Put(b *Block) error
+
+	// Block returns the block at height, or ErrBlockNotFound.
+	Block(height uint64) (*Block, error)
+
+	// BlockByHash returns the block with hash, or ErrBlockNotFound.
+	BlockByHash(hash [32]byte) (*Block, error)
+
+	// Blocks returns every stored block in height order.
+	Blocks() ([]*Block, error)
+
+	// Prune replaces the blocks at heights 1 through through with their
+	// pruned form (see prune.go). Hashes do not change.
+	Prune(through uint64) error
+}
+
+// Buckets of a BoltBlockStore: blocks maps a big-endian height to the
+// block's JSON, hashes maps a block hash to its height.
+var (
+	blocksBucket = []byte("blocks")
+	hashesBucket = []byte("hashes")
+)
+
+// BoltBlockStore is a BlockStore in a BoltDB file. See "Chain storage
+// semantics".
+type BoltBlockStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltBlockStore opens or creates the BoltDB file at path.
+func OpenBoltBlockStore(path string) (*BoltBlockStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("open block store: %w", err)
+	}
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open block store: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{blocksBucket, hashesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("open block store: %w", err)
+	}
+	return &BoltBlockStore{db: db}, nil
+}
+
+// Close closes the underlying file.
+func (s *BoltBlockStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltBlockStore) Put(b *Block) error {
+	raw, err := json.Marshal(encodePayloads(b))
+	if err != nil {
+		return fmt.Errorf("encode block %d: %w", b.Header.Height, err)
+	}
+	key := heightKey(b.Header.Height)
+	hash := b.Hash()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		blocks, hashes := tx.Bucket(blocksBucket), tx.Bucket(hashesBucket)
+
+		// Drop the block this one replaces and every block above it.
+		var stale [][]byte
+		c := blocks.Cursor()
+		for k, old := c.Seek(key); k != nil; k, old = c.Next() {
+			prev, err := decodeStoredBlock(old)
+			if err != nil {
+				return err
+			}
+			oldHash := prev.Hash()
+			if err := hashes.Delete(oldHash[:]); err != nil {
+				return err
+			}
+			stale = append(stale, append([]byte(nil), k...))
+		}
+		for _, k := range stale {
+			if err := blocks.Delete(k); err != nil {
+				return err
+			}
+		}
+		if err := blocks.Put(key, raw); err != nil {
+			return err
+		}
+		return hashes.Put(hash[:], key)
+	})
+}
+
+func (s *BoltBlockStore) Block(height uint64) (*Block, error) {
+	var b *Block
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(blocksBucket).Get(heightKey(height))
+		if raw == nil {
+			return fmt.Errorf("%w: height %d", ErrBlockNotFound, height)
+		}
+		var err error
+		b, err = decodeStoredBlock(raw)
+		return err
+	})
+	return b, err
+}
+
+func (s *BoltBlockStore) BlockByHash(hash [32]byte) (*Block, error) {
+	var b *Block
+	err := s.db.View(func(tx *bolt.Tx) error {
+		key := tx.Bucket(hashesBucket).Get(hash[:])
+		if key == nil {
+			return fmt.Errorf("%w: hash %x", ErrBlockNotFound, hash)
+		}
+		var err error
+		b, err = decodeStoredBlock(tx.Bucket(blocksBucket).Get(key))
+		return err
+	})
+	return b, err
+}
+
+func (s *BoltBlockStore) Blocks() ([]*Block, error) {
+	var out []*Block
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(blocksBucket).ForEach(func(_, raw []byte) error {
+			b, err := decodeStoredBlock(raw)
+			if err != nil {
+				return err
+			}
+			out = append(out, b)
+			return nil
+		})
+	})
+	return out, err
+}
+
+func (s *BoltBlockStore) Prune(through uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		blocks := tx.Bucket(blocksBucket)
+
+		// Collect first: bolt cursors must not see their bucket change.
+		pruned := make(map[string][]byte)
+		c := blocks.Cursor()
+		end := heightKey(through)
+		for k, raw := c.Seek(heightKey(1)); k != nil && bytes.Compare(k, end) <= 0; k, raw = c.Next() {
+			b, err := decodeStoredBlock(raw)
+			if err != nil {
+				return err
+			}
+			if b.Pruned {
+				continue
+			}
+			out, err := json.Marshal(pruneBlock(b))
+			if err != nil {
+				return fmt.Errorf("encode block %d: %w", b.Header.Height, err)
+			}
+			pruned[string(k)] = out
+		}
+		for k, raw := range pruned {
+			if err := blocks.Put([]byte(k), raw); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// heightKey is the blocks bucket key for height; big-endian, so bolt's
+// byte order is height order.
+func heightKey(height uint64) []byte {
+	var key [8]byte
+	binary.BigEndian.PutUint64(key[:], height)
+	return key[:]
+}
+
+// decodeStoredBlock is the inverse of Put's encoding.
+func decodeStoredBlock(raw []byte) (*Block, error) {
+	var b Block
+	if raw == nil {
+		return nil, fmt.Errorf("decode block: %w", ErrBlockNotFound)
+	}
+	if err := json.Unmarshal(raw, &b); err != nil {
+		return nil, fmt.Errorf("decode block: %w", err)
+	}
+	return decodePayloads(&b)
+}
+
+// openChain opens the node's block store, if it has one, and reloads the
+// chain from it. The returned func closes a store the node opened itself.
+func (n *Node) openChain() (func(), error) {
+	closeStore := func() {}
+	if n.store == nil && n.cfg.DataDir != "" {
+		s, err := OpenBoltBlockStore(filepath.Join(n.cfg.DataDir, chainFile))
+		if err != nil {
+			return nil, err
+		}
+		n.store = s
+		closeStore = func() {
+			if err := s.Close(); err != nil {
+				n.log.chain.Error("block store close failed", "err", err)
+			}
+		}
+	}
+	if n.store == nil {
+		return closeStore, nil
+	}
+
+	blocks, err := n.store.Blocks()
+	if err != nil {
+		closeStore()
+		return nil, fmt.Errorf("load chain: %w", err)
+	}
+	if err := validateChain(blocks, n.cfg.BaseFee); err != nil {
+		closeStore()
+		return nil, fmt.Errorf("load chain: %w", err)
+	}
+	n.blocksMu.Lock()
+	n.setChainLocked(0, blocks...)
+	n.blocksMu.Unlock()
+	if len(blocks) > 0 {
+		n.log.chain.Info("chain reloaded", "blocks", len(blocks), "tipHeight", blocks[len(blocks)-1].Header.Height)
+	}
+	return closeStore, nil
+}
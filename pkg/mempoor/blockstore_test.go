@@ -0,0 +1,126 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBoltBlockStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), chainFile)
+	s, err := OpenBoltBlockStore(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	payload := strings.Repeat("bulk data ", 50)
+	b0 := &Block{Header: BlockHeader{Height: 0, Timestamp: time.Unix(1, 0).UTC()}}
+	b1, _ := CompressPayloads(&Block{
+		Header:       BlockHeader{Height: 1, PrevHash: b0.Hash(), Timestamp: time.Unix(2, 0).UTC()},
+		Transactions: []*Tx{NewUnsignedTx("alice", "bob", payload, 0, 5, 10)},
+	}, CompressGzip)
+	for _, b := range []*Block{b0, b1} {
+		if err := s.Put(b); err != nil {
+			t.Fatalf("put: %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	if s, err = OpenBoltBlockStore(path); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+
+	got, err := s.BlockByHash(b1.Hash())
+	if err != nil || got.Header.Height != 1 || got.Compression != CompressGzip {
+		t.Fatalf("expected block 1 by hash, got %+v, %v", got, err)
+	}
+	plain, err := DecompressPayloads(got)
+	if err != nil || plain.Transactions[0].Payload != payload {
+		t.Fatalf("expected the payload to survive the store, got %v", err)
+	}
+	if blocks, err := s.Blocks(); err != nil || len(blocks) != 2 || blocks[0].Hash() != b0.Hash() {
+		t.Fatalf("expected both blocks in height order, got %d, %v", len(blocks), err)
+	}
+
+	// Replacing a height drops the old hash.
+	b1b := &Block{Header: BlockHeader{Height: 1, PrevHash: b0.Hash(), Timestamp: time.Unix(3, 0).UTC()}}
+	if err := s.Put(b1b); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if _, err := s.BlockByHash(b1.Hash()); !errors.Is(err, ErrBlockNotFound) {
+		t.Fatalf("expected ErrBlockNotFound for the replaced block, got %v", err)
+	}
+	if got, err := s.Block(1); err != nil || got.Hash() != b1b.Hash() {
+		t.Fatalf("expected the replacement at height 1, got %v", err)
+	}
+	if _, err := s.Block(7); !errors.Is(err, ErrBlockNotFound) {
+		t.Fatalf("expected ErrBlockNotFound, got %v", err)
+	}
+}
+
+func TestNodeReloadsChain(t *testing.T) {
+	dir := t.TempDir()
+
+	n1 := NewNode(NodeConfig{DataDir: dir, MaxTxPerBlock: 10})
+	closeChain, err := n1.openChain()
+	if err != nil {
+		t.Fatalf("open chain: %v", err)
+	}
+	_, _ = n1.mempool.Add(newTx("alice", 5, 10))
+	block, err := n1.produceBlock(context.Background(), [32]byte{}, 0, time.Unix(1, 0).UTC())
+	if err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	closeChain()
+
+	n2 := NewNode(NodeConfig{DataDir: dir, MaxTxPerBlock: 10})
+	closeChain, err = n2.openChain()
+	if err != nil {
+		t.Fatalf("reopen chain: %v", err)
+	}
+	defer closeChain()
+
+	if hash, height := n2.chainTip(); hash != block.Hash() || height != 1 {
+		t.Fatalf("expected to build on the stored block, got height %d", height)
+	}
+}
+
+func TestBlockLoopResumesAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+	cfg := NodeConfig{DataDir: dir, MaxTxPerBlock: 10, BlockInterval: time.Second}
+
+	var last *Block
+	for restart, sender := range []string{"alice", "bob", "carol"} {
+		n, closeChain := startFromDisk(t, cfg)
+		if restart > 0 {
+			if hash, height := n.chainTip(); hash != last.Hash() || height != last.Header.Height+1 {
+				t.Fatalf("restart %d: expected to resume after height %d, got next height %d", restart, last.Header.Height, height)
+			}
+		}
+		_, _ = n.mempool.Add(newTx(sender, 5, 10))
+		block, err := n.produceNext(context.Background())
+		if err != nil {
+			t.Fatalf("restart %d: produce: %v", restart, err)
+		}
+		if want := uint64(restart + 1); block.Header.Height != want {
+			t.Fatalf("restart %d: expected height %d, got %d", restart, want, block.Header.Height)
+		}
+		if restart > 0 && block.Header.PrevHash != last.Hash() {
+			t.Fatalf("restart %d: block does not link to the block before the restart", restart)
+		}
+		last = block
+		closeChain()
+	}
+
+	n, closeChain := startFromDisk(t, cfg)
+	defer closeChain()
+	if err := validateChain(n.blocks, 0); err != nil || len(n.blocks) != 4 {
+		t.Fatalf("expected one valid chain of 4 blocks, got %d: %v", len(n.blocks), err)
+	}
+}
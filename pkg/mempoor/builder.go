@@ -1,11 +1,16 @@
 package mempoor
 
 import (
+	"context"
+	"fmt"
 	"time"
 )
 
 // NewBlockBuilder constructs a builder with the given mempool and config.
 func NewBlockBuilder(mp Mempool, cfg BlockBuilderConfig) *BlockBuilder {
+	if cfg.Clock == nil {
+		cfg.Clock = SystemClock
+	}
 	return &BlockBuilder{
 		mp:  mp,
 		cfg: cfg,
@@ -13,26 +18,224 @@ func NewBlockBuilder(mp Mempool, cfg BlockBuilderConfig) *BlockBuilder {
 }
 
 // BuildBlock selects transactions under the configured constraints and
-// constructs a block. If no transactions are available, ErrEmptyBlock is returned.
+// constructs a block. If no transactions are available, ErrEmptyBlock is returned,
+// unless AllowEmpty is set.
 //
 // prevHash: block hash of previous block in chain
 // height: height of new block
-// now: block timestamp (supplied by caller for determinism & testability)
-func (b *BlockBuilder) BuildBlock(prevHash [32]byte, height uint64, now time.Time) (*Block, error) {
-	// Build constraints for one block.
-	constraints := BlockConstraints{
+// now: block timestamp (supplied by caller for determinism & testability;
+// the zero time means "read the configured Clock")
+//
+// Deadline semantics (ctx):
+//   - A ctx that is already done when selection would start fails the
+//     build with ctx.Err(); nothing is selected or purged.
+//   - Once selection has started, ctx ending (deadline or cancel) stops
+//     it early and the block holds whatever was selected so far, so a
+//     large pool or a slow strategy cannot overrun the block interval.
+//     The build is reported with BuildStats.Interrupted.
+//   - With Candidates, previews stop at the deadline too; the winning
+//     preview is then reserved without a deadline, since its filter
+//     already bounds the block.
+//   - BeforeSelect and AfterAssemble hooks are not interrupted.
+func (b *BlockBuilder) BuildBlock(ctx context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, error) {
+	block, res, err := b.ReserveBlock(ctx, prevHash, height, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := res.Commit(); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// ReserveBlock is BuildBlock without the final commit: the block's txs
+// are held out of the mempool by the returned Reservation. The caller
+// commits once the block is safely stored, or rolls back to return the
+// txs to the pool if anything downstream fails.
+//
+// With Pools configured, the primary mempool (DefaultPool) is drawn from
+// first, then each named pool in order, each limited to the gas and tx
+// count left in the block and to its PoolGasLimits entry.
+//
+// With Candidates configured, the block is the best of several
+// concurrently previewed candidates; see bestCandidate.
+//
+// ctx bounds selection as for BuildBlock. On ErrEmptyBlock no
+// reservation is returned.
+func (b *BlockBuilder) ReserveBlock(ctx context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, Reservation, error) {
+	now, err := b.timestamp(now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkExtraData(b.cfg.ExtraData); err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+
+	constraints, err := b.beforeSelect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(b.cfg.Candidates) > 0 {
+		constraints = b.bestCandidate(constraints)
+	}
+
+	// Ask mempool for the best transactions, held until commit/rollback.
+	var res Reservation
+	if len(b.cfg.Pools) == 0 && len(b.cfg.PoolGasLimits) == 0 {
+		res = b.mp.Reserve(constraints)
+	} else {
+		multi := &multiReservation{}
+		multi.result = b.splitPools(constraints, func(mp Mempool, c BlockConstraints) BlockSelectionResult {
+			part := mp.Reserve(c)
+			multi.parts = append(multi.parts, part)
+			return part.Result()
+		})
+		res = multi
+	}
+	selection := res.Result()
+
+	if b.cfg.OnBuild != nil {
+		b.cfg.OnBuild(BuildStats{
+			Height:        height,
+			SelectionTime: time.Since(start),
+			TxCount:       len(selection.Transactions),
+			MaxTx:         constraints.MaxTx,
+			GasUsed:       selection.GasUsed,
+			GasLimit:      constraints.GasLimit,
+			Fees:          selectionFees(selection),
+			Interrupted:   stopped(ctx.Done()),
+		})
+	}
+	if len(selection.Purged) > 0 && b.cfg.OnPurge != nil {
+		b.cfg.OnPurge(selection.Purged)
+	}
+
+	if len(selection.Transactions) == 0 && !b.cfg.AllowEmpty {
+		_ = res.Commit() // nothing held; finalize any purges
+		return nil, nil, ErrEmptyBlock
+	}
+
+	block := b.assembleBlock(prevHash, height, now, selection)
+	if err := b.afterAssemble(block); err != nil {
+		_ = res.Rollback()
+		return nil, nil, err
+	}
+	return block, res, nil
+}
+
+// BuildTemplate returns the block BuildBlock would produce right now,
+// without removing, reserving, or purging anything, so external
+// proposers can inspect or bid on it. The pool may change before the
+// real block is built; a template is a preview, not a promise.
+//
+// Returns ErrEmptyBlock if nothing would be selected, unless AllowEmpty
+// is set. ctx bounds selection as for BuildBlock.
+func (b *BlockBuilder) BuildTemplate(ctx context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, error) {
+	now, err := b.timestamp(now)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExtraData(b.cfg.ExtraData); err != nil {
+		return nil, err
+	}
+
+	constraints, err := b.beforeSelect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.cfg.Candidates) > 0 {
+		constraints = b.bestCandidate(constraints)
+	}
+
+	selection := b.preview(constraints)
+
+	if len(selection.Transactions) == 0 && !b.cfg.AllowEmpty {
+		return nil, ErrEmptyBlock
+	}
+
+	block := b.assembleBlock(prevHash, height, now, selection)
+	if err := b.afterAssemble(block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// timestamp returns now, or the clock's time if now is zero and the
+// builder is not Deterministic.
+func (b *BlockBuilder) timestamp(now time.Time) (time.Time, error) {
+	if !now.IsZero() {
+		return now, nil
+	}
+	if b.cfg.Deterministic {
+		return now, ErrNoTimestamp
+	}
+	return b.cfg.Clock.Now(), nil
+}
+
+// checkExtraData enforces MaxExtraDataBytes.
+func checkExtraData(data []byte) error {
+	if len(data) > MaxExtraDataBytes {
+		return fmt.Errorf("%w: %d bytes, max %d", ErrExtraDataTooLarge, len(data), MaxExtraDataBytes)
+	}
+	return nil
+}
+
+// constraints builds the selection constraints for one block.
+func (b *BlockBuilder) constraints() BlockConstraints {
+	b.limitsMu.RLock()
+	defer b.limitsMu.RUnlock()
+
+	return BlockConstraints{
 		GasLimit: b.cfg.GasLimit,
 		MaxTx:    b.cfg.MaxTxPerBlock,
 		MinFee:   b.cfg.MinFee,
+
+		LaneGasLimits: b.cfg.LaneGasLimits,
+		BaseFee:       b.cfg.BaseFee,
+
+		Strategy:  b.cfg.Strategy,
+		Packing:   b.cfg.Packing,
+		Lookahead: b.cfg.PackLookahead,
 	}
+}
 
-	// Ask mempool for the best transactions.
-	selection := b.mp.SelectTransactions(constraints)
+// splitPools draws from the primary mempool and then each named pool
+// through take, splitting the block's gas and tx budget between them,
+// and merges the results.
+func (b *BlockBuilder) splitPools(c BlockConstraints, take func(Mempool, BlockConstraints) BlockSelectionResult) BlockSelectionResult {
+	pools := append([]NamedPool{{Name: DefaultPool, Mempool: b.mp}}, b.cfg.Pools...)
 
-	if len(selection.Transactions) == 0 {
-		return nil, ErrEmptyBlock
+	var merged BlockSelectionResult
+	for _, p := range pools {
+		pc := c
+		pc.MaxTx = c.MaxTx - len(merged.Transactions)
+		if c.GasLimit > 0 {
+			pc.GasLimit = c.GasLimit - merged.GasUsed
+			if pc.GasLimit == 0 {
+				break
+			}
+		}
+		if pc.MaxTx <= 0 {
+			break
+		}
+		if quota := b.cfg.PoolGasLimits[p.Name]; quota > 0 && (pc.GasLimit == 0 || quota < pc.GasLimit) {
+			pc.GasLimit = quota
+		}
+
+		sel := take(p.Mempool, pc)
+		merged.Transactions = append(merged.Transactions, sel.Transactions...)
+		merged.GasUsed += sel.GasUsed
+		merged.Burned += sel.Burned
+		merged.Tips += sel.Tips
+		merged.Purged = append(merged.Purged, sel.Purged...)
 	}
+	merged.Groups = ParallelGroups(merged.Transactions)
+	return merged
+}
 
+// assembleBlock wraps a selection in a block header.
+func (b *BlockBuilder) assembleBlock(prevHash [32]byte, height uint64, now time.Time, selection BlockSelectionResult) *Block {
 	// Construct header with fields we have agreed upon.
 	header := BlockHeader{
 		Height:    height,
@@ -40,14 +243,26 @@ func (b *BlockBuilder) BuildBlock(prevHash [32]byte, height uint64, now time.Tim
 		Timestamp: now,
 		TxCount:   len(selection.Transactions),
 		GasUsed:   selection.GasUsed, // trust mempool per Q3
+		Burned:    selection.Burned,
+		Tipped:    selection.Tips,
+		Proposer:  b.cfg.Proposer,
+		ChainID:   b.cfg.ChainID,
+	}
+	header.TotalFees, header.AvgFeePerGas = feeSummary(selection.Burned, selection.Tips, selection.GasUsed)
+	if len(b.cfg.ExtraData) > 0 {
+		// Copy so hooks editing one header cannot leak into the next.
+		header.ExtraData = append([]byte(nil), b.cfg.ExtraData...)
 	}
 
-	block := &Block{
+	groups := selection.Groups
+	if groups == nil {
+		groups = ParallelGroups(selection.Transactions) // custom Reservation
+	}
+	return &Block{
 		Header:       header,
 		Transactions: selection.Transactions,
+		Groups:       groups,
 	}
-
-	return block, nil
 }
 
 /*
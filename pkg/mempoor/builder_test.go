@@ -1,6 +1,9 @@
 package mempoor
 
 import (
+	"context"
+	"errors"
+	"sort"
 	"testing"
 	"time"
 )
@@ -8,17 +11,31 @@ import (
 // ---- Fake mempool implementation for testing ----
 
 type fakeMempool struct {
-	result BlockSelectionResult
+	// Embedded so the fake satisfies the full interface; the builder
+	// only calls Reserve, any other call panics.
+	Mempool
+
+	result     BlockSelectionResult
+	committed  int
+	rolledBack int
 }
 
-func (f *fakeMempool) Add(tx *Tx) error     { return nil }
-func (f *fakeMempool) Update(tx *Tx) error  { return nil }
-func (f *fakeMempool) Remove(id TxID) error { return nil }
-func (f *fakeMempool) List() []*Tx          { return nil }
 func (f *fakeMempool) SelectTransactions(c BlockConstraints) BlockSelectionResult {
 	return f.result
 }
 
+func (f *fakeMempool) Reserve(c BlockConstraints) Reservation {
+	return &fakeReservation{mp: f}
+}
+
+type fakeReservation struct {
+	mp *fakeMempool
+}
+
+func (r *fakeReservation) Result() BlockSelectionResult { return r.mp.result }
+func (r *fakeReservation) Commit() error                { r.mp.committed++; return nil }
+func (r *fakeReservation) Rollback() error              { r.mp.rolledBack++; return nil }
+
 // ---- Tests ----
 
 // Ensure builder returns ErrEmptyBlock when mempool yields zero txs.
@@ -40,12 +57,72 @@ func TestBuildBlock_EmptySelection(t *testing.T) {
 	height := uint64(10)
 	now := time.Now().UTC()
 
-	blk, err := builder.BuildBlock(prev, height, now)
+	blk, err := builder.BuildBlock(context.Background(), prev, height, now)
 	if err != ErrEmptyBlock {
 		t.Fatalf("expected ErrEmptyBlock, got blk=%+v err=%v", blk, err)
 	}
 }
 
+func TestBuildBlock_AllowEmpty(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{
+		GasLimit:      1_000_000,
+		MaxTxPerBlock: 100,
+		AllowEmpty:    true,
+	})
+
+	prev := [32]byte{4, 5, 6}
+	blk, err := builder.BuildBlock(context.Background(), prev, 3, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if blk.Header.TxCount != 0 || blk.Header.GasUsed != 0 || len(blk.Transactions) != 0 {
+		t.Fatalf("expected an empty block, got %+v", blk.Header)
+	}
+	if blk.Header.Height != 3 || blk.Header.PrevHash != prev {
+		t.Fatalf("unexpected header: %+v", blk.Header)
+	}
+}
+
+func TestBuildBlock_ProposerAndExtraData(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	_, _ = mp.Add(newTx("alice", 5, 10))
+
+	now := time.Unix(100, 0).UTC()
+	build := func(proposer string, extra []byte) (*Block, error) {
+		b := NewBlockBuilder(mp, BlockBuilderConfig{
+			GasLimit:      1_000_000,
+			MaxTxPerBlock: 100,
+			Proposer:      proposer,
+			ExtraData:     extra,
+		})
+		return b.BuildTemplate(context.Background(), [32]byte{}, 1, now)
+	}
+
+	plain, err := build("", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signed, err := build("node-1", []byte("v1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signed.Header.Proposer != "node-1" || string(signed.Header.ExtraData) != "v1" {
+		t.Fatalf("expected proposer fields in header, got %+v", signed.Header)
+	}
+	if plain.Hash() == signed.Hash() {
+		t.Fatalf("expected proposer fields to change the hash")
+	}
+	other, _ := build("node-2", []byte("v1"))
+	if other.Hash() == signed.Hash() {
+		t.Fatalf("expected a different proposer to change the hash")
+	}
+
+	if _, err := build("node-1", make([]byte, MaxExtraDataBytes+1)); !errors.Is(err, ErrExtraDataTooLarge) {
+		t.Fatalf("expected ErrExtraDataTooLarge, got %v", err)
+	}
+}
+
 // Ensure builder propagates header fields and selected txs correctly.
 func TestBuildBlock_HeaderAndTxs(t *testing.T) {
 	tx1 := &Tx{
@@ -86,7 +163,7 @@ func TestBuildBlock_HeaderAndTxs(t *testing.T) {
 	height := uint64(7)
 	now := time.Unix(12345, 0).UTC()
 
-	blk, err := builder.BuildBlock(prev, height, now)
+	blk, err := builder.BuildBlock(context.Background(), prev, height, now)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -145,8 +222,8 @@ func TestBuildBlock_Statelessness(t *testing.T) {
 	prev1 := [32]byte{1}
 	prev2 := [32]byte{2}
 
-	blk1, _ := builder.BuildBlock(prev1, 1, time.Unix(111, 0).UTC())
-	blk2, _ := builder.BuildBlock(prev2, 2, time.Unix(222, 0).UTC())
+	blk1, _ := builder.BuildBlock(context.Background(), prev1, 1, time.Unix(111, 0).UTC())
+	blk2, _ := builder.BuildBlock(context.Background(), prev2, 2, time.Unix(222, 0).UTC())
 
 	if blk1.Header.Height != 1 || blk2.Header.Height != 2 {
 		t.Fatalf("builder must not retain height between calls")
@@ -158,3 +235,255 @@ func TestBuildBlock_Statelessness(t *testing.T) {
 		t.Fatalf("builder must not retain timestamps between calls")
 	}
 }
+
+// Ensure ReserveBlock leaves the decision to the caller and BuildBlock commits.
+func TestReserveBlock_DefersCommit(t *testing.T) {
+	tx := &Tx{ID: "tx1", Fee: 1, Gas: 10}
+	mp := &fakeMempool{
+		result: BlockSelectionResult{Transactions: []*Tx{tx}, GasUsed: 10},
+	}
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{MaxTxPerBlock: 10})
+
+	blk, res, err := builder.ReserveBlock(context.Background(), [32]byte{}, 0, time.Unix(1, 0).UTC())
+	if err != nil || blk == nil || res == nil {
+		t.Fatalf("unexpected ReserveBlock result: blk=%v res=%v err=%v", blk, res, err)
+	}
+	if mp.committed != 0 || mp.rolledBack != 0 {
+		t.Fatalf("ReserveBlock must not commit or roll back on its own")
+	}
+
+	if _, err := builder.BuildBlock(context.Background(), [32]byte{}, 1, time.Unix(2, 0).UTC()); err != nil {
+		t.Fatalf("unexpected BuildBlock error: %v", err)
+	}
+	if mp.committed != 1 {
+		t.Fatalf("expected BuildBlock to commit its reservation, got %d", mp.committed)
+	}
+}
+
+// Ensure purges are reported even when the block ends up empty.
+func TestBuildBlock_ReportsPurged(t *testing.T) {
+	low := &Tx{ID: "low", Fee: 1, Gas: 10}
+	mp := &fakeMempool{
+		result: BlockSelectionResult{Purged: []*Tx{low}},
+	}
+
+	var got []*Tx
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{
+		MaxTxPerBlock: 10,
+		MinFee:        5,
+		OnPurge:       func(purged []*Tx) { got = append(got, purged...) },
+	})
+
+	if _, err := builder.BuildBlock(context.Background(), [32]byte{}, 0, time.Unix(1, 0).UTC()); err != ErrEmptyBlock {
+		t.Fatalf("expected ErrEmptyBlock, got %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "low" {
+		t.Fatalf("expected OnPurge to see the purged tx, got %v", got)
+	}
+}
+
+// Ensure a template previews the next block without touching the pool.
+func TestBuildBlock_DeadlineKeepsPartialSelection(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	for _, sender := range []string{"a", "b", "c", "d", "e"} {
+		_, _ = mp.Add(newTx(sender, 5, 10))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The third tx the walk looks at ends the context, as a deadline
+	// would mid-selection.
+	seen := 0
+	var stats BuildStats
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{
+		MaxTxPerBlock: 10,
+		BeforeSelect: []BeforeSelectHook{func(c *BlockConstraints) error {
+			c.Filter = func(*Tx) bool {
+				if seen++; seen == 3 {
+					cancel()
+				}
+				return true
+			}
+			return nil
+		}},
+		OnBuild: func(s BuildStats) { stats = s },
+	})
+
+	blk, err := builder.BuildBlock(ctx, [32]byte{}, 0, time.Unix(1, 0).UTC())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if blk.Header.TxCount != 3 || mp.Count() != 2 {
+		t.Fatalf("expected 3 txs in the block and 2 pending, got %d and %d", blk.Header.TxCount, mp.Count())
+	}
+	if !stats.Interrupted {
+		t.Fatalf("expected the build to be reported as interrupted")
+	}
+
+	// A context that is already done selects nothing.
+	if _, err := builder.BuildBlock(ctx, [32]byte{}, 1, time.Unix(2, 0).UTC()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if mp.Count() != 2 {
+		t.Fatalf("expected the pool untouched, got %d", mp.Count())
+	}
+}
+
+func TestBuildTemplate_LeavesPoolUntouched(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	_, _ = mp.Add(newTx("alice", 10, 100))
+	_, _ = mp.Add(newTx("carol", 1, 100)) // below MinFee
+
+	var purged int
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{
+		MaxTxPerBlock: 10,
+		MinFee:        5,
+		OnPurge:       func(txs []*Tx) { purged += len(txs) },
+	})
+
+	tmpl, err := builder.BuildTemplate(context.Background(), [32]byte{}, 7, time.Unix(1, 0).UTC())
+	if err != nil {
+		t.Fatalf("unexpected BuildTemplate error: %v", err)
+	}
+	if tmpl.Header.Height != 7 || tmpl.Header.TxCount != 1 || tmpl.Transactions[0].Sender != "alice" {
+		t.Fatalf("unexpected template: %+v", tmpl.Header)
+	}
+	if mp.Count() != 2 || purged != 0 {
+		t.Fatalf("template must not select or purge, pool has %d, purged %d", mp.Count(), purged)
+	}
+
+	blk, err := builder.BuildBlock(context.Background(), [32]byte{}, 7, time.Unix(1, 0).UTC())
+	if err != nil || blk.Hash() != tmpl.Hash() {
+		t.Fatalf("expected the built block to match the template")
+	}
+	if _, err := builder.BuildTemplate(context.Background(), [32]byte{}, 8, time.Time{}); err != ErrEmptyBlock {
+		t.Fatalf("expected ErrEmptyBlock, got %v", err)
+	}
+}
+
+func TestBuildBlock_BestOfCandidates(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	mp := NewMempool(MempoolConfig{Clock: clock})
+	old := NewUnsignedTxWithClock(clock, "alice", "bob", "a", 0, 1, 10)
+	clock.Advance(time.Second)
+	rich := NewUnsignedTxWithClock(clock, "carol", "bob", "c", 0, 50, 10)
+	_, _ = mp.Add(old)
+	_, _ = mp.Add(rich)
+
+	// FIFO alone would take the old low-fee tx; the priority candidate
+	// earns more and wins.
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{
+		MaxTxPerBlock:    1,
+		Strategy:         StrategyFIFO,
+		Candidates:       DefaultBlockCandidates,
+		CandidateWorkers: 2,
+	})
+
+	tmpl, err := builder.BuildTemplate(context.Background(), [32]byte{}, 1, time.Time{})
+	if err != nil || tmpl.Transactions[0].ID != rich.ID {
+		t.Fatalf("expected the template to carry the high-fee tx, got %v, %v", tmpl, err)
+	}
+	blk, err := builder.BuildBlock(context.Background(), [32]byte{}, 1, time.Time{})
+	if err != nil || len(blk.Transactions) != 1 || blk.Transactions[0].ID != rich.ID {
+		t.Fatalf("expected the best candidate's tx, got %v, %v", blk, err)
+	}
+	if _, err := mp.Get(old.ID); err != nil {
+		t.Fatalf("the losing candidate's tx should stay pending: %v", err)
+	}
+}
+
+func TestProduceBlock_RollsBackOnSinkFailure(t *testing.T) {
+	fail := errors.New("disk full")
+	var sinkErr error
+	n := NewNode(NodeConfig{
+		MaxTxPerBlock: 10,
+		BlockSinks:    []BlockSink{func(*Block) error { return sinkErr }},
+	})
+	tx := newTx("alice", 10, 100)
+	_, _ = n.mempool.Add(tx)
+
+	sinkErr = fail
+	if _, err := n.produceBlock(context.Background(), [32]byte{}, 0, time.Time{}); !errors.Is(err, fail) {
+		t.Fatalf("expected the sink error, got %v", err)
+	}
+	if _, err := n.mempool.Get(tx.ID); err != nil {
+		t.Fatalf("tx should be back in the pool: %v", err)
+	}
+	if len(n.blocks) != 0 {
+		t.Fatalf("failed block must not be stored")
+	}
+
+	sinkErr = nil
+	blk, err := n.produceBlock(context.Background(), [32]byte{}, 0, time.Time{})
+	if err != nil || len(blk.Transactions) != 1 || len(n.blocks) != 1 {
+		t.Fatalf("expected the block to be stored on retry, got %v", err)
+	}
+	if n.mempool.Count() != 0 {
+		t.Fatalf("committed tx should leave the pool")
+	}
+}
+
+// lastInBuilder is a custom Builder that fills blocks newest-first.
+type lastInBuilder struct {
+	mp Mempool
+}
+
+func (b *lastInBuilder) BuildBlock(ctx context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, error) {
+	blk, err := b.BuildTemplate(ctx, prevHash, height, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range blk.Transactions {
+		_ = b.mp.Remove(tx.ID)
+	}
+	return blk, nil
+}
+
+func (b *lastInBuilder) BuildTemplate(_ context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, error) {
+	txs := b.mp.List()
+	if len(txs) == 0 {
+		return nil, ErrEmptyBlock
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Timestamp.After(txs[j].Timestamp) })
+	txs = txs[:1]
+	blk := &Block{
+		Header:       BlockHeader{Height: height, PrevHash: prevHash, Timestamp: now, TxCount: 1, GasUsed: txs[0].Gas, Tipped: txs[0].Fee},
+		Transactions: txs,
+	}
+	blk.Header.TotalFees, blk.Header.AvgFeePerGas = feeSummary(0, blk.Header.Tipped, blk.Header.GasUsed)
+	return blk, nil
+}
+
+func TestNodeWithCustomBuilder(t *testing.T) {
+	fail := errors.New("disk full")
+	var sinkErr error
+	n := NewNodeWithBuilder(NodeConfig{
+		BlockSinks: []BlockSink{func(*Block) error { return sinkErr }},
+	}, func(mp Mempool, _ []NamedPool) Builder { return &lastInBuilder{mp: mp} })
+
+	older := newTx("alice", 50, 10)
+	older.Timestamp = time.Unix(1, 0)
+	newer := newTx("bob", 1, 10)
+	newer.Timestamp = time.Unix(2, 0)
+	_, _ = n.mempool.Add(older)
+	_, _ = n.mempool.Add(newer)
+
+	// Without ReserveBlock, a failed publish reinserts the block's txs.
+	sinkErr = fail
+	if _, err := n.produceBlock(context.Background(), [32]byte{}, 0, time.Time{}); !errors.Is(err, fail) {
+		t.Fatalf("expected the sink error, got %v", err)
+	}
+	if n.mempool.Count() != 2 {
+		t.Fatalf("expected the tx reinserted, got %d pending", n.mempool.Count())
+	}
+
+	sinkErr = nil
+	blk, err := n.produceBlock(context.Background(), [32]byte{}, 0, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if blk.Transactions[0].ID != newer.ID || n.mempool.Count() != 1 {
+		t.Fatalf("expected the custom builder to pick the newest tx, got %v", blk.Transactions)
+	}
+}
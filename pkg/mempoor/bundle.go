@@ -0,0 +1,266 @@
+package mempoor
+
+import (
+	"container/heap"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrInvalidBundle is returned by AddBundle for a malformed bundle, and
+// by Add and Update for a tx that would join, leave, or split a bundle.
+var ErrInvalidBundle = errors.New("mempool: invalid bundle")
+
+// Bundle semantics (Tx.BundleID):
+//   - A bundle is added with AddBundle, all or nothing: if any member is
+//     refused, or the pool has to evict a member to fit a later one, the
+//     members already admitted are removed again (published as TxAdded,
+//     then TxRemoved) and the error is returned.
+//   - Members are ordered by the bundle's total Fee, Gas, MaxFee, and
+//     Tip, earliest Timestamp and CreatedAt, and lowest member ID, so the
+//     whole bundle competes as one tx under the PriorityPolicy.
+//   - Selection takes every member in one block, in BundleIndex order, or
+//     none: all must be nonce-ready, not waiting on dependencies,
+//     accepted by the Filter, and able to cover the BaseFee, and together
+//     fit MaxTx, GasLimit, and the lane quota. MinFee applies to the bundle's average fee per member;
+//     a bundle below it is purged whole unless a member is pinned.
+//   - If a member leaves the pool without being committed (removed,
+//     evicted, or purged), the rest of the bundle is removed with it and
+//     published as TxRemoved. A rolled-back or reinserted bundle that
+//     does not come back whole is dropped the same way.
+//   - Members share a lane, may not be scheduled or orphaned, and may
+//     not depend on each other through DependsOn; use nonces or
+//     BundleIndex order instead.
+type bundle struct {
+	members []*txRecord // pending members by BundleIndex
+
+	// key stands in for every member in the lane heap; see refresh.
+	key Tx
+}
+
+// GenerateBundleID derives a bundle ID from its members' IDs, for callers
+// that do not name their bundles.
+func GenerateBundleID(ids []TxID) string {
+	h := sha256.New()
+	for _, id := range ids {
+		h.Write([]byte(id))
+		h.Write([]byte{'|'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AddBundle atomically inserts txs as the bundle id, stamping each with
+// BundleID = id and BundleIndex = its position. Evicted IDs are returned
+// as for Add; see "Bundle semantics".
+func (m *mempool) AddBundle(id string, txs []*Tx) ([]TxID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkBundle(id, txs); err != nil {
+		return nil, err
+	}
+
+	var evicted []TxID
+	for i, tx := range txs {
+		tx.BundleID, tx.BundleIndex = id, i
+
+		var err error
+		if m.cfg.MaxOrphans > 0 && m.missingDependency(tx) {
+			err = ErrTxOrphaned
+		} else {
+			var ev []TxID
+			ev, err = m.addLocked(tx)
+			evicted = append(evicted, ev...)
+		}
+		if err != nil {
+			m.dropBundle(id)
+			return evicted, fmt.Errorf("bundle tx %d: %w", i, err)
+		}
+	}
+
+	if m.settleBundles(txs, TxRemoved) {
+		return evicted, fmt.Errorf("%w: a member was evicted to fit the rest", ErrMempoolFull)
+	}
+	return evicted, nil
+}
+
+// checkBundle validates a bundle on its own. Caller must hold the lock.
+func (m *mempool) checkBundle(id string, txs []*Tx) error {
+	if id == "" || len(txs) == 0 {
+		return fmt.Errorf("%w: need an ID and at least one tx", ErrInvalidBundle)
+	}
+	if _, exists := m.bundles[id]; exists {
+		return fmt.Errorf("%w: bundle %s is already pending", ErrInvalidBundle, id)
+	}
+
+	now := m.cfg.Clock.Now()
+	ids := make(map[TxID]struct{}, len(txs))
+	last := make(map[string]uint64)
+	for i, tx := range txs {
+		switch {
+		case tx.Lane != txs[0].Lane:
+			return fmt.Errorf("%w: tx %d is in lane %s, not %s", ErrInvalidBundle, i, tx.Lane, txs[0].Lane)
+		case tx.NotBefore.After(now):
+			return fmt.Errorf("%w: tx %d is scheduled", ErrInvalidBundle, i)
+		}
+		if n, seen := last[tx.Sender]; seen && tx.Nonce <= n {
+			return fmt.Errorf("%w: tx %d is out of nonce order for %s", ErrInvalidBundle, i, tx.Sender)
+		}
+		last[tx.Sender] = tx.Nonce
+		ids[tx.ID] = struct{}{}
+	}
+	for i, tx := range txs {
+		for _, dep := range tx.DependsOn {
+			if _, ok := ids[dep]; ok {
+				return fmt.Errorf("%w: tx %d depends on another member", ErrInvalidBundle, i)
+			}
+		}
+	}
+	return nil
+}
+
+// settleBundles drops every bundle among txs that is only partly pending,
+// reporting the first member as typ. It returns whether any was dropped.
+// Caller must hold the write lock.
+func (m *mempool) settleBundles(txs []*Tx, typ MempoolEventType) bool {
+	want := make(map[string]int)
+	for _, tx := range txs {
+		if tx.BundleID != "" {
+			want[tx.BundleID]++
+		}
+	}
+	ids := make([]string, 0, len(want))
+	for id := range want {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	broken := false
+	for _, id := range ids {
+		b := m.bundles[id]
+		if b == nil || len(b.members) == want[id] {
+			continue
+		}
+		broken = true
+		first := b.members[0]
+		m.removeRecord(first)
+		m.dropped(typ, first.tx)
+	}
+	return broken
+}
+
+// indexBundle adds rec, already in its lane heap, to its bundle.
+// Caller must hold the write lock.
+func (m *mempool) indexBundle(rec *txRecord) {
+	id := rec.tx.BundleID
+	if id == "" {
+		return
+	}
+	b := m.bundles[id]
+	if b == nil {
+		b = &bundle{}
+		m.bundles[id] = b
+	}
+
+	heap.Remove(m.heapOf(rec.tx.Lane), rec.index)
+	m.rekeyBundle(b, func() {
+		i := sort.Search(len(b.members), func(i int) bool {
+			return b.members[i].tx.BundleIndex > rec.tx.BundleIndex
+		})
+		b.members = append(b.members, nil)
+		copy(b.members[i+1:], b.members[i:])
+		b.members[i] = rec
+		rec.bundle = b
+	})
+}
+
+// unindexBundle removes rec, already out of its lane heap, from its
+// bundle. Caller must hold the write lock.
+func (m *mempool) unindexBundle(rec *txRecord) {
+	b := rec.bundle
+	if b == nil {
+		return
+	}
+	kept := b.members[:0]
+	for _, r := range b.members {
+		if r != rec {
+			kept = append(kept, r)
+		}
+	}
+	b.members = kept
+	rec.bundle = nil
+
+	if len(b.members) == 0 {
+		delete(m.bundles, rec.tx.BundleID)
+		return
+	}
+	m.rekeyBundle(b, nil)
+}
+
+// rekeyBundle applies change (which may be nil) to b and re-sorts its
+// members under the new key. Members are taken out of their heap and
+// pushed back, since several heap entries change at once.
+// Caller must hold the write lock.
+func (m *mempool) rekeyBundle(b *bundle, change func()) {
+	for _, r := range b.members {
+		heap.Remove(m.heapOf(r.tx.Lane), r.index)
+	}
+	if change != nil {
+		change()
+	}
+	b.refresh()
+	for _, r := range b.members {
+		heap.Push(m.heapOf(r.tx.Lane), r)
+	}
+}
+
+// refresh recomputes the ordering key from the current members.
+func (b *bundle) refresh() {
+	if len(b.members) == 0 {
+		b.key = Tx{}
+		return
+	}
+	key := *b.members[0].tx
+	key.Fee, key.Gas, key.MaxFee, key.Tip = 0, 0, 0, 0
+	for _, r := range b.members {
+		tx := r.tx
+		maxFee, tip := tx.feeCaps()
+		key.Fee += tx.Fee
+		key.Gas += tx.Gas
+		key.MaxFee += maxFee
+		key.Tip += tip
+		if tx.Timestamp.Before(key.Timestamp) {
+			key.Timestamp = tx.Timestamp
+		}
+		if tx.CreatedAt.Before(key.CreatedAt) {
+			key.CreatedAt = tx.CreatedAt
+		}
+		if tx.ID < key.ID {
+			key.ID = tx.ID
+		}
+	}
+	b.key = key
+}
+
+// bundlePinned reports whether any member is pinned.
+func (m *mempool) bundlePinned(b *bundle) bool {
+	for _, r := range b.members {
+		if _, ok := m.pinned[r.tx.ID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dropBundle removes what is left of bundle id, publishing each member
+// as TxRemoved. Caller must hold the write lock.
+func (m *mempool) dropBundle(id string) {
+	b := m.bundles[id]
+	for b != nil && len(b.members) > 0 {
+		r := b.members[0]
+		m.removeRecord(r)
+		m.dropped(TxRemoved, r.tx)
+	}
+}
@@ -0,0 +1,132 @@
+package mempoor
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestBundleSelectedAsUnit(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	backrun := newTx("searcher", 100, 10)
+	victim := newTx("alice", 1, 10)
+	if _, err := mp.AddBundle("b1", []*Tx{victim, backrun}); err != nil {
+		t.Fatalf("unexpected AddBundle error: %v", err)
+	}
+	_, _ = mp.Add(newTx("carol", 60, 10))
+
+	// The bundle does not fit in one slot; carol goes alone.
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 1})
+	if len(res.Transactions) != 1 || res.Transactions[0].Sender != "carol" {
+		t.Fatalf("expected only carol, got %v", res.Transactions)
+	}
+
+	// Bundle fee 101 outranks anything else; members keep bundle order.
+	_, _ = mp.Add(newTx("dan", 90, 10))
+	res = mp.SelectTransactions(BlockConstraints{MaxTx: 2})
+	if len(res.Transactions) != 2 || res.Transactions[0].ID != victim.ID || res.Transactions[1].ID != backrun.ID {
+		t.Fatalf("expected the bundle in order, got %v", res.Transactions)
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBundleRespectsGasLimit(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	_, _ = mp.AddBundle("b1", []*Tx{newTx("alice", 50, 60), newTx("bob", 50, 60)})
+
+	if res := mp.SelectTransactions(BlockConstraints{MaxTx: 10, GasLimit: 100}); len(res.Transactions) != 0 {
+		t.Fatalf("expected nothing selected, got %v", res.Transactions)
+	}
+	if mp.Count() != 2 {
+		t.Fatalf("expected the bundle to stay pending, got %d txs", mp.Count())
+	}
+}
+
+func TestBundleMemberRemovalDropsBundle(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	a, b := newTx("alice", 5, 10), newTx("bob", 5, 10)
+	_, _ = mp.AddBundle("b1", []*Tx{a, b})
+	_, _ = mp.Add(newTx("carol", 5, 10))
+
+	if err := mp.Remove(a.ID); err != nil {
+		t.Fatalf("unexpected Remove error: %v", err)
+	}
+	if _, err := mp.Get(b.ID); !errors.Is(err, ErrTxNotFound) {
+		t.Fatalf("expected the rest of the bundle to be removed, got %v", err)
+	}
+	if mp.Count() != 1 {
+		t.Fatalf("expected only carol left, got %d", mp.Count())
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddBundleIsAllOrNothing(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 2, Overflow: OverflowReject})
+	_, _ = mp.Add(newTx("carol", 5, 10))
+
+	_, err := mp.AddBundle("b1", []*Tx{newTx("alice", 5, 10), newTx("bob", 5, 10)})
+	if !errors.Is(err, ErrMempoolFull) {
+		t.Fatalf("expected ErrMempoolFull, got %v", err)
+	}
+	if mp.Count() != 1 {
+		t.Fatalf("expected the partial bundle to be undone, got %d txs", mp.Count())
+	}
+
+	if _, err := mp.Add(&Tx{ID: "x", BundleID: "b2"}); !errors.Is(err, ErrInvalidBundle) {
+		t.Fatalf("expected Add to refuse bundled txs, got %v", err)
+	}
+	mixed := newTx("dan", 5, 10)
+	mixed.Lane = LaneUrgent
+	if _, err := mp.AddBundle("b3", []*Tx{newTx("erin", 5, 10), mixed}); !errors.Is(err, ErrInvalidBundle) {
+		t.Fatalf("expected mixed lanes to be refused, got %v", err)
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBundleRollbackRestoresWholeBundle(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	_, _ = mp.AddBundle("b1", []*Tx{newTx("alice", 5, 10), newTx("bob", 5, 10)})
+
+	res := mp.Reserve(BlockConstraints{MaxTx: 10})
+	if len(res.Result().Transactions) != 2 || mp.Count() != 0 {
+		t.Fatalf("expected the whole bundle reserved")
+	}
+	if err := res.Rollback(); err != nil {
+		t.Fatalf("unexpected Rollback error: %v", err)
+	}
+	if mp.Count() != 2 {
+		t.Fatalf("expected the bundle back, got %d txs", mp.Count())
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddBundleRPC(t *testing.T) {
+	n := NewNode(NodeConfig{})
+
+	rec := callRPC(n, `{"method":"tx.addBundle","params":{"txs":[
+		{"sender":"alice","recipient":"bob","fee":1,"gas":1},
+		{"sender":"searcher","recipient":"bob","fee":50,"gas":1}]}}`)
+	if rec.Code != http.StatusOK || n.mempool.Count() != 2 {
+		t.Fatalf("expected the bundle added, got %d: %s", rec.Code, rec.Body)
+	}
+	for _, tx := range n.mempool.List() {
+		if tx.BundleID == "" {
+			t.Fatalf("expected a generated bundle ID on %s", tx.ID)
+		}
+	}
+
+	rec = callRPC(n, `{"method":"tx.addBundle","params":{"txs":[
+		{"sender":"carol","recipient":"bob","fee":1,"gas":1,"lane":0},
+		{"sender":"dan","recipient":"bob","fee":1,"gas":1,"lane":1}]}}`)
+	if rec.Code != http.StatusBadRequest || n.mempool.Count() != 2 {
+		t.Fatalf("expected a mixed-lane bundle to be refused, got %d: %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,110 @@
+package mempoor
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BlockCandidate is one alternative way to fill a block, tried by the
+// builder alongside its configured Strategy and Packing.
+type BlockCandidate struct {
+	Strategy SelectionStrategy
+	Packing  PackingMode
+}
+
+// DefaultBlockCandidates tries every strategy, with and without knapsack
+// packing.
+var DefaultBlockCandidates = []BlockCandidate{
+	{Strategy: StrategyPriority, Packing: PackKnapsack},
+	{Strategy: StrategyFIFO},
+	{Strategy: StrategyFIFO, Packing: PackKnapsack},
+	{Strategy: StrategyFairShare},
+	{Strategy: StrategyFairShare, Packing: PackKnapsack},
+}
+
+// bestCandidate previews the block under the base constraints c and
+// under each configured candidate, concurrently, and returns the
+// constraints that reproduce the highest-fee preview.
+//
+// Best-of semantics:
+//   - Candidates are scored by tips, what the proposer earns (the total
+//     fee without a BaseFee); ties go to the earlier one, and
+//     the base constraints come first, so best-of never picks a block
+//     worth less than the one the builder would have built alone.
+//   - Previews use PeekTransactions, so the pool is only read (RLock)
+//     while candidates run; nothing is reserved or purged until the
+//     winner is selected for real.
+//   - The returned constraints add a Filter limited to the winner's txs.
+//     If the pool changes between preview and selection, txs that left
+//     are simply missing from the block and newcomers wait for the next
+//     one.
+//   - At most CandidateWorkers previews run at once. A closed c.Done
+//     stops them all; the winner's constraints drop Done, so the real
+//     selection still takes the winning preview's txs.
+func (b *BlockBuilder) bestCandidate(c BlockConstraints) BlockConstraints {
+	options := make([]BlockConstraints, 0, 1+len(b.cfg.Candidates))
+	options = append(options, c)
+	for _, cand := range b.cfg.Candidates {
+		oc := c
+		oc.Strategy = cand.Strategy
+		oc.Packing = cand.Packing
+		options = append(options, oc)
+	}
+
+	workers := b.cfg.CandidateWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	previews := make([]BlockSelectionResult, len(options))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(options); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				previews[i] = b.preview(options[i])
+			}
+		}()
+	}
+	for i := range options {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	best, bestTips := 0, previews[0].Tips
+	for i := 1; i < len(previews); i++ {
+		if tips := previews[i].Tips; tips > bestTips {
+			best, bestTips = i, tips
+		}
+	}
+
+	won := make(map[TxID]struct{}, len(previews[best].Transactions))
+	for _, tx := range previews[best].Transactions {
+		won[tx.ID] = struct{}{}
+	}
+	winner := options[best]
+	winner.Done = nil
+	prev := winner.Filter
+	winner.Filter = func(tx *Tx) bool {
+		_, ok := won[tx.ID]
+		return ok && (prev == nil || prev(tx))
+	}
+	return winner
+}
+
+// preview returns the selection c would make right now, across every
+// pool, without changing them.
+func (b *BlockBuilder) preview(c BlockConstraints) BlockSelectionResult {
+	if len(b.cfg.Pools) == 0 && len(b.cfg.PoolGasLimits) == 0 {
+		return b.mp.PeekTransactions(c)
+	}
+	return b.splitPools(c, Mempool.PeekTransactions)
+}
+
+// selectionFees is what the selected txs pay in total.
+func selectionFees(res BlockSelectionResult) uint64 {
+	return res.Burned + res.Tips
+}
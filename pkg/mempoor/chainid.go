@@ -0,0 +1,32 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWrongChain is returned for a tx or chain that belongs to another
+// network than the node's.
+var ErrWrongChain = errors.New("mempool: wrong chain ID")
+
+// Chain ID semantics (NodeConfig.ChainID):
+//   - The chain ID names a network. It is recorded in the genesis block
+//     and in every block header, and is part of their hashes, so two
+//     networks never share a block, genesis included.
+//   - Add rejects a tx whose ChainID differs from the mempool's with
+//     ErrWrongChain (HTTP 400 over RPC): a client pointed at the wrong
+//     node is told so instead of having its tx mixed into another chain.
+//     Without a chain ID the node accepts only txs that carry none.
+//   - Validate requires every block to carry its parent's ChainID;
+//     block.propose requires the node's. A node restarted with another
+//     ChainID than its stored chain's refuses to start with
+//     ErrWrongChain.
+//   - A tx's ChainID is not part of its ID.
+
+// checkChainID rejects tx unless it is for chainID.
+func checkChainID(tx *Tx, chainID string) error {
+	if tx.ChainID != chainID {
+		return fmt.Errorf("%w: tx is for %q, node serves %q", ErrWrongChain, tx.ChainID, chainID)
+	}
+	return nil
+}
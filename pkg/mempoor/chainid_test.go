@@ -0,0 +1,63 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChainIDIsolation(t *testing.T) {
+	dir := t.TempDir()
+	n := NewNode(NodeConfig{DataDir: dir, MaxTxPerBlock: 10, ChainID: "testnet-1"})
+	closeChain, err := n.openChain()
+	if err != nil {
+		t.Fatalf("open chain: %v", err)
+	}
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+
+	if _, err := n.mempool.Add(newTx("alice", 10, 10)); !errors.Is(err, ErrWrongChain) {
+		t.Fatalf("expected ErrWrongChain for a tx without a chain ID, got %v", err)
+	}
+	other := newTx("bob", 10, 10)
+	other.ChainID = "mainnet"
+	if _, err := n.mempool.Add(other); !errors.Is(err, ErrWrongChain) {
+		t.Fatalf("expected ErrWrongChain for another chain's tx, got %v", err)
+	}
+	tx := newTx("carol", 10, 10)
+	tx.ChainID = "testnet-1"
+	if _, err := n.mempool.Add(rehash(tx)); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+
+	prevHash, height := n.chainTip()
+	block, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second))
+	if err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	if block.Header.ChainID != "testnet-1" || n.blocks[0].Header.ChainID != "testnet-1" {
+		t.Fatalf("expected the chain ID in every header, got %q and %q", n.blocks[0].Header.ChainID, block.Header.ChainID)
+	}
+	if NewGenesisBlock(GenesisConfig{}).Hash() == n.blocks[0].Hash() {
+		t.Fatalf("expected the chain ID to change the genesis hash")
+	}
+
+	forged := *block
+	forged.Header.ChainID = "mainnet"
+	if err := Validate(&forged, n.blocks[0], 0); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected ErrInvalidBlock for a chain ID change, got %v", err)
+	}
+	closeChain()
+
+	restarted := NewNode(NodeConfig{DataDir: dir, MaxTxPerBlock: 10, ChainID: "mainnet"})
+	closeChain, err = restarted.openChain()
+	if err != nil {
+		t.Fatalf("reopen chain: %v", err)
+	}
+	defer closeChain()
+	if err := restarted.initGenesis(); !errors.Is(err, ErrWrongChain) {
+		t.Fatalf("expected ErrWrongChain on a data dir from another network, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package mempoor
+
+// Chain index semantics:
+//   - Heights on the chain are contiguous from genesis, so n.blocks is
+//     itself the height index: the block at height h is n.blocks[h].
+//   - n.byHash maps the hash of every block on the chain to its height.
+//     It changes together with n.blocks, under blocksMu, so a lookup by
+//     hash never finds a displaced block. Pruning keeps hashes, so it
+//     leaves the index alone.
+//   - n.txIndex locates every tx on the chain the same way; see
+//     receipt.go.
+
+// setChainLocked replaces the blocks from height from on with tail,
+// which continues the chain at from, and keeps byHash and txIndex in
+// step. The caller holds blocksMu for writing.
+func (n *Node) setChainLocked(from int, tail ...*Block) {
+	for _, b := range n.blocks[from:] {
+		delete(n.byHash, b.Hash())
+		n.indexTxs(b, false)
+	}
+	n.blocks = append(n.blocks[:from:from], tail...)
+	for _, b := range tail {
+		n.byHash[b.Hash()] = b.Header.Height
+		n.indexTxs(b, true)
+	}
+}
+
+// blockAt returns the block at height on the chain.
+func (n *Node) blockAt(height uint64) (*Block, bool) {
+	n.blocksMu.RLock()
+	defer n.blocksMu.RUnlock()
+
+	if height >= uint64(len(n.blocks)) {
+		return nil, false
+	}
+	return n.blocks[height], true
+}
+
+// blockByHash returns the block on the chain with hash.
+func (n *Node) blockByHash(hash [32]byte) (*Block, bool) {
+	n.blocksMu.RLock()
+	defer n.blocksMu.RUnlock()
+
+	height, ok := n.byHash[hash]
+	if !ok {
+		return nil, false
+	}
+	return n.blocks[height], true
+}
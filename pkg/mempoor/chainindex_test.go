@@ -0,0 +1,78 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// getBlock calls block.get with params and returns the block's hash, or
+// the RPC error.
+func getBlock(t *testing.T, n *Node, params string) (string, string) {
+	t.Helper()
+	rec := callRPC(n, fmt.Sprintf(`{"method":"block.get","params":%s}`, params))
+	var resp struct {
+		Result struct {
+			Block blockDTO `json:"block"`
+			Error string   `json:"error"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode block.get: %v: %s", err, rec.Body)
+	}
+	return resp.Result.Block.Hash, resp.Result.Error
+}
+
+func TestBlockGetByHash(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	_, _ = n.mempool.Add(newTx("alice", 5, 10))
+	prevHash, height := n.chainTip()
+	b1, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second))
+	if err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	b1Hash := b1.Hash()
+	hexB1 := hex.EncodeToString(b1Hash[:])
+
+	if got, errMsg := getBlock(t, n, fmt.Sprintf(`{"hash":%q}`, hexB1)); got != hexB1 {
+		t.Fatalf("expected block 1 by hash, got %q (%s)", got, errMsg)
+	}
+	if got, _ := getBlock(t, n, `{"height":1}`); got != hexB1 {
+		t.Fatalf("expected block 1 by height, got %q", got)
+	}
+
+	// A reorg drops the displaced block from the index.
+	competing := forkBlock(n.blocks[0], newTx("bob", 50, 10))
+	if _, err := n.reorgBlock(competing); err != nil {
+		t.Fatalf("reorg: %v", err)
+	}
+	if _, errMsg := getBlock(t, n, fmt.Sprintf(`{"hash":%q}`, hexB1)); errMsg != "block not found" {
+		t.Fatalf("expected the displaced block gone, got %q", errMsg)
+	}
+	competingHash := competing.Hash()
+	if b, ok := n.blockByHash(competingHash); !ok || b.Header.Height != 1 {
+		t.Fatalf("expected the competing block indexed at height 1")
+	}
+	if _, errMsg := getBlock(t, n, `{"height":2}`); errMsg != "block not found" {
+		t.Fatalf("expected no block above the tip, got %q", errMsg)
+	}
+
+	if rec := callRPC(n, `{"method":"block.get","params":{"hash":"zz"}}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected a malformed hash to be refused, got %d", rec.Code)
+	}
+	both := fmt.Sprintf(`{"method":"block.get","params":{"height":1,"hash":%q}}`, hexB1)
+	if rec := callRPC(n, both); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected height and hash together to be refused, got %d", rec.Code)
+	}
+	genesis := n.blocks[0].Hash()
+	if got, _ := getBlock(t, n, `{}`); got != hex.EncodeToString(genesis[:]) {
+		t.Fatalf("expected the genesis block without height or hash, got %q", got)
+	}
+}
@@ -0,0 +1,140 @@
+package mempoor
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// checkpointFile is the name of the chain checkpoint inside
+// NodeConfig.DataDir.
+const checkpointFile = "checkpoint.json"
+
+// checkpointVersion is the chainCheckpoint format version.
+const checkpointVersion = 1
+
+// Checkpoint semantics (NodeConfig.CheckpointEvery):
+//   - With a DataDir, every CheckpointEvery blocks the node writes a
+//     checkpoint once the block is committed: the tip's height and hash
+//     and the account state (balances and next nonces) as of that tip.
+//     The mempool is snapshotted and its journal folded in the same
+//     step, so checkpoint.json and mempool.json describe one moment;
+//     pool changes after it go to the journal as usual.
+//   - Both files are written to a temp file and renamed, so a crash
+//     leaves the previous checkpoint or the new one, never half of one.
+//   - On startup, and after a reorg or import, the account state starts
+//     from the checkpoint and replays only the blocks above it, instead
+//     of every block from genesis. The mempool reloads from its snapshot
+//     plus the journal, as always.
+//   - A checkpoint whose tip is no longer on the chain (a reorg or
+//     import replaced it, or the block store lost it) is ignored, and
+//     the state is rebuilt from genesis.
+//
+// NOTE: The stored chain is still validated block by block on reload;
+// the checkpoint saves replaying the state, not checking the chain.
+
+// chainCheckpoint is the on-disk form of a checkpoint.
+type chainCheckpoint struct {
+	Version  int               `json:"version"`
+	Height   uint64            `json:"height"`
+	TipHash  string            `json:"tipHash"`  // hex
+	Accounts bool              `json:"accounts"` // Balances and Nonces were recorded
+	Balances map[string]uint64 `json:"balances,omitempty"`
+	Nonces   map[string]uint64 `json:"nonces,omitempty"`
+	Created  time.Time         `json:"created"`
+}
+
+// maybeWriteCheckpoint writes a checkpoint if b, just committed, is at a
+// CheckpointEvery boundary. Caller must hold produceMu.
+func (n *Node) maybeWriteCheckpoint(b *Block) {
+	every := n.cfg.CheckpointEvery
+	if every <= 0 || n.cfg.DataDir == "" || b.Header.Height%uint64(every) != 0 {
+		return
+	}
+	if err := n.writeCheckpoint(b); err != nil {
+		n.log.chain.Error("checkpoint failed", "height", b.Header.Height, "err", err)
+		return
+	}
+	n.log.chain.Info("checkpoint written", "height", b.Header.Height, "hash", fmt.Sprintf("%x", b.Hash()))
+}
+
+// writeCheckpoint records tip and the state as of it, then snapshots the
+// mempool; see "Checkpoint semantics".
+func (n *Node) writeCheckpoint(tip *Block) error {
+	hash := tip.Hash()
+	cp := &chainCheckpoint{
+		Version: checkpointVersion,
+		Height:  tip.Header.Height,
+		TipHash: hex.EncodeToString(hash[:]),
+		Created: n.cfg.Clock.Now().UTC(),
+	}
+	if n.state != nil {
+		cp.Accounts = true
+		cp.Balances, cp.Nonces = n.state.snapshot()
+	}
+	raw, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+
+	if err := os.MkdirAll(n.cfg.DataDir, 0o755); err != nil {
+		return fmt.Errorf("create data dir: %w", err)
+	}
+	path := filepath.Join(n.cfg.DataDir, checkpointFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	n.lastCheckpoint = cp
+	return n.checkpoint()
+}
+
+// loadCheckpoint reads the checkpoint in DataDir, if any.
+func (n *Node) loadCheckpoint() error {
+	if n.cfg.DataDir == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(filepath.Join(n.cfg.DataDir, checkpointFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read checkpoint: %w", err)
+	}
+	var cp chainCheckpoint
+	if err := json.Unmarshal(raw, &cp); err != nil {
+		return fmt.Errorf("decode checkpoint: %w", err)
+	}
+	if cp.Version != checkpointVersion {
+		return fmt.Errorf("checkpoint version %d, want %d", cp.Version, checkpointVersion)
+	}
+	n.lastCheckpoint = &cp
+	return nil
+}
+
+// checkpointedState returns the blocks of chain above the last
+// checkpoint and the state as of it, if the checkpoint's tip is still
+// on chain.
+func (n *Node) checkpointedState(chain []*Block) (above []*Block, balances, nonces map[string]uint64, ok bool) {
+	cp := n.lastCheckpoint
+	if cp == nil || !cp.Accounts || cp.Height >= uint64(len(chain)) {
+		return nil, nil, nil, false
+	}
+	hash := chain[cp.Height].Hash()
+	if hex.EncodeToString(hash[:]) != cp.TipHash {
+		return nil, nil, nil, false
+	}
+	balances, nonces = make(map[string]uint64), make(map[string]uint64)
+	maps.Copy(balances, cp.Balances)
+	maps.Copy(nonces, cp.Nonces)
+	return chain[cp.Height+1:], balances, nonces, true
+}
@@ -0,0 +1,106 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// startFromDisk opens dir's chain and state the way run does.
+func startFromDisk(t *testing.T, cfg NodeConfig) (*Node, func()) {
+	t.Helper()
+	n := NewNode(cfg)
+	closeChain, err := n.openChain()
+	if err != nil {
+		t.Fatalf("open chain: %v", err)
+	}
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	if err := n.loadCheckpoint(); err != nil {
+		t.Fatalf("load checkpoint: %v", err)
+	}
+	if err := n.rebuildState(); err != nil {
+		t.Fatalf("rebuild state: %v", err)
+	}
+	return n, closeChain
+}
+
+func TestCheckpointEveryNBlocks(t *testing.T) {
+	dir := t.TempDir()
+	cfg := NodeConfig{
+		DataDir:         dir,
+		MaxTxPerBlock:   1,
+		BlockInterval:   time.Second,
+		CheckpointEvery: 2,
+		Genesis:         GenesisConfig{Balances: map[string]uint64{"alice": 100, "bob": 100, "carol": 100}},
+	}
+	n1, closeChain := startFromDisk(t, cfg)
+	for _, sender := range []string{"alice", "bob", "carol"} {
+		tx := newTx(sender, 10, 10)
+		tx.Recipient, tx.Amount = "dave", 5
+		if _, err := n1.mempool.Add(rehash(tx)); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	if _, err := n1.Mine(context.Background(), 3); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	closeChain()
+
+	raw, err := os.ReadFile(filepath.Join(dir, checkpointFile))
+	if err != nil {
+		t.Fatalf("read checkpoint: %v", err)
+	}
+	var cp chainCheckpoint
+	if err := json.Unmarshal(raw, &cp); err != nil {
+		t.Fatalf("decode checkpoint: %v", err)
+	}
+	if cp.Height != 2 || !cp.Accounts || cp.Balances["dave"] != 10 {
+		t.Fatalf("expected a checkpoint at height 2 with dave at 10, got %+v", cp)
+	}
+	if _, err := os.Stat(filepath.Join(dir, mempoolFile)); err != nil {
+		t.Fatalf("expected a mempool snapshot beside the checkpoint: %v", err)
+	}
+
+	// Mark the checkpoint so the restart shows it was used: only block 3
+	// is replayed on top of it.
+	cp.Balances["marker"] = 7
+	raw, _ = json.Marshal(cp)
+	if err := os.WriteFile(filepath.Join(dir, checkpointFile), raw, 0o644); err != nil {
+		t.Fatalf("write checkpoint: %v", err)
+	}
+	n2, closeChain := startFromDisk(t, cfg)
+	defer closeChain()
+	if n2.state.Balance("marker") != 7 || n2.state.Balance("dave") != 15 {
+		t.Fatalf("expected the state from the checkpoint plus block 3, got marker=%d dave=%d",
+			n2.state.Balance("marker"), n2.state.Balance("dave"))
+	}
+}
+
+func TestCheckpointOffChainIsIgnored(t *testing.T) {
+	dir := t.TempDir()
+	cfg := NodeConfig{DataDir: dir, MaxTxPerBlock: 1, BlockInterval: time.Second,
+		Genesis: GenesisConfig{Balances: map[string]uint64{"alice": 100}}}
+	n1, closeChain := startFromDisk(t, cfg)
+	_, _ = n1.mempool.Add(newTx("alice", 10, 10))
+	if _, err := n1.Mine(context.Background(), 1); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	closeChain()
+
+	// A checkpoint for a block the chain does not hold.
+	raw, _ := json.Marshal(chainCheckpoint{Version: checkpointVersion, Height: 1, TipHash: "00", Accounts: true,
+		Balances: map[string]uint64{"alice": 1}})
+	if err := os.WriteFile(filepath.Join(dir, checkpointFile), raw, 0o644); err != nil {
+		t.Fatalf("write checkpoint: %v", err)
+	}
+	n2, closeChain := startFromDisk(t, cfg)
+	defer closeChain()
+	if got := n2.state.Balance("alice"); got != 90 {
+		t.Fatalf("expected the state rebuilt from genesis (alice 90), got %d", got)
+	}
+}
@@ -0,0 +1,58 @@
+package mempoor
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts time.Now so mempool, builder, and tx construction can
+// be driven deterministically in tests and simulations.
+type Clock interface {
+	// Now returns the current time in UTC.
+	Now() time.Time
+}
+
+// SystemClock is the real wall clock. It is the default everywhere a
+// Clock is accepted.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now().UTC() }
+
+// FakeClock is a manually driven Clock. It only moves when Set or
+// Advance is called. Safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a FakeClock reading start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start.UTC()}
+}
+
+// Now returns the clock's current reading.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set moves the clock to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = t.UTC()
+}
+
+// Advance moves the clock forward by d and returns the new reading.
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	return c.now
+}
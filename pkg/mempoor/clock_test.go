@@ -0,0 +1,58 @@
+package mempoor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFakeClockDrivesTxTimestamps(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	a := NewUnsignedTxWithClock(clock, "alice", "bob", "hi", 0, 10, 10)
+	b := NewUnsignedTxWithClock(clock, "alice", "bob", "hi", 0, 10, 10)
+	if a.ID != b.ID || !a.CreatedAt.Equal(start) {
+		t.Fatalf("expected identical deterministic txs at %v, got %v / %v", start, a.CreatedAt, b.CreatedAt)
+	}
+
+	clock.Advance(time.Minute)
+	upd := NewTxUpdateWithClock(clock, a.ID, a.Sender, a.Recipient, a.Payload, a.Nonce, 20, a.Gas, a.CreatedAt)
+	if !upd.Timestamp.Equal(start.Add(time.Minute)) || !upd.CreatedAt.Equal(start) {
+		t.Fatalf("unexpected update times: created=%v ts=%v", upd.CreatedAt, upd.Timestamp)
+	}
+}
+
+func TestFakeClockDrivesOrphanExpiry(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	mp := NewMempool(MempoolConfig{MaxOrphans: 10, OrphanTTL: time.Minute, Clock: clock})
+
+	_, _ = mp.Add(NewUnsignedTxWithClock(clock, "alice", "bob", "a", 5, 10, 10))
+
+	clock.Advance(30 * time.Second)
+	_, _ = mp.Add(newTx("carol", 10, 10))
+	if len(mp.Orphans()) != 1 {
+		t.Fatalf("orphan must survive before its TTL")
+	}
+
+	clock.Advance(time.Minute)
+	_, _ = mp.Add(newTx("dan", 10, 10))
+	if len(mp.Orphans()) != 0 {
+		t.Fatalf("orphan must expire once the clock passes its TTL")
+	}
+}
+
+func TestBuilderUsesClockForZeroTime(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	mp := NewMempool(MempoolConfig{})
+	_, _ = mp.Add(newTx("alice", 10, 10))
+
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{MaxTxPerBlock: 10, Clock: clock})
+	blk, err := builder.BuildBlock(context.Background(), [32]byte{}, 0, time.Time{})
+	if err != nil {
+		t.Fatalf("BuildBlock: %v", err)
+	}
+	if !blk.Header.Timestamp.Equal(clock.Now()) {
+		t.Fatalf("expected block stamped by clock, got %v", blk.Header.Timestamp)
+	}
+}
@@ -0,0 +1,176 @@
+package mempoor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrUnsupportedCompression is returned for a PayloadCompression this
+// build cannot encode or decode.
+var ErrUnsupportedCompression = errors.New("mempool: unsupported payload compression")
+
+// PayloadCompression selects how tx payloads of stored or served blocks
+// are compressed.
+//
+// Compression semantics (NodeConfig.BlockCompression, block.list/get):
+//   - Only Tx.Payload is compressed; IDs, and so block hashes, are
+//     unchanged. Block.Compression records the codec of a block's
+//     payloads; CompressPayloads and DecompressPayloads return copies and
+//     never touch the txs they are given.
+//   - The node compresses each block as it stores it, after BlockSinks
+//     have seen the plain block, and decompresses on every read, so
+//     callers never see the difference unless they ask for it.
+//   - Over RPC, block.list and block.get serve plain payloads unless
+//     "compression" is requested; compressed payloads are sent base64
+//     encoded, and the block carries "compression" to say so.
+//   - Every payload is compressed, so small ones may grow; the codec pays
+//     off for blocks whose payloads carry bulk data.
+//
+// NOTE: Only gzip is built in; zstd would need a module outside the
+// standard library, so ParsePayloadCompression rejects it with
+// ErrUnsupportedCompression.
+type PayloadCompression int
+
+const (
+	// CompressNone leaves payloads as they are. Default.
+	CompressNone PayloadCompression = iota
+
+	// CompressGzip stores payloads gzip-compressed.
+	CompressGzip
+)
+
+func (c PayloadCompression) String() string {
+	switch c {
+	case CompressNone:
+		return "none"
+	case CompressGzip:
+		return "gzip"
+	default:
+		return "unknown"
+	}
+}
+
+// ParsePayloadCompression maps a codec name (as used by the CLI and RPC)
+// to a PayloadCompression. "" means CompressNone.
+func ParsePayloadCompression(name string) (PayloadCompression, error) {
+	switch name {
+	case "", "none":
+		return CompressNone, nil
+	case "gzip":
+		return CompressGzip, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedCompression, name)
+	}
+}
+
+// CompressPayloads returns a copy of b with its tx payloads compressed
+// under c. b must not be compressed already.
+func CompressPayloads(b *Block, c PayloadCompression) (*Block, error) {
+	if b.Compression != CompressNone {
+		return nil, fmt.Errorf("%w: block is already %s", ErrUnsupportedCompression, b.Compression)
+	}
+	return mapPayloads(b, c, func(p string) (string, error) {
+		switch c {
+		case CompressNone:
+			return p, nil
+		case CompressGzip:
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf)
+			if _, err := io.WriteString(zw, p); err != nil {
+				return "", err
+			}
+			if err := zw.Close(); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		default:
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedCompression, c)
+		}
+	})
+}
+
+// DecompressPayloads returns a copy of b with plain tx payloads, or b
+// itself if it is not compressed.
+func DecompressPayloads(b *Block) (*Block, error) {
+	if b.Compression == CompressNone {
+		return b, nil
+	}
+	return mapPayloads(b, CompressNone, func(p string) (string, error) {
+		switch b.Compression {
+		case CompressGzip:
+			zr, err := gzip.NewReader(bytes.NewReader([]byte(p)))
+			if err != nil {
+				return "", err
+			}
+			raw, err := io.ReadAll(zr)
+			if err != nil {
+				return "", err
+			}
+			return string(raw), nil
+		default:
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedCompression, b.Compression)
+		}
+	})
+}
+
+// mapPayloads returns a copy of b, marked as compressed under c, whose
+// txs are copies with f applied to their payloads.
+func mapPayloads(b *Block, c PayloadCompression, f func(string) (string, error)) (*Block, error) {
+	out := *b
+	out.Compression = c
+	out.Transactions = make([]*Tx, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		cp := *tx
+		p, err := f(tx.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("payload of tx %s: %w", tx.ID, err)
+		}
+		cp.Payload = p
+		out.Transactions[i] = &cp
+	}
+	return &out, nil
+}
+
+// encodePayloads returns a copy of a compressed block with its binary
+// payloads base64-encoded for JSON.
+func encodePayloads(b *Block) *Block {
+	if b.Compression == CompressNone {
+		return b
+	}
+	out, _ := mapPayloads(b, b.Compression, func(p string) (string, error) {
+		return base64.StdEncoding.EncodeToString([]byte(p)), nil
+	})
+	return out
+}
+
+// decodePayloads is the inverse of encodePayloads.
+func decodePayloads(b *Block) (*Block, error) {
+	if b.Compression == CompressNone {
+		return b, nil
+	}
+	return mapPayloads(b, b.Compression, func(p string) (string, error) {
+		raw, err := base64.StdEncoding.DecodeString(p)
+		return string(raw), err
+	})
+}
+
+// servedBlock returns stored block b as the RPC serves it: with plain
+// payloads, or compressed under c and encoded for JSON.
+func servedBlock(b *Block, c PayloadCompression) (*Block, error) {
+	if c == b.Compression {
+		return encodePayloads(b), nil
+	}
+	plain, err := DecompressPayloads(b)
+	if err != nil || c == CompressNone {
+		return plain, err
+	}
+	compressed, err := CompressPayloads(plain, c)
+	if err != nil {
+		return nil, err
+	}
+	return encodePayloads(compressed), nil
+}
@@ -0,0 +1,79 @@
+package mempoor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompressPayloadsRoundTrip(t *testing.T) {
+	payload := strings.Repeat("bulk data ", 100)
+	tx := NewUnsignedTx("alice", "bob", payload, 0, 5, 10)
+	b := &Block{Header: BlockHeader{Height: 3}, Transactions: []*Tx{tx}}
+
+	compressed, err := CompressPayloads(b, CompressGzip)
+	if err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if compressed.Compression != CompressGzip || len(compressed.Transactions[0].Payload) >= len(payload) {
+		t.Fatalf("expected a smaller gzip payload, got %d bytes", len(compressed.Transactions[0].Payload))
+	}
+	if tx.Payload != payload || compressed.Hash() != b.Hash() {
+		t.Fatalf("compression must not touch the source tx or the hash")
+	}
+
+	plain, err := DecompressPayloads(compressed)
+	if err != nil || plain.Compression != CompressNone || plain.Transactions[0].Payload != payload {
+		t.Fatalf("expected the payload back, got %v", err)
+	}
+	if _, err := ParsePayloadCompression("zstd"); !errors.Is(err, ErrUnsupportedCompression) {
+		t.Fatalf("expected ErrUnsupportedCompression, got %v", err)
+	}
+}
+
+func TestNodeStoresCompressedBlocks(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10, BlockCompression: CompressGzip})
+	payload := strings.Repeat("x", 512)
+	_, _ = n.mempool.Add(NewUnsignedTx("alice", "bob", payload, 0, 5, 10))
+	if _, err := n.produceBlock(context.Background(), [32]byte{}, 0, time.Unix(1, 0).UTC()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n.blocks[0].Compression != CompressGzip {
+		t.Fatalf("expected the stored block compressed")
+	}
+
+	get := func(body string) blockDTO {
+		t.Helper()
+		var resp struct {
+			Result getBlockResult `json:"result"`
+		}
+		if err := json.NewDecoder(callRPC(n, body).Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp.Result.Block
+	}
+
+	if b := get(`{"method":"block.get","params":{"height":0}}`); b.Compression != "" || b.Txs[0].Payload != payload {
+		t.Fatalf("expected a plain payload by default, got %q", b.Compression)
+	}
+
+	b := get(`{"method":"block.get","params":{"height":0,"compression":"gzip"}}`)
+	raw, err := base64.StdEncoding.DecodeString(b.Txs[0].Payload)
+	if err != nil || b.Compression != "gzip" {
+		t.Fatalf("expected a base64 gzip payload, got %q: %v", b.Compression, err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	if got, _ := io.ReadAll(zr); string(got) != payload {
+		t.Fatalf("compressed payload does not decode to the original")
+	}
+}
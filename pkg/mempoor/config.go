@@ -0,0 +1,288 @@
+package mempoor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ConfigFile is the YAML form of the NodeConfig settings a deployment
+// can set without recompiling (mempoor start --config). JSON is YAML,
+// so a JSON file works too.
+//
+// Config file semantics:
+//   - Every field is optional; an absent field keeps the value the
+//     config already has, which for the CLI is DefaultNodeConfig's.
+//   - Unknown fields are an error, so a misspelt setting is not silently
+//     ignored. Durations are Go durations ("2s", "1h30m").
+//   - CLI flags that are given override the file; flags left at their
+//     defaults do not.
+//
+// Config reload semantics (SIGHUP with NodeConfig.ConfigFile):
+//   - The node re-reads the file and applies gasLimit, maxTxPerBlock,
+//     minFee, and blockInterval together, as one limits update (see
+//     limits.go): a build sees all old or all new values. A new interval
+//     restarts the block ticker.
+//   - Every changed setting is logged as "old -> new". Other settings
+//     that changed are logged as ignored: they take a restart. The
+//     mempool and chain are untouched either way.
+//   - The file's values win over flags given at startup.
+//   - A file that fails to parse, or invalid limits, change nothing.
+//   - With a LimitsFile too, it is applied after the config file.
+type ConfigFile struct {
+	Listen     *string `yaml:"listen"`
+	GRPCListen *string `yaml:"grpcListen"`
+	DataDir    *string `yaml:"dataDir"`
+	ChainID    *string `yaml:"chainId"`
+
+	Peers        []string       `yaml:"peers"`
+	PeerInterval *time.Duration `yaml:"peerInterval"`
+
+	BlockInterval *time.Duration `yaml:"blockInterval"`
+	GasLimit      *uint64        `yaml:"gasLimit"`
+	MaxTxPerBlock *int           `yaml:"maxTxPerBlock"`
+	MinFee        *uint64        `yaml:"minFee"`
+	BaseFee       *uint64        `yaml:"baseFee"`
+	MaxMempoolTxs *int           `yaml:"maxMempoolTxs"`
+
+	Strategy     *string        `yaml:"strategy"`
+	BestOf       *bool          `yaml:"bestOf"`
+	EmptyBlocks  *bool          `yaml:"emptyBlocks"`
+	FillTarget   *int           `yaml:"fillTarget"`
+	BuildTimeout *time.Duration `yaml:"buildTimeout"`
+	ForkChoice   *string        `yaml:"forkChoice"`
+
+	Proposer         *string `yaml:"proposer"`
+	ExtraData        *string `yaml:"extraData"`
+	BlockCompression *string `yaml:"blockCompression"`
+	KeyFile          *string `yaml:"keyFile"`
+	LimitsFile       *string `yaml:"limitsFile"`
+	ReplayLog        *string `yaml:"replayLog"`
+
+	LogLevel  *string `yaml:"logLevel"`
+	LogFormat *string `yaml:"logFormat"`
+
+	RetainBlocks    *int           `yaml:"retainBlocks"`
+	RetainAge       *time.Duration `yaml:"retainAge"`
+	DrainOnShutdown *bool          `yaml:"drainOnShutdown"`
+	CheckpointEvery *int           `yaml:"checkpointEvery"`
+
+	Genesis *struct {
+		ExtraData *string           `yaml:"extraData"`
+		Balances  map[string]uint64 `yaml:"balances"`
+	} `yaml:"genesis"`
+}
+
+// ReadConfigFile reads and parses the config file at path.
+func ReadConfigFile(path string) (ConfigFile, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ConfigFile{}, fmt.Errorf("read config file: %w", err)
+	}
+	var f ConfigFile
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return ConfigFile{}, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Apply sets the fields of cfg that f sets.
+func (f ConfigFile) Apply(cfg *NodeConfig) error {
+	setIf(&cfg.ListenAddr, f.Listen)
+	setIf(&cfg.GRPCListenAddr, f.GRPCListen)
+	setIf(&cfg.DataDir, f.DataDir)
+	setIf(&cfg.ChainID, f.ChainID)
+	setIf(&cfg.BlockInterval, f.BlockInterval)
+	setIf(&cfg.GasLimit, f.GasLimit)
+	setIf(&cfg.MaxTxPerBlock, f.MaxTxPerBlock)
+	setIf(&cfg.MinFee, f.MinFee)
+	setIf(&cfg.BaseFee, f.BaseFee)
+	setIf(&cfg.MaxMempoolTxs, f.MaxMempoolTxs)
+	setIf(&cfg.ProduceEmptyBlocks, f.EmptyBlocks)
+	setIf(&cfg.FillTarget, f.FillTarget)
+	setIf(&cfg.BuildTimeout, f.BuildTimeout)
+	setIf(&cfg.Proposer, f.Proposer)
+	setIf(&cfg.KeyFile, f.KeyFile)
+	setIf(&cfg.LimitsFile, f.LimitsFile)
+	setIf(&cfg.ReplayLog, f.ReplayLog)
+	setIf(&cfg.Retention.Blocks, f.RetainBlocks)
+	setIf(&cfg.Retention.Age, f.RetainAge)
+	setIf(&cfg.DrainOnShutdown, f.DrainOnShutdown)
+	setIf(&cfg.CheckpointEvery, f.CheckpointEvery)
+	setIf(&cfg.PeerInterval, f.PeerInterval)
+	if f.Peers != nil {
+		cfg.Peers = f.Peers
+	}
+
+	if f.Strategy != nil {
+		s, err := ParseSelectionStrategy(*f.Strategy)
+		if err != nil {
+			return err
+		}
+		cfg.Strategy = s
+	}
+	if f.BestOf != nil {
+		cfg.Candidates = nil
+		if *f.BestOf {
+			cfg.Candidates = DefaultBlockCandidates
+		}
+	}
+	if f.ForkChoice != nil {
+		fc, err := ParseForkChoice(*f.ForkChoice)
+		if err != nil {
+			return err
+		}
+		cfg.ForkChoice = fc
+	}
+	if f.ExtraData != nil {
+		cfg.ExtraData = []byte(*f.ExtraData)
+	}
+	if f.BlockCompression != nil {
+		c, err := ParsePayloadCompression(*f.BlockCompression)
+		if err != nil {
+			return err
+		}
+		cfg.BlockCompression = c
+	}
+	if f.LogLevel != nil {
+		level, err := ParseLogLevel(*f.LogLevel)
+		if err != nil {
+			return err
+		}
+		cfg.LogLevel = level
+	}
+	if f.LogFormat != nil {
+		format, err := ParseLogFormat(*f.LogFormat)
+		if err != nil {
+			return err
+		}
+		cfg.LogFormat = format
+	}
+	if f.Genesis != nil {
+		if f.Genesis.ExtraData != nil {
+			cfg.Genesis.ExtraData = []byte(*f.Genesis.ExtraData)
+		}
+		if f.Genesis.Balances != nil {
+			cfg.Genesis.Balances = f.Genesis.Balances
+		}
+	}
+	return nil
+}
+
+// setIf copies *v into *dst if v is non-nil.
+func setIf[T any](dst *T, v *T) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+// reloadableKeys are the config keys a reload applies; see "Config
+// reload semantics".
+var reloadableKeys = map[string]bool{
+	"gasLimit": true, "maxTxPerBlock": true, "minFee": true, "blockInterval": true,
+}
+
+// configView returns cfg's config-file settings by key, for diffing.
+func configView(cfg NodeConfig) map[string]string {
+	v := func(x any) string { return fmt.Sprint(x) }
+	forkChoice := cfg.ForkChoice
+	if forkChoice == nil {
+		forkChoice = MostFees{}
+	}
+	return map[string]string{
+		"listen":            cfg.ListenAddr,
+		"grpcListen":        cfg.GRPCListenAddr,
+		"dataDir":           cfg.DataDir,
+		"chainId":           cfg.ChainID,
+		"peers":             strings.Join(cfg.Peers, ","),
+		"peerInterval":      v(cfg.PeerInterval),
+		"blockInterval":     v(cfg.BlockInterval),
+		"gasLimit":          v(cfg.GasLimit),
+		"maxTxPerBlock":     v(cfg.MaxTxPerBlock),
+		"minFee":            v(cfg.MinFee),
+		"baseFee":           v(cfg.BaseFee),
+		"maxMempoolTxs":     v(cfg.MaxMempoolTxs),
+		"strategy":          v(cfg.Strategy),
+		"bestOf":            v(len(cfg.Candidates) > 0),
+		"emptyBlocks":       v(cfg.ProduceEmptyBlocks),
+		"fillTarget":        v(cfg.FillTarget),
+		"buildTimeout":      v(cfg.BuildTimeout),
+		"forkChoice":        v(forkChoice),
+		"proposer":          cfg.Proposer,
+		"extraData":         string(cfg.ExtraData),
+		"blockCompression":  v(cfg.BlockCompression),
+		"keyFile":           cfg.KeyFile,
+		"limitsFile":        cfg.LimitsFile,
+		"replayLog":         cfg.ReplayLog,
+		"logLevel":          v(cfg.LogLevel),
+		"logFormat":         v(cfg.LogFormat),
+		"retainBlocks":      v(cfg.Retention.Blocks),
+		"retainAge":         v(cfg.Retention.Age),
+		"drainOnShutdown":   v(cfg.DrainOnShutdown),
+		"checkpointEvery":   v(cfg.CheckpointEvery),
+		"genesis.extraData": string(cfg.Genesis.ExtraData),
+		"genesis.balances":  v(cfg.Genesis.Balances),
+	}
+}
+
+// reloadConfigFile re-reads NodeConfig.ConfigFile and applies its
+// runtime-safe settings; see "Config reload semantics".
+func (n *Node) reloadConfigFile() error {
+	f, err := ReadConfigFile(n.cfg.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	// Compare against what runs now: the live limits, and the startup
+	// config for everything else.
+	current := n.cfg
+	limits, _ := n.limits.get()
+	current.GasLimit, current.MaxTxPerBlock, current.MinFee = limits.GasLimit, limits.MaxTxPerBlock, limits.MinFee
+	current.BlockInterval = n.limits.blockInterval()
+	next := current
+	if err := f.Apply(&next); err != nil {
+		return err
+	}
+
+	before, after := configView(current), configView(next)
+	var changed, ignored []string
+	for _, key := range slices.Sorted(maps.Keys(after)) {
+		if before[key] == after[key] {
+			continue
+		}
+		if reloadableKeys[key] {
+			changed = append(changed, fmt.Sprintf("%s %s -> %s", key, before[key], after[key]))
+		} else {
+			ignored = append(ignored, key)
+		}
+	}
+	if len(ignored) > 0 {
+		n.log.node.Warn("config changes ignored until restart", "keys", strings.Join(ignored, ","))
+	}
+	if len(changed) == 0 {
+		n.log.node.Info("config reloaded", "changes", "none")
+		return nil
+	}
+
+	u := limitsUpdate{
+		GasLimit:      &next.GasLimit,
+		MaxTxPerBlock: &next.MaxTxPerBlock,
+		MinFee:        &next.MinFee,
+		Interval:      &next.BlockInterval,
+	}
+	if _, _, err := n.setLimits(u, "config"); err != nil {
+		return err
+	}
+	n.log.node.Info("config reloaded", "changes", strings.Join(changed, ", "))
+	return nil
+}
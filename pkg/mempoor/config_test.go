@@ -0,0 +1,83 @@
+package mempoor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigFileApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "node.yaml")
+	yaml := `
+blockInterval: 500ms
+gasLimit: 42
+minFee: 7
+strategy: fifo
+genesis:
+  balances: { alice: 10 }
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	f, err := ReadConfigFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	cfg := DefaultNodeConfig("127.0.0.1:8080", "")
+	if err := f.Apply(&cfg); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if cfg.BlockInterval != 500*time.Millisecond || cfg.GasLimit != 42 || cfg.MinFee != 7 ||
+		cfg.Strategy != StrategyFIFO || cfg.Genesis.Balances["alice"] != 10 {
+		t.Fatalf("expected the file's settings applied, got %+v", cfg)
+	}
+	if cfg.MaxTxPerBlock != 1000 || cfg.ListenAddr != "127.0.0.1:8080" {
+		t.Fatalf("expected absent keys to keep their defaults, got %+v", cfg)
+	}
+
+	if err := os.WriteFile(path, []byte("gasLimt: 1\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := ReadConfigFile(path); err == nil || !strings.Contains(err.Error(), "gasLimt") {
+		t.Fatalf("expected an unknown key rejected, got %v", err)
+	}
+}
+
+func TestReloadConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "node.yaml")
+	n := NewNode(NodeConfig{ConfigFile: path, BlockInterval: time.Second, MaxTxPerBlock: 10, MinFee: 1})
+	if _, err := n.mempool.Add(newTx("alice", 10, 10)); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("minFee: 5\nblockInterval: 250ms\nlisten: 0.0.0.0:9\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := n.reloadConfigFile(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	limits, version := n.limits.get()
+	if limits.MinFee != 5 || limits.MaxTxPerBlock != 10 || version != 2 {
+		t.Fatalf("expected minFee 5 applied as version 2, got %+v v%d", limits, version)
+	}
+	if got := n.limits.blockInterval(); got != 250*time.Millisecond {
+		t.Fatalf("expected a 250ms interval, got %s", got)
+	}
+	if n.mempool.Count() != 1 {
+		t.Fatalf("expected the mempool kept")
+	}
+
+	// Invalid limits change nothing.
+	if err := os.WriteFile(path, []byte("maxTxPerBlock: 0\nminFee: 9\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := n.reloadConfigFile(); err == nil {
+		t.Fatalf("expected invalid limits rejected")
+	}
+	if limits, _ := n.limits.get(); limits.MinFee != 5 {
+		t.Fatalf("expected a rejected reload to change nothing, got %+v", limits)
+	}
+}
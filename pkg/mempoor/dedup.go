@@ -0,0 +1,94 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DedupMode controls how Add treats a tx whose (Sender, Recipient,
+// Payload) matches a pending tx, regardless of TxID.
+type DedupMode int
+
+const (
+	DedupOff    DedupMode = iota // no content dedup (default)
+	DedupReject                  // reject the duplicate
+	DedupMerge                   // fold the duplicate into the pending tx
+)
+
+// ErrTxDuplicate matches any *DuplicateError via errors.Is.
+var ErrTxDuplicate = errors.New("mempool: duplicate tx content")
+
+// DuplicateError reports which pending tx a duplicate collided with.
+// With DedupMerge, Merged is true and the pending tx now carries the
+// higher of the two fees; the caller should use Existing as the tx ID.
+type DuplicateError struct {
+	Existing TxID
+	Merged   bool
+}
+
+func (e *DuplicateError) Error() string {
+	if e.Merged {
+		return fmt.Sprintf("%v: merged into %s", ErrTxDuplicate, e.Existing)
+	}
+	return fmt.Sprintf("%v: already pending as %s", ErrTxDuplicate, e.Existing)
+}
+
+func (e *DuplicateError) Is(target error) bool { return target == ErrTxDuplicate }
+
+// contentKey identifies a tx's content for dedup. Nonce, fee, and gas
+// are deliberately excluded.
+func contentKey(tx *Tx) string {
+	return tx.Sender + "\x00" + tx.Recipient + "\x00" + tx.Payload
+}
+
+// checkDuplicate applies the dedup policy to an incoming tx. A pending
+// tx counts as a duplicate only if tx was created within DedupWindow of
+// it (0 = any pending tx). Caller must hold the write lock.
+func (m *mempool) checkDuplicate(tx *Tx) error {
+	rec, ok := m.contents[contentKey(tx)]
+	if !ok {
+		return nil
+	}
+
+	existing := rec.tx
+	if w := m.cfg.DedupWindow; w > 0 && tx.CreatedAt.Sub(existing.CreatedAt) > w {
+		return nil
+	}
+
+	if m.cfg.Dedup == DedupReject {
+		return &DuplicateError{Existing: existing.ID}
+	}
+
+	// Merge: keep the pending tx (and its place in line) but take the
+	// higher fee, with its MaxFee and Tip.
+	if tx.Fee > existing.Fee {
+		merged := *existing
+		merged.Fee, merged.MaxFee, merged.Tip = tx.Fee, tx.MaxFee, tx.Tip
+		m.recordVersion(rec)
+		rec.tx = &merged
+		m.fixRecord(rec)
+		m.events.publish(TxUpdated, rec.tx)
+	}
+	return &DuplicateError{Existing: existing.ID, Merged: true}
+}
+
+// indexContent records rec as the newest pending tx for its content.
+// Caller must hold the write lock.
+func (m *mempool) indexContent(rec *txRecord) {
+	if m.cfg.Dedup == DedupOff {
+		return
+	}
+	m.contents[contentKey(rec.tx)] = rec
+}
+
+// unindexContent forgets rec, unless a newer tx has since taken its key.
+// Caller must hold the write lock.
+func (m *mempool) unindexContent(rec *txRecord) {
+	if m.cfg.Dedup == DedupOff {
+		return
+	}
+	key := contentKey(rec.tx)
+	if m.contents[key] == rec {
+		delete(m.contents, key)
+	}
+}
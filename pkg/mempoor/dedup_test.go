@@ -0,0 +1,79 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDedupRejectsSameContent(t *testing.T) {
+	mp := NewMempool(MempoolConfig{Dedup: DedupReject})
+
+	first := NewUnsignedTx("alice", "bob", "hello", 0, 10, 10)
+	second := NewUnsignedTx("alice", "bob", "hello", 0, 20, 10)
+
+	_, _ = mp.Add(first)
+	_, err := mp.Add(second)
+
+	var dup *DuplicateError
+	if !errors.As(err, &dup) || dup.Existing != first.ID || dup.Merged {
+		t.Fatalf("expected rejected duplicate of %s, got %v", first.ID, err)
+	}
+	if !errors.Is(err, ErrTxDuplicate) {
+		t.Fatalf("expected errors.Is(err, ErrTxDuplicate)")
+	}
+	if len(mp.List()) != 1 {
+		t.Fatalf("expected only the first tx pending")
+	}
+}
+
+func TestDedupMergeKeepsHigherFee(t *testing.T) {
+	mp := NewMempool(MempoolConfig{Dedup: DedupMerge})
+
+	first := NewUnsignedTx("alice", "bob", "hello", 0, 10, 10)
+	second := NewUnsignedTx("alice", "bob", "hello", 0, 50, 10)
+
+	_, _ = mp.Add(first)
+	_, err := mp.Add(second)
+
+	var dup *DuplicateError
+	if !errors.As(err, &dup) || !dup.Merged || dup.Existing != first.ID {
+		t.Fatalf("expected merge into %s, got %v", first.ID, err)
+	}
+
+	got, err := mp.Get(first.ID)
+	if err != nil || got.Fee != 50 {
+		t.Fatalf("expected merged tx with fee=50, got %+v err=%v", got, err)
+	}
+	if len(mp.List()) != 1 {
+		t.Fatalf("expected a single pending tx after merge")
+	}
+}
+
+func TestDedupWindowAndDistinctContent(t *testing.T) {
+	mp := NewMempool(MempoolConfig{Dedup: DedupReject, DedupWindow: time.Minute})
+
+	first := NewUnsignedTx("alice", "bob", "hello", 0, 10, 10)
+	first.CreatedAt = time.Now().UTC().Add(-time.Hour)
+	_, _ = mp.Add(first)
+
+	// Same content, but outside the window.
+	if _, err := mp.Add(NewUnsignedTx("alice", "bob", "hello", 0, 10, 10)); err != nil {
+		t.Fatalf("expected tx outside dedup window accepted, got %v", err)
+	}
+	// Different payload is never a duplicate.
+	if _, err := mp.Add(NewUnsignedTx("alice", "bob", "other", 0, 10, 10)); err != nil {
+		t.Fatalf("expected distinct content accepted, got %v", err)
+	}
+}
+
+func TestDedupForgetsSelectedTxs(t *testing.T) {
+	mp := NewMempool(MempoolConfig{Dedup: DedupReject})
+
+	_, _ = mp.Add(NewUnsignedTx("alice", "bob", "hello", 0, 10, 10))
+	_ = mp.SelectTransactions(BlockConstraints{MaxTx: 10})
+
+	if _, err := mp.Add(NewUnsignedTx("alice", "bob", "hello", 0, 10, 10)); err != nil {
+		t.Fatalf("expected content reusable once no longer pending, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package mempoor
+
+import "errors"
+
+// ErrInvalidDependency is returned by Add for a tx that lists itself in
+// DependsOn.
+var ErrInvalidDependency = errors.New("mempool: tx cannot depend on itself")
+
+// Dependency semantics (Tx.DependsOn):
+//   - A tx waits until every dependency has been committed to a block;
+//     until then selection skips it, like a tx over the gas limit, and it
+//     keeps its place in its lane heap. Dependencies in the same block do
+//     not count: a dependent is offered in a later block at the earliest.
+//   - A dependency that is not pending, orphaned, or scheduled when the
+//     tx is admitted is assumed to be included already.
+//   - If a dependency leaves the pool without being committed (removed,
+//     purged, evicted, or an expired orphan), every tx waiting on it is
+//     removed too, transitively, and published as TxRemoved.
+//   - A waiting tx still counts against MaxTxs and may itself be evicted.
+//
+// NOTE: The pool does not remember committed txs, so a dependency that
+// is reserved (see Reserve) when its dependent arrives counts as
+// included.
+
+// checkDependencies validates tx.DependsOn on its own.
+func checkDependencies(tx *Tx) error {
+	for _, dep := range tx.DependsOn {
+		if dep == tx.ID {
+			return ErrInvalidDependency
+		}
+	}
+	return nil
+}
+
+// indexDeps registers rec as waiting on each of its unmet dependencies.
+func (m *mempool) indexDeps(rec *txRecord) {
+	rec.waiting = 0
+	for _, dep := range rec.tx.DependsOn {
+		_, pending := m.table[dep]
+		_, orphaned := m.orphans[dep]
+		_, scheduled := m.scheduled[dep]
+		if !pending && !orphaned && !scheduled {
+			continue
+		}
+		rec.waiting++
+		m.dependents[dep] = append(m.dependents[dep], rec)
+	}
+}
+
+// unindexDeps removes rec from the waiting lists of its dependencies.
+func (m *mempool) unindexDeps(rec *txRecord) {
+	for _, dep := range rec.tx.DependsOn {
+		recs := m.dependents[dep]
+		kept := recs[:0]
+		for _, r := range recs {
+			if r != rec {
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) == 0 {
+			delete(m.dependents, dep)
+		} else {
+			m.dependents[dep] = kept
+		}
+	}
+	rec.waiting = 0
+}
+
+// satisfyDependents records that id was committed.
+// Caller must hold the write lock.
+func (m *mempool) satisfyDependents(id TxID) {
+	for _, rec := range m.dependents[id] {
+		rec.waiting--
+	}
+	delete(m.dependents, id)
+}
+
+// dropped publishes typ for tx, which left the pool without being
+// committed, and removes the rest of its bundle and every tx waiting
+// on it.
+// Caller must hold the write lock.
+func (m *mempool) dropped(typ MempoolEventType, tx *Tx) {
+	delete(m.pinned, tx.ID)
+	delete(m.required, tx.ID)
+	m.events.publish(typ, tx)
+
+	if tx.BundleID != "" {
+		m.dropBundle(tx.BundleID)
+	}
+
+	recs := m.dependents[tx.ID]
+	delete(m.dependents, tx.ID)
+	for _, rec := range recs {
+		if m.table[rec.tx.ID] != rec {
+			continue // already dropped through another dependency
+		}
+		m.removeRecord(rec)
+		m.dropped(TxRemoved, rec.tx)
+	}
+}
@@ -0,0 +1,127 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDependentWaitsForCommit(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	dep := newTx("alice", 1, 10)
+	child := newTx("carol", 100, 10)
+	child.DependsOn = []TxID{dep.ID}
+	_, _ = mp.Add(dep)
+	if _, err := mp.Add(child); err != nil {
+		t.Fatalf("add child: %v", err)
+	}
+
+	// The high-fee child is skipped; its dependency goes first.
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 10})
+	if len(res.Transactions) != 1 || res.Transactions[0].ID != dep.ID {
+		t.Fatalf("expected only the dependency selected, got %v", res.Transactions)
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("invariants: %v", err)
+	}
+
+	res = mp.SelectTransactions(BlockConstraints{MaxTx: 10})
+	if len(res.Transactions) != 1 || res.Transactions[0].ID != child.ID {
+		t.Fatalf("expected the child in the next block, got %v", res.Transactions)
+	}
+}
+
+func TestDependentWaitsForReservationCommit(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	dep := newTx("alice", 1, 10)
+	child := newTx("carol", 100, 10)
+	child.DependsOn = []TxID{dep.ID}
+	_, _ = mp.Add(dep)
+	_, _ = mp.Add(child)
+
+	r := mp.Reserve(BlockConstraints{MaxTx: 10})
+	if peek := mp.PeekTransactions(BlockConstraints{MaxTx: 10}); len(peek.Transactions) != 0 {
+		t.Fatalf("child must wait while its dependency is only reserved")
+	}
+	_ = r.Rollback()
+	if peek := mp.PeekTransactions(BlockConstraints{MaxTx: 10}); len(peek.Transactions) != 1 {
+		t.Fatalf("expected only the dependency offered after rollback, got %v", peek.Transactions)
+	}
+}
+
+func TestDroppedDependencyCascades(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	events, cancel := mp.Subscribe()
+	defer cancel()
+
+	root := newTx("alice", 10, 10)
+	mid := newTx("carol", 10, 10)
+	mid.DependsOn = []TxID{root.ID}
+	leaf := newTx("dan", 10, 10)
+	leaf.DependsOn = []TxID{mid.ID, root.ID}
+	other := newTx("erin", 10, 10)
+	for _, tx := range []*Tx{root, mid, leaf, other} {
+		_, _ = mp.Add(tx)
+	}
+
+	if err := mp.Remove(root.ID); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got := mp.List(); len(got) != 1 || got[0].ID != other.ID {
+		t.Fatalf("expected only the unrelated tx left, got %v", got)
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("invariants: %v", err)
+	}
+
+	removed := 0
+	for len(events) > 0 {
+		if ev := <-events; ev.Type == TxRemoved {
+			removed++
+		}
+	}
+	if removed != 3 {
+		t.Fatalf("expected 3 TxRemoved events, got %d", removed)
+	}
+}
+
+func TestPurgedDependencyCascades(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	cheap := newTx("alice", 1, 10)
+	child := newTx("carol", 100, 10)
+	child.DependsOn = []TxID{cheap.ID}
+	_, _ = mp.Add(cheap)
+	_, _ = mp.Add(child)
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 10, MinFee: 5})
+	if len(res.Transactions) != 0 || len(res.Purged) != 1 {
+		t.Fatalf("expected one purge and nothing selected, got %+v", res)
+	}
+	if len(mp.List()) != 0 {
+		t.Fatalf("expected the dependent dropped with its purged dependency")
+	}
+}
+
+func TestUnknownDependencyIsAssumedIncluded(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	tx := newTx("alice", 1, 10)
+	tx.DependsOn = []TxID{"mined-long-ago"}
+	_, _ = mp.Add(tx)
+
+	if res := mp.SelectTransactions(BlockConstraints{MaxTx: 10}); len(res.Transactions) != 1 {
+		t.Fatalf("expected tx with an unknown dependency to be selectable")
+	}
+}
+
+func TestSelfDependencyRejected(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	tx := newTx("alice", 1, 10)
+	tx.DependsOn = []TxID{tx.ID}
+	if _, err := mp.Add(tx); !errors.Is(err, ErrInvalidDependency) {
+		t.Fatalf("expected ErrInvalidDependency, got %v", err)
+	}
+}
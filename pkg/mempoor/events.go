@@ -0,0 +1,107 @@
+package mempoor
+
+import "sync"
+
+// MempoolEventType identifies what happened to a transaction.
+type MempoolEventType int
+
+const (
+	TxAdded     MempoolEventType = iota // accepted by Add
+	TxUpdated                           // replaced by Update (fee bump)
+	TxRemoved                           // deleted by Remove
+	TxSelected                          // taken by SelectTransactions for a block
+	TxPurged                            // dropped by SelectTransactions for Fee < MinFee
+	TxEvicted                           // dropped to make room at capacity, or orphan expired
+	TxOrphaned                          // parked by Add until its dependency arrives
+	TxScheduled                         // held by Add until its NotBefore time
+)
+
+func (t MempoolEventType) String() string {
+	switch t {
+	case TxAdded:
+		return "added"
+	case TxUpdated:
+		return "updated"
+	case TxRemoved:
+		return "removed"
+	case TxSelected:
+		return "selected"
+	case TxPurged:
+		return "purged"
+	case TxEvicted:
+		return "evicted"
+	case TxOrphaned:
+		return "orphaned"
+	case TxScheduled:
+		return "scheduled"
+	default:
+		return "unknown"
+	}
+}
+
+// MempoolEvent is a single pool change delivered to subscribers.
+type MempoolEvent struct {
+	Type MempoolEventType
+	Tx   *Tx
+}
+
+// subscriberBuffer is the per-subscriber channel capacity. Events are
+// delivered without blocking the mempool: a subscriber that falls more
+// than this far behind misses events rather than stalling Add/Select.
+const subscriberBuffer = 256
+
+// subscribers fans mempool events out to any number of listeners, and
+// to the configured MempoolObserver, if any.
+type subscribers struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]chan MempoolEvent
+
+	observer MempoolObserver // set once at construction; may be nil
+}
+
+// subscribe registers a new listener. The returned cancel func
+// unregisters it and closes the channel; it is safe to call twice.
+func (s *subscribers) subscribe() (<-chan MempoolEvent, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subs == nil {
+		s.subs = make(map[int]chan MempoolEvent)
+	}
+
+	id := s.next
+	s.next++
+	ch := make(chan MempoolEvent, subscriberBuffer)
+	s.subs[id] = ch
+
+	cancel := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if ch, ok := s.subs[id]; ok {
+			delete(s.subs, id)
+			close(ch)
+		}
+	}
+	return ch, cancel
+}
+
+// publish calls the observer inline, then delivers ev to every listener
+// without blocking.
+func (s *subscribers) publish(typ MempoolEventType, tx *Tx) {
+	if s.observer != nil {
+		observe(s.observer, typ, tx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ev := MempoolEvent{Type: typ, Tx: tx}
+	for _, ch := range s.subs {
+		select {
+		case ch <- ev:
+		default: // slow subscriber; drop
+		}
+	}
+}
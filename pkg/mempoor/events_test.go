@@ -0,0 +1,81 @@
+package mempoor
+
+import (
+	"testing"
+)
+
+func TestSubscribeReceivesLifecycleEvents(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	events, cancel := mp.Subscribe()
+	defer cancel()
+
+	removed := newTx("alice", 10, 10)
+	purged := newTx("bob", 1, 10)
+	selected := newTx("carol", 100, 10)
+
+	_, _ = mp.Add(removed)
+	_, _ = mp.Add(purged)
+	_, _ = mp.Add(selected)
+
+	bumped := NewTxUpdate(removed.ID, removed.Sender, removed.Recipient, removed.Payload,
+		removed.Nonce, 20, removed.Gas, removed.CreatedAt)
+	_ = mp.Update(bumped)
+	_ = mp.Remove(removed.ID)
+	_ = mp.SelectTransactions(BlockConstraints{MaxTx: 10, MinFee: 5})
+
+	want := []struct {
+		typ MempoolEventType
+		id  TxID
+	}{
+		{TxAdded, removed.ID},
+		{TxAdded, purged.ID},
+		{TxAdded, selected.ID},
+		{TxUpdated, removed.ID},
+		{TxRemoved, removed.ID},
+		{TxPurged, purged.ID},     // purges are final at selection time
+		{TxSelected, selected.ID}, // selections are final at commit
+
+	}
+
+	for i, w := range want {
+		ev := <-events
+		if ev.Type != w.typ || ev.Tx.ID != w.id {
+			t.Fatalf("event %d: expected %s %s, got %s %s", i, w.typ, w.id, ev.Type, ev.Tx.ID)
+		}
+	}
+}
+
+func TestSubscribeEvictionEvent(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 1})
+
+	low := newTx("alice", 1, 10)
+	_, _ = mp.Add(low)
+
+	events, cancel := mp.Subscribe()
+	defer cancel()
+
+	_, _ = mp.Add(newTx("bob", 10, 10))
+
+	if ev := <-events; ev.Type != TxEvicted || ev.Tx.ID != low.ID {
+		t.Fatalf("expected eviction of low tx first, got %s %s", ev.Type, ev.Tx.ID)
+	}
+	if ev := <-events; ev.Type != TxAdded {
+		t.Fatalf("expected TxAdded after eviction, got %s", ev.Type)
+	}
+}
+
+func TestSubscribeCancelClosesChannel(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	events, cancel := mp.Subscribe()
+	cancel()
+	cancel() // idempotent
+
+	if _, ok := <-events; ok {
+		t.Fatalf("expected channel closed after cancel")
+	}
+
+	// Publishing after cancel must not panic.
+	_, _ = mp.Add(newTx("alice", 1, 10))
+}
@@ -0,0 +1,180 @@
+package mempoor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// EvictionPolicy chooses which pending tx a full pool drops to make room
+// for a new one. It is separate from PriorityPolicy so operators can, for
+// example, schedule by fee but shed the oldest txs under pressure.
+//
+// Eviction semantics:
+//   - Lanes still come first: the victim is taken from the lane drained
+//     last, and a tx in an even later lane is rejected outright.
+//   - Within that lane the victim is the tx that EvictBefore ranks
+//     first. If the incoming tx would rank before it, the incoming tx is
+//     rejected with ErrTxUnderpriced and nothing is evicted.
+//   - A nil EvictionPolicy evicts the lowest-priority tx under the
+//     PriorityPolicy, which is also the cheapest to find.
+//
+// Implementations must be a strict weak ordering and deterministic; end
+// with a TxID comparison to break ties.
+//
+// PERF: A custom policy scans every tx in the victim's lane, O(n), per
+// eviction; the nil default scans only the heap leaves, O(n/2), unless
+// txs are pinned.
+type EvictionPolicy interface {
+	// EvictBefore reports whether a should be evicted before b.
+	EvictBefore(a, b *Tx) bool
+}
+
+// EvictionFunc adapts an ordinary function to an EvictionPolicy.
+type EvictionFunc func(a, b *Tx) bool
+
+// EvictBefore calls f(a, b).
+func (f EvictionFunc) EvictBefore(a, b *Tx) bool { return f(a, b) }
+
+// Built-in eviction policies.
+var (
+	// EvictLowestFee drops the lowest Fee first, newest Timestamp first
+	// among equal fees.
+	EvictLowestFee EvictionPolicy = EvictionFunc(evictLowestFee)
+
+	// EvictOldest drops the oldest CreatedAt first, regardless of fee.
+	EvictOldest EvictionPolicy = EvictionFunc(evictOldest)
+
+	// EvictLargestGas drops the highest Gas first, lowest Fee first among
+	// equal gas.
+	EvictLargestGas EvictionPolicy = EvictionFunc(evictLargestGas)
+
+	// EvictRandom drops a pseudo-random tx, seeded once per process.
+	EvictRandom EvictionPolicy = RandomEviction(rand.Uint64())
+)
+
+// RandomEviction returns a policy that ranks txs by a hash of seed and
+// TxID, so victims are spread uniformly but reproducible for a seed.
+func RandomEviction(seed uint64) EvictionPolicy {
+	return EvictionFunc(func(a, b *Tx) bool {
+		if ha, hb := seededHash(seed, a.ID), seededHash(seed, b.ID); ha != hb {
+			return ha < hb
+		}
+		return a.ID < b.ID
+	})
+}
+
+// ParseEvictionPolicy maps "priority", "fee", "oldest", "gas", or
+// "random" to an EvictionPolicy. An empty name or "priority" yields nil,
+// the PriorityPolicy-driven default.
+func ParseEvictionPolicy(name string) (EvictionPolicy, error) {
+	switch name {
+	case "", "priority":
+		return nil, nil
+	case "fee":
+		return EvictLowestFee, nil
+	case "oldest":
+		return EvictOldest, nil
+	case "gas":
+		return EvictLargestGas, nil
+	case "random":
+		return EvictRandom, nil
+	default:
+		return nil, fmt.Errorf("mempool: unknown eviction policy %q", name)
+	}
+}
+
+func evictLowestFee(ti, tj *Tx) bool {
+	if ti.Fee != tj.Fee {
+		return ti.Fee < tj.Fee
+	}
+	if !ti.Timestamp.Equal(tj.Timestamp) {
+		return ti.Timestamp.After(tj.Timestamp)
+	}
+	return ti.ID < tj.ID
+}
+
+func evictOldest(ti, tj *Tx) bool {
+	if !ti.CreatedAt.Equal(tj.CreatedAt) {
+		return ti.CreatedAt.Before(tj.CreatedAt)
+	}
+	return ti.ID < tj.ID
+}
+
+func evictLargestGas(ti, tj *Tx) bool {
+	if ti.Gas != tj.Gas {
+		return ti.Gas > tj.Gas
+	}
+	if ti.Fee != tj.Fee {
+		return ti.Fee < tj.Fee
+	}
+	return ti.ID < tj.ID
+}
+
+func seededHash(seed uint64, id TxID) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], seed)
+	h.Write(buf[:])
+	h.Write([]byte(id))
+	return h.Sum64()
+}
+
+// evictionVictim returns the heap and index of the tx to evict for
+// incoming, or ok=false if incoming should be rejected instead. Lanes are
+// tried from the last drained; pinned and required txs are never chosen.
+// h is nil if every pending tx is. Caller must hold the write lock.
+func (m *mempool) evictionVictim(incoming *Tx) (h *txHeap, i int, ok bool) {
+	for l := numLanes - 1; l >= 0; l-- {
+		h = m.heapOf(laneOrder[l])
+		if i = m.victimIn(h); i < 0 {
+			continue
+		}
+
+		victim := h.recs[i].tx
+		if ri, rv := incoming.Lane.rank(), victim.Lane.rank(); ri != rv {
+			return h, i, ri < rv
+		}
+		if m.cfg.Eviction == nil {
+			return h, i, m.cfg.Priority.Less(incoming, victim)
+		}
+		return h, i, m.cfg.Eviction.EvictBefore(victim, incoming)
+	}
+	return nil, -1, false
+}
+
+// victimIn returns the index of the unprotected tx in h to evict first, or
+// -1 if there is none.
+//
+// PERF: Without pinned or required txs and with the default policy, the victim is the
+// lowest-priority tx, which in a max-heap is always a leaf, so only the
+// second half of the slice is scanned — O(n/2). A paired min-heap would
+// make this O(log n) at the cost of double bookkeeping on every mutation.
+// Otherwise every tx in h is scanned.
+func (m *mempool) victimIn(h *txHeap) int {
+	n := h.Len()
+	if n == 0 {
+		return -1
+	}
+
+	evictBefore := func(a, b *Tx) bool { return h.policy.Less(b, a) }
+	from := 0
+	if m.cfg.Eviction != nil {
+		evictBefore = m.cfg.Eviction.EvictBefore
+	} else if len(m.pinned) == 0 && len(m.required) == 0 {
+		from = n / 2
+	}
+
+	victim := -1
+	for j := from; j < n; j++ {
+		tx := h.recs[j].tx
+		if m.protected(tx.ID) {
+			continue
+		}
+		if victim < 0 || evictBefore(tx, h.recs[victim].tx) {
+			victim = j
+		}
+	}
+	return victim
+}
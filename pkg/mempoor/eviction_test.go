@@ -0,0 +1,109 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEvictOldestIgnoresFee(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	mp := NewMempool(MempoolConfig{MaxTxs: 2, Eviction: EvictOldest})
+
+	oldRich := NewUnsignedTxWithClock(clock, "alice", "bob", "a", 0, 100, 1)
+	clock.Advance(time.Second)
+	newPoor := NewUnsignedTxWithClock(clock, "carol", "bob", "c", 0, 1, 1)
+	clock.Advance(time.Second)
+	newest := NewUnsignedTxWithClock(clock, "dan", "bob", "d", 0, 1, 1)
+
+	_, _ = mp.Add(oldRich)
+	_, _ = mp.Add(newPoor)
+	evicted, err := mp.Add(newest)
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if len(evicted) != 1 || evicted[0] != oldRich.ID {
+		t.Fatalf("expected the oldest tx evicted, got %v", evicted)
+	}
+}
+
+func TestEvictLargestGas(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 2, Eviction: EvictLargestGas})
+
+	hog := newTx("alice", 100, 9_000)
+	small := newTx("carol", 1, 10)
+	_, _ = mp.Add(hog)
+	_, _ = mp.Add(small)
+
+	evicted, err := mp.Add(newTx("dan", 1, 20))
+	if err != nil || len(evicted) != 1 || evicted[0] != hog.ID {
+		t.Fatalf("expected the gas hog evicted, got %v, %v", evicted, err)
+	}
+
+	// A tx larger than everything pending would be its own victim.
+	if _, err := mp.Add(newTx("erin", 100, 50_000)); !errors.Is(err, ErrTxUnderpriced) {
+		t.Fatalf("expected ErrTxUnderpriced, got %v", err)
+	}
+}
+
+func TestEvictionRespectsLanes(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 2, Eviction: EvictLowestFee})
+
+	urgent := newTx("alice", 1, 1)
+	urgent.Lane = LaneUrgent
+	normal := newTx("carol", 100, 1)
+	_, _ = mp.Add(urgent)
+	_, _ = mp.Add(normal)
+
+	// The cheap urgent tx is protected by its lane.
+	evicted, err := mp.Add(newTx("dan", 200, 1))
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if len(evicted) != 1 || evicted[0] != normal.ID {
+		t.Fatalf("expected the normal-lane tx evicted, got %v", evicted)
+	}
+
+	low := newTx("erin", 1_000, 1)
+	low.Lane = LaneLow
+	if _, err := mp.Add(low); !errors.Is(err, ErrTxUnderpriced) {
+		t.Fatalf("expected a low-lane tx to be rejected, got %v", err)
+	}
+}
+
+func TestRandomEvictionIsSeeded(t *testing.T) {
+	pick := func(seed uint64) TxID {
+		// A fixed clock keeps TxIDs identical across runs.
+		clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+		mp := NewMempool(MempoolConfig{MaxTxs: 8, Eviction: RandomEviction(seed)})
+		for i := 0; i < 8; i++ {
+			_, _ = mp.Add(NewUnsignedTxWithClock(clock, "s", "bob", string(rune('a'+i)), uint64(i), 10, 1))
+		}
+		evicted, err := mp.Add(NewUnsignedTxWithClock(clock, "late", "bob", "z", 0, 10, 1))
+		if err != nil && !errors.Is(err, ErrTxUnderpriced) {
+			t.Fatalf("add: %v", err)
+		}
+		if len(evicted) == 0 {
+			return ""
+		}
+		return evicted[0]
+	}
+
+	if pick(42) != pick(42) {
+		t.Fatalf("same seed should pick the same victim")
+	}
+}
+
+func TestParseEvictionPolicy(t *testing.T) {
+	for _, name := range []string{"", "priority", "fee", "oldest", "gas", "random"} {
+		if _, err := ParseEvictionPolicy(name); err != nil {
+			t.Fatalf("%q: %v", name, err)
+		}
+	}
+	if p, _ := ParseEvictionPolicy("priority"); p != nil {
+		t.Fatalf("expected nil for the default policy")
+	}
+	if _, err := ParseEvictionPolicy("lifo"); err == nil {
+		t.Fatalf("expected error for unknown policy")
+	}
+}
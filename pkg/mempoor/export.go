@@ -0,0 +1,44 @@
+package mempoor
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// Chain export semantics (ExportChain, block.export):
+//   - The export is JSONL: one block per line, genesis first, in the
+//     same JSON form block.get serves, so every line carries its hash,
+//     signature, and payloads and stands on its own.
+//   - Payloads are written as stored: compressed blocks keep their
+//     codec, named in "compression", with base64 payloads.
+//   - The chain is copied under the block lock and written after it is
+//     released, so the export is a consistent snapshot of the chain and
+//     a slow reader never holds up block production or a reorg.
+
+// ExportChain writes every block of the node's chain to w; see "Chain
+// export semantics".
+func (n *Node) ExportChain(w io.Writer) error {
+	n.blocksMu.RLock()
+	chain := slices.Clone(n.blocks)
+	n.blocksMu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, b := range chain {
+		if err := enc.Encode(makeBlockDTO(encodePayloads(b))); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// rpcBlockExport streams the chain as the response body instead of a
+// JSON result; an error after the first block can only cut it short.
+func (n *Node) rpcBlockExport(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	_ = n.ExportChain(w)
+}
@@ -0,0 +1,102 @@
+package mempoor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportChain(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10, AdminToken: "secret"})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	if _, err := n.mempool.Add(newTx("alice", 10, 10)); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	prevHash, height := n.chainTip()
+	if _, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second)); err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+
+	var out strings.Builder
+	if err := n.ExportChain(&out); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	var prev *Block
+	lines := 0
+	sc := bufio.NewScanner(strings.NewReader(out.String()))
+	for sc.Scan() {
+		var d blockDTO
+		if err := json.Unmarshal(sc.Bytes(), &d); err != nil {
+			t.Fatalf("line %d: %v", lines, err)
+		}
+		b, err := parseBlockDTO(d)
+		if err != nil {
+			t.Fatalf("line %d: %v", lines, err)
+		}
+		if err := Validate(b, prev, 0); err != nil {
+			t.Fatalf("exported block %d does not validate: %v", lines, err)
+		}
+		prev = b
+		lines++
+	}
+	if lines != 2 {
+		t.Fatalf("expected 2 exported blocks, got %d", lines)
+	}
+
+	if rec := callRPC(n, `{"method":"block.export"}`); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected block.export to require the admin token, got %d", rec.Code)
+	}
+}
+
+// stallingWriter blocks its first write until release is closed.
+type stallingWriter struct {
+	started, release chan struct{}
+}
+
+func (w stallingWriter) Write(p []byte) (int, error) {
+	close(w.started)
+	<-w.release
+	return len(p), nil
+}
+
+func TestExportChainDoesNotHoldTheChain(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+
+	w := stallingWriter{started: make(chan struct{}), release: make(chan struct{})}
+	exported := make(chan error, 1)
+	go func() { exported <- n.ExportChain(w) }()
+	<-w.started
+
+	// The reader is stalled mid-export; blocks are still produced.
+	if _, err := n.mempool.Add(newTx("alice", 10, 10)); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	produced := make(chan error, 1)
+	go func() {
+		prevHash, height := n.chainTip()
+		_, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second))
+		produced <- err
+	}()
+	select {
+	case err := <-produced:
+		if err != nil {
+			t.Fatalf("produce: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("block production waited on a stalled export")
+	}
+
+	close(w.release)
+	if err := <-exported; err != nil {
+		t.Fatalf("export: %v", err)
+	}
+}
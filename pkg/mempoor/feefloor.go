@@ -0,0 +1,79 @@
+package mempoor
+
+import "errors"
+
+// DefaultFeeFloorStepPercent is used when FeeFloorConfig.StepPercent is
+// zero: the floor moves by 1/8 per block, like EIP-1559's base fee.
+const DefaultFeeFloorStepPercent = 12
+
+// ErrFeeBelowFloor is returned by Add while the dynamic fee floor is
+// above the tx's fee.
+var ErrFeeBelowFloor = errors.New("mempool: fee below dynamic fee floor")
+
+// FeeFloorConfig enables a minimum admission fee that follows pool
+// congestion instead of being tuned by hand.
+//
+// Floor semantics:
+//   - After every selection the pool's fullness is compared to
+//     TargetPercent of MaxTxs. Above target the floor rises by
+//     StepPercent (at least 1); below target it decays by the same
+//     step, down to 0. At exactly the target it holds.
+//   - Add rejects txs with Fee < floor with ErrFeeBelowFloor. Updates,
+//     rollbacks, and Reinsert are not subject to the floor.
+//   - The floor starts at 0 and is not persisted.
+type FeeFloorConfig struct {
+	// TargetPercent is the fullness, in percent of MaxTxs, the floor
+	// steers toward. 0 disables the floor. Ignored when MaxTxs == 0.
+	TargetPercent int
+
+	// StepPercent is the relative change per block.
+	// 0 = DefaultFeeFloorStepPercent.
+	StepPercent uint64
+
+	// Max caps the floor. 0 = no cap.
+	Max uint64
+}
+
+// enabled reports whether the floor is active for a pool of maxTxs.
+func (c FeeFloorConfig) enabled(maxTxs int) bool {
+	return c.TargetPercent > 0 && maxTxs > 0
+}
+
+// adjustFeeFloor moves the floor one step toward the target fullness.
+// Caller must hold the write lock.
+func (m *mempool) adjustFeeFloor() {
+	cfg := m.cfg.FeeFloor
+	if !cfg.enabled(m.cfg.MaxTxs) {
+		return
+	}
+
+	step := cfg.StepPercent
+	if step == 0 {
+		step = DefaultFeeFloorStepPercent
+	}
+	delta := m.feeFloor * step / 100
+	if delta == 0 {
+		delta = 1
+	}
+
+	fill := len(m.table) * 100
+	target := cfg.TargetPercent * m.cfg.MaxTxs
+	switch {
+	case fill > target:
+		m.feeFloor += delta
+		if cfg.Max > 0 && m.feeFloor > cfg.Max {
+			m.feeFloor = cfg.Max
+		}
+	case fill < target:
+		m.feeFloor -= min(delta, m.feeFloor)
+	}
+}
+
+// FeeFloor returns the current dynamic admission floor (0 when the
+// floor is disabled or the pool is uncongested).
+func (m *mempool) FeeFloor() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.feeFloor
+}
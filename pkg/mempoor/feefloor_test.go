@@ -0,0 +1,69 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFeeFloorRisesWhenCongestedAndDecays(t *testing.T) {
+	mp := NewMempool(MempoolConfig{
+		MaxTxs:   10,
+		FeeFloor: FeeFloorConfig{TargetPercent: 50, StepPercent: 50},
+	})
+
+	for i := 0; i < 8; i++ {
+		if _, err := mp.Add(newTx(fmt.Sprintf("s%d", i), 100, 10)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	// Each block takes one tx; the pool stays above 50% for a while.
+	c := BlockConstraints{MaxTx: 1}
+	mp.SelectTransactions(c) // 7 left: 0 -> 1
+	mp.SelectTransactions(c) // 6 left: 1 -> 2
+	mp.SelectTransactions(c) // 5 left: at target, holds
+	if got := mp.FeeFloor(); got != 2 {
+		t.Fatalf("expected floor 2, got %d", got)
+	}
+
+	if _, err := mp.Add(newTx("cheap", 1, 10)); !errors.Is(err, ErrFeeBelowFloor) {
+		t.Fatalf("expected ErrFeeBelowFloor, got %v", err)
+	}
+
+	mp.SelectTransactions(c) // 4 left: 2 -> 1
+	mp.SelectTransactions(c) // 3 left: 1 -> 0
+	mp.SelectTransactions(c) // stays at 0
+	if got := mp.FeeFloor(); got != 0 {
+		t.Fatalf("expected floor to decay to 0, got %d", got)
+	}
+	if _, err := mp.Add(newTx("cheap", 1, 10)); err != nil {
+		t.Fatalf("expected cheap tx admitted after decay, got %v", err)
+	}
+}
+
+func TestFeeFloorRespectsMax(t *testing.T) {
+	mp := NewMempool(MempoolConfig{
+		MaxTxs:   2,
+		FeeFloor: FeeFloorConfig{TargetPercent: 10, Max: 3},
+	})
+	_, _ = mp.Add(newTx("alice", 100, 10))
+	_, _ = mp.Add(newTx("bob", 100, 10))
+
+	for i := 0; i < 10; i++ {
+		mp.SelectTransactions(BlockConstraints{MaxTx: 0})
+	}
+	if got := mp.FeeFloor(); got != 3 {
+		t.Fatalf("expected floor capped at 3, got %d", got)
+	}
+}
+
+func TestFeeFloorDisabledByDefault(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 1})
+	_, _ = mp.Add(newTx("alice", 100, 10))
+	mp.SelectTransactions(BlockConstraints{MaxTx: 0})
+
+	if got := mp.FeeFloor(); got != 0 {
+		t.Fatalf("expected disabled floor to stay 0, got %d", got)
+	}
+}
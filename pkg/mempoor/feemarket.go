@@ -0,0 +1,91 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// ErrInvalidFee is returned by Add and Update for a dynamic-fee tx whose
+// Fee does not equal its MaxFee.
+var ErrInvalidFee = errors.New("mempool: invalid fee")
+
+// Fee market semantics (Tx.MaxFee, Tx.Tip, BlockConstraints.BaseFee):
+//   - A dynamic-fee tx sets MaxFee, the most it pays in total, and Tip,
+//     the most of that it offers the proposer. Its Fee must equal MaxFee,
+//     so eviction, RBF, the fee floor, and MinFee see what it can pay.
+//     A legacy tx (MaxFee 0) offers its whole Fee as both.
+//   - A block burns BaseFee per unit of gas. A tx pays the burn plus its
+//     effective tip, min(Tip, MaxFee - BaseFee*Gas); one whose MaxFee
+//     does not cover the burn is skipped and stays pending.
+//   - With BaseFee > 0, selection orders txs by effective tip (ties in
+//     PriorityPolicy order) instead of by the heap, under every strategy
+//     but StrategyFIFO. With BaseFee 0 the market is off: legacy txs tip
+//     their Fee and the PriorityPolicy orders as before.
+//   - Knapsack packing and best-of candidates compare plans by tips,
+//     i.e. by what the proposer earns. A bundle is ordered by the sums
+//     of its members' MaxFee, Tip, and Gas.
+//   - Blocks record the burned and tipped totals in the header.
+//
+// PERF: With BaseFee > 0 each lane is ordered up front, O(n log n) per
+// lane per block, like StrategyFIFO.
+
+// feeCaps returns what tx offers in total and to the proposer.
+func (tx *Tx) feeCaps() (maxFee, tip uint64) {
+	if tx.MaxFee == 0 {
+		return tx.Fee, tx.Fee
+	}
+	return tx.MaxFee, tx.Tip
+}
+
+// burnFor returns baseFee * gas, saturating on overflow.
+func burnFor(baseFee, gas uint64) uint64 {
+	hi, lo := bits.Mul64(baseFee, gas)
+	if hi != 0 {
+		return math.MaxUint64
+	}
+	return lo
+}
+
+// EffectiveTip returns what tx pays the proposer under baseFee, and
+// false if its MaxFee does not cover the burn. See "Fee market
+// semantics".
+func EffectiveTip(tx *Tx, baseFee uint64) (uint64, bool) {
+	maxFee, tip := tx.feeCaps()
+	burn := burnFor(baseFee, tx.Gas)
+	if maxFee < burn {
+		return 0, false
+	}
+	return min(tip, maxFee-burn), true
+}
+
+// txFees returns what tx burns and tips in a block under baseFee. A tx
+// whose MaxFee does not cover the burn, which only a required tx can be
+// (see required.go), burns its MaxFee and tips nothing.
+func txFees(tx *Tx, baseFee uint64) (burned, tip uint64) {
+	maxFee, _ := tx.feeCaps()
+	tip, _ = EffectiveTip(tx, baseFee)
+	return min(burnFor(baseFee, tx.Gas), maxFee), tip
+}
+
+// checkFee validates a dynamic-fee tx; legacy txs always pass.
+func checkFee(tx *Tx) error {
+	if tx.MaxFee != 0 && tx.Fee != tx.MaxFee {
+		return fmt.Errorf("%w: fee %d must equal maxFee %d", ErrInvalidFee, tx.Fee, tx.MaxFee)
+	}
+	return nil
+}
+
+// tipOrder orders by effective tip under baseFee, highest first, then
+// by policy.
+func tipOrder(baseFee uint64, policy PriorityPolicy) func(a, b *Tx) bool {
+	return func(a, b *Tx) bool {
+		ta, _ := EffectiveTip(a, baseFee)
+		tb, _ := EffectiveTip(b, baseFee)
+		if ta != tb {
+			return ta > tb
+		}
+		return policy.Less(a, b)
+	}
+}
@@ -0,0 +1,96 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newDynamicTx(sender string, maxFee, tip, gas uint64) *Tx {
+	tx := newTx(sender, maxFee, gas)
+	tx.MaxFee, tx.Tip = maxFee, tip
+	return tx
+}
+
+func TestEffectiveTip(t *testing.T) {
+	cases := []struct {
+		tx      *Tx
+		baseFee uint64
+		tip     uint64
+		ok      bool
+	}{
+		{newTx("a", 100, 10), 0, 100, true},
+		{newTx("a", 100, 10), 5, 50, true},
+		{newDynamicTx("a", 1000, 80, 10), 5, 80, true},
+		{newDynamicTx("a", 70, 60, 10), 5, 20, true},
+		{newDynamicTx("a", 40, 10, 10), 5, 0, false},
+	}
+	for i, tc := range cases {
+		tip, ok := EffectiveTip(tc.tx, tc.baseFee)
+		if tip != tc.tip || ok != tc.ok {
+			t.Fatalf("case %d: expected (%d, %v), got (%d, %v)", i, tc.tip, tc.ok, tip, ok)
+		}
+	}
+}
+
+func TestBaseFeeOrdersByTip(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	legacy := newTx("a", 100, 10) // tips 50 at base fee 5
+	rich := newDynamicTx("b", 1000, 80, 10)
+	capped := newDynamicTx("d", 70, 60, 10) // tips 20
+	broke := newDynamicTx("c", 40, 10, 10)  // cannot cover the burn
+	for _, tx := range []*Tx{legacy, rich, capped, broke} {
+		if _, err := mp.Add(tx); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+	}
+
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{MaxTxPerBlock: 10, BaseFee: 5})
+	blk, err := builder.BuildBlock(context.Background(), [32]byte{}, 0, time.Unix(1, 0).UTC())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []TxID{rich.ID, legacy.ID, capped.ID}
+	if len(blk.Transactions) != len(want) {
+		t.Fatalf("expected %d txs, got %v", len(want), blk.Transactions)
+	}
+	for i, id := range want {
+		if blk.Transactions[i].ID != id {
+			t.Fatalf("position %d: expected %s, got %s", i, id, blk.Transactions[i].ID)
+		}
+	}
+	if blk.Header.Burned != 150 || blk.Header.Tipped != 150 {
+		t.Fatalf("expected 150 burned and 150 tipped, got %d and %d", blk.Header.Burned, blk.Header.Tipped)
+	}
+	if blk.Header.TotalFees != 300 || blk.Header.AvgFeePerGas != 10 {
+		t.Fatalf("expected 300 in fees at 10 per gas, got %d and %d", blk.Header.TotalFees, blk.Header.AvgFeePerGas)
+	}
+	if _, err := mp.Get(broke.ID); err != nil {
+		t.Fatalf("expected the unaffordable tx to stay pending: %v", err)
+	}
+}
+
+func TestDynamicFeeMustMatchMaxFee(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	tx := newDynamicTx("a", 100, 10, 10)
+	tx.Fee = 5
+	if _, err := mp.Add(tx); !errors.Is(err, ErrInvalidFee) {
+		t.Fatalf("expected ErrInvalidFee, got %v", err)
+	}
+
+	tx.Fee = tx.MaxFee
+	if _, err := mp.Add(tx); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	bump := *tx
+	bump.Fee = 200
+	if err := mp.Update(&bump); !errors.Is(err, ErrInvalidFee) {
+		t.Fatalf("expected ErrInvalidFee on update, got %v", err)
+	}
+	bump.MaxFee = 200
+	if err := mp.Update(&bump); err != nil {
+		t.Fatalf("unexpected Update error: %v", err)
+	}
+}
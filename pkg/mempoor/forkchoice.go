@@ -0,0 +1,79 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownForkChoice is returned by ParseForkChoice for a name it does
+// not know.
+var ErrUnknownForkChoice = errors.New("blockbuilder: unknown fork choice")
+
+// ForkChoice decides between two branches competing for the same heights.
+//
+// Fork choice semantics (NodeConfig.ForkChoice):
+//   - Both branches start on the same parent and are in height order:
+//     current is what the node holds or has in hand, competing is what
+//     arrived. Prefer reports whether to switch to competing.
+//   - A rule keeps current on a tie, so equal branches never flip back
+//     and forth.
+//   - block.reorg asks with the node's blocks from the competing block's
+//     height up to the tip as current; see "Reorg semantics".
+//   - block.propose asks with the proposal as current and the node's
+//     own candidate as competing: the node produces its own block only
+//     if the rule prefers it; see "Proposal semantics".
+//   - Imports apply no fork choice; see "Chain import semantics".
+type ForkChoice interface {
+	Prefer(current, competing []*Block) bool
+}
+
+// MostFees is the default ForkChoice: competing wins if its blocks pay
+// strictly more TotalFees together than current's.
+type MostFees struct{}
+
+func (MostFees) Prefer(current, competing []*Block) bool {
+	return totalFees(competing) > totalFees(current)
+}
+
+func (MostFees) String() string { return "most-fees" }
+
+// LongestChain is a ForkChoice under which competing wins only if it has
+// strictly more blocks than current. Two blocks for one height tie, so
+// the first one seen stays.
+type LongestChain struct{}
+
+func (LongestChain) Prefer(current, competing []*Block) bool {
+	return len(competing) > len(current)
+}
+
+func (LongestChain) String() string { return "longest-chain" }
+
+// ParseForkChoice parses a fork choice name, as the CLI and config file
+// give it.
+func ParseForkChoice(name string) (ForkChoice, error) {
+	switch name {
+	case "", "most-fees", "fees":
+		return MostFees{}, nil
+	case "longest-chain", "longest":
+		return LongestChain{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownForkChoice, name)
+	}
+}
+
+// forkChoice returns the node's fork choice.
+func (n *Node) forkChoice() ForkChoice {
+	if n.cfg.ForkChoice == nil {
+		return MostFees{}
+	}
+	return n.cfg.ForkChoice
+}
+
+// totalFees sums blocks' TotalFees.
+func totalFees(blocks []*Block) uint64 {
+	var fees uint64
+	for _, b := range blocks {
+		fees += b.Header.TotalFees
+	}
+	return fees
+}
@@ -0,0 +1,72 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestForkChoiceRules(t *testing.T) {
+	block := func(fees uint64) *Block { return &Block{Header: BlockHeader{TotalFees: fees}} }
+	one, two := []*Block{block(90)}, []*Block{block(40), block(40)}
+
+	if !(MostFees{}).Prefer(two, one) || (MostFees{}).Prefer(one, two) {
+		t.Fatalf("expected MostFees to prefer the branch paying 90 over 80")
+	}
+	if (MostFees{}).Prefer(one, []*Block{block(90)}) {
+		t.Fatalf("expected MostFees to keep the current branch on a tie")
+	}
+	if !(LongestChain{}).Prefer(one, two) || (LongestChain{}).Prefer(two, one) {
+		t.Fatalf("expected LongestChain to prefer the two-block branch")
+	}
+	if (LongestChain{}).Prefer(one, []*Block{block(1000)}) {
+		t.Fatalf("expected LongestChain to keep the current branch on a tie")
+	}
+}
+
+func TestParseForkChoice(t *testing.T) {
+	for name, want := range map[string]ForkChoice{"": MostFees{}, "most-fees": MostFees{}, "longest-chain": LongestChain{}} {
+		got, err := ParseForkChoice(name)
+		if err != nil || got != want {
+			t.Fatalf("ParseForkChoice(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseForkChoice("heaviest"); !errors.Is(err, ErrUnknownForkChoice) {
+		t.Fatalf("expected ErrUnknownForkChoice, got %v", err)
+	}
+}
+
+func TestProposeBlockUsesForkChoice(t *testing.T) {
+	n, big, small1, _ := newAuctionNode(t)
+	n.cfg.ForkChoice = LongestChain{}
+
+	// The node's own block pays more, but one block ties with one block.
+	out, err := n.proposeBlock(context.Background(), proposal(0, [32]byte{}, small1), [32]byte{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !out.Accepted || out.Block.Transactions[0].ID != small1.ID {
+		t.Fatalf("expected the proposal to stand under LongestChain, got %+v", out)
+	}
+	if _, err := n.mempool.Get(big.ID); err != nil {
+		t.Fatalf("expected the node's own tx still pending: %v", err)
+	}
+}
+
+func TestReorgUsesForkChoice(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10, ForkChoice: LongestChain{}})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	_, _ = n.mempool.Add(newTx("alice", 5, 10))
+	prevHash, height := n.chainTip()
+	if _, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second)); err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+
+	// A richer block for the same height does not make a longer chain.
+	if _, err := n.reorgBlock(forkBlock(n.blocks[0], newTx("bob", 500, 10))); !errors.Is(err, ErrForkRejected) {
+		t.Fatalf("expected ErrForkRejected under LongestChain, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrGenesisMismatch is returned on startup when the stored chain begins
+// with a different genesis block than NodeConfig.Genesis describes.
+var ErrGenesisMismatch = errors.New("blockbuilder: genesis mismatch")
+
+// GenesisTime is the genesis timestamp when GenesisConfig sets none.
+var GenesisTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// genesisBalancePrefix marks initial balances in the genesis header's
+// Extra, e.g. "balance:alice" = "100".
+const genesisBalancePrefix = "balance:"
+
+// GenesisConfig describes a chain's genesis block.
+//
+// Genesis semantics (NodeConfig.Genesis):
+//   - The genesis block has height 0, a zero PrevHash, no txs, and
+//     Timestamp, ExtraData, Balances, and ChainID from the config; nothing else,
+//     not even the node's Proposer, goes into it. The same config always
+//     yields the same block, so nodes started with the same genesis
+//     share block 0 and can compare their chains from there.
+//   - Balances are recorded in the header's Extra as "balance:<addr>"
+//     entries, so they are part of the genesis hash.
+//   - A node creates the genesis block on its first start and builds
+//     block 1 on it. On later starts the stored chain must begin with the
+//     same genesis, or the node refuses to start with ErrGenesisMismatch.
+type GenesisConfig struct {
+	Timestamp time.Time         // zero = GenesisTime
+	ExtraData []byte            // at most MaxExtraDataBytes
+	Balances  map[string]uint64 // initial balance per address
+	ChainID   string            // the node sets NodeConfig.ChainID
+}
+
+// NewGenesisBlock returns the genesis block for cfg. See "Genesis
+// semantics".
+func NewGenesisBlock(cfg GenesisConfig) *Block {
+	ts := cfg.Timestamp
+	if ts.IsZero() {
+		ts = GenesisTime
+	}
+
+	b := &Block{Header: BlockHeader{Height: 0, Timestamp: ts.UTC(), ChainID: cfg.ChainID}}
+	if len(cfg.ExtraData) > 0 {
+		b.Header.ExtraData = append([]byte(nil), cfg.ExtraData...)
+	}
+	if len(cfg.Balances) > 0 {
+		b.Header.Extra = make(map[string]string, len(cfg.Balances))
+		for addr, bal := range cfg.Balances {
+			b.Header.Extra[genesisBalancePrefix+addr] = strconv.FormatUint(bal, 10)
+		}
+	}
+	return b
+}
+
+// GenesisBalances returns the initial balances recorded in genesis block
+// b, or nil if it has none.
+func GenesisBalances(b *Block) (map[string]uint64, error) {
+	var out map[string]uint64
+	for k, v := range b.Header.Extra {
+		addr, ok := strings.CutPrefix(k, genesisBalancePrefix)
+		if !ok {
+			continue
+		}
+		bal, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("genesis balance of %q: %w", addr, err)
+		}
+		if out == nil {
+			out = make(map[string]uint64)
+		}
+		out[addr] = bal
+	}
+	return out, nil
+}
+
+// ParseGenesisBalances parses "addr=balance" pairs separated by commas
+// (as used by the CLI), e.g. "alice=100,bob=50". "" means none.
+func ParseGenesisBalances(s string) (map[string]uint64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	out := make(map[string]uint64)
+	for _, pair := range strings.Split(s, ",") {
+		addr, val, ok := strings.Cut(pair, "=")
+		addr = strings.TrimSpace(addr)
+		if !ok || addr == "" {
+			return nil, fmt.Errorf("genesis balance %q: want addr=balance", pair)
+		}
+		bal, err := strconv.ParseUint(strings.TrimSpace(val), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("genesis balance %q: %w", pair, err)
+		}
+		if _, dup := out[addr]; dup {
+			return nil, fmt.Errorf("genesis balance %q: duplicate address", pair)
+		}
+		out[addr] = bal
+	}
+	return out, nil
+}
+
+// initGenesis makes sure the node's chain starts with its genesis block:
+// it stores one on an empty chain and checks the stored one otherwise.
+func (n *Node) initGenesis() error {
+	gcfg := n.cfg.Genesis
+	gcfg.ChainID = n.cfg.ChainID
+	genesis := NewGenesisBlock(gcfg)
+
+	n.blocksMu.Lock()
+	defer n.blocksMu.Unlock()
+
+	if len(n.blocks) > 0 {
+		if stored := n.blocks[0].Header.ChainID; stored != n.cfg.ChainID {
+			return fmt.Errorf("%w: stored chain is %q, configured %q", ErrWrongChain, stored, n.cfg.ChainID)
+		}
+		if got, want := n.blocks[0].Hash(), genesis.Hash(); n.blocks[0].Header.Height != 0 || got != want {
+			return fmt.Errorf("%w: stored %x, configured %x", ErrGenesisMismatch, got, want)
+		}
+		return nil
+	}
+
+	if n.store != nil {
+		if err := n.store.Put(genesis); err != nil {
+			return fmt.Errorf("store genesis: %w", err)
+		}
+	}
+	n.setChainLocked(len(n.blocks), genesis)
+	n.log.chain.Info("genesis created", "hash", fmt.Sprintf("%x", genesis.Hash()), "balances", len(n.cfg.Genesis.Balances))
+	return nil
+}
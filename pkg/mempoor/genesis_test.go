@@ -0,0 +1,82 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenesisBlockIsDeterministic(t *testing.T) {
+	cfg := GenesisConfig{ExtraData: []byte("testnet"), Balances: map[string]uint64{"alice": 100, "bob": 50}}
+	a, b := NewGenesisBlock(cfg), NewGenesisBlock(cfg)
+	if a.Hash() != b.Hash() || a.Header.Height != 0 || !a.Header.Timestamp.Equal(GenesisTime) {
+		t.Fatalf("expected identical genesis blocks at height 0 and GenesisTime")
+	}
+
+	balances, err := GenesisBalances(a)
+	if err != nil || len(balances) != 2 || balances["alice"] != 100 || balances["bob"] != 50 {
+		t.Fatalf("expected the configured balances back, got %v, %v", balances, err)
+	}
+
+	other := NewGenesisBlock(GenesisConfig{ExtraData: []byte("testnet"), Balances: map[string]uint64{"alice": 101, "bob": 50}})
+	if other.Hash() == a.Hash() {
+		t.Fatalf("balances must be part of the genesis hash")
+	}
+
+	if _, err := ParseGenesisBalances("alice=100,alice=1"); err == nil {
+		t.Fatalf("expected a duplicate address to be rejected")
+	}
+	if got, err := ParseGenesisBalances("alice=100, bob=50"); err != nil || got["bob"] != 50 {
+		t.Fatalf("unexpected parse result %v, %v", got, err)
+	}
+}
+
+func TestNodeBuildsOnGenesis(t *testing.T) {
+	dir := t.TempDir()
+	cfg := NodeConfig{DataDir: dir, MaxTxPerBlock: 10, Genesis: GenesisConfig{Balances: map[string]uint64{"alice": 100}}}
+
+	n1 := NewNode(cfg)
+	closeChain, err := n1.openChain()
+	if err != nil {
+		t.Fatalf("open chain: %v", err)
+	}
+	if err := n1.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	genesis := NewGenesisBlock(cfg.Genesis)
+	prevHash, height := n1.chainTip()
+	if prevHash != genesis.Hash() || height != 1 {
+		t.Fatalf("expected block 1 to build on genesis, got height %d", height)
+	}
+	_, _ = n1.mempool.Add(newTx("alice", 5, 10))
+	if _, err := n1.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second)); err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	closeChain()
+
+	// Restarting with another genesis must fail; with the same one, the
+	// chain is kept.
+	cfg.Genesis.Balances = map[string]uint64{"alice": 200}
+	n2 := NewNode(cfg)
+	if closeChain, err = n2.openChain(); err != nil {
+		t.Fatalf("reopen chain: %v", err)
+	}
+	if err := n2.initGenesis(); !errors.Is(err, ErrGenesisMismatch) {
+		t.Fatalf("expected ErrGenesisMismatch, got %v", err)
+	}
+	closeChain()
+
+	cfg.Genesis.Balances = map[string]uint64{"alice": 100}
+	n3 := NewNode(cfg)
+	if closeChain, err = n3.openChain(); err != nil {
+		t.Fatalf("reopen chain: %v", err)
+	}
+	defer closeChain()
+	if err := n3.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	if _, height := n3.chainTip(); height != 2 {
+		t.Fatalf("expected to resume at height 2, got %d", height)
+	}
+}
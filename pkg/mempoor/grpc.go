@@ -0,0 +1,349 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"mempoor/pkg/mempoorpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// gRPC semantics (NodeConfig.GRPCListenAddr):
+//   - The service in pkg/mempoorpb/mempoor.proto runs beside /rpc. AddTx,
+//     UpdateTx, RemoveTx, ListTxs, and GetBlock go through the JSON-RPC
+//     handler of tx.add, tx.update, tx.remove, tx.list, and block.get,
+//     so both APIs check, count, and log a call alike.
+//   - Errors map to status codes by the HTTP status the JSON-RPC call
+//     got: 400 InvalidArgument, 401 Unauthenticated, 403
+//     PermissionDenied, 413 and 429 ResourceExhausted. A missing tx or
+//     block is NotFound; any other refusal JSON-RPC reports inside its
+//     result is FailedPrecondition.
+//   - The "authorization" metadata is passed on as the Authorization
+//     header, for methods that need the AdminToken.
+//   - StreamBlocks sends every block that joins the chain, as the head
+//     subscription sees it (see "Head subscription semantics"). With
+//     from_height it first sends the stored blocks from there, then
+//     continues with new ones, without repeats or gaps in between.
+//   - Shutdown ends every stream, then stops the server.
+
+// grpcServer implements mempoorpb.MempoorServer on a Node.
+type grpcServer struct {
+	mempoorpb.UnimplementedMempoorServer
+	n *Node
+}
+
+// newGRPCServer returns a gRPC server with the node's service registered.
+func (n *Node) newGRPCServer() *grpc.Server {
+	gs := grpc.NewServer()
+	mempoorpb.RegisterMempoorServer(gs, &grpcServer{n: n})
+	return gs
+}
+
+func (s *grpcServer) AddTx(ctx context.Context, req *mempoorpb.AddTxRequest) (*mempoorpb.AddTxResponse, error) {
+	lane, err := ParseLane(req.GetLane())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	p := addTxParams{
+		Sender:    req.GetSender(),
+		Recipient: req.GetRecipient(),
+		Payload:   req.GetPayload(),
+		Nonce:     req.GetNonce(),
+		Fee:       req.GetFee(),
+		MaxFee:    req.GetMaxFee(),
+		Tip:       req.GetTip(),
+		Gas:       req.GetGas(),
+		Amount:    req.GetAmount(),
+		ChainID:   req.GetChainId(),
+		ParentID:  req.GetParentId(),
+		DependsOn: req.GetDependsOn(),
+		NotBefore: fromTimestamp(req.GetNotBefore()),
+		Lane:      lane,
+		Pool:      req.GetPool(),
+	}
+	var res addTxResult
+	if err := s.call(ctx, "tx.add", p, &res); err != nil {
+		return nil, err
+	}
+	return &mempoorpb.AddTxResponse{
+		TxId:      res.TxID,
+		Orphan:    res.Orphan,
+		Scheduled: res.Scheduled,
+		Merged:    res.Merged,
+		Evicted:   res.Evicted,
+	}, nil
+}
+
+func (s *grpcServer) UpdateTx(ctx context.Context, req *mempoorpb.UpdateTxRequest) (*mempoorpb.UpdateTxResponse, error) {
+	p := updateTxParams{ID: req.GetId(), Fee: req.GetFee(), Tip: req.Tip}
+	if err := s.call(ctx, "tx.update", p, &okResult{}); err != nil {
+		return nil, err
+	}
+	return &mempoorpb.UpdateTxResponse{}, nil
+}
+
+func (s *grpcServer) RemoveTx(ctx context.Context, req *mempoorpb.RemoveTxRequest) (*mempoorpb.RemoveTxResponse, error) {
+	if err := s.call(ctx, "tx.remove", removeTxParams{ID: req.GetId()}, &okResult{}); err != nil {
+		return nil, err
+	}
+	return &mempoorpb.RemoveTxResponse{}, nil
+}
+
+func (s *grpcServer) ListTxs(ctx context.Context, req *mempoorpb.ListTxsRequest) (*mempoorpb.ListTxsResponse, error) {
+	p := listTxParams{
+		Offset:    int(req.GetOffset()),
+		Limit:     int(req.GetLimit()),
+		Sort:      req.GetSort(),
+		Pool:      req.GetPool(),
+		Sender:    req.GetSender(),
+		Recipient: req.GetRecipient(),
+		MinFee:    req.GetMinFee(),
+		MaxFee:    req.GetMaxFee(),
+		MinGas:    req.GetMinGas(),
+	}
+	var res listTxResult
+	if err := s.call(ctx, "tx.list", p, &res); err != nil {
+		return nil, err
+	}
+	out := &mempoorpb.ListTxsResponse{Total: int64(res.Total)}
+	for _, tx := range res.Transactions {
+		out.Transactions = append(out.Transactions, pbTx(tx))
+	}
+	return out, nil
+}
+
+func (s *grpcServer) GetBlock(ctx context.Context, req *mempoorpb.GetBlockRequest) (*mempoorpb.Block, error) {
+	p := blockGetParams{Compression: req.GetCompression()}
+	switch sel := req.GetBlock().(type) {
+	case *mempoorpb.GetBlockRequest_Height:
+		p.Height = &sel.Height
+	case *mempoorpb.GetBlockRequest_Hash:
+		if sel.Hash == "" {
+			return nil, status.Error(codes.InvalidArgument, "hash is empty")
+		}
+		p.Hash = sel.Hash
+	}
+	var res getBlockResult
+	if err := s.call(ctx, "block.get", p, &res); err != nil {
+		return nil, err
+	}
+	return pbBlock(res.Block)
+}
+
+// StreamBlocks sends the chain's blocks; see "gRPC semantics".
+func (s *grpcServer) StreamBlocks(req *mempoorpb.StreamBlocksRequest, stream grpc.ServerStreamingServer[mempoorpb.BlockEvent]) error {
+	heads, cancel := s.n.SubscribeHeads()
+	defer cancel()
+
+	send := func(b *Block, reorg bool) error {
+		pb, err := pbBlock(makeBlockDTO(b))
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return stream.Send(&mempoorpb.BlockEvent{Block: pb, Reorg: reorg})
+	}
+
+	// Subscribed first, so no block lands between the stored ones and
+	// the feed. Heights below next were sent already, or not asked for.
+	var next uint64
+	if req.FromHeight != nil {
+		next = req.GetFromHeight()
+		_, tip := s.n.chainTip()
+		for h := next; h < tip; h++ {
+			b, ok := s.n.blockAt(h)
+			if !ok {
+				continue // pruned away
+			}
+			if err := send(b, false); err != nil {
+				return err
+			}
+		}
+		next = max(next, tip)
+	}
+
+	for {
+		select {
+		case ev, ok := <-heads:
+			if !ok {
+				return nil
+			}
+			height := ev.Block.Header.Height
+			if !ev.Reorg && height < next {
+				continue
+			}
+			if err := send(ev.Block, ev.Reorg); err != nil {
+				return err
+			}
+			next = height + 1
+		case <-stream.Context().Done():
+			return nil
+		case <-s.n.streams.closing():
+			return status.Error(codes.Unavailable, "node shutting down")
+		}
+	}
+}
+
+// call runs method with params through the JSON-RPC handler and decodes
+// its result into out; see "gRPC semantics".
+func (s *grpcServer) call(ctx context.Context, method string, params, out any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "/rpc", nil)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if auth := md.Get("authorization"); len(auth) > 0 {
+			r.Header.Set("Authorization", auth[0])
+		}
+	}
+
+	rec := &bufferedResponse{header: make(http.Header), code: http.StatusOK}
+	s.n.serveRPC(rec, r, rpcRequest{Method: method, Params: raw})
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  string          `json:"error"`
+	}
+	if err := json.Unmarshal(rec.body.Bytes(), &resp); err != nil {
+		return status.Error(codes.Internal, "invalid response")
+	}
+	if rec.code != http.StatusOK {
+		return status.Error(grpcCode(rec.code), resp.Error)
+	}
+
+	// Refusals come back as a result holding an error.
+	var refused struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(resp.Result, &refused) == nil && refused.Error != "" {
+		code := codes.FailedPrecondition
+		if refused.Error == ErrTxNotFound.Error() || refused.Error == "block not found" {
+			code = codes.NotFound
+		}
+		return status.Error(code, refused.Error)
+	}
+	if err := json.Unmarshal(resp.Result, out); err != nil {
+		return status.Error(codes.Internal, "invalid result")
+	}
+	return nil
+}
+
+// grpcCode maps a JSON-RPC HTTP status to a gRPC code.
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusRequestEntityTooLarge, http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusInternalServerError:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+func pbTx(tx *Tx) *mempoorpb.Tx {
+	deps := make([]string, len(tx.DependsOn))
+	for i, id := range tx.DependsOn {
+		deps[i] = string(id)
+	}
+	return &mempoorpb.Tx{
+		Id:          string(tx.ID),
+		Sender:      tx.Sender,
+		Recipient:   tx.Recipient,
+		Fee:         tx.Fee,
+		Gas:         tx.Gas,
+		Payload:     tx.Payload,
+		Amount:      tx.Amount,
+		ChainId:     tx.ChainID,
+		MaxFee:      tx.MaxFee,
+		Tip:         tx.Tip,
+		Nonce:       tx.Nonce,
+		ParentId:    string(tx.ParentID),
+		DependsOn:   deps,
+		NotBefore:   toTimestamp(tx.NotBefore),
+		Lane:        tx.Lane.String(),
+		Pool:        tx.Pool,
+		BundleId:    tx.BundleID,
+		BundleIndex: int64(tx.BundleIndex),
+		CreatedAt:   toTimestamp(tx.CreatedAt),
+		Timestamp:   toTimestamp(tx.Timestamp),
+	}
+}
+
+func pbBlock(d blockDTO) (*mempoorpb.Block, error) {
+	var err error
+	decode := func(s string) []byte {
+		raw, derr := hex.DecodeString(s)
+		err = errors.Join(err, derr)
+		return raw
+	}
+	b := &mempoorpb.Block{
+		Height:       d.Height,
+		PrevHash:     d.PrevHash,
+		Timestamp:    toTimestamp(d.Timestamp),
+		TxCount:      int64(d.TxCount),
+		GasUsed:      d.GasUsed,
+		Hash:         d.Hash,
+		Burned:       d.Burned,
+		Tipped:       d.Tipped,
+		TotalFees:    d.TotalFees,
+		AvgFeePerGas: d.AvgFeePerGas,
+		Proposer:     d.Proposer,
+		ExtraData:    decode(d.ExtraData),
+		Extra:        d.Extra,
+		ChainId:      d.ChainID,
+		Compression:  d.Compression,
+		Signer:       decode(d.Signer),
+		Signature:    decode(d.Signature),
+		Pruned:       d.Pruned,
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for _, tx := range d.Txs {
+		b.Transactions = append(b.Transactions, pbTx(tx))
+	}
+	for _, g := range d.Groups {
+		group := &mempoorpb.TxGroup{Indices: make([]int64, len(g))}
+		for i, idx := range g {
+			group.Indices[i] = int64(idx)
+		}
+		b.Groups = append(b.Groups, group)
+	}
+	return b, nil
+}
+
+// toTimestamp converts t, leaving the zero time unset.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// fromTimestamp converts ts, mapping unset to the zero time.
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,124 @@
+package mempoor
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"mempoor/pkg/mempoorpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialGRPC serves n's gRPC API in memory and returns a client for it.
+func dialGRPC(t *testing.T, n *Node) mempoorpb.MempoorClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	gs := n.newGRPCServer()
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return mempoorpb.NewMempoorClient(conn)
+}
+
+func TestGRPCTxLifecycle(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	c := dialGRPC(t, n)
+	ctx := context.Background()
+
+	added, err := c.AddTx(ctx, &mempoorpb.AddTxRequest{Sender: "alice", Recipient: "bob", Fee: 10, Gas: 5, Lane: "urgent"})
+	if err != nil || added.GetTxId() == "" {
+		t.Fatalf("add: %v, %v", added, err)
+	}
+	if _, err := c.AddTx(ctx, &mempoorpb.AddTxRequest{Sender: "alice"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without a recipient, got %v", err)
+	}
+
+	if _, err := c.UpdateTx(ctx, &mempoorpb.UpdateTxRequest{Id: added.GetTxId(), Fee: 20}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	list, err := c.ListTxs(ctx, &mempoorpb.ListTxsRequest{Sender: "alice"})
+	if err != nil || list.GetTotal() != 1 {
+		t.Fatalf("list: %v, %v", list, err)
+	}
+	if tx := list.GetTransactions()[0]; tx.GetId() != added.GetTxId() || tx.GetFee() != 20 || tx.GetLane() != "urgent" || tx.GetCreatedAt() == nil {
+		t.Fatalf("expected the bumped tx, got %v", tx)
+	}
+
+	if _, err := c.RemoveTx(ctx, &mempoorpb.RemoveTxRequest{Id: added.GetTxId()}); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := c.RemoveTx(ctx, &mempoorpb.RemoveTxRequest{Id: added.GetTxId()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound removing it again, got %v", err)
+	}
+}
+
+func TestGRPCGetAndStreamBlocks(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10, BlockInterval: time.Second})
+	c := dialGRPC(t, n)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, _ = n.mempool.Add(newTx("alice", 10, 5))
+	if _, err := n.Mine(ctx, 1); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	b, err := c.GetBlock(ctx, &mempoorpb.GetBlockRequest{Block: &mempoorpb.GetBlockRequest_Height{Height: 0}})
+	if err != nil || len(b.GetTransactions()) != 1 || len(b.GetSignature()) == 0 {
+		t.Fatalf("get by height: %v, %v", b, err)
+	}
+	if byHash, err := c.GetBlock(ctx, &mempoorpb.GetBlockRequest{Block: &mempoorpb.GetBlockRequest_Hash{Hash: b.GetHash()}}); err != nil || byHash.GetHeight() != 0 {
+		t.Fatalf("get by hash: %v, %v", byHash, err)
+	}
+	if _, err := c.GetBlock(ctx, &mempoorpb.GetBlockRequest{Block: &mempoorpb.GetBlockRequest_Height{Height: 9}}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound past the tip, got %v", err)
+	}
+
+	// The stored block, then the next one live.
+	stream, err := c.StreamBlocks(ctx, &mempoorpb.StreamBlocksRequest{FromHeight: new(uint64)})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	if ev, err := stream.Recv(); err != nil || ev.GetBlock().GetHeight() != 0 {
+		t.Fatalf("expected block 0 replayed, got %v, %v", ev, err)
+	}
+	_, _ = n.mempool.Add(newTx("bob", 10, 5))
+	if _, err := n.Mine(ctx, 1); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	if ev, err := stream.Recv(); err != nil || ev.GetBlock().GetHeight() != 1 {
+		t.Fatalf("expected block 1 live, got %v, %v", ev, err)
+	}
+
+	n.streams.stop()
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable on shutdown, got %v", err)
+	}
+}
+
+func TestGRPCPassesAdminToken(t *testing.T) {
+	n := NewNode(NodeConfig{AdminToken: "s3cret"})
+	s := &grpcServer{n: n}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong"))
+	if err := s.call(ctx, "admin.clear", clearParams{}, &clearResult{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for a bad token, got %v", err)
+	}
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer s3cret"))
+	if err := s.call(ctx, "admin.clear", clearParams{}, &clearResult{}); err != nil {
+		t.Fatalf("expected the token accepted, got %v", err)
+	}
+}
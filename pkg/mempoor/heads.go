@@ -0,0 +1,73 @@
+package mempoor
+
+import "sync"
+
+// HeadEvent reports a new chain tip.
+type HeadEvent struct {
+	// Block is the new tip, with plain payloads. Subscribers must not
+	// modify it.
+	Block *Block
+
+	// Reorg reports that Block replaced blocks at or above its height
+	// (block.reorg, or the first block of an import that diverged)
+	// instead of extending the chain.
+	Reorg bool
+}
+
+// Head subscription semantics (SubscribeHeads):
+//   - Every block that joins the chain is delivered once, in height
+//     order, after it is stored: produced and proposed blocks, a reorg's
+//     new tip, and each block an import adopts. Blocks reloaded at
+//     startup and the genesis block are not.
+//   - Delivery never blocks the node: a subscriber more than
+//     subscriberBuffer events behind misses heads, and can tell from a
+//     gap in heights that are not reorgs.
+//   - Streaming RPCs build on the same feed.
+
+// headFeed fans HeadEvents out to any number of subscribers.
+type headFeed struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]chan HeadEvent
+}
+
+// SubscribeHeads returns a channel of new chain tips and a cancel func
+// that unsubscribes and closes the channel; it is safe to call twice.
+// See "Head subscription semantics".
+func (n *Node) SubscribeHeads() (<-chan HeadEvent, func()) {
+	f := &n.heads
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.subs == nil {
+		f.subs = make(map[int]chan HeadEvent)
+	}
+	id := f.next
+	f.next++
+	ch := make(chan HeadEvent, subscriberBuffer)
+	f.subs[id] = ch
+
+	cancel := func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if ch, ok := f.subs[id]; ok {
+			delete(f.subs, id)
+			close(ch)
+		}
+	}
+	return ch, cancel
+}
+
+// publish delivers ev to every subscriber without blocking.
+func (f *headFeed) publish(ev HeadEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, ch := range f.subs {
+		select {
+		case ch <- ev:
+		default: // slow subscriber; drop
+		}
+	}
+}
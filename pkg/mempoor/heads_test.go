@@ -0,0 +1,41 @@
+package mempoor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSubscribeHeads(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 1, BlockInterval: time.Second})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	heads, cancel := n.SubscribeHeads()
+
+	_, _ = n.mempool.Add(newTx("alice", 5, 10))
+	_, _ = n.mempool.Add(newTx("bob", 6, 10))
+	if _, err := n.Mine(context.Background(), 2); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	for want := uint64(1); want <= 2; want++ {
+		ev := <-heads
+		if ev.Block.Header.Height != want || ev.Reorg {
+			t.Fatalf("expected head %d extending the chain, got %d (reorg %v)", want, ev.Block.Header.Height, ev.Reorg)
+		}
+	}
+
+	competing := forkBlock(n.blocks[1], newTx("carol", 100, 10))
+	if _, err := n.reorgBlock(competing); err != nil {
+		t.Fatalf("reorg: %v", err)
+	}
+	if ev := <-heads; ev.Block.Hash() != competing.Hash() || !ev.Reorg {
+		t.Fatalf("expected the reorg's tip flagged as a reorg, got height %d (reorg %v)", ev.Block.Header.Height, ev.Reorg)
+	}
+
+	cancel()
+	cancel()
+	if _, ok := <-heads; ok {
+		t.Fatalf("expected the channel closed after cancel")
+	}
+}
@@ -0,0 +1,81 @@
+package mempoor
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// Health probe semantics (GET /healthz, GET /readyz):
+//   - /healthz answers 200 "ok" whenever the process can serve HTTP at
+//     all: it is a liveness probe and checks nothing else.
+//   - /readyz answers 200 when every readiness check passes and 503
+//     otherwise, with the result of each check as JSON:
+//     "rpc" (the RPC handler is mounted and the node is not shutting
+//     down), "blockLoop" (the block production loop is running), and
+//     "storage" (the block store answers a read; a node without one
+//     passes).
+//   - Once shutdown begins /readyz fails, so load balancers stop
+//     routing to the node while in-flight RPCs finish.
+//   - Both accept GET and HEAD, need no admin token, and are not RPC
+//     methods.
+
+// nodeHealth holds the readiness state the node's goroutines report.
+type nodeHealth struct {
+	serving   atomic.Bool // RPC mounted and not shutting down
+	blockLoop atomic.Bool // runBlockLoop is running
+}
+
+type readyResult struct {
+	Ready  bool              `json:"ready"`
+	Checks map[string]string `json:"checks"` // check -> "ok" or why not
+}
+
+// readiness runs the /readyz checks.
+func (n *Node) readiness() readyResult {
+	out := readyResult{Ready: true, Checks: map[string]string{
+		"rpc":       "ok",
+		"blockLoop": "ok",
+		"storage":   "ok",
+	}}
+	fail := func(check, why string) {
+		out.Ready = false
+		out.Checks[check] = why
+	}
+	if !n.health.serving.Load() {
+		fail("rpc", "not serving")
+	}
+	if !n.health.blockLoop.Load() {
+		fail("blockLoop", "not running")
+	}
+	if n.store != nil {
+		if _, err := n.store.Block(0); err != nil {
+			fail("storage", err.Error())
+		}
+	}
+	return out
+}
+
+func (n *Node) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+func (n *Node) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ready := n.readiness()
+	status := http.StatusOK
+	if !ready.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ready)
+}
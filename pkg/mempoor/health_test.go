@@ -0,0 +1,56 @@
+package mempoor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func probe(t *testing.T, handler http.HandlerFunc, path string) (*httptest.ResponseRecorder, readyResult) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var ready readyResult
+	if path == "/readyz" {
+		if err := json.Unmarshal(rec.Body.Bytes(), &ready); err != nil {
+			t.Fatalf("decode %s: %v: %s", path, err, rec.Body)
+		}
+	}
+	return rec, ready
+}
+
+func TestHealthProbes(t *testing.T) {
+	store, err := OpenBoltBlockStore(filepath.Join(t.TempDir(), chainFile))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	n := NewNode(NodeConfig{BlockStore: store})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+
+	if rec, _ := probe(t, n.handleHealthz, "/healthz"); rec.Code != http.StatusOK || rec.Body.String() != "ok\n" {
+		t.Fatalf("expected /healthz to pass, got %d %q", rec.Code, rec.Body)
+	}
+
+	// Not started yet: neither serving nor producing.
+	rec, ready := probe(t, n.handleReadyz, "/readyz")
+	if rec.Code != http.StatusServiceUnavailable || ready.Checks["rpc"] == "ok" || ready.Checks["blockLoop"] == "ok" || ready.Checks["storage"] != "ok" {
+		t.Fatalf("expected rpc and blockLoop to fail before start, got %d %v", rec.Code, ready.Checks)
+	}
+
+	n.health.serving.Store(true)
+	n.health.blockLoop.Store(true)
+	if rec, ready := probe(t, n.handleReadyz, "/readyz"); rec.Code != http.StatusOK || !ready.Ready {
+		t.Fatalf("expected the node to be ready, got %d %v", rec.Code, ready.Checks)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("close store: %v", err)
+	}
+	if rec, ready := probe(t, n.handleReadyz, "/readyz"); rec.Code != http.StatusServiceUnavailable || ready.Checks["storage"] == "ok" {
+		t.Fatalf("expected a closed store to fail readiness, got %d %v", rec.Code, ready.Checks)
+	}
+}
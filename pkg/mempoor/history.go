@@ -0,0 +1,46 @@
+package mempoor
+
+import "time"
+
+// DefaultMaxHistory is used when MempoolConfig.MaxHistory is zero.
+const DefaultMaxHistory = 16
+
+// TxVersion is a superseded version of a pending tx, recorded each time
+// a fee bump replaces it.
+type TxVersion struct {
+	Fee       uint64    `json:"fee"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// recordVersion appends the version of rec being replaced, dropping the
+// oldest entries beyond MaxHistory. Caller must hold the write lock.
+func (m *mempool) recordVersion(rec *txRecord) {
+	max := m.cfg.MaxHistory
+	if max == 0 {
+		max = DefaultMaxHistory
+	}
+
+	rec.history = append(rec.history, TxVersion{
+		Fee:       rec.tx.Fee,
+		Timestamp: rec.tx.Timestamp,
+	})
+	if over := len(rec.history) - max; over > 0 {
+		rec.history = append([]TxVersion(nil), rec.history[over:]...)
+	}
+}
+
+// GetHistory returns the superseded versions of a pending tx, oldest
+// first. The current version is not included; use Get for that. History
+// is dropped once the tx leaves the pool.
+//
+// Strict: if ID not present → ErrTxNotFound.
+func (m *mempool) GetHistory(id TxID) ([]TxVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rec, ok := m.table[id]
+	if !ok {
+		return nil, ErrTxNotFound
+	}
+	return append([]TxVersion{}, rec.history...), nil
+}
@@ -0,0 +1,40 @@
+package mempoor
+
+import (
+	"testing"
+)
+
+func TestGetHistoryRecordsFeeBumps(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxHistory: 2})
+
+	tx := newTx("alice", 10, 10)
+	_, _ = mp.Add(tx)
+
+	if h, err := mp.GetHistory(tx.ID); err != nil || len(h) != 0 {
+		t.Fatalf("expected empty history for fresh tx, got %v err=%v", h, err)
+	}
+
+	for _, fee := range []uint64{20, 30, 40} {
+		bump := NewTxUpdate(tx.ID, tx.Sender, tx.Recipient, tx.Payload, tx.Nonce, fee, tx.Gas, tx.CreatedAt)
+		if err := mp.Update(bump); err != nil {
+			t.Fatalf("unexpected Update error: %v", err)
+		}
+	}
+
+	h, err := mp.GetHistory(tx.ID)
+	if err != nil {
+		t.Fatalf("unexpected GetHistory error: %v", err)
+	}
+	// Versions were 10, 20, 30 (current is 40); bounded to the last 2.
+	if len(h) != 2 || h[0].Fee != 20 || h[1].Fee != 30 {
+		t.Fatalf("expected bounded history [20 30], got %+v", h)
+	}
+}
+
+func TestGetHistoryStrictNotFound(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	if _, err := mp.GetHistory("missing"); err != ErrTxNotFound {
+		t.Fatalf("expected ErrTxNotFound, got %v", err)
+	}
+}
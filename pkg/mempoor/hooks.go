@@ -0,0 +1,59 @@
+package mempoor
+
+import (
+	"context"
+	"fmt"
+)
+
+// BeforeSelectHook runs before the builder asks the mempool for txs. It
+// may tighten c for this block, e.g. lower GasLimit or install a Filter.
+// An error aborts the build before anything is selected.
+type BeforeSelectHook func(c *BlockConstraints) error
+
+// AfterAssembleHook runs on each assembled block before it is returned,
+// e.g. to compute a merkle root or sign the header into Header.Extra.
+// It must not change Transactions. An error aborts the build: ReserveBlock
+// rolls its reservation back, so no tx is lost.
+//
+// Hooks also run for BuildTemplate, so a template carries the same
+// Extra fields the real block would.
+type AfterAssembleHook func(b *Block) error
+
+// FilterHook returns a BeforeSelectHook that keeps only txs for which
+// keep returns true. Txs it rejects stay pending. It composes with any
+// Filter installed by an earlier hook: both must accept a tx.
+func FilterHook(keep func(*Tx) bool) BeforeSelectHook {
+	return func(c *BlockConstraints) error {
+		prev := c.Filter
+		c.Filter = func(tx *Tx) bool {
+			return (prev == nil || prev(tx)) && keep(tx)
+		}
+		return nil
+	}
+}
+
+// beforeSelect returns the constraints for one block after running the
+// BeforeSelect hooks on them.
+func (b *BlockBuilder) beforeSelect(ctx context.Context) (BlockConstraints, error) {
+	c := b.constraints()
+	for _, hook := range b.cfg.BeforeSelect {
+		if err := hook(&c); err != nil {
+			return c, fmt.Errorf("blockbuilder: before select: %w", err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return c, err
+	}
+	c.Done = ctx.Done()
+	return c, nil
+}
+
+// afterAssemble runs the AfterAssemble hooks on block.
+func (b *BlockBuilder) afterAssemble(block *Block) error {
+	for _, hook := range b.cfg.AfterAssemble {
+		if err := hook(block); err != nil {
+			return fmt.Errorf("blockbuilder: after assemble: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBuilderHooks(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	keep := newTx("alice", 10, 100)
+	skip := newTx("mallory", 50, 100)
+	_, _ = mp.Add(keep)
+	_, _ = mp.Add(skip)
+
+	var seen []uint64
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{
+		GasLimit:      1_000,
+		MaxTxPerBlock: 10,
+		BeforeSelect: []BeforeSelectHook{
+			func(c *BlockConstraints) error { seen = append(seen, c.GasLimit); return nil },
+			FilterHook(func(tx *Tx) bool { return tx.Sender != "mallory" }),
+		},
+		AfterAssemble: []AfterAssembleHook{
+			func(b *Block) error { b.Header.Extra = map[string]string{"signer": "node-1"}; return nil },
+		},
+	})
+
+	blk, err := builder.BuildBlock(context.Background(), [32]byte{}, 0, time.Unix(1, 0).UTC())
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if len(seen) != 1 || seen[0] != 1_000 {
+		t.Fatalf("BeforeSelect should see the configured constraints, got %v", seen)
+	}
+	if len(blk.Transactions) != 1 || blk.Transactions[0].ID != keep.ID {
+		t.Fatalf("expected only the unfiltered tx, got %v", blk.Transactions)
+	}
+	if _, err := mp.Get(skip.ID); err != nil {
+		t.Fatalf("filtered tx should stay pending: %v", err)
+	}
+
+	plain := *blk
+	plain.Header.Extra = nil
+	if blk.Header.Extra["signer"] != "node-1" || plain.Hash() == blk.Hash() {
+		t.Fatalf("Extra should be set and part of the hash")
+	}
+}
+
+func TestBuilderHookErrors(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	_, _ = mp.Add(newTx("alice", 10, 100))
+
+	boom := errors.New("boom")
+	failing := NewBlockBuilder(mp, BlockBuilderConfig{
+		MaxTxPerBlock: 10,
+		AfterAssemble: []AfterAssembleHook{func(*Block) error { return boom }},
+	})
+	if _, err := failing.BuildBlock(context.Background(), [32]byte{}, 0, time.Time{}); !errors.Is(err, boom) {
+		t.Fatalf("expected the hook error, got %v", err)
+	}
+	if mp.Count() != 1 {
+		t.Fatalf("a failed AfterAssemble hook must roll the txs back")
+	}
+
+	aborted := NewBlockBuilder(mp, BlockBuilderConfig{
+		MaxTxPerBlock: 10,
+		BeforeSelect:  []BeforeSelectHook{func(*BlockConstraints) error { return boom }},
+	})
+	if _, err := aborted.BuildTemplate(context.Background(), [32]byte{}, 0, time.Time{}); !errors.Is(err, boom) {
+		t.Fatalf("expected the hook error, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package mempoor
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Chain import semantics (ImportChain, block.import):
+//   - The input is an export (see export.go): one block per line,
+//     starting at genesis. Every block is validated against the one
+//     before it, and, with tracked accounts, the whole chain against the
+//     genesis balances, before anything changes; one bad block rejects
+//     the import.
+//   - The imported genesis must be the node's own (ErrGenesisMismatch),
+//     so an import never changes which network the node is on.
+//   - Blocks the node already has are skipped. If the import diverges
+//     from the node's chain, it replaces the node's blocks from the
+//     first differing height, however long either side is; otherwise
+//     it extends the chain. No fork choice applies: importing is an
+//     operator decision.
+//   - New blocks go through the BlockSinks and the store in height
+//     order, then the tip moves in one step. Txs of displaced blocks
+//     return to their pools and the new blocks' txs leave them, as in a
+//     reorg.
+//
+// NOTE: A store failure part-way leaves the blocks stored so far as the
+// chain, which is still valid; ImportResult counts only those.
+
+// ImportResult reports what ImportChain changed.
+type ImportResult struct {
+	Imported   int `json:"imported"`   // blocks added to the chain
+	Displaced  int `json:"displaced"`  // blocks they replaced
+	Reinserted int `json:"reinserted"` // displaced txs returned to the pools
+}
+
+// ImportChain reads an exported chain from r and adopts it; see "Chain
+// import semantics".
+func (n *Node) ImportChain(r io.Reader) (ImportResult, error) {
+	var blocks []*Block
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 64<<20)
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var d blockDTO
+		if err := json.Unmarshal(sc.Bytes(), &d); err != nil {
+			return ImportResult{}, fmt.Errorf("%w: line %d: %v", ErrInvalidBlock, len(blocks)+1, err)
+		}
+		b, err := parseBlockDTO(d)
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("line %d: %w", len(blocks)+1, err)
+		}
+		blocks = append(blocks, b)
+	}
+	if err := sc.Err(); err != nil {
+		return ImportResult{}, fmt.Errorf("read chain: %w", err)
+	}
+	return n.importBlocks(blocks)
+}
+
+// importBlocks adopts blocks, a complete chain from genesis.
+func (n *Node) importBlocks(blocks []*Block) (ImportResult, error) {
+	var out ImportResult
+	if len(blocks) == 0 {
+		return out, fmt.Errorf("%w: empty import", ErrInvalidBlock)
+	}
+
+	n.produceMu.Lock()
+	defer n.produceMu.Unlock()
+
+	if err := validateChain(blocks, n.cfg.BaseFee); err != nil {
+		return out, err
+	}
+	if n.state != nil {
+		for _, b := range blocks {
+			if b.Pruned {
+				return out, fmt.Errorf("%w: block %d is pruned; accounts need every tx", ErrInvalidBlock, b.Header.Height)
+			}
+		}
+		scratch := NewState(n.cfg.Genesis.Balances)
+		if err := scratch.Rebuild(blocks[1:], n.cfg.BaseFee); err != nil {
+			return out, fmt.Errorf("%w: %w", ErrInvalidBlock, err)
+		}
+	}
+
+	n.blocksMu.RLock()
+	chain := n.blocks
+	n.blocksMu.RUnlock()
+
+	if len(chain) > 0 && chain[0].Hash() != blocks[0].Hash() {
+		return out, fmt.Errorf("%w: imported genesis %x", ErrGenesisMismatch, blocks[0].Hash())
+	}
+	fork := 0
+	for fork < len(chain) && fork < len(blocks) && chain[fork].Hash() == blocks[fork].Hash() {
+		fork++
+	}
+	if fork == len(blocks) {
+		return out, nil // nothing new
+	}
+	displaced, adopted := chain[fork:], blocks[fork:]
+	if err := checkDisplaceable(displaced); err != nil {
+		return out, err
+	}
+
+	// Collect the displaced txs before anything changes.
+	kept := make(map[TxID]bool)
+	for _, b := range adopted {
+		for _, tx := range b.Transactions {
+			kept[tx.ID] = true
+		}
+	}
+	var orphaned []*Tx
+	for _, b := range displaced {
+		plain, err := DecompressPayloads(b)
+		if err != nil {
+			return out, fmt.Errorf("import: block %d: %w", b.Header.Height, err)
+		}
+		for _, tx := range plain.Transactions {
+			if !kept[tx.ID] {
+				orphaned = append(orphaned, tx)
+			}
+		}
+	}
+
+	next := chain[:fork:fork]
+	var storeErr error
+	for _, b := range adopted {
+		if storeErr = n.storeImported(b); storeErr != nil {
+			break
+		}
+		next = append(next, b)
+	}
+	stored := next[fork:]
+	if len(stored) == 0 {
+		return out, storeErr
+	}
+
+	n.blocksMu.Lock()
+	n.setChainLocked(fork, stored...)
+	n.blocksMu.Unlock()
+	if err := n.rebuildState(); err != nil {
+		n.log.chain.Error("import state rebuild failed", "err", err)
+	}
+
+	out.Imported, out.Displaced = len(stored), len(displaced)
+	if err := n.reinsert(orphaned); err != nil {
+		n.log.mempool.Error("import reinsert failed", "err", err)
+	}
+	out.Reinserted = len(orphaned)
+	for i, b := range stored {
+		plain, err := DecompressPayloads(b)
+		if err != nil {
+			plain = b
+		}
+		for _, p := range n.pools {
+			p.mp.CommitExternal(plain.Transactions)
+		}
+		n.dropReplays(plain)
+		n.tracker.included(plain)
+		n.oracle.Refresh(n.mempool, plain)
+		n.heads.publish(HeadEvent{Block: plain, Reorg: i == 0 && len(displaced) > 0})
+	}
+
+	n.log.chain.Info("chain imported", "from", fork, "imported", out.Imported, "displaced", out.Displaced,
+		"reinserted", out.Reinserted, "tip", fmt.Sprintf("%x", next[len(next)-1].Hash()))
+	return out, storeErr
+}
+
+// storeImported publishes b to the BlockSinks and the store, in the
+// form the node keeps it.
+func (n *Node) storeImported(b *Block) error {
+	for _, sink := range n.cfg.BlockSinks {
+		if err := sink(b); err != nil {
+			return fmt.Errorf("publish block: %w", err)
+		}
+	}
+	if b.Compression == CompressNone && n.cfg.BlockCompression != CompressNone {
+		if compressed, err := CompressPayloads(b, n.cfg.BlockCompression); err == nil {
+			*b = *compressed
+		}
+	}
+	if n.store != nil {
+		if err := n.store.Put(b); err != nil {
+			return fmt.Errorf("store block %d: %w", b.Header.Height, err)
+		}
+	}
+	return nil
+}
+
+type importParams struct {
+	Blocks []blockDTO `json:"blocks"`
+}
+
+// rpcBlockImport imports the blocks in params, an export's lines as a
+// JSON array.
+func (n *Node) rpcBlockImport(w http.ResponseWriter, params json.RawMessage) {
+	var p importParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for block.import")
+		return
+	}
+
+	blocks := make([]*Block, len(p.Blocks))
+	for i, d := range p.Blocks {
+		b, err := parseBlockDTO(d)
+		if err != nil {
+			writeRPCResult(w, http.StatusOK, rpcResponse{Error: fmt.Sprintf("block %d: %v", i, err)})
+			return
+		}
+		blocks[i] = b
+	}
+
+	out, err := n.importBlocks(blocks)
+	if errors.Is(err, ErrInvalidBlock) || errors.Is(err, ErrGenesisMismatch) || errors.Is(err, ErrForkRejected) {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeRPCError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeRPCResult(w, http.StatusOK, out)
+}
@@ -0,0 +1,85 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// chainNode returns a node at genesis that produced one block per tx.
+func chainNode(t *testing.T, genesis GenesisConfig, txs ...*Tx) *Node {
+	t.Helper()
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10, Genesis: genesis})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	for i, tx := range txs {
+		if _, err := n.mempool.Add(tx); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+		prevHash, height := n.chainTip()
+		if _, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Duration(i+1)*time.Second)); err != nil {
+			t.Fatalf("produce: %v", err)
+		}
+	}
+	return n
+}
+
+func exportChain(t *testing.T, n *Node) string {
+	t.Helper()
+	var out strings.Builder
+	if err := n.ExportChain(&out); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	return out.String()
+}
+
+func TestImportChainExtendsAndReplaces(t *testing.T) {
+	src := chainNode(t, GenesisConfig{},
+		NewUnsignedTx("alice", "bob", "a", 0, 10, 10),
+		NewUnsignedTx("alice", "bob", "b", 1, 10, 10))
+	export := exportChain(t, src)
+
+	// A node on the same genesis with a block of its own.
+	own := NewUnsignedTx("carol", "bob", "c", 0, 10, 10)
+	dst := chainNode(t, GenesisConfig{}, own)
+
+	out, err := dst.ImportChain(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if out.Imported != 2 || out.Displaced != 1 || out.Reinserted != 1 {
+		t.Fatalf("expected 2 imported, 1 displaced, 1 reinserted, got %+v", out)
+	}
+	if got := exportChain(t, dst); got != export {
+		t.Fatalf("expected the imported chain to match the source")
+	}
+	if _, err := dst.mempool.Get(own.ID); err != nil {
+		t.Fatalf("expected the displaced tx back in the pool: %v", err)
+	}
+
+	// Importing it again changes nothing.
+	if out, err := dst.ImportChain(strings.NewReader(export)); err != nil || out.Imported != 0 {
+		t.Fatalf("expected a no-op re-import, got %+v, %v", out, err)
+	}
+}
+
+func TestImportChainRejectsBadChains(t *testing.T) {
+	src := chainNode(t, GenesisConfig{}, NewUnsignedTx("alice", "bob", "a", 0, 10, 10))
+	export := exportChain(t, src)
+	dst := chainNode(t, GenesisConfig{})
+
+	tampered := strings.Replace(export, `"gasUsed":10`, `"gasUsed":11`, 1)
+	if _, err := dst.ImportChain(strings.NewReader(tampered)); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected ErrInvalidBlock, got %v", err)
+	}
+	foreign := chainNode(t, GenesisConfig{ExtraData: []byte("other")})
+	if _, err := foreign.ImportChain(strings.NewReader(export)); !errors.Is(err, ErrGenesisMismatch) {
+		t.Fatalf("expected ErrGenesisMismatch, got %v", err)
+	}
+	if len(dst.blocks) != 1 || len(foreign.blocks) != 1 {
+		t.Fatalf("expected rejected imports to leave the chains alone")
+	}
+}
@@ -0,0 +1,245 @@
+package mempoor
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+)
+
+// ErrInvariantViolated is wrapped by CheckInvariants for every failure.
+var ErrInvariantViolated = errors.New("mempool: invariant violated")
+
+// Compact rebuilds the heaps and indexes into right-sized storage.
+//
+// Go slices and maps never give memory back on their own: after a burst
+// of churn the heap slices keep their peak capacity and the maps keep
+// their peak bucket count. Compact copies every structure into fresh
+// storage sized for the current contents. Ordering, index entries, and
+// replacement history are preserved exactly; no events are published.
+func (m *mempool) Compact() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.lanes {
+		h := &m.lanes[i]
+		recs := make([]*txRecord, len(h.recs))
+		copy(recs, h.recs)
+		h.recs = recs
+		heap.Init(h) // no-op on a valid heap; repairs an invalid one
+	}
+
+	table := make(map[TxID]*txRecord, len(m.table))
+	for id, rec := range m.table {
+		table[id] = rec
+	}
+	m.table = table
+
+	senders := make(map[string][]*txRecord, len(m.senders))
+	for sender, recs := range m.senders {
+		senders[sender] = append([]*txRecord(nil), recs...)
+	}
+	m.senders = senders
+
+	contents := make(map[string]*txRecord, len(m.contents))
+	for key, rec := range m.contents {
+		contents[key] = rec
+	}
+	m.contents = contents
+
+	orphans := make(map[TxID]*orphan, len(m.orphans))
+	for id, o := range m.orphans {
+		orphans[id] = o
+	}
+	m.orphans = orphans
+
+	nextNonce := make(map[string]uint64, len(m.nextNonce))
+	for sender, n := range m.nextNonce {
+		nextNonce[sender] = n
+	}
+	m.nextNonce = nextNonce
+
+	scheduled := make(map[TxID]*scheduledTx, len(m.scheduled))
+	for id, s := range m.scheduled {
+		scheduled[id] = s
+	}
+	m.scheduled = scheduled
+	m.schedule = append(scheduleQueue(nil), m.schedule...)
+
+	pinned := make(map[TxID]struct{}, len(m.pinned))
+	for id := range m.pinned {
+		pinned[id] = struct{}{}
+	}
+	m.pinned = pinned
+
+	required := make(map[TxID]uint64, len(m.required))
+	for id, seq := range m.required {
+		required[id] = seq
+	}
+	m.required = required
+
+	dependents := make(map[TxID][]*txRecord, len(m.dependents))
+	for id, recs := range m.dependents {
+		dependents[id] = append([]*txRecord(nil), recs...)
+	}
+	m.dependents = dependents
+
+	bundles := make(map[string]*bundle, len(m.bundles))
+	for id, b := range m.bundles {
+		bundles[id] = b
+	}
+	m.bundles = bundles
+}
+
+// CheckInvariants verifies that the heaps, table, and secondary indexes
+// agree with each other. It returns nil for a healthy pool, or an error
+// wrapping ErrInvariantViolated that describes the first problem found.
+//
+// Checked:
+//   - every heap record sits at its recorded index, in its own lane, and
+//     satisfies the heap property against its parent;
+//   - the heaps and the table hold exactly the same records;
+//   - each sender index is nonce-sorted and covers every pending tx;
+//   - the running gas total matches the pending txs;
+//   - content-index entries point at pending txs with matching content;
+//   - no orphan is also pending;
+//   - the activation queue is a valid heap matching its index, with no
+//     scheduled tx also pending;
+//   - every waiting record is pending and waits exactly as often as it
+//     appears in the dependents index;
+//   - each bundle holds exactly its pending members, in BundleIndex
+//     order, keyed by their combined Fee and Gas.
+func (m *mempool) CheckInvariants() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	violated := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvariantViolated, fmt.Sprintf(format, args...))
+	}
+
+	inHeap := 0
+	for i := range m.lanes {
+		h := &m.lanes[i]
+		lane := Lane(i)
+		for j, rec := range h.recs {
+			if rec.index != j {
+				return violated("lane %s: record %s at %d has index %d", lane, rec.tx.ID, j, rec.index)
+			}
+			if rec.tx.Lane != lane {
+				return violated("lane %s: holds tx %s of lane %s", lane, rec.tx.ID, rec.tx.Lane)
+			}
+			if j > 0 && h.Less(j, (j-1)/2) {
+				return violated("lane %s: heap order broken at %d", lane, j)
+			}
+			if m.table[rec.tx.ID] != rec {
+				return violated("lane %s: tx %s missing from table", lane, rec.tx.ID)
+			}
+		}
+		inHeap += h.Len()
+	}
+	if inHeap != len(m.table) {
+		return violated("heaps hold %d records, table holds %d", inHeap, len(m.table))
+	}
+
+	var gas uint64
+	for _, rec := range m.table {
+		gas += rec.tx.Gas
+	}
+	if gas != m.pendingGas {
+		return violated("pending gas is %d, txs sum to %d", m.pendingGas, gas)
+	}
+
+	indexed := 0
+	for sender, recs := range m.senders {
+		if len(recs) == 0 {
+			return violated("sender %s has an empty index entry", sender)
+		}
+		for j, rec := range recs {
+			if rec.tx.Sender != sender {
+				return violated("sender %s indexes tx %s from %s", sender, rec.tx.ID, rec.tx.Sender)
+			}
+			if m.table[rec.tx.ID] != rec {
+				return violated("sender %s indexes non-pending tx %s", sender, rec.tx.ID)
+			}
+			if j > 0 && recs[j-1].tx.Nonce > rec.tx.Nonce {
+				return violated("sender %s index not sorted by nonce", sender)
+			}
+		}
+		indexed += len(recs)
+	}
+	if indexed != len(m.table) {
+		return violated("sender index holds %d records, table holds %d", indexed, len(m.table))
+	}
+
+	for key, rec := range m.contents {
+		if m.table[rec.tx.ID] != rec {
+			return violated("content index points at non-pending tx %s", rec.tx.ID)
+		}
+		if contentKey(rec.tx) != key {
+			return violated("content index key mismatch for tx %s", rec.tx.ID)
+		}
+	}
+
+	for id := range m.orphans {
+		if _, ok := m.table[id]; ok {
+			return violated("tx %s is both orphaned and pending", id)
+		}
+	}
+
+	if len(m.schedule) != len(m.scheduled) {
+		return violated("activation queue holds %d txs, index holds %d", len(m.schedule), len(m.scheduled))
+	}
+	for j, s := range m.schedule {
+		if s.index != j || m.scheduled[s.tx.ID] != s {
+			return violated("scheduled tx %s at %d is mis-indexed", s.tx.ID, j)
+		}
+		if j > 0 && m.schedule.Less(j, (j-1)/2) {
+			return violated("activation queue order broken at %d", j)
+		}
+		if _, ok := m.table[s.tx.ID]; ok {
+			return violated("tx %s is both scheduled and pending", s.tx.ID)
+		}
+	}
+
+	waits := make(map[*txRecord]int)
+	for dep, recs := range m.dependents {
+		for _, rec := range recs {
+			if m.table[rec.tx.ID] != rec {
+				return violated("dependents of %s include non-pending tx %s", dep, rec.tx.ID)
+			}
+			waits[rec]++
+		}
+	}
+	for _, rec := range m.table {
+		if rec.waiting != waits[rec] {
+			return violated("tx %s waits on %d dependencies, index has %d", rec.tx.ID, rec.waiting, waits[rec])
+		}
+	}
+
+	bundled := 0
+	for id, b := range m.bundles {
+		var fee, gas uint64
+		for j, rec := range b.members {
+			if m.table[rec.tx.ID] != rec || rec.bundle != b || rec.tx.BundleID != id {
+				return violated("bundle %s holds a stray tx %s", id, rec.tx.ID)
+			}
+			if j > 0 && b.members[j-1].tx.BundleIndex >= rec.tx.BundleIndex {
+				return violated("bundle %s not sorted by index", id)
+			}
+			fee += rec.tx.Fee
+			gas += rec.tx.Gas
+		}
+		if len(b.members) == 0 || b.key.Fee != fee || b.key.Gas != gas {
+			return violated("bundle %s has a stale key", id)
+		}
+		bundled += len(b.members)
+	}
+	for _, rec := range m.table {
+		if rec.tx.BundleID != "" {
+			bundled--
+		}
+	}
+	if bundled != 0 {
+		return violated("bundle index and pending bundle txs disagree")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCheckInvariants_HealthyAfterChurn(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 50, Dedup: DedupMerge})
+
+	var ids []TxID
+	for i := 0; i < 200; i++ {
+		tx := NewUnsignedTx(fmt.Sprintf("s%d", i%7), "bob", fmt.Sprintf("p%d", i), uint64(i/7), uint64(i%13+1), 10)
+		tx.Lane = Lane(i % int(numLanes))
+		if _, err := mp.Add(tx); err == nil {
+			ids = append(ids, tx.ID)
+		}
+	}
+	for i, id := range ids {
+		if i%3 == 0 {
+			_ = mp.Remove(id)
+		}
+	}
+	mp.SelectTransactions(BlockConstraints{GasLimit: 100, MaxTx: 10})
+
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("unexpected violation: %v", err)
+	}
+}
+
+func TestCompact_PreservesOrderAndContents(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	for i := 0; i < 100; i++ {
+		if _, err := mp.Add(newTx(fmt.Sprintf("s%d", i), uint64(i+1), 10)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	mp.SelectTransactions(BlockConstraints{GasLimit: 1000, MaxTx: 90})
+
+	before, _ := mp.Snapshot()
+	mp.Compact()
+	after, _ := mp.Snapshot()
+
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("unexpected violation after Compact: %v", err)
+	}
+	if len(before) != len(after) {
+		t.Fatalf("expected %d txs after Compact, got %d", len(before), len(after))
+	}
+	for i := range before {
+		if before[i].ID != after[i].ID {
+			t.Fatalf("order changed at %d: %s vs %s", i, before[i].ID, after[i].ID)
+		}
+	}
+
+	m := mp.(*mempool)
+	for _, h := range m.lanes {
+		if cap(h.recs) != len(h.recs) {
+			t.Fatalf("expected right-sized heap, len %d cap %d", len(h.recs), cap(h.recs))
+		}
+	}
+
+	res := mp.SelectTransactions(BlockConstraints{GasLimit: 1000, MaxTx: 1})
+	if len(res.Transactions) != 1 || res.Transactions[0].ID != before[0].ID {
+		t.Fatalf("expected highest-priority tx to be selected after Compact")
+	}
+}
+
+func TestCheckInvariants_DetectsCorruption(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	tx := newTx("alice", 10, 10)
+	if _, err := mp.Add(tx); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	m := mp.(*mempool)
+	delete(m.table, tx.ID)
+
+	if err := mp.CheckInvariants(); !errors.Is(err, ErrInvariantViolated) {
+		t.Fatalf("expected ErrInvariantViolated, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package mempoor
+
+import "container/heap"
+
+// Iterate calls fn for each pending tx in priority order (the order of
+// Policy) until fn returns false. It holds the read lock throughout and
+// does not copy the pool.
+//
+// Iterate semantics:
+//   - The *Tx passed to fn is the live pool entry: fn must not modify
+//     it or retain it after returning.
+//   - fn must not call back into the mempool; write methods would
+//     deadlock on the held read lock.
+//   - Stopping early is cheap: the walk only expands the part of each
+//     heap it has visited.
+//
+// PERF: Yielding k txs costs O(k log k) time and O(k) scratch space,
+// independent of the pool size.
+func (m *mempool) Iterate(fn func(*Tx) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.iterateLocked(fn)
+}
+
+// TopN returns copies of the n highest-priority pending txs, in Policy
+// order, without removing them. n <= 0 returns nil.
+//
+// Unlike PeekTransactions, TopN ignores nonce order, dependencies, and
+// block limits: it answers "who ranks highest", not "what would the next
+// block contain".
+//
+// PERF: O(n log n), independent of the pool size; see Iterate.
+func (m *mempool) TopN(n int) []*Tx {
+	if n <= 0 {
+		return nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]*Tx, 0, min(n, len(m.table)))
+	m.iterateLocked(func(tx *Tx) bool {
+		cp := *tx
+		out = append(out, &cp)
+		return len(out) < n
+	})
+	return out
+}
+
+// iterateLocked walks each lane heap in laneOrder without mutating it.
+// Caller must hold at least the read lock.
+func (m *mempool) iterateLocked(fn func(*Tx) bool) {
+	for _, lane := range laneOrder {
+		h := m.heapOf(lane)
+		if h.Len() == 0 {
+			continue
+		}
+
+		// The frontier holds heap positions whose parents have already
+		// been yielded; its best entry is the next tx in order.
+		w := &heapWalk{h: h, idx: []int{0}}
+		for w.Len() > 0 {
+			i := heap.Pop(w).(int)
+			if !fn(h.recs[i].tx) {
+				return
+			}
+			if l := 2*i + 1; l < h.Len() {
+				heap.Push(w, l)
+			}
+			if r := 2*i + 2; r < h.Len() {
+				heap.Push(w, r)
+			}
+		}
+	}
+}
+
+// heapWalk is a max-heap of positions into a txHeap, used to visit the
+// txHeap in sorted order while leaving it (and each rec.index) intact.
+//
+// A flat walk orders by less instead of the txHeap's own policy. The
+// heap shape says nothing about that order, so a flat walk starts with
+// every position and callers must not expand children.
+type heapWalk struct {
+	h   *txHeap
+	idx []int
+
+	less func(a, b *Tx) bool // flat walks only
+	flat bool
+}
+
+// newHeapWalk starts a walk of h in the order c selects in: arrival
+// order under StrategyFIFO, effective tip under a BaseFee (see
+// feemarket.go), else h's own order.
+func newHeapWalk(h *txHeap, c BlockConstraints) *heapWalk {
+	var less func(a, b *Tx) bool
+	switch {
+	case c.Strategy == StrategyFIFO:
+		less = arrivalOrder
+	case c.BaseFee > 0:
+		less = tipOrder(c.BaseFee, h.policy)
+	default:
+		return &heapWalk{h: h, idx: []int{0}}
+	}
+
+	w := &heapWalk{h: h, idx: make([]int, h.Len()), less: less, flat: true}
+	for i := range w.idx {
+		w.idx[i] = i
+	}
+	heap.Init(w)
+	return w
+}
+
+// arrivalOrder orders by Timestamp ASC, ID ASC.
+func arrivalOrder(a, b *Tx) bool {
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return a.Timestamp.Before(b.Timestamp)
+	}
+	return a.ID < b.ID
+}
+
+func (w heapWalk) Len() int { return len(w.idx) }
+
+func (w heapWalk) Less(i, j int) bool {
+	if w.less != nil {
+		return w.less(w.h.recs[w.idx[i]].key(), w.h.recs[w.idx[j]].key())
+	}
+	return w.h.Less(w.idx[i], w.idx[j])
+}
+
+func (w heapWalk) Swap(i, j int) { w.idx[i], w.idx[j] = w.idx[j], w.idx[i] }
+
+func (w *heapWalk) Push(x any) { w.idx = append(w.idx, x.(int)) }
+
+func (w *heapWalk) Pop() any {
+	n := len(w.idx)
+	i := w.idx[n-1]
+	w.idx = w.idx[:n-1]
+	return i
+}
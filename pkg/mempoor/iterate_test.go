@@ -0,0 +1,79 @@
+package mempoor
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIterate_MatchesSnapshotOrder(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	for i := 0; i < 50; i++ {
+		tx := newTx(fmt.Sprintf("s%d", i), uint64((i*37)%50+1), 10)
+		tx.Lane = Lane(i % int(numLanes))
+		if _, err := mp.Add(tx); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	want, _ := mp.Snapshot()
+	var got []TxID
+	mp.Iterate(func(tx *Tx) bool {
+		got = append(got, tx.ID)
+		return true
+	})
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d txs, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i].ID {
+			t.Fatalf("position %d: expected %s, got %s", i, want[i].ID, got[i])
+		}
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("Iterate must not disturb the heaps: %v", err)
+	}
+}
+
+func TestIterate_StopsEarly(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	for i := 0; i < 10; i++ {
+		if _, err := mp.Add(newTx(fmt.Sprintf("s%d", i), uint64(i+1), 10)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	var fees []uint64
+	mp.Iterate(func(tx *Tx) bool {
+		fees = append(fees, tx.Fee)
+		return len(fees) < 3
+	})
+
+	if len(fees) != 3 || fees[0] != 10 || fees[1] != 9 || fees[2] != 8 {
+		t.Fatalf("expected top 3 fees [10 9 8], got %v", fees)
+	}
+}
+
+func TestTopN(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	for i := 0; i < 20; i++ {
+		_, _ = mp.Add(newTx(fmt.Sprintf("s%d", i), uint64(i+1), 10))
+	}
+
+	top := mp.TopN(3)
+	if len(top) != 3 || top[0].Fee != 20 || top[1].Fee != 19 || top[2].Fee != 18 {
+		t.Fatalf("unexpected top 3: %+v", top)
+	}
+
+	top[0].Fee = 0
+	if got, _ := mp.Get(top[0].ID); got.Fee != 20 {
+		t.Fatalf("TopN must return copies")
+	}
+	if mp.Count() != 20 {
+		t.Fatalf("TopN must not remove txs")
+	}
+	if len(mp.TopN(100)) != 20 || mp.TopN(0) != nil {
+		t.Fatalf("unexpected TopN bounds handling")
+	}
+}
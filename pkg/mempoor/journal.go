@@ -0,0 +1,246 @@
+package mempoor
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Journal files inside NodeConfig.DataDir. journalOldFile holds entries
+// rotated out by a checkpoint that has not finished writing its snapshot.
+const (
+	journalFile    = "mempool.journal"
+	journalOldFile = "mempool.journal.old"
+)
+
+// DefaultJournalCompactEvery is the number of journal entries after which
+// the node folds the journal into a fresh snapshot.
+const DefaultJournalCompactEvery = 10_000
+
+// journalEntry is one line of the journal. Every entry sets the full
+// state of one tx ID, so replaying an entry twice is harmless.
+type journalEntry struct {
+	Op string `json:"op"` // "put" or "del"
+	Tx *Tx    `json:"tx,omitempty"`
+	ID TxID   `json:"id,omitempty"`
+}
+
+// journal is a write-ahead log of pool changes between snapshots. It is
+// installed as the mempool's Observer, so it sees every change in order
+// while the mempool lock is held.
+//
+// Journal semantics:
+//   - Add/Update and scheduling append a "put" of the full tx; Remove,
+//     selection, purge, and eviction append a "del".
+//   - Txs held by an open Reservation are still "put" in the journal, so
+//     a crash mid-block returns them to the pool instead of losing them.
+//   - Write errors cannot fail the mempool operation; the first one is
+//     kept and reported by the next checkpoint.
+//
+// PERF: Each entry is one write(2) under the mempool lock, plus an
+// fsync when sync is set.
+type journal struct {
+	NopObserver
+
+	mu      sync.Mutex
+	dir     string
+	f       *os.File
+	sync    bool
+	entries int
+	err     error
+}
+
+func newJournal(dir string, sync bool) *journal {
+	return &journal{dir: dir, sync: sync}
+}
+
+// open starts appending to the journal file. Entries before open are
+// dropped; the node opens the journal right after replaying it.
+func (j *journal) open() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.MkdirAll(j.dir, 0o755); err != nil {
+		return fmt.Errorf("create data dir: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(j.dir, journalFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open journal: %w", err)
+	}
+	j.f = f
+	return nil
+}
+
+func (j *journal) OnAdd(tx *Tx)      { j.append(journalEntry{Op: "put", Tx: tx}) }
+func (j *journal) OnUpdate(tx *Tx)   { j.append(journalEntry{Op: "put", Tx: tx}) }
+func (j *journal) OnRemove(tx *Tx)   { j.append(journalEntry{Op: "del", ID: tx.ID}) }
+func (j *journal) OnSelect(tx *Tx)   { j.append(journalEntry{Op: "del", ID: tx.ID}) }
+func (j *journal) OnPurge(tx *Tx)    { j.append(journalEntry{Op: "del", ID: tx.ID}) }
+func (j *journal) OnEvict(tx *Tx)    { j.append(journalEntry{Op: "del", ID: tx.ID}) }
+func (j *journal) OnSchedule(tx *Tx) { j.append(journalEntry{Op: "put", Tx: tx}) }
+
+func (j *journal) append(e journalEntry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return
+	}
+
+	raw, err := json.Marshal(e)
+	if err == nil {
+		_, err = j.f.Write(append(raw, '\n'))
+	}
+	if err == nil && j.sync {
+		err = j.f.Sync()
+	}
+	if err != nil {
+		if j.err == nil {
+			j.err = fmt.Errorf("write journal: %w", err)
+		}
+		return
+	}
+	j.entries++
+}
+
+// pending returns the number of entries written since the last rotate.
+func (j *journal) pending() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.entries
+}
+
+// rotate moves the current entries aside into journalOldFile (appending
+// if a previous checkpoint left one behind) and starts an empty journal.
+// Any snapshot taken after rotate covers every rotated entry.
+func (j *journal) rotate() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.err != nil {
+		err := j.err
+		j.err = nil
+		return err
+	}
+	if j.f == nil {
+		return nil
+	}
+
+	cur := filepath.Join(j.dir, journalFile)
+	old := filepath.Join(j.dir, journalOldFile)
+	if err := j.f.Close(); err != nil {
+		return fmt.Errorf("close journal: %w", err)
+	}
+	j.f = nil
+
+	if err := appendFile(old, cur); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(cur, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("open journal: %w", err)
+	}
+	j.f = f
+	j.entries = 0
+	return nil
+}
+
+// dropRotated deletes journalOldFile once a snapshot covers it.
+func (j *journal) dropRotated() error {
+	err := os.Remove(filepath.Join(j.dir, journalOldFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove rotated journal: %w", err)
+	}
+	return nil
+}
+
+func (j *journal) close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return nil
+	}
+	err := j.f.Close()
+	j.f = nil
+	return err
+}
+
+// appendFile appends src to dst (creating dst) and syncs it.
+func appendFile(dst, src string) error {
+	in, err := os.Open(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("rotate journal: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("rotate journal: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("rotate journal: %w", err)
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("rotate journal: %w", err)
+	}
+	return out.Close()
+}
+
+// replayJournal applies the entries in path to txs, keyed by ID. A torn
+// final line (crash mid-write) is ignored; corruption anywhere else is
+// an error. A missing file is not an error.
+func replayJournal(path string, txs map[TxID]*Tx) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read journal: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var torn error
+	for line := 1; sc.Scan(); line++ {
+		if torn != nil {
+			return torn // a bad line that was not the last one
+		}
+
+		var e journalEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			torn = fmt.Errorf("decode journal %s line %d: %w", filepath.Base(path), line, err)
+			continue
+		}
+		switch e.Op {
+		case "put":
+			if e.Tx == nil {
+				return fmt.Errorf("decode journal %s line %d: put without tx", filepath.Base(path), line)
+			}
+			txs[e.Tx.ID] = e.Tx
+		case "del":
+			delete(txs, e.ID)
+		default:
+			return fmt.Errorf("decode journal %s line %d: unknown op %q", filepath.Base(path), line, e.Op)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("read journal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package mempoor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// crashNode opens the journal the way run does, without a snapshot.
+func crashNode(t *testing.T, dir string) *Node {
+	t.Helper()
+	n := NewNode(NodeConfig{DataDir: dir})
+	if err := n.journal.open(); err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	t.Cleanup(func() { _ = n.journal.close() })
+	return n
+}
+
+func TestJournalReplayAfterCrash(t *testing.T) {
+	dir := t.TempDir()
+	n1 := crashNode(t, dir)
+
+	kept := newTx("alice", 10, 10)
+	bumped := newTx("bob", 10, 10)
+	removed := newTx("carol", 10, 10)
+	mined := newTx("dan", 99, 10)
+	for _, tx := range []*Tx{kept, bumped, removed, mined} {
+		if _, err := n1.mempool.Add(tx); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	bump := *bumped
+	bump.Fee = 50
+	if err := n1.mempool.Update(&bump); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	_ = n1.mempool.Remove(removed.ID)
+	n1.mempool.SelectTransactions(BlockConstraints{MaxTx: 1})
+
+	// No storeMempool: the process "crashed".
+	n2 := NewNode(NodeConfig{DataDir: dir})
+	if err := n2.loadMempool(); err != nil {
+		t.Fatalf("loadMempool: %v", err)
+	}
+
+	if got := len(n2.mempool.List()); got != 2 {
+		t.Fatalf("expected 2 txs after replay, got %d", got)
+	}
+	if _, err := n2.mempool.Get(kept.ID); err != nil {
+		t.Fatalf("expected kept tx: %v", err)
+	}
+	got, err := n2.mempool.Get(bumped.ID)
+	if err != nil || got.Fee != 50 {
+		t.Fatalf("expected bumped tx at fee 50, got %+v err=%v", got, err)
+	}
+}
+
+func TestJournalIgnoresTornTail(t *testing.T) {
+	dir := t.TempDir()
+	n1 := crashNode(t, dir)
+	tx := newTx("alice", 10, 10)
+	_, _ = n1.mempool.Add(tx)
+
+	f, err := os.OpenFile(filepath.Join(dir, journalFile), os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	_, _ = f.WriteString(`{"op":"put","tx":{"ID":`)
+	_ = f.Close()
+
+	n2 := NewNode(NodeConfig{DataDir: dir})
+	if err := n2.loadMempool(); err != nil {
+		t.Fatalf("torn tail must be ignored, got %v", err)
+	}
+	if len(n2.mempool.List()) != 1 {
+		t.Fatalf("expected the complete entry to be replayed")
+	}
+}
+
+func TestJournalCheckpointCompacts(t *testing.T) {
+	dir := t.TempDir()
+	n1 := crashNode(t, dir)
+
+	a := newTx("alice", 10, 10)
+	_, _ = n1.mempool.Add(a)
+	if err := n1.checkpoint(); err != nil {
+		t.Fatalf("checkpoint: %v", err)
+	}
+	if n1.journal.pending() != 0 {
+		t.Fatalf("expected empty journal after checkpoint")
+	}
+	if _, err := os.Stat(filepath.Join(dir, journalOldFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected rotated journal removed, got %v", err)
+	}
+
+	b := newTx("bob", 10, 10)
+	_, _ = n1.mempool.Add(b)
+
+	n2 := NewNode(NodeConfig{DataDir: dir})
+	if err := n2.loadMempool(); err != nil {
+		t.Fatalf("loadMempool: %v", err)
+	}
+	if len(n2.mempool.List()) != 2 {
+		t.Fatalf("expected snapshot + journal to yield 2 txs, got %d", len(n2.mempool.List()))
+	}
+}
@@ -0,0 +1,100 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Lane is a coarse priority class layered above the PriorityPolicy.
+// SelectTransactions drains lanes in order urgent → normal → low, so
+// any urgent tx beats any normal tx regardless of fee. The zero value is
+// LaneNormal, so txs that never set a lane behave as before.
+type Lane int
+
+const (
+	LaneNormal Lane = iota
+	LaneUrgent
+	LaneLow
+
+	numLanes = 3
+)
+
+// laneOrder is the order in which lanes are drained for a block.
+var laneOrder = [numLanes]Lane{LaneUrgent, LaneNormal, LaneLow}
+
+// ErrInvalidLane is returned by Add for a Lane outside the known set.
+var ErrInvalidLane = errors.New("mempool: invalid lane")
+
+// rank returns the lane's position in laneOrder (0 = drained first).
+func (l Lane) rank() int {
+	switch l {
+	case LaneUrgent:
+		return 0
+	case LaneNormal:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (l Lane) valid() bool { return l >= 0 && l < numLanes }
+
+func (l Lane) String() string {
+	switch l {
+	case LaneNormal:
+		return "normal"
+	case LaneUrgent:
+		return "urgent"
+	case LaneLow:
+		return "low"
+	default:
+		return fmt.Sprintf("lane(%d)", int(l))
+	}
+}
+
+// ParseLane maps "urgent", "normal", or "low" to a Lane. An empty name
+// means LaneNormal.
+func ParseLane(name string) (Lane, error) {
+	switch name {
+	case "", "normal":
+		return LaneNormal, nil
+	case "urgent":
+		return LaneUrgent, nil
+	case "low":
+		return LaneLow, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLane, name)
+	}
+}
+
+// MarshalText encodes the lane by name so JSON shows "urgent", not 1.
+func (l Lane) MarshalText() ([]byte, error) {
+	if !l.valid() {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLane, int(l))
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText decodes a lane name produced by MarshalText.
+func (l *Lane) UnmarshalText(b []byte) error {
+	lane, err := ParseLane(string(b))
+	if err != nil {
+		return err
+	}
+	*l = lane
+	return nil
+}
+
+// lanePolicy orders by lane first, then by the configured policy. It is
+// the mempool-wide order used for eviction, Snapshot, and listings; each
+// per-lane heap uses the inner policy directly.
+type lanePolicy struct {
+	inner PriorityPolicy
+}
+
+func (p lanePolicy) Less(a, b *Tx) bool {
+	if ra, rb := a.Lane.rank(), b.Lane.rank(); ra != rb {
+		return ra < rb
+	}
+	return p.inner.Less(a, b)
+}
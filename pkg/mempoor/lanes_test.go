@@ -0,0 +1,123 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+)
+
+func laneTx(sender string, lane Lane, fee, gas uint64) *Tx {
+	tx := newTx(sender, fee, gas)
+	tx.Lane = lane
+	return tx
+}
+
+func TestSelectTransactions_DrainsLanesInOrder(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	low := laneTx("alice", LaneLow, 1000, 10)
+	normal := laneTx("bob", LaneNormal, 500, 10)
+	urgent := laneTx("carol", LaneUrgent, 1, 10)
+	for _, tx := range []*Tx{low, normal, urgent} {
+		if _, err := mp.Add(tx); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	res := mp.SelectTransactions(BlockConstraints{GasLimit: 1000, MaxTx: 10})
+	want := []TxID{urgent.ID, normal.ID, low.ID}
+	if len(res.Transactions) != len(want) {
+		t.Fatalf("expected %d txs, got %d", len(want), len(res.Transactions))
+	}
+	for i, id := range want {
+		if res.Transactions[i].ID != id {
+			t.Fatalf("position %d: expected %s, got %s (lane %s)", i, id, res.Transactions[i].ID, res.Transactions[i].Lane)
+		}
+	}
+}
+
+func TestSelectTransactions_LaneGasQuota(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	u1 := laneTx("alice", LaneUrgent, 30, 60)
+	u2 := laneTx("bob", LaneUrgent, 20, 60)
+	n1 := laneTx("carol", LaneNormal, 10, 60)
+	for _, tx := range []*Tx{u1, u2, n1} {
+		if _, err := mp.Add(tx); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	res := mp.SelectTransactions(BlockConstraints{
+		GasLimit:      1000,
+		MaxTx:         10,
+		LaneGasLimits: map[Lane]uint64{LaneUrgent: 100},
+	})
+	if len(res.Transactions) != 2 {
+		t.Fatalf("expected 2 txs, got %d", len(res.Transactions))
+	}
+	if res.Transactions[0].ID != u1.ID || res.Transactions[1].ID != n1.ID {
+		t.Fatalf("expected [u1 n1], got [%s %s]", res.Transactions[0].ID, res.Transactions[1].ID)
+	}
+	if _, err := mp.Get(u2.ID); err != nil {
+		t.Fatalf("over-quota urgent tx should stay pending: %v", err)
+	}
+}
+
+func TestAdd_EvictsFromLowLaneFirst(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 2})
+
+	low := laneTx("alice", LaneLow, 1000, 10)
+	normal := laneTx("bob", LaneNormal, 5, 10)
+	if _, err := mp.Add(low); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := mp.Add(normal); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	evicted, err := mp.Add(laneTx("carol", LaneNormal, 1, 10))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if len(evicted) != 1 || evicted[0] != low.ID {
+		t.Fatalf("expected low-lane tx evicted, got %v", evicted)
+	}
+}
+
+func TestAdd_RejectsInvalidLane(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	if _, err := mp.Add(laneTx("alice", Lane(7), 10, 10)); !errors.Is(err, ErrInvalidLane) {
+		t.Fatalf("expected ErrInvalidLane, got %v", err)
+	}
+}
+
+func TestUpdate_RejectsInvalidLaneBeforeFeeBump(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MinFeeBumpPercent: 10})
+	tx := laneTx("alice", LaneNormal, 10, 10)
+	if _, err := mp.Add(tx); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	// Neither bumped enough nor valid: reported as invalid.
+	bad := *tx
+	bad.Lane = Lane(7)
+	if err := mp.Update(&bad); !errors.Is(err, ErrInvalidLane) {
+		t.Fatalf("expected ErrInvalidLane, got %v", err)
+	}
+}
+
+func TestParseLane(t *testing.T) {
+	for _, name := range []string{"urgent", "normal", "low"} {
+		lane, err := ParseLane(name)
+		if err != nil {
+			t.Fatalf("ParseLane(%q): %v", name, err)
+		}
+		if lane.String() != name {
+			t.Fatalf("round trip: %q -> %q", name, lane.String())
+		}
+	}
+	if _, err := ParseLane("fast"); !errors.Is(err, ErrInvalidLane) {
+		t.Fatalf("expected ErrInvalidLane, got %v", err)
+	}
+}
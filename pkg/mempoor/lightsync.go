@@ -0,0 +1,276 @@
+package mempoor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// MaxHeaderBatch caps how many headers one block.headers call returns,
+// and is the light client's batch size when LightConfig.Batch is 0.
+const MaxHeaderBatch = 500
+
+// Light sync semantics (LightClient, block.headers):
+//   - A full node serves block.headers: from a height, up to limit
+//     blocks (0 or more than MaxHeaderBatch = MaxHeaderBatch) in the
+//     pruned form block.get serves for pruned blocks, i.e. the header,
+//     signature, and a stub per tx with only its ID and Gas. That is
+//     all a block's hash and Validate need, so headers verify without
+//     any payloads.
+//   - A LightClient syncs from one peer, in batches, from genesis to the
+//     peer's tip. Each header is checked with Validate, in pruned form,
+//     against the one before it (height, PrevHash, timestamp, ChainID,
+//     tx count, gas, fee summary, and signature, if any; stubs carry no
+//     fees, so Burned and Tipped are taken as hashed) and kept as a
+//     LightHeader:
+//     the header, its hash, and its TxRoot. The tx stubs are dropped
+//     once the next header has linked to them.
+//   - The genesis header must carry LightConfig.ChainID and, if set,
+//     hash to GenesisHash; with RequireSigned every later header must
+//     carry a signature.
+//   - A header that fails any check stops the sync with the error;
+//     nothing from that header on is kept.
+//
+// NOTE: A light client does not follow reorgs: once the peer's chain
+// no longer links to the synced tip, every sync fails with
+// ErrInvalidBlock until the client is started afresh.
+
+// LightHeader is a verified block header kept by a LightClient.
+type LightHeader struct {
+	Header BlockHeader
+	Hash   [32]byte
+	TxRoot [32]byte          // see TxRoot
+	Signer ed25519.PublicKey // nil for an unsigned block
+}
+
+// TxRoot commits to a block's tx IDs, in order, so a light client can
+// check a list of IDs claimed for a block against its header.
+func TxRoot(b *Block) [32]byte {
+	h := sha256.New()
+	for _, tx := range b.Transactions {
+		h.Write([]byte(tx.ID))
+		h.Write([]byte{'\n'})
+	}
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// LightConfig configures a LightClient.
+type LightConfig struct {
+	// Peer is the RPC address (host:port) of the full node to sync from.
+	Peer string
+
+	// ChainID must match the peer's genesis header. GenesisHash, if not
+	// zero, pins the genesis block too.
+	ChainID     string
+	GenesisHash [32]byte
+
+	// RequireSigned rejects headers above genesis without a signature.
+	RequireSigned bool
+
+	// Interval is how often Run syncs; 0 = DefaultPeerInterval. Batch is
+	// how many headers each call asks for; 0 = MaxHeaderBatch.
+	Interval time.Duration
+	Batch    int
+
+	// Logger receives the client's logs. nil = text logs on stdout.
+	Logger *slog.Logger
+}
+
+// LightClient syncs and verifies block headers only, for lightweight
+// verification built on this package; see "Light sync semantics".
+type LightClient struct {
+	cfg LightConfig
+	log *slog.Logger
+
+	mu      sync.RWMutex
+	headers []LightHeader
+	byHash  map[[32]byte]uint64
+	last    *Block // the tip in stub form, for linking the next header
+}
+
+// NewLightClient returns a LightClient with no headers synced yet.
+func NewLightClient(cfg LightConfig) *LightClient {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultPeerInterval
+	}
+	if cfg.Batch <= 0 || cfg.Batch > MaxHeaderBatch {
+		cfg.Batch = MaxHeaderBatch
+	}
+	log := cfg.Logger
+	if log == nil {
+		log = NewLogger(os.Stdout, LogText, slog.LevelInfo)
+	}
+	return &LightClient{
+		cfg:    cfg,
+		log:    log.With("component", "light"),
+		byHash: make(map[[32]byte]uint64),
+	}
+}
+
+// Sync fetches and verifies every header the peer has beyond the
+// client's tip, and returns how many it added.
+func (c *LightClient) Sync(ctx context.Context) (int, error) {
+	synced := 0
+	for {
+		var res headersResult
+		p := headersParams{From: c.next(), Limit: c.cfg.Batch}
+		if err := postRPC(ctx, c.cfg.Peer, "block.headers", p, &res); err != nil {
+			return synced, err
+		}
+		for i, d := range res.Headers {
+			b, err := parseBlockDTO(d)
+			if err == nil {
+				err = c.accept(b)
+			}
+			if err != nil {
+				return synced, fmt.Errorf("header %d: %w", p.From+uint64(i), err)
+			}
+			synced++
+		}
+		if len(res.Headers) < p.Limit {
+			return synced, nil
+		}
+	}
+}
+
+// Run syncs every Interval until ctx ends.
+func (c *LightClient) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		synced, err := c.Sync(ctx)
+		if synced > 0 {
+			tip, _ := c.Tip()
+			c.log.Info("headers synced", "headers", synced, "height", tip.Header.Height,
+				"hash", fmt.Sprintf("%x", tip.Hash))
+		}
+		if err != nil && ctx.Err() == nil {
+			c.log.Error("header sync failed", "peer", c.cfg.Peer, "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// next returns the height of the next header to fetch.
+func (c *LightClient) next() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return uint64(len(c.headers))
+}
+
+// accept verifies b against the tip and keeps its header.
+func (c *LightClient) accept(b *Block) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if b.Header.Height != uint64(len(c.headers)) {
+		return fmt.Errorf("%w: got height %d, want %d", ErrInvalidBlock, b.Header.Height, len(c.headers))
+	}
+	stub := pruneBlock(b)
+	if err := Validate(stub, c.last, 0); err != nil {
+		return err
+	}
+	hash := b.Hash()
+	if c.last == nil {
+		if b.Header.ChainID != c.cfg.ChainID {
+			return fmt.Errorf("%w: peer's chain is %q, want %q", ErrGenesisMismatch, b.Header.ChainID, c.cfg.ChainID)
+		}
+		if c.cfg.GenesisHash != ([32]byte{}) && hash != c.cfg.GenesisHash {
+			return fmt.Errorf("%w: peer's genesis is %x", ErrGenesisMismatch, hash)
+		}
+	} else if c.cfg.RequireSigned && len(b.Signature) == 0 {
+		return fmt.Errorf("%w: block %d is unsigned", ErrBadSignature, b.Header.Height)
+	}
+
+	c.headers = append(c.headers, LightHeader{
+		Header: b.Header,
+		Hash:   hash,
+		TxRoot: TxRoot(b),
+		Signer: ed25519.PublicKey(b.Signer),
+	})
+	c.byHash[hash] = b.Header.Height
+	c.last = stub
+	return nil
+}
+
+// Tip returns the last synced header; false before genesis is synced.
+func (c *LightClient) Tip() (LightHeader, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.headers) == 0 {
+		return LightHeader{}, false
+	}
+	return c.headers[len(c.headers)-1], true
+}
+
+// Header returns the synced header at height.
+func (c *LightClient) Header(height uint64) (LightHeader, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if height >= uint64(len(c.headers)) {
+		return LightHeader{}, false
+	}
+	return c.headers[height], true
+}
+
+// HeaderByHash returns the synced header with hash.
+func (c *LightClient) HeaderByHash(hash [32]byte) (LightHeader, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	height, ok := c.byHash[hash]
+	if !ok {
+		return LightHeader{}, false
+	}
+	return c.headers[height], true
+}
+
+type headersParams struct {
+	From  uint64 `json:"from"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+type headersResult struct {
+	Headers []blockDTO `json:"headers"`
+}
+
+// rpcBlockHeaders serves headers for light clients; see "Light sync
+// semantics".
+func (n *Node) rpcBlockHeaders(w http.ResponseWriter, params json.RawMessage) {
+	var p headersParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			writeRPCError(w, http.StatusBadRequest, "invalid params for block.headers")
+			return
+		}
+	}
+	if p.Limit <= 0 || p.Limit > MaxHeaderBatch {
+		p.Limit = MaxHeaderBatch
+	}
+
+	n.blocksMu.RLock()
+	var chain []*Block
+	if p.From < uint64(len(n.blocks)) {
+		chain = n.blocks[p.From:min(uint64(len(n.blocks)), p.From+uint64(p.Limit))]
+	}
+	n.blocksMu.RUnlock()
+
+	out := headersResult{Headers: make([]blockDTO, 0, len(chain))}
+	for _, b := range chain {
+		out.Headers = append(out.Headers, makeBlockDTO(pruneBlock(b)))
+	}
+	writeRPCResult(w, http.StatusOK, out)
+}
@@ -0,0 +1,116 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newLightPeer returns a node with three blocks above genesis, served
+// over RPC at the returned address.
+func newLightPeer(t *testing.T) (*Node, string) {
+	t.Helper()
+	n := NewNode(NodeConfig{MaxTxPerBlock: 1, BlockInterval: time.Second, ChainID: "light-1"})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	for _, sender := range []string{"alice", "bob", "carol"} {
+		tx := newTx(sender, 5, 10)
+		tx.ChainID = "light-1"
+		if _, err := n.mempool.Add(rehash(tx)); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	if blocks, err := n.Mine(context.Background(), 3); err != nil || len(blocks) != 3 {
+		t.Fatalf("mine: %d blocks, %v", len(blocks), err)
+	}
+	return n, servePeer(t, n)
+}
+
+func newTestLightClient(cfg LightConfig) *LightClient {
+	cfg.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewLightClient(cfg)
+}
+
+func TestLightClientSyncsHeaders(t *testing.T) {
+	n, addr := newLightPeer(t)
+
+	c := newTestLightClient(LightConfig{Peer: addr, ChainID: "light-1", GenesisHash: n.blocks[0].Hash(), RequireSigned: true, Batch: 2})
+	synced, err := c.Sync(context.Background())
+	if err != nil || synced != 4 {
+		t.Fatalf("expected 4 headers synced, got %d, %v", synced, err)
+	}
+	tip, ok := c.Tip()
+	if !ok || tip.Header.Height != 3 || tip.Hash != n.blocks[3].Hash() {
+		t.Fatalf("expected the peer's tip, got %+v", tip)
+	}
+	h, ok := c.HeaderByHash(n.blocks[2].Hash())
+	if !ok || h.Header.Height != 2 || h.TxRoot != TxRoot(n.blocks[2]) || len(h.Signer) == 0 {
+		t.Fatalf("expected header 2 with its tx root and signer, got %+v", h)
+	}
+
+	if synced, err := c.Sync(context.Background()); err != nil || synced != 0 {
+		t.Fatalf("expected nothing new, got %d, %v", synced, err)
+	}
+}
+
+func TestLightClientRejectsForeignChain(t *testing.T) {
+	_, addr := newLightPeer(t)
+
+	c := newTestLightClient(LightConfig{Peer: addr, ChainID: "other"})
+	if _, err := c.Sync(context.Background()); !errors.Is(err, ErrGenesisMismatch) {
+		t.Fatalf("expected ErrGenesisMismatch, got %v", err)
+	}
+	if _, ok := c.Tip(); ok {
+		t.Fatalf("expected no headers kept")
+	}
+}
+
+func TestLightClientRejectsTamperedHeader(t *testing.T) {
+	n, _ := newLightPeer(t)
+
+	// A peer that rewrites block 2's proposer: its signature no longer
+	// verifies.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		n.handleRPC(rec, r)
+		body := strings.Replace(rec.Body.String(), `"height":2,`, `"height":2,"proposer":"evil",`, 1)
+		_, _ = io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	c := newTestLightClient(LightConfig{Peer: strings.TrimPrefix(srv.URL, "http://"), ChainID: "light-1"})
+	synced, err := c.Sync(context.Background())
+	if !errors.Is(err, ErrInvalidBlock) || synced != 2 {
+		t.Fatalf("expected ErrInvalidBlock after 2 headers, got %d, %v", synced, err)
+	}
+	if tip, _ := c.Tip(); tip.Header.Height != 1 {
+		t.Fatalf("expected the tip to stay at 1, got %d", tip.Header.Height)
+	}
+}
+
+func TestBlockHeadersRPC(t *testing.T) {
+	n, _ := newLightPeer(t)
+
+	rec := callRPC(n, `{"method":"block.headers","params":{"from":1,"limit":2}}`)
+	var resp struct {
+		Result headersResult `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	got := resp.Result.Headers
+	if len(got) != 2 || got[0].Height != 1 || got[1].Height != 2 {
+		t.Fatalf("expected headers 1 and 2, got %+v", got)
+	}
+	if tx := got[0].Txs[0]; tx.ID == "" || tx.Sender != "" || !got[0].Pruned {
+		t.Fatalf("expected a tx stub with only its ID, got %+v", tx)
+	}
+}
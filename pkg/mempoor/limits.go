@@ -0,0 +1,178 @@
+package mempoor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrInvalidLimits is returned by SetLimits for limits no block could be
+// built under.
+var ErrInvalidLimits = errors.New("blockbuilder: invalid block limits")
+
+// BlockLimits are the block limits that can change while a node runs.
+//
+// Reload semantics (admin.setBlockLimits, SIGHUP with LimitsFile):
+//   - An update names any subset of the limits; the rest are kept. It is
+//     applied atomically: a build sees either all old or all new limits.
+//   - A build reads the limits when it starts, so an update takes effect
+//     at the next tick; a build in progress finishes under the old ones.
+//   - Every applied update bumps the node's limits version, starting
+//     from 1 for the configured limits, and is logged with its source.
+//   - The node's fill trigger and block.propose checks follow the
+//     update. A custom Builder follows it only if it has a SetLimits
+//     method like BlockBuilder's.
+type BlockLimits struct {
+	GasLimit      uint64 `json:"gasLimit"` // 0 = unlimited
+	MaxTxPerBlock int    `json:"maxTxPerBlock"`
+	MinFee        uint64 `json:"minFee"`
+}
+
+// check validates l.
+func (l BlockLimits) check() error {
+	if l.MaxTxPerBlock <= 0 {
+		return fmt.Errorf("%w: maxTxPerBlock must be positive, got %d", ErrInvalidLimits, l.MaxTxPerBlock)
+	}
+	return nil
+}
+
+// Limits returns the builder's current limits.
+func (b *BlockBuilder) Limits() BlockLimits {
+	b.limitsMu.RLock()
+	defer b.limitsMu.RUnlock()
+
+	return BlockLimits{GasLimit: b.cfg.GasLimit, MaxTxPerBlock: b.cfg.MaxTxPerBlock, MinFee: b.cfg.MinFee}
+}
+
+// SetLimits replaces the builder's GasLimit, MaxTxPerBlock, and MinFee
+// for every build that starts afterwards. See "Reload semantics".
+func (b *BlockBuilder) SetLimits(l BlockLimits) error {
+	if err := l.check(); err != nil {
+		return err
+	}
+
+	b.limitsMu.Lock()
+	defer b.limitsMu.Unlock()
+
+	b.cfg.GasLimit, b.cfg.MaxTxPerBlock, b.cfg.MinFee = l.GasLimit, l.MaxTxPerBlock, l.MinFee
+	return nil
+}
+
+// limitsUpdate is a partial BlockLimits; nil fields are kept.
+type limitsUpdate struct {
+	GasLimit      *uint64 `json:"gasLimit"`
+	MaxTxPerBlock *int    `json:"maxTxPerBlock"`
+	MinFee        *uint64 `json:"minFee"`
+
+	// Interval replaces the block interval; only the config file sets
+	// it. See config.go.
+	Interval *time.Duration `json:"-"`
+}
+
+// apply returns l with u's fields replaced.
+func (u limitsUpdate) apply(l BlockLimits) BlockLimits {
+	if u.GasLimit != nil {
+		l.GasLimit = *u.GasLimit
+	}
+	if u.MaxTxPerBlock != nil {
+		l.MaxTxPerBlock = *u.MaxTxPerBlock
+	}
+	if u.MinFee != nil {
+		l.MinFee = *u.MinFee
+	}
+	return l
+}
+
+// liveLimits holds a node's current block limits and interval, and
+// their version.
+type liveLimits struct {
+	mu       sync.RWMutex
+	limits   BlockLimits
+	interval time.Duration
+	version  uint64
+
+	// retick wakes the block loop to restart its ticker after an
+	// interval change.
+	retick chan struct{}
+}
+
+func newLiveLimits(cfg NodeConfig) *liveLimits {
+	return &liveLimits{
+		limits:   BlockLimits{GasLimit: cfg.GasLimit, MaxTxPerBlock: cfg.MaxTxPerBlock, MinFee: cfg.MinFee},
+		interval: cfg.BlockInterval,
+		version:  1,
+		retick:   make(chan struct{}, 1),
+	}
+}
+
+// blockInterval returns the current block interval.
+func (l *liveLimits) blockInterval() time.Duration {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.interval
+}
+
+func (l *liveLimits) get() (BlockLimits, uint64) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.limits, l.version
+}
+
+// limitSetter is implemented by Builders whose limits can be reloaded.
+type limitSetter interface {
+	SetLimits(BlockLimits) error
+}
+
+// setLimits applies u on top of the node's current limits, to the
+// builder first, and logs the change with source. See "Reload
+// semantics".
+func (n *Node) setLimits(u limitsUpdate, source string) (BlockLimits, uint64, error) {
+	n.limits.mu.Lock()
+	defer n.limits.mu.Unlock()
+
+	next := u.apply(n.limits.limits)
+	if err := next.check(); err != nil {
+		return n.limits.limits, n.limits.version, err
+	}
+	if u.Interval != nil && *u.Interval <= 0 {
+		return n.limits.limits, n.limits.version, fmt.Errorf("%w: blockInterval must be positive, got %s", ErrInvalidLimits, *u.Interval)
+	}
+	if s, ok := n.builder.(limitSetter); ok {
+		if err := s.SetLimits(next); err != nil {
+			return n.limits.limits, n.limits.version, err
+		}
+	}
+	n.limits.limits = next
+	n.limits.version++
+	if u.Interval != nil && *u.Interval != n.limits.interval {
+		n.limits.interval = *u.Interval
+		select {
+		case n.limits.retick <- struct{}{}:
+		default:
+		}
+	}
+
+	n.log.builder.Info("limits updated", "version", n.limits.version, "gasLimit", next.GasLimit,
+		"maxTxPerBlock", next.MaxTxPerBlock, "minFee", next.MinFee, "blockInterval", n.limits.interval, "source", source)
+	return next, n.limits.version, nil
+}
+
+// reloadLimitsFile applies NodeConfig.LimitsFile, a JSON object with any
+// of BlockLimits' fields.
+func (n *Node) reloadLimitsFile() error {
+	raw, err := os.ReadFile(n.cfg.LimitsFile)
+	if err != nil {
+		return fmt.Errorf("read limits file: %w", err)
+	}
+	var u limitsUpdate
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return fmt.Errorf("decode limits file: %w", err)
+	}
+	_, _, err = n.setLimits(u, "sighup")
+	return err
+}
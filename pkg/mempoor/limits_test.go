@@ -0,0 +1,64 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSetLimitsAppliesToNextBuild(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	for _, s := range []string{"a", "b", "c"} {
+		_, _ = mp.Add(newTx(s, 5, 10))
+	}
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{MaxTxPerBlock: 1})
+	if err := builder.SetLimits(BlockLimits{MaxTxPerBlock: 0}); !errors.Is(err, ErrInvalidLimits) {
+		t.Fatalf("expected ErrInvalidLimits, got %v", err)
+	}
+	if err := builder.SetLimits(BlockLimits{GasLimit: 20, MaxTxPerBlock: 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	blk, err := builder.BuildBlock(context.Background(), [32]byte{}, 0, time.Unix(1, 0).UTC())
+	if err != nil || len(blk.Transactions) != 2 {
+		t.Fatalf("expected 2 txs under the new gas limit, got %v, %v", blk, err)
+	}
+	if l := builder.Limits(); l.GasLimit != 20 || l.MaxTxPerBlock != 10 {
+		t.Fatalf("unexpected limits %+v", l)
+	}
+}
+
+func TestSetBlockLimitsRPC(t *testing.T) {
+	n := NewNode(NodeConfig{GasLimit: 100, MaxTxPerBlock: 5, MinFee: 1, AdminToken: "s3cret"})
+
+	rec := callRPCWithToken(n, `{"method":"admin.setBlockLimits","params":{"minFee":7}}`, "s3cret")
+	var resp struct {
+		Result blockLimitsResult `json:"result"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := BlockLimits{GasLimit: 100, MaxTxPerBlock: 5, MinFee: 7}
+	if resp.Result.Limits != want || resp.Result.Version != 2 {
+		t.Fatalf("expected %+v at version 2, got %+v", want, resp.Result)
+	}
+	if got := n.builder.(*BlockBuilder).Limits(); got != want {
+		t.Fatalf("expected the builder updated, got %+v", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "limits.json")
+	if err := os.WriteFile(path, []byte(`{"maxTxPerBlock": 0}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	n.cfg.LimitsFile = path
+	if err := n.reloadLimitsFile(); !errors.Is(err, ErrInvalidLimits) {
+		t.Fatalf("expected ErrInvalidLimits from the file, got %v", err)
+	}
+	if l, v := n.limits.get(); l != want || v != 2 {
+		t.Fatalf("a rejected update must change nothing, got %+v at version %d", l, v)
+	}
+}
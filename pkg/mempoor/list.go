@@ -0,0 +1,182 @@
+package mempoor
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SortOrder selects the ordering used by ListPage.
+type SortOrder int
+
+const (
+	SortByPriority SortOrder = iota // block inclusion order, per the PriorityPolicy
+	SortByFee                       // Fee DESC
+	SortByAge                       // CreatedAt ASC (oldest first)
+	SortBySender                    // Sender ASC, Nonce ASC
+)
+
+func (o SortOrder) String() string {
+	switch o {
+	case SortByPriority:
+		return "priority"
+	case SortByFee:
+		return "fee"
+	case SortByAge:
+		return "age"
+	case SortBySender:
+		return "sender"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSortOrder maps a name accepted by tx.list ("priority", "fee",
+// "age", "sender") to a SortOrder. An empty name means SortByPriority.
+func ParseSortOrder(name string) (SortOrder, error) {
+	switch name {
+	case "", "priority":
+		return SortByPriority, nil
+	case "fee":
+		return SortByFee, nil
+	case "age":
+		return SortByAge, nil
+	case "sender":
+		return SortBySender, nil
+	default:
+		return 0, fmt.Errorf("mempool: unknown sort order %q", name)
+	}
+}
+
+// less reports whether a sorts before b under o. SortByPriority defers
+// to policy; every other order falls back to TxID so pages are stable
+// across calls.
+func (o SortOrder) less(policy PriorityPolicy, a, b *Tx) bool {
+	switch o {
+	case SortByFee:
+		if a.Fee != b.Fee {
+			return a.Fee > b.Fee
+		}
+	case SortByAge:
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	case SortBySender:
+		if a.Sender != b.Sender {
+			return a.Sender < b.Sender
+		}
+		if a.Nonce != b.Nonce {
+			return a.Nonce < b.Nonce
+		}
+	default:
+		return policy.Less(a, b)
+	}
+	return a.ID < b.ID
+}
+
+// ListPage returns up to limit txs starting at offset under the given
+// order, plus the total number of pending txs. limit <= 0 returns
+// everything from offset on; an offset past the end yields an empty page.
+//
+// PERF: SortByPriority walks the heaps and only copies the page,
+// O((offset+limit) log n). Other orders sort a copy of the pool,
+// O(n log n); fine for CLI and dashboards.
+func (m *mempool) ListPage(offset, limit int, order SortOrder) ([]*Tx, int) {
+	if order != SortByPriority {
+		return pageTxs(m.List(), offset, limit, order, m.order)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if offset < 0 {
+		offset = 0
+	}
+	out := make([]*Tx, 0)
+	pos := 0
+	m.iterateLocked(func(tx *Tx) bool {
+		if limit > 0 && len(out) >= limit {
+			return false
+		}
+		if pos >= offset {
+			out = append(out, tx)
+		}
+		pos++
+		return true
+	})
+	return out, len(m.table)
+}
+
+// pageTxs sorts txs in place under order and returns the requested
+// window along with len(txs).
+func pageTxs(txs []*Tx, offset, limit int, order SortOrder, policy PriorityPolicy) ([]*Tx, int) {
+	total := len(txs)
+
+	sort.Slice(txs, func(i, j int) bool {
+		return order.less(policy, txs[i], txs[j])
+	})
+
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= total {
+		return []*Tx{}, total
+	}
+
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+	return txs[offset:end], total
+}
+
+// TxFilter narrows a listing. Zero-valued fields do not filter.
+type TxFilter struct {
+	Sender    string
+	Recipient string
+	MinFee    uint64
+	MaxFee    uint64 // 0 = no upper bound
+	MinGas    uint64
+}
+
+// Match reports whether tx satisfies every set field of f.
+func (f TxFilter) Match(tx *Tx) bool {
+	switch {
+	case f.Sender != "" && tx.Sender != f.Sender:
+		return false
+	case f.Recipient != "" && tx.Recipient != f.Recipient:
+		return false
+	case tx.Fee < f.MinFee:
+		return false
+	case f.MaxFee > 0 && tx.Fee > f.MaxFee:
+		return false
+	case tx.Gas < f.MinGas:
+		return false
+	}
+	return true
+}
+
+// ListFilter returns the pending txs matching f in no particular order.
+//
+// PERF: With Sender set, only that sender's txs are scanned via the
+// sender index, O(k). Otherwise O(n) over the table under a read lock;
+// only matches are copied out.
+func (m *mempool) ListFilter(f TxFilter) []*Tx {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]*Tx, 0)
+	if f.Sender != "" {
+		for _, rec := range m.senders[f.Sender] {
+			if f.Match(rec.tx) {
+				out = append(out, rec.tx)
+			}
+		}
+		return out
+	}
+	for _, rec := range m.table {
+		if f.Match(rec.tx) {
+			out = append(out, rec.tx)
+		}
+	}
+	return out
+}
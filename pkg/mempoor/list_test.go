@@ -0,0 +1,127 @@
+package mempoor
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestListPageOrdersAndPages(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	base := time.Now().UTC()
+	mk := func(sender string, fee uint64, age time.Duration) *Tx {
+		tx := newTx(sender, fee, 10)
+		tx.CreatedAt = base.Add(-age)
+		return tx
+	}
+
+	a := mk("carol", 10, 3*time.Minute)
+	b := mk("alice", 30, 1*time.Minute)
+	c := mk("bob", 20, 2*time.Minute)
+	for _, tx := range []*Tx{a, b, c} {
+		_, _ = mp.Add(tx)
+	}
+
+	cases := []struct {
+		order SortOrder
+		want  []TxID
+	}{
+		{SortByPriority, []TxID{b.ID, c.ID, a.ID}},
+		{SortByFee, []TxID{b.ID, c.ID, a.ID}},
+		{SortByAge, []TxID{a.ID, c.ID, b.ID}},
+		{SortBySender, []TxID{b.ID, c.ID, a.ID}},
+	}
+
+	for _, tc := range cases {
+		page, total := mp.ListPage(0, 0, tc.order)
+		if total != 3 || len(page) != 3 {
+			t.Fatalf("%s: expected 3/3, got %d/%d", tc.order, len(page), total)
+		}
+		for i, id := range tc.want {
+			if page[i].ID != id {
+				t.Fatalf("%s: position %d expected %s, got %s", tc.order, i, id, page[i].ID)
+			}
+		}
+	}
+
+	page, total := mp.ListPage(1, 1, SortByAge)
+	if total != 3 || len(page) != 1 || page[0].ID != c.ID {
+		t.Fatalf("expected second-oldest tx on page (1,1); got %+v total=%d", page, total)
+	}
+
+	page, total = mp.ListPage(5, 10, SortByFee)
+	if total != 3 || len(page) != 0 {
+		t.Fatalf("expected empty page past end; got %d total=%d", len(page), total)
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	for _, name := range []string{"", "priority", "fee", "age", "sender"} {
+		if _, err := ParseSortOrder(name); err != nil {
+			t.Fatalf("unexpected error for %q: %v", name, err)
+		}
+	}
+	if _, err := ParseSortOrder("bogus"); err == nil {
+		t.Fatalf("expected error for unknown sort order")
+	}
+}
+
+func TestListFilter(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	a := NewUnsignedTx("alice", "bob", "a", 0, 5, 100)
+	b := NewUnsignedTx("alice", "dan", "b", 0, 50, 10)
+	c := NewUnsignedTx("carol", "bob", "c", 0, 500, 100)
+	for _, tx := range []*Tx{a, b, c} {
+		_, _ = mp.Add(tx)
+	}
+
+	cases := []struct {
+		name string
+		f    TxFilter
+		want int
+	}{
+		{"none", TxFilter{}, 3},
+		{"sender", TxFilter{Sender: "alice"}, 2},
+		{"recipient", TxFilter{Recipient: "bob"}, 2},
+		{"fee range", TxFilter{MinFee: 10, MaxFee: 100}, 1},
+		{"min gas", TxFilter{MinGas: 50}, 2},
+		{"combined", TxFilter{Sender: "alice", MinGas: 50}, 1},
+		{"no match", TxFilter{Sender: "nobody"}, 0},
+	}
+
+	for _, tc := range cases {
+		if got := mp.ListFilter(tc.f); len(got) != tc.want {
+			t.Fatalf("%s: expected %d matches, got %d", tc.name, tc.want, len(got))
+		}
+	}
+}
+
+func TestTxListPagesFilteredTxs(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	for fee := uint64(1); fee <= 5; fee++ {
+		_, _ = n.mempool.Add(newTx("alice", fee*10, 1))
+	}
+	_, _ = n.mempool.Add(newTx("carol", 100, 1))
+
+	rec := callRPC(n, `{"method":"tx.list","params":{"sender":"alice","minFee":20,"sort":"fee","offset":1,"limit":2}}`)
+	var body struct {
+		Result listTxResult `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("list: %d %s", rec.Code, rec.Body)
+	}
+	got := body.Result
+	if got.Total != 4 || len(got.Transactions) != 2 {
+		t.Fatalf("expected 2 of 4 matching txs, got %d of %d", len(got.Transactions), got.Total)
+	}
+	if got.Transactions[0].Fee != 40 || got.Transactions[1].Fee != 30 {
+		t.Fatalf("expected the second and third highest fees, got %d and %d", got.Transactions[0].Fee, got.Transactions[1].Fee)
+	}
+
+	if rec = callRPC(n, `{"method":"tx.list","params":{"sort":"bogus"}}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown sort, got %d", rec.Code)
+	}
+}
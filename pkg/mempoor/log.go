@@ -0,0 +1,118 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// ErrUnknownLogFormat is returned by ParseLogFormat for a name it does
+// not know.
+var ErrUnknownLogFormat = errors.New("blockbuilder: unknown log format")
+
+// LogFormat selects how the node writes its logs.
+//
+// Logging semantics (NodeConfig.Logger, LogFormat, LogLevel):
+//   - The node logs through log/slog, to stdout unless a Logger is
+//     given. Every record carries a "component" attribute: "node"
+//     (lifecycle and config), "rpc", "mempool", "builder", or "chain".
+//   - Records are messages plus attributes, never preformatted lines:
+//     a produced block is "block produced" with height, txs, gasUsed,
+//     fees, hash, prevHash, time, and proposer.
+//   - Errors the node recovers from are logged at Error, chain and
+//     limit changes at Info, and each RPC call at Debug.
+type LogFormat int
+
+const (
+	// LogText writes slog's key=value text. Default.
+	LogText LogFormat = iota
+
+	// LogJSON writes one JSON object per record.
+	LogJSON
+)
+
+func (f LogFormat) String() string {
+	switch f {
+	case LogText:
+		return "text"
+	case LogJSON:
+		return "json"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLogFormat maps a format name (as used by the CLI) to a
+// LogFormat. "" means LogText.
+func ParseLogFormat(name string) (LogFormat, error) {
+	switch name {
+	case "", "text":
+		return LogText, nil
+	case "json":
+		return LogJSON, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownLogFormat, name)
+	}
+}
+
+// ParseLogLevel maps a level name (debug, info, warn, error, any case)
+// to a slog.Level. "" means info.
+func ParseLogLevel(name string) (slog.Level, error) {
+	if name == "" {
+		return slog.LevelInfo, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToLower(name))); err != nil {
+		return 0, fmt.Errorf("blockbuilder: unknown log level %q", name)
+	}
+	return level, nil
+}
+
+// NewLogger returns a logger writing records at level and above to w in
+// format.
+func NewLogger(w io.Writer, format LogFormat, level slog.Level) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == LogJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// nodeLoggers are the node's per-component loggers.
+type nodeLoggers struct {
+	node, rpc, mempool, builder, chain *slog.Logger
+}
+
+// newNodeLoggers derives the component loggers from cfg.Logger, or
+// from LogFormat and LogLevel on stdout without one.
+func newNodeLoggers(cfg NodeConfig) nodeLoggers {
+	base := cfg.Logger
+	if base == nil {
+		base = NewLogger(os.Stdout, cfg.LogFormat, cfg.LogLevel)
+	}
+	component := func(name string) *slog.Logger { return base.With("component", name) }
+	return nodeLoggers{
+		node:    component("node"),
+		rpc:     component("rpc"),
+		mempool: component("mempool"),
+		builder: component("builder"),
+		chain:   component("chain"),
+	}
+}
+
+// logBlock logs a block the node produced.
+func (l nodeLoggers) logBlock(msg string, b *Block) {
+	l.chain.Info(msg,
+		"height", b.Header.Height,
+		"txs", b.Header.TxCount,
+		"gasUsed", b.Header.GasUsed,
+		"fees", b.Header.TotalFees,
+		"hash", fmt.Sprintf("%x", b.Hash()),
+		"prevHash", fmt.Sprintf("%x", b.Header.PrevHash),
+		"time", b.Header.Timestamp,
+		"proposer", b.Header.Proposer,
+	)
+}
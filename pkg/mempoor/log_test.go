@@ -0,0 +1,66 @@
+package mempoor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestNodeLogsStructuredRecords(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewNode(NodeConfig{
+		MaxTxPerBlock: 10,
+		BlockInterval: time.Second,
+		Logger:        NewLogger(&buf, LogJSON, slog.LevelInfo),
+	})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	if _, err := n.mempool.Add(newTx("alice", 10, 10)); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	if _, err := n.Mine(context.Background(), 1); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	callRPC(n, `{"method":"fee.floor"}`) // Debug: below the level
+
+	var records []map[string]any
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var rec map[string]any
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatalf("expected JSON records, got %q: %v", sc.Text(), err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected the genesis and block records, got %v", records)
+	}
+	mined := records[1]
+	if mined["msg"] != "block mined" || mined["component"] != "chain" || mined["height"] != float64(1) || mined["txs"] != float64(1) {
+		t.Fatalf("unexpected block record: %v", mined)
+	}
+}
+
+func TestParseLogSettings(t *testing.T) {
+	if level, err := ParseLogLevel("DEBUG"); err != nil || level != slog.LevelDebug {
+		t.Fatalf("expected debug, got %v, %v", level, err)
+	}
+	if level, err := ParseLogLevel(""); err != nil || level != slog.LevelInfo {
+		t.Fatalf("expected info by default, got %v, %v", level, err)
+	}
+	if _, err := ParseLogLevel("loud"); err == nil {
+		t.Fatalf("expected an unknown level to be refused")
+	}
+	if format, err := ParseLogFormat("json"); err != nil || format != LogJSON {
+		t.Fatalf("expected json, got %v, %v", format, err)
+	}
+	if _, err := ParseLogFormat("xml"); !errors.Is(err, ErrUnknownLogFormat) {
+		t.Fatalf("expected ErrUnknownLogFormat, got %v", err)
+	}
+}
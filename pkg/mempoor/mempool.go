@@ -3,62 +3,108 @@ package mempoor
 import (
 	"container/heap"
 	"errors"
+	"fmt"
+	"math"
+	"sort"
 	"sync"
 )
 
 // Errors exposed by the mempool implementation.
 var (
-	ErrTxExists   = errors.New("mempool: tx already exists")
-	ErrTxNotFound = errors.New("mempool: tx not found")
+	ErrTxExists      = errors.New("mempool: tx already exists")
+	ErrTxNotFound    = errors.New("mempool: tx not found")
+	ErrTxUnderpriced = errors.New("mempool: pool full and tx priority too low")
+	ErrFeeTooLow     = errors.New("mempool: replacement fee too low")
+	ErrTxOrphaned    = errors.New("mempool: tx parked as orphan until its dependency arrives")
+	ErrMempoolFull   = errors.New("mempool: pool full, retry later or with a higher fee")
 )
 
+// OverflowMode selects what Add does when the pool is at MaxTxs.
+type OverflowMode int
+
+const (
+	// OverflowEvict drops the lowest-priority tx to make room. Default.
+	OverflowEvict OverflowMode = iota
+	// OverflowReject fails the Add with ErrMempoolFull and evicts nothing,
+	// pushing back on senders instead of silently churning the pool.
+	OverflowReject
+)
+
+func (o OverflowMode) String() string {
+	switch o {
+	case OverflowEvict:
+		return "evict"
+	case OverflowReject:
+		return "reject"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseOverflowMode maps "evict" or "reject" to an OverflowMode. An
+// empty name means OverflowEvict.
+func ParseOverflowMode(name string) (OverflowMode, error) {
+	switch name {
+	case "", "evict":
+		return OverflowEvict, nil
+	case "reject":
+		return OverflowReject, nil
+	default:
+		return 0, fmt.Errorf("mempool: unknown overflow mode %q", name)
+	}
+}
+
 // txRecord is the heap element wrapping a Tx.
 type txRecord struct {
 	tx    *Tx
 	index int // current index in the heap
-}
 
-// txHeap is a max-heap ordered by (Fee DESC, Timestamp ASC, ID ASC).
-type txHeap []*txRecord
+	history []TxVersion // superseded versions, oldest first
 
-func (h txHeap) Len() int { return len(h) }
+	waiting int // DependsOn entries not yet committed; see deps.go
 
-func (h txHeap) Less(i, j int) bool {
-	ti := h[i].tx
-	tj := h[j].tx
+	bundle *bundle // nil unless tx.BundleID is set; see bundle.go
+}
 
-	// 1) Higher fee first
-	if ti.Fee != tj.Fee {
-		return ti.Fee > tj.Fee
+// key is what the heap orders rec by: its bundle's combined key, or tx.
+func (rec *txRecord) key() *Tx {
+	if rec.bundle != nil {
+		return &rec.bundle.key
 	}
+	return rec.tx
+}
 
-	// 2) Earlier timestamp first
-	if !ti.Timestamp.Equal(tj.Timestamp) {
-		return ti.Timestamp.Before(tj.Timestamp)
-	}
+// txHeap is a max-heap ordered by the mempool's PriorityPolicy
+// (FeeFirst by default: Fee DESC, Timestamp ASC, ID ASC).
+type txHeap struct {
+	recs   []*txRecord
+	policy PriorityPolicy
+}
+
+func (h txHeap) Len() int { return len(h.recs) }
 
-	// 3) Stable ordering by TxID
-	return ti.ID < tj.ID
+func (h txHeap) Less(i, j int) bool {
+	return h.policy.Less(h.recs[i].key(), h.recs[j].key())
 }
 
 func (h txHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
-	h[i].index = i
-	h[j].index = j
+	h.recs[i], h.recs[j] = h.recs[j], h.recs[i]
+	h.recs[i].index = i
+	h.recs[j].index = j
 }
 
 func (h *txHeap) Push(x any) {
-	n := len(*h)
+	n := len(h.recs)
 	rec := x.(*txRecord)
 	rec.index = n
-	*h = append(*h, rec)
+	h.recs = append(h.recs, rec)
 }
 
 func (h *txHeap) Pop() any {
-	old := *h
+	old := h.recs
 	n := len(old)
 	rec := old[n-1]
-	*h = old[:n-1]
+	h.recs = old[:n-1]
 	rec.index = -1
 	return rec
 }
@@ -67,38 +113,281 @@ func (h *txHeap) Pop() any {
 // It is concurrency-safe via an internal RWMutex.
 type mempool struct {
 	mu    sync.RWMutex
-	heap  txHeap
+	lanes [numLanes]txHeap // one priority heap per Lane
 	table map[TxID]*txRecord
+
+	// order is the mempool-wide order: lane first, then cfg.Priority.
+	order PriorityPolicy
+
+	// senders holds each sender's pending records sorted by nonce ASC.
+	// It lives beside the global heap and gates selection order.
+	senders map[string][]*txRecord
+
+	// contents maps (sender, recipient, payload) to the newest pending
+	// tx with that content. Only maintained when Dedup != DedupOff.
+	contents map[string]*txRecord
+
+	// orphans holds txs waiting for a missing dependency; nextNonce is
+	// the nonce after each sender's highest selected tx. Both are only
+	// maintained when MaxOrphans > 0.
+	orphans   map[TxID]*orphan
+	nextNonce map[string]uint64
+
+	// scheduled indexes the activation queue of txs waiting for their
+	// NotBefore time; see schedule.go.
+	scheduled map[TxID]*scheduledTx
+	schedule  scheduleQueue
+
+	// dependents maps a tx ID to the pending records whose DependsOn
+	// lists it and that are still waiting for it to be committed.
+	dependents map[TxID][]*txRecord
+
+	// bundles indexes pending bundle members by BundleID; see bundle.go.
+	bundles map[string]*bundle
+
+	events subscribers
+
+	// pinned holds IDs protected from eviction and purges; see pin.go.
+	pinned map[TxID]struct{}
+
+	// required maps IDs that must top the next block to the order they
+	// were required in; see required.go.
+	required    map[TxID]uint64
+	requiredSeq uint64
+
+	// pendingGas is the total Gas of the txs in table, kept current by
+	// indexRecord/unindexRecord.
+	pendingGas uint64
+
+	// feeFloor is the dynamic admission floor; see FeeFloorConfig.
+	feeFloor uint64
+
+	// allow and deny are the sender ACL; see SenderACL.
+	allow map[string]struct{}
+	deny  map[string]struct{}
+
+	cfg MempoolConfig
 }
 
 // NewMempool creates an empty, concurrency-safe mempool instance.
-func NewMempool() Mempool {
+func NewMempool(cfg MempoolConfig) Mempool {
+	if cfg.Priority == nil {
+		cfg.Priority = FeeFirst
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = SystemClock
+	}
+
 	mp := &mempool{
-		table: make(map[TxID]*txRecord),
-		heap:  txHeap{},
+		table:   make(map[TxID]*txRecord),
+		order:   lanePolicy{inner: cfg.Priority},
+		senders: make(map[string][]*txRecord),
+		events:  subscribers{observer: cfg.Observer},
+		cfg:     cfg,
+
+		contents:  make(map[string]*txRecord),
+		orphans:   make(map[TxID]*orphan),
+		nextNonce: make(map[string]uint64),
+
+		dependents: make(map[TxID][]*txRecord),
+		scheduled:  make(map[TxID]*scheduledTx),
+		pinned:     make(map[TxID]struct{}),
+		required:   make(map[TxID]uint64),
+		bundles:    make(map[string]*bundle),
+	}
+	for i := range mp.lanes {
+		mp.lanes[i] = txHeap{policy: cfg.Priority}
+	}
+
+	mp.allow = make(map[string]struct{}, len(cfg.SenderACL.Allow))
+	for _, s := range cfg.SenderACL.Allow {
+		mp.allow[s] = struct{}{}
+	}
+	mp.deny = make(map[string]struct{}, len(cfg.SenderACL.Deny))
+	for _, s := range cfg.SenderACL.Deny {
+		mp.deny[s] = struct{}{}
 	}
-	heap.Init(&mp.heap)
 	return mp
 }
 
 // Add inserts a new transaction into the mempool.
 //
+// Capacity semantics:
+//   - If MaxTxs == 0 → unbounded.
+//   - With Overflow == OverflowReject, a full pool fails the Add with
+//     ErrMempoolFull. Otherwise:
+//   - If the pool is full, the lowest-priority tx (lowest fee, latest
+//     timestamp) is evicted and its ID returned, or the tx chosen by the
+//     configured EvictionPolicy; see eviction.go.
+//   - If the incoming tx would itself be the one evicted, it is
+//     rejected with ErrTxUnderpriced and nothing is evicted.
+//
+// Dedup semantics (Dedup != DedupOff): see dedup.go.
+//
+// Fee floor semantics (FeeFloor.TargetPercent > 0): see feefloor.go.
+//
+// Sender ACL semantics: see acl.go.
+//
+// Sender limit semantics (MaxTxsPerSender > 0): see sender.go.
+//
+// A Payload longer than MaxPayloadBytes fails with ErrPayloadTooLarge.
+//
+// Pinned txs are never evicted: see pin.go.
+//
+// Dependency semantics (DependsOn): see deps.go.
+//
+// Schedule semantics (NotBefore): see schedule.go.
+//
+// Orphan semantics (MaxOrphans > 0):
+//   - A tx whose dependency is not pending is parked in the orphan area
+//     and ErrTxOrphaned is returned. It is promoted automatically once
+//     the dependency arrives. See orphan.go.
+//
 // NOTE: This assumes tx has already passed basic validation.
-// PERF: For production, you might want to enforce max-size bounds here
-// and evict lower-priority transactions when full.
-func (m *mempool) Add(tx *Tx) error {
+func (m *mempool) Add(tx *Tx) ([]TxID, error) {
+	if tx.BundleID != "" {
+		return nil, fmt.Errorf("%w: use AddBundle", ErrInvalidBundle)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.addLocked(tx)
+}
+
+// AddBatch inserts txs under a single lock acquisition. Each tx gets the
+// same semantics as Add; errs[i] reports the outcome for txs[i] (nil on
+// success, ErrTxOrphaned or ErrTxScheduled if parked). A failing tx does not stop the batch.
+// Txs later in the batch may evict or unblock earlier ones.
+func (m *mempool) AddBatch(txs []*Tx) []error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		if tx.BundleID != "" {
+			errs[i] = fmt.Errorf("%w: use AddBundle", ErrInvalidBundle)
+			continue
+		}
+		_, errs[i] = m.addLocked(tx)
+	}
+	return errs
+}
+
+// addLocked implements Add. Caller must hold the write lock.
+func (m *mempool) addLocked(tx *Tx) ([]TxID, error) {
+	now := m.cfg.Clock.Now()
+	m.activateDue(now)
+
 	if _, exists := m.table[tx.ID]; exists {
-		return ErrTxExists
+		return nil, ErrTxExists
+	}
+	if _, exists := m.orphans[tx.ID]; exists {
+		return nil, ErrTxExists
+	}
+	if _, exists := m.scheduled[tx.ID]; exists {
+		return nil, ErrTxExists
+	}
+	if !tx.Lane.valid() {
+		return nil, ErrInvalidLane
+	}
+	if err := checkChainID(tx, m.cfg.ChainID); err != nil {
+		return nil, err
+	}
+	if err := checkFee(tx); err != nil {
+		return nil, err
+	}
+	if err := CheckPayloadSize(tx.Payload, m.cfg.MaxPayloadBytes); err != nil {
+		return nil, err
+	}
+	if err := checkDependencies(tx); err != nil {
+		return nil, err
+	}
+	if !m.senderPermitted(tx.Sender) {
+		return nil, ErrSenderDenied
+	}
+	if tx.Fee < m.feeFloor {
+		return nil, ErrFeeBelowFloor
+	}
+	if err := m.checkNonce(tx); err != nil {
+		return nil, err
+	}
+	if err := m.checkFunds(tx, nil); err != nil {
+		return nil, err
+	}
+
+	if m.cfg.Dedup != DedupOff {
+		if err := m.checkDuplicate(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if tx.NotBefore.After(now) {
+		if err := m.scheduleLocked(tx); err != nil {
+			return nil, err
+		}
+		return nil, ErrTxScheduled
+	}
+
+	if m.cfg.MaxOrphans > 0 {
+		m.expireOrphans(now)
+		if m.missingDependency(tx) {
+			return m.addOrphan(tx), ErrTxOrphaned
+		}
+	}
+
+	if m.senderAtLimit(tx.Sender) {
+		return nil, ErrSenderLimit
+	}
+
+	evicted, err := m.admit(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.orphans) > 0 {
+		evicted = append(evicted, m.promoteOrphans()...)
+	}
+
+	return evicted, nil
+}
+
+// admit pushes tx into the priority heap, evicting lower-priority txs
+// when at capacity. Caller must hold the write lock.
+func (m *mempool) admit(tx *Tx) ([]TxID, error) {
+	var evicted []TxID
+	for m.cfg.MaxTxs > 0 && len(m.table) >= m.cfg.MaxTxs {
+		if m.cfg.Overflow == OverflowReject {
+			return nil, ErrMempoolFull
+		}
+
+		h, lowest, ok := m.evictionVictim(tx)
+		if h == nil {
+			return nil, ErrMempoolFull // everything left is pinned or required
+		}
+		if !ok {
+			return nil, ErrTxUnderpriced
+		}
+		victim := h.recs[lowest]
+
+		m.removeRecord(victim)
+		evicted = append(evicted, victim.tx.ID)
+		m.dropped(TxEvicted, victim.tx)
 	}
 
 	rec := &txRecord{tx: tx}
-	heap.Push(&m.heap, rec)
+	heap.Push(m.heapOf(tx.Lane), rec)
 	m.table[tx.ID] = rec
+	m.indexRecord(rec)
+	m.indexBundle(rec)
+	m.events.publish(TxAdded, tx)
 
-	return nil
+	return evicted, nil
+}
+
+// heapOf returns the heap holding txs of the given lane.
+func (m *mempool) heapOf(lane Lane) *txHeap {
+	return &m.lanes[lane]
 }
 
 // Update replaces an existing transaction with the same ID.
@@ -108,6 +397,9 @@ func (m *mempool) Add(tx *Tx) error {
 //   - PUT, not PATCH: we treat tx as a full replacement.
 //   - Only fee is logically supposed to change; callers are expected
 //     to preserve immutable fields (ID, Sender, Recipient, Payload, CreatedAt).
+//   - RBF: the new fee must exceed the old fee by at least
+//     MinFeeBumpPercent (and always strictly), else ErrFeeTooLow wrapped
+//     with the required minimum.
 //
 // PERF: For stricter safety, you could enforce immutability here by
 // checking old vs new fields and rejecting illegal changes.
@@ -120,95 +412,199 @@ func (m *mempool) Update(tx *Tx) error {
 		return ErrTxNotFound
 	}
 
-	// Full replacement of the Tx pointer.
-	rec.tx = tx
+	if !tx.Lane.valid() {
+		return ErrInvalidLane
+	}
+	if err := checkFee(tx); err != nil {
+		return err
+	}
+	if tx.BundleID != rec.tx.BundleID || tx.BundleIndex != rec.tx.BundleIndex ||
+		(tx.BundleID != "" && tx.Lane != rec.tx.Lane) {
+		return fmt.Errorf("%w: bundle membership and lane cannot change", ErrInvalidBundle)
+	}
+	if err := CheckPayloadSize(tx.Payload, m.cfg.MaxPayloadBytes); err != nil {
+		return err
+	}
+
+	if required := MinReplacementFee(rec.tx.Fee, m.cfg.MinFeeBumpPercent); tx.Fee < required {
+		return fmt.Errorf("%w: need fee >= %d (min bump %d%% over %d)",
+			ErrFeeTooLow, required, m.cfg.MinFeeBumpPercent, rec.tx.Fee)
+	}
+	if err := m.checkFunds(tx, rec); err != nil {
+		return err
+	}
 
-	// Re-establish heap ordering after fee / timestamp changes.
-	heap.Fix(&m.heap, rec.index)
+	// Full replacement of the Tx pointer. Re-index by sender in case the
+	// caller did not preserve Sender/Nonce.
+	m.unindexRecord(rec)
+	m.recordVersion(rec)
+	old := rec.tx
+	rec.tx = tx
+	m.indexRecord(rec)
+
+	// Re-establish heap ordering after fee / timestamp changes, moving
+	// heaps if the lane changed.
+	if old.Lane == tx.Lane {
+		m.fixRecord(rec)
+	} else {
+		heap.Remove(m.heapOf(old.Lane), rec.index)
+		heap.Push(m.heapOf(tx.Lane), rec)
+	}
+	m.events.publish(TxUpdated, tx)
 
 	return nil
 }
 
+// MinReplacementFee returns the lowest fee that may replace oldFee under
+// a bumpPercent RBF policy. The result is strictly above oldFee, except
+// that it saturates at math.MaxUint64 instead of wrapping.
+func MinReplacementFee(oldFee, bumpPercent uint64) uint64 {
+	// Split to avoid overflowing oldFee*bumpPercent; round the bump up.
+	bump := oldFee/100*bumpPercent + (oldFee%100*bumpPercent+99)/100
+	if bump == 0 {
+		bump = 1
+	}
+	if oldFee > math.MaxUint64-bump {
+		return math.MaxUint64
+	}
+	return oldFee + bump
+}
+
 // Remove deletes a transaction by ID.
 //
 // Q3 semantics:
 // - Strict: if ID not present → ErrTxNotFound.
+// - A scheduled tx (see schedule.go) is cancelled.
 func (m *mempool) Remove(id TxID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if tx, ok := m.unscheduleLocked(id); ok {
+		m.dropped(TxRemoved, tx)
+		return nil
+	}
+
 	rec, ok := m.table[id]
 	if !ok {
 		return ErrTxNotFound
 	}
 
-	// Remove from heap and map.
-	heap.Remove(&m.heap, rec.index)
-	delete(m.table, id)
+	m.removeRecord(rec)
+	m.dropped(TxRemoved, rec.tx)
 
 	return nil
 }
 
+// Get returns the transaction with the given ID in O(1) via the table.
+//
+// Strict: if ID not present → ErrTxNotFound.
+func (m *mempool) Get(id TxID) (*Tx, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rec, ok := m.table[id]
+	if !ok {
+		return nil, ErrTxNotFound
+	}
+	return rec.tx, nil
+}
+
 // SelectTransactions atomically selects the highest-priority transactions
 // that satisfy the given constraints, and removes them from the mempool.
 //
 // Q4 semantics:
-//   - Any tx with Fee < MinFee is purged permanently.
-//     It is removed from both heap and table and NOT returned.
+//   - Any unpinned tx with Fee < MinFee is purged permanently.
+//     It is removed from both heap and table, NOT included in
+//     Transactions, and reported in Purged instead.
+//
+// Nonce semantics:
+//   - A tx is only eligible while no tx from the same sender with a
+//     lower nonce is still pending. Blocked txs are parked and released
+//     back into the heap once the blocking tx is selected or purged, so
+//     a sender's N, N+1, ... can all land in the same block.
 //
 // Gas limit semantics:
 //   - If GasLimit == 0 → no gas limit enforced.
 //   - If including a tx would exceed GasLimit, that tx is skipped for this
 //     selection but kept in the mempool.
 //
-// PERF: The simple "skipped" list below is O(k) reinsertion overhead
-// per selection. For very large mempools, you could optimize this by
-// structuring buckets or using a more advanced scheduler.
+// SelectTransactions is Reserve followed immediately by Commit.
 func (m *mempool) SelectTransactions(c BlockConstraints) BlockSelectionResult {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	result := BlockSelectionResult{
-		Transactions: nil,
-		GasUsed:      0,
-	}
-
-	if c.MaxTx <= 0 || m.heap.Len() == 0 {
-		return result
-	}
-
-	var skipped []*txRecord
+	result := m.selectLocked(c)
+	m.commitLocked(result.Transactions)
+	m.adjustFeeFloor()
+	return result
+}
 
-	for len(result.Transactions) < c.MaxTx && m.heap.Len() > 0 {
-		rec := heap.Pop(&m.heap).(*txRecord)
-		tx := rec.tx
+// selectLocked takes the selected txs out of the heap, table, and sender
+// index without finalizing them, and purges low-fee txs; see Reserve.
+// The walk itself is planSelection. Caller must hold the write lock.
+func (m *mempool) selectLocked(c BlockConstraints) BlockSelectionResult {
+	m.activateDue(m.cfg.Clock.Now())
+	plan := m.planSelection(c)
 
-		// 1) Purge low-fee txs permanently.
-		if tx.Fee < c.MinFee {
-			delete(m.table, tx.ID)
-			continue
+	for _, rec := range plan.selected {
+		m.removeRecord(rec)
+	}
+	for _, rec := range plan.purged {
+		if m.table[rec.tx.ID] != rec {
+			continue // dropped along with a purged dependency
 		}
+		m.removeRecord(rec)
+		m.dropped(TxPurged, rec.tx)
+	}
+	plan.result.Groups = ParallelGroups(plan.result.Transactions)
+	return plan.result
+}
 
-		// 2) Enforce gas limit (if any).
-		if c.GasLimit > 0 && result.GasUsed+tx.Gas > c.GasLimit {
-			// Skip this tx for this block, but keep it in mempool.
-			skipped = append(skipped, rec)
-			continue
-		}
+// removeRecord deletes rec from its heap, the table, and every index.
+// Caller must hold the write lock.
+func (m *mempool) removeRecord(rec *txRecord) {
+	heap.Remove(m.heapOf(rec.tx.Lane), rec.index)
+	delete(m.table, rec.tx.ID)
+	m.unindexRecord(rec)
+	m.unindexBundle(rec)
+}
 
-		// 3) Accept the tx.
-		result.Transactions = append(result.Transactions, tx)
-		result.GasUsed += tx.Gas
-		delete(m.table, tx.ID)
+// fixRecord restores heap order after rec's tx changed in place.
+// Caller must hold the write lock.
+func (m *mempool) fixRecord(rec *txRecord) {
+	if rec.bundle != nil {
+		m.rekeyBundle(rec.bundle, nil)
+		return
 	}
+	heap.Fix(m.heapOf(rec.tx.Lane), rec.index)
+}
 
-	// Reinsert skipped txs back into the heap.
-	for _, rec := range skipped {
-		heap.Push(&m.heap, rec)
-		// map entry is still present for skipped txs.
+// PeekTransactions returns what SelectTransactions would return for c
+// right now, without changing the pool. Purged lists txs that would be
+// purged; they stay pending. No events are published, except for
+// scheduled txs that fall due (see schedule.go).
+//
+// NOTE: Peek runs the same planSelection walk as SelectTransactions, so
+// the answer can never drift from it; it only skips applying the plan.
+func (m *mempool) PeekTransactions(c BlockConstraints) BlockSelectionResult {
+	// Activating due scheduled txs is the one change Peek makes, so the
+	// answer matches what SelectTransactions would see.
+	now := m.cfg.Clock.Now()
+	m.mu.RLock()
+	due := m.hasDue(now)
+	m.mu.RUnlock()
+	if due {
+		m.mu.Lock()
+		m.activateDue(now)
+		m.mu.Unlock()
 	}
 
-	return result
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := m.planSelection(c).result
+	res.Groups = ParallelGroups(res.Transactions)
+	return res
 }
 
 // List returns all transactions currently in the mempool in no particular order.
@@ -226,3 +622,224 @@ func (m *mempool) List() []*Tx {
 	}
 	return out
 }
+
+// Count returns the number of pending txs in O(1), without copying the
+// pool. Orphaned, scheduled, and reserved txs are not counted.
+func (m *mempool) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.table)
+}
+
+// PendingGas returns the total Gas of the pending txs in O(1); it is
+// maintained incrementally on every insert, update, and removal.
+func (m *mempool) PendingGas() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.pendingGas
+}
+
+// ---- secondary index helpers (caller must hold the write lock) ----
+
+// indexRecord adds rec to every secondary index. Pair every insert into
+// the table with indexRecord and every delete with unindexRecord.
+func (m *mempool) indexRecord(rec *txRecord) {
+	m.pendingGas += rec.tx.Gas
+	m.indexSender(rec)
+	m.indexContent(rec)
+	m.indexDeps(rec)
+}
+
+// unindexRecord removes rec from every secondary index.
+func (m *mempool) unindexRecord(rec *txRecord) {
+	m.pendingGas -= rec.tx.Gas
+	m.unindexSender(rec)
+	m.unindexContent(rec)
+	m.unindexDeps(rec)
+}
+
+// indexSender inserts rec into its sender's nonce-sorted slice.
+func (m *mempool) indexSender(rec *txRecord) {
+	recs := m.senders[rec.tx.Sender]
+	i := sort.Search(len(recs), func(i int) bool {
+		return recs[i].tx.Nonce > rec.tx.Nonce
+	})
+	recs = append(recs, nil)
+	copy(recs[i+1:], recs[i:])
+	recs[i] = rec
+	m.senders[rec.tx.Sender] = recs
+}
+
+// unindexSender removes rec from its sender's slice, dropping the
+// sender entry entirely once it has no pending txs.
+func (m *mempool) unindexSender(rec *txRecord) {
+	sender := rec.tx.Sender
+	recs := m.senders[sender]
+	for i, r := range recs {
+		if r == rec {
+			recs = append(recs[:i], recs[i+1:]...)
+			break
+		}
+	}
+	if len(recs) == 0 {
+		delete(m.senders, sender)
+		return
+	}
+	m.senders[sender] = recs
+}
+
+// isExecutable reports whether rec has the lowest pending nonce of its
+// sender. Equal nonces are all executable; ordering among them falls
+// back to priority.
+func (m *mempool) isExecutable(rec *txRecord) bool {
+	recs := m.senders[rec.tx.Sender]
+	return len(recs) == 0 || recs[0].tx.Nonce >= rec.tx.Nonce
+}
+
+// Snapshot atomically copies every pending tx in priority order.
+// The returned txs are detached copies: mutating them does not affect
+// the pool.
+func (m *mempool) Snapshot() ([]*Tx, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]*Tx, 0, len(m.table))
+	for _, rec := range m.table {
+		cp := *rec.tx
+		out = append(out, &cp)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return m.order.Less(out[i], out[j])
+	})
+	return out, nil
+}
+
+// Restore atomically replaces the pool contents with txs.
+//
+// The input is validated before anything is touched: duplicate IDs fail
+// with ErrTxExists, and more txs than MaxTxs fail outright rather than
+// silently evicting. Txs whose NotBefore is still in the future go back
+// into the activation queue and do not count against MaxTxs.
+func (m *mempool) Restore(txs []*Tx) error {
+	now := m.cfg.Clock.Now()
+	pending := 0
+	for _, tx := range txs {
+		if !tx.NotBefore.After(now) {
+			pending++
+		}
+	}
+	if m.cfg.MaxTxs > 0 && pending > m.cfg.MaxTxs {
+		return fmt.Errorf("mempool: restore of %d txs exceeds capacity %d", pending, m.cfg.MaxTxs)
+	}
+
+	table := make(map[TxID]*txRecord, pending)
+	scheduled := make(map[TxID]*scheduledTx)
+	var schedule scheduleQueue
+	var lanes [numLanes]txHeap
+	for i := range lanes {
+		lanes[i] = txHeap{policy: m.cfg.Priority}
+	}
+	for _, tx := range txs {
+		_, exists := table[tx.ID]
+		if _, ok := scheduled[tx.ID]; exists || ok {
+			return fmt.Errorf("%w: %s", ErrTxExists, tx.ID)
+		}
+		if !tx.Lane.valid() {
+			return fmt.Errorf("%w: %s", ErrInvalidLane, tx.ID)
+		}
+		cp := *tx
+		if cp.NotBefore.After(now) {
+			s := &scheduledTx{tx: &cp, index: len(schedule)}
+			schedule = append(schedule, s)
+			scheduled[tx.ID] = s
+			continue
+		}
+		h := &lanes[cp.Lane]
+		rec := &txRecord{tx: &cp, index: h.Len()}
+		h.recs = append(h.recs, rec)
+		table[tx.ID] = rec
+	}
+	for i := range lanes {
+		heap.Init(&lanes[i])
+	}
+	heap.Init(&schedule)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lanes = lanes
+	m.table = table
+	m.pinned = make(map[TxID]struct{})
+	m.required = make(map[TxID]uint64)
+	m.scheduled = scheduled
+	m.schedule = schedule
+	m.orphans = make(map[TxID]*orphan)
+	m.dependents = make(map[TxID][]*txRecord)
+	m.senders = make(map[string][]*txRecord)
+	m.contents = make(map[string]*txRecord)
+	m.bundles = make(map[string]*bundle)
+	m.pendingGas = 0
+	for _, rec := range table {
+		m.indexRecord(rec)
+	}
+	for _, rec := range table {
+		m.indexBundle(rec)
+	}
+	return nil
+}
+
+// Clear atomically empties the pool: pending, orphaned, and scheduled
+// txs are all dropped and published as TxRemoved. The dropped txs are
+// returned in no particular order; callers that do not need them can
+// ignore the result. Open reservations, the sender ACL, the fee floor,
+// and selected-nonce tracking are kept.
+func (m *mempool) Clear() []*Tx {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]*Tx, 0, len(m.table)+len(m.orphans)+len(m.schedule))
+	for _, rec := range m.table {
+		out = append(out, rec.tx)
+	}
+	for _, o := range m.orphans {
+		out = append(out, o.tx)
+	}
+	for _, s := range m.schedule {
+		out = append(out, s.tx)
+	}
+
+	for i := range m.lanes {
+		m.lanes[i] = txHeap{policy: m.cfg.Priority}
+	}
+	m.table = make(map[TxID]*txRecord)
+	m.senders = make(map[string][]*txRecord)
+	m.contents = make(map[string]*txRecord)
+	m.orphans = make(map[TxID]*orphan)
+	m.dependents = make(map[TxID][]*txRecord)
+	m.scheduled = make(map[TxID]*scheduledTx)
+	m.schedule = nil
+	m.pinned = make(map[TxID]struct{})
+	m.required = make(map[TxID]uint64)
+	m.bundles = make(map[string]*bundle)
+	m.pendingGas = 0
+
+	// Everything is gone already, so there is nothing to cascade to.
+	for _, tx := range out {
+		m.events.publish(TxRemoved, tx)
+	}
+	return out
+}
+
+// Subscribe registers a listener for pool change events.
+func (m *mempool) Subscribe() (<-chan MempoolEvent, func()) {
+	return m.events.subscribe()
+}
+
+// Policy returns the mempool-wide order: lane first, then the
+// configured PriorityPolicy.
+func (m *mempool) Policy() PriorityPolicy {
+	return m.order
+}
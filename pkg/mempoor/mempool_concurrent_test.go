@@ -8,7 +8,7 @@ import (
 )
 
 func TestMempoolConcurrentAccess(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	var wg sync.WaitGroup
 	stop := make(chan struct{})
@@ -21,7 +21,7 @@ func TestMempoolConcurrentAccess(t *testing.T) {
 	newRandomTx := func(r *rand.Rand) *Tx {
 		fee := uint64(r.Intn(1000))
 		gas := uint64(r.Intn(50) + 1)
-		return NewUnsignedTx("alice", "bob", "payload", fee, gas)
+		return NewUnsignedTx("alice", "bob", "payload", 0, fee, gas)
 	}
 
 	// --- Goroutine: Add ---
@@ -34,7 +34,7 @@ func TestMempoolConcurrentAccess(t *testing.T) {
 			case <-stop:
 				return
 			default:
-				_ = mp.Add(newRandomTx(r))
+				_, _ = mp.Add(newRandomTx(r))
 			}
 			time.Sleep(time.Millisecond)
 		}
@@ -1,13 +1,21 @@
 package mempoor
 
 import (
+	"errors"
+	"math"
 	"testing"
 	"time"
 )
 
 // helper to create tx quickly
 func newTx(sender string, fee, gas uint64) *Tx {
-	return NewUnsignedTx(sender, "bob", "data", fee, gas)
+	return NewUnsignedTx(sender, "bob", "data", 0, fee, gas)
+}
+
+// rehash gives tx the ID of its contents again after a test changed them.
+func rehash(tx *Tx) *Tx {
+	tx.ID = GenerateTxID(tx.Sender, tx.Recipient, tx.Payload, tx.Nonce, tx.Amount, tx.ChainID, tx.CreatedAt)
+	return tx
 }
 
 // This test does NOT try to assert functional correctness under concurrency.
@@ -21,15 +29,15 @@ func newTx(sender string, fee, gas uint64) *Tx {
 //  - Call SelectTransactions
 
 func TestAddAndList(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	tx1 := newTx("alice", 10, 100)
 	tx2 := newTx("carol", 20, 200)
 
-	if err := mp.Add(tx1); err != nil {
+	if _, err := mp.Add(tx1); err != nil {
 		t.Fatalf("unexpected Add error: %v", err)
 	}
-	if err := mp.Add(tx2); err != nil {
+	if _, err := mp.Add(tx2); err != nil {
 		t.Fatalf("unexpected Add error: %v", err)
 	}
 
@@ -40,18 +48,18 @@ func TestAddAndList(t *testing.T) {
 }
 
 func TestAddDuplicateFails(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	tx := newTx("alice", 10, 100)
-	_ = mp.Add(tx)
+	_, _ = mp.Add(tx)
 
-	if err := mp.Add(tx); err != ErrTxExists {
+	if _, err := mp.Add(tx); err != ErrTxExists {
 		t.Fatalf("expected ErrTxExists on duplicate Add")
 	}
 }
 
 func TestUpdateStrictNotFound(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	tx := newTx("alice", 10, 100)
 
@@ -61,10 +69,10 @@ func TestUpdateStrictNotFound(t *testing.T) {
 }
 
 func TestUpdateReplacesRecordAndChangesPriority(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	tx := newTx("alice", 10, 100)
-	_ = mp.Add(tx)
+	_, _ = mp.Add(tx)
 
 	// Fee bump
 	updated := &Tx{
@@ -98,10 +106,10 @@ func TestUpdateReplacesRecordAndChangesPriority(t *testing.T) {
 }
 
 func TestRemoveStrictNotFound(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	tx := newTx("alice", 10, 100)
-	_ = mp.Add(tx)
+	_, _ = mp.Add(tx)
 
 	if err := mp.Remove("does-not-exist"); err != ErrTxNotFound {
 		t.Fatalf("expected ErrTxNotFound for missing Remove")
@@ -109,9 +117,9 @@ func TestRemoveStrictNotFound(t *testing.T) {
 }
 
 func TestRemoveSuccess(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 	tx := newTx("alice", 10, 100)
-	_ = mp.Add(tx)
+	_, _ = mp.Add(tx)
 
 	if err := mp.Remove(tx.ID); err != nil {
 		t.Fatalf("unexpected Remove error: %v", err)
@@ -123,15 +131,15 @@ func TestRemoveSuccess(t *testing.T) {
 }
 
 func TestSelectTransactionsPriorityOrdering(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	low := newTx("alice", 1, 50)
 	med := newTx("bob", 10, 50)
 	high := newTx("carol", 100, 50)
 
-	_ = mp.Add(low)
-	_ = mp.Add(med)
-	_ = mp.Add(high)
+	_, _ = mp.Add(low)
+	_, _ = mp.Add(med)
+	_, _ = mp.Add(high)
 
 	res := mp.SelectTransactions(BlockConstraints{
 		MaxTx:    3,
@@ -151,12 +159,12 @@ func TestSelectTransactionsPriorityOrdering(t *testing.T) {
 }
 
 func TestSelectTransactionsGasLimit(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	// gas = 60, 60, 60, 60
 	for i := 0; i < 4; i++ {
 		tx := newTx("alice", uint64(i), 60)
-		_ = mp.Add(tx)
+		_, _ = mp.Add(tx)
 	}
 
 	res := mp.SelectTransactions(BlockConstraints{
@@ -175,13 +183,13 @@ func TestSelectTransactionsGasLimit(t *testing.T) {
 }
 
 func TestSelectTransactionsLowFeePurge(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	low := newTx("alice", 1, 10)
 	high := newTx("bob", 100, 10)
 
-	_ = mp.Add(low)
-	_ = mp.Add(high)
+	_, _ = mp.Add(low)
+	_, _ = mp.Add(high)
 
 	res := mp.SelectTransactions(BlockConstraints{
 		MaxTx:    10,
@@ -196,6 +204,10 @@ func TestSelectTransactionsLowFeePurge(t *testing.T) {
 		t.Fatalf("expected high-fee tx selected")
 	}
 
+	if len(res.Purged) != 1 || res.Purged[0].ID != low.ID {
+		t.Fatalf("expected low-fee tx reported as purged, got %v", res.Purged)
+	}
+
 	// low must be permanently removed from the mempool
 	list := mp.List()
 	if len(list) != 0 {
@@ -204,15 +216,15 @@ func TestSelectTransactionsLowFeePurge(t *testing.T) {
 }
 
 func TestSelectTransactionsSkipButKeepForGas(t *testing.T) {
-	mp := NewMempool()
+	mp := NewMempool(MempoolConfig{})
 
 	// high priority but too expensive for this block
 	big := newTx("carol", 100, 100)
 	// lower fee but cheap → should be included
 	small := newTx("alice", 1, 1)
 
-	_ = mp.Add(big)
-	_ = mp.Add(small)
+	_, _ = mp.Add(big)
+	_, _ = mp.Add(small)
 
 	res := mp.SelectTransactions(BlockConstraints{
 		MaxTx:    10,
@@ -232,3 +244,416 @@ func TestSelectTransactionsSkipButKeepForGas(t *testing.T) {
 		t.Fatalf("expected big tx still in mempool")
 	}
 }
+
+func TestGetReturnsTx(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	tx := newTx("alice", 10, 100)
+	_, _ = mp.Add(tx)
+
+	got, err := mp.Get(tx.ID)
+	if err != nil {
+		t.Fatalf("unexpected Get error: %v", err)
+	}
+	if got.ID != tx.ID || got.Fee != 10 {
+		t.Fatalf("unexpected tx from Get: %+v", got)
+	}
+}
+
+func TestGetStrictNotFound(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	if _, err := mp.Get("does-not-exist"); err != ErrTxNotFound {
+		t.Fatalf("expected ErrTxNotFound for missing Get")
+	}
+}
+
+func TestAddEvictsLowestPriorityWhenFull(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 2})
+
+	low := newTx("alice", 1, 10)
+	mid := newTx("bob", 5, 10)
+	high := newTx("carol", 10, 10)
+
+	_, _ = mp.Add(low)
+	_, _ = mp.Add(mid)
+
+	evicted, err := mp.Add(high)
+	if err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	if len(evicted) != 1 || evicted[0] != low.ID {
+		t.Fatalf("expected low-fee tx evicted, got %v", evicted)
+	}
+
+	if len(mp.List()) != 2 {
+		t.Fatalf("expected mempool to stay at capacity 2, got %d", len(mp.List()))
+	}
+	if _, err := mp.Get(low.ID); err != ErrTxNotFound {
+		t.Fatalf("expected evicted tx to be gone")
+	}
+}
+
+func TestAddEvictsLatestTimestampOnFeeTie(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 2})
+
+	now := time.Now().UTC()
+	older := newTx("alice", 5, 10)
+	older.Timestamp = now
+	newer := newTx("bob", 5, 10)
+	newer.Timestamp = now.Add(time.Second)
+
+	_, _ = mp.Add(older)
+	_, _ = mp.Add(newer)
+
+	evicted, err := mp.Add(newTx("carol", 10, 10))
+	if err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	if len(evicted) != 1 || evicted[0] != newer.ID {
+		t.Fatalf("expected latest tx evicted on fee tie, got %v", evicted)
+	}
+}
+
+func TestAddRejectsUnderpricedWhenFull(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 1})
+
+	_, _ = mp.Add(newTx("alice", 10, 10))
+
+	evicted, err := mp.Add(newTx("bob", 1, 10))
+	if err != ErrTxUnderpriced {
+		t.Fatalf("expected ErrTxUnderpriced, got %v", err)
+	}
+	if len(evicted) != 0 {
+		t.Fatalf("expected nothing evicted, got %v", evicted)
+	}
+	if len(mp.List()) != 1 {
+		t.Fatalf("expected original tx to remain")
+	}
+}
+
+func TestSelectTransactionsNonceOrdering(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	// Higher nonce carries the higher fee but must still wait for nonce 0.
+	n1 := NewUnsignedTx("alice", "bob", "second", 1, 100, 10)
+	n0 := NewUnsignedTx("alice", "bob", "first", 0, 1, 10)
+	other := NewUnsignedTx("carol", "bob", "other", 0, 50, 10)
+
+	_, _ = mp.Add(n1)
+	_, _ = mp.Add(n0)
+	_, _ = mp.Add(other)
+
+	res := mp.SelectTransactions(BlockConstraints{
+		MaxTx:    10,
+		GasLimit: 1_000_000,
+	})
+
+	if len(res.Transactions) != 3 {
+		t.Fatalf("expected 3 txs, got %d", len(res.Transactions))
+	}
+
+	pos := make(map[TxID]int)
+	for i, tx := range res.Transactions {
+		pos[tx.ID] = i
+	}
+	if pos[n0.ID] > pos[n1.ID] {
+		t.Fatalf("expected nonce 0 before nonce 1; got %+v", res.Transactions)
+	}
+	if res.Transactions[0].ID != other.ID {
+		t.Fatalf("expected carol's tx first by fee; got %+v", res.Transactions)
+	}
+}
+
+func TestSelectTransactionsNonceBlockedStaysPending(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	n0 := NewUnsignedTx("alice", "bob", "first", 0, 1, 100)
+	n1 := NewUnsignedTx("alice", "bob", "second", 1, 100, 10)
+
+	_, _ = mp.Add(n0)
+	_, _ = mp.Add(n1)
+
+	// n0 does not fit the gas limit, so n1 must not be selected either.
+	res := mp.SelectTransactions(BlockConstraints{
+		MaxTx:    10,
+		GasLimit: 50,
+	})
+
+	if len(res.Transactions) != 0 {
+		t.Fatalf("expected no txs while nonce 0 is pending; got %+v", res.Transactions)
+	}
+	if len(mp.List()) != 2 {
+		t.Fatalf("expected both txs to remain pending")
+	}
+}
+
+func TestUpdateRejectsInsufficientFeeBump(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MinFeeBumpPercent: 10})
+
+	tx := newTx("alice", 100, 10)
+	_, _ = mp.Add(tx)
+
+	bump := func(fee uint64) *Tx {
+		return NewTxUpdate(tx.ID, tx.Sender, tx.Recipient, tx.Payload, tx.Nonce, fee, tx.Gas, tx.CreatedAt)
+	}
+
+	for _, fee := range []uint64{50, 100, 109} {
+		if err := mp.Update(bump(fee)); !errors.Is(err, ErrFeeTooLow) {
+			t.Fatalf("fee=%d: expected ErrFeeTooLow, got %v", fee, err)
+		}
+	}
+
+	if err := mp.Update(bump(110)); err != nil {
+		t.Fatalf("expected 10%% bump to be accepted, got %v", err)
+	}
+
+	got, _ := mp.Get(tx.ID)
+	if got.Fee != 110 {
+		t.Fatalf("expected fee=110 after bump, got %d", got.Fee)
+	}
+}
+
+func TestMinReplacementFee(t *testing.T) {
+	cases := []struct {
+		old, pct, want uint64
+	}{
+		{old: 0, pct: 10, want: 1},
+		{old: 5, pct: 10, want: 6},
+		{old: 100, pct: 10, want: 110},
+		{old: 101, pct: 10, want: 112},
+		{old: 100, pct: 0, want: 101},
+		{old: math.MaxUint64 - 5, pct: 10, want: math.MaxUint64},
+		{old: math.MaxUint64, pct: 0, want: math.MaxUint64},
+	}
+
+	for _, c := range cases {
+		if got := MinReplacementFee(c.old, c.pct); got != c.want {
+			t.Fatalf("MinReplacementFee(%d, %d) = %d, want %d", c.old, c.pct, got, c.want)
+		}
+	}
+}
+
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	low := newTx("alice", 1, 10)
+	high := newTx("carol", 100, 10)
+	_, _ = mp.Add(low)
+	_, _ = mp.Add(high)
+
+	snap, err := mp.Snapshot()
+	if err != nil {
+		t.Fatalf("unexpected Snapshot error: %v", err)
+	}
+	if len(snap) != 2 || snap[0].ID != high.ID || snap[1].ID != low.ID {
+		t.Fatalf("expected snapshot in priority order; got %+v", snap)
+	}
+
+	// Snapshot must be detached from the pool.
+	snap[0].Fee = 0
+	if got, _ := mp.Get(high.ID); got.Fee != 100 {
+		t.Fatalf("mutating snapshot changed pool state")
+	}
+	snap[0].Fee = 100
+
+	restored := NewMempool(MempoolConfig{})
+	_, _ = restored.Add(newTx("dave", 5, 10)) // replaced by Restore
+
+	if err := restored.Restore(snap); err != nil {
+		t.Fatalf("unexpected Restore error: %v", err)
+	}
+
+	if len(restored.List()) != 2 {
+		t.Fatalf("expected exactly the snapshot txs after Restore, got %d", len(restored.List()))
+	}
+	got, err := restored.Get(low.ID)
+	if err != nil {
+		t.Fatalf("expected low tx after Restore: %v", err)
+	}
+	if !got.Timestamp.Equal(low.Timestamp) {
+		t.Fatalf("expected Restore to preserve Timestamp")
+	}
+
+	res := restored.SelectTransactions(BlockConstraints{MaxTx: 10})
+	if len(res.Transactions) != 2 || res.Transactions[0].ID != high.ID {
+		t.Fatalf("expected restored pool to keep priority order; got %+v", res.Transactions)
+	}
+}
+
+func TestRestoreRejectsDuplicatesAtomically(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	existing := newTx("alice", 1, 10)
+	_, _ = mp.Add(existing)
+
+	dup := newTx("carol", 10, 10)
+	if err := mp.Restore([]*Tx{dup, dup}); !errors.Is(err, ErrTxExists) {
+		t.Fatalf("expected ErrTxExists, got %v", err)
+	}
+
+	if _, err := mp.Get(existing.ID); err != nil {
+		t.Fatalf("expected pool untouched after failed Restore")
+	}
+}
+
+func TestAddBatchPerTxErrors(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	a := newTx("alice", 10, 10)
+	b := newTx("carol", 20, 10)
+
+	errs := mp.AddBatch([]*Tx{a, b, a})
+	if len(errs) != 3 {
+		t.Fatalf("expected one error slot per tx, got %d", len(errs))
+	}
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if errs[2] != ErrTxExists {
+		t.Fatalf("expected ErrTxExists for duplicate in batch, got %v", errs[2])
+	}
+	if len(mp.List()) != 2 {
+		t.Fatalf("expected 2 txs after batch, got %d", len(mp.List()))
+	}
+}
+
+func TestPeekTransactionsLeavesPoolUntouched(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	low := newTx("alice", 1, 10)
+	n0 := NewUnsignedTx("bob", "carol", "a", 0, 20, 10)
+	n1 := NewUnsignedTx("bob", "carol", "b", 1, 90, 10)
+	for _, tx := range []*Tx{low, n0, n1} {
+		if _, err := mp.Add(tx); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	events, cancel := mp.Subscribe()
+	defer cancel()
+
+	c := BlockConstraints{MaxTx: 10, GasLimit: 1_000, MinFee: 5}
+	peek := mp.PeekTransactions(c)
+
+	if len(peek.Transactions) != 2 || peek.Transactions[0].ID != n0.ID || peek.Transactions[1].ID != n1.ID {
+		t.Fatalf("expected bob's txs in nonce order, got %v", peek.Transactions)
+	}
+	if len(peek.Purged) != 1 || peek.Purged[0].ID != low.ID {
+		t.Fatalf("expected low-fee tx reported as would-be purged, got %v", peek.Purged)
+	}
+	if got := len(mp.List()); got != 3 {
+		t.Fatalf("peek must not remove txs; pool has %d", got)
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("peek left the pool inconsistent: %v", err)
+	}
+	select {
+	case ev := <-events:
+		t.Fatalf("peek must not publish events, got %v", ev.Type)
+	default:
+	}
+
+	res := mp.SelectTransactions(c)
+	if len(res.Transactions) != len(peek.Transactions) || res.GasUsed != peek.GasUsed {
+		t.Fatalf("selection diverged from peek: %v vs %v", res, peek)
+	}
+	for i := range res.Transactions {
+		if res.Transactions[i].ID != peek.Transactions[i].ID {
+			t.Fatalf("selection diverged from peek at %d", i)
+		}
+	}
+}
+
+func TestAddOverflowRejectReturnsFull(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 1, Overflow: OverflowReject})
+
+	low := newTx("alice", 1, 10)
+	if _, err := mp.Add(low); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+
+	evicted, err := mp.Add(newTx("bob", 1000, 10))
+	if !errors.Is(err, ErrMempoolFull) {
+		t.Fatalf("expected ErrMempoolFull, got %v", err)
+	}
+	if len(evicted) != 0 {
+		t.Fatalf("reject mode must not evict, got %v", evicted)
+	}
+	if _, err := mp.Get(low.ID); err != nil {
+		t.Fatalf("existing tx must stay pending: %v", err)
+	}
+
+	// Once a block drains the pool, Adds succeed again.
+	mp.SelectTransactions(BlockConstraints{MaxTx: 10})
+	if _, err := mp.Add(newTx("bob", 1000, 10)); err != nil {
+		t.Fatalf("expected Add to succeed after drain, got %v", err)
+	}
+}
+
+func TestCountAndPendingGas(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 3})
+
+	a, b, c := newTx("alice", 10, 100), newTx("carol", 20, 200), newTx("dan", 30, 300)
+	for _, tx := range []*Tx{a, b, c} {
+		_, _ = mp.Add(tx)
+	}
+	if mp.Count() != 3 || mp.PendingGas() != 600 {
+		t.Fatalf("expected 3 txs / 600 gas, got %d / %d", mp.Count(), mp.PendingGas())
+	}
+
+	// Eviction of alice makes room for erin.
+	_, _ = mp.Add(newTx("erin", 40, 1_000))
+	if mp.Count() != 3 || mp.PendingGas() != 1_500 {
+		t.Fatalf("after eviction: got %d / %d", mp.Count(), mp.PendingGas())
+	}
+
+	bumped := *b
+	bumped.Fee, bumped.Gas = 50, 250
+	if err := mp.Update(&bumped); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	_ = mp.Remove(c.ID)
+	mp.SelectTransactions(BlockConstraints{MaxTx: 1})
+	if mp.Count() != 1 || mp.PendingGas() != 1_000 {
+		t.Fatalf("after update/remove/select: got %d / %d", mp.Count(), mp.PendingGas())
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("invariants: %v", err)
+	}
+}
+
+func TestClear(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	mp := NewMempool(MempoolConfig{Clock: clock, MaxOrphans: 10})
+	events, cancel := mp.Subscribe()
+	defer cancel()
+
+	pending := NewUnsignedTxWithClock(clock, "alice", "bob", "a", 0, 10, 100)
+	orphan := NewUnsignedTxWithClock(clock, "carol", "bob", "c", 5, 10, 100)
+	scheduled := NewUnsignedTxWithClock(clock, "dan", "bob", "d", 0, 10, 100)
+	scheduled.NotBefore = clock.Now().Add(time.Hour)
+	for _, tx := range []*Tx{pending, orphan, scheduled} {
+		_, _ = mp.Add(tx)
+	}
+	for len(events) > 0 {
+		<-events
+	}
+
+	if got := mp.Clear(); len(got) != 3 {
+		t.Fatalf("expected 3 cleared txs, got %d", len(got))
+	}
+	if mp.Count() != 0 || mp.PendingGas() != 0 || len(mp.ListScheduled()) != 0 {
+		t.Fatalf("pool not empty after Clear")
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("invariants: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 TxRemoved events, got %d", len(events))
+	}
+
+	if _, err := mp.Add(pending); err != nil {
+		t.Fatalf("re-add after Clear: %v", err)
+	}
+}
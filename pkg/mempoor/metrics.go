@@ -0,0 +1,121 @@
+package mempoor
+
+import (
+	"sync"
+	"time"
+)
+
+// BuildStats describes one block build; see BlockBuilderConfig.OnBuild.
+type BuildStats struct {
+	Height uint64
+
+	// SelectionTime is the wall time spent in BeforeSelect hooks,
+	// best-of candidates, and selection.
+	SelectionTime time.Duration
+
+	TxCount  int
+	MaxTx    int // 0 = unlimited
+	GasUsed  uint64
+	GasLimit uint64 // 0 = unlimited
+	Fees     uint64
+
+	// Interrupted reports that the build's context ended during
+	// selection, so the block may be smaller than the pool allowed.
+	Interrupted bool
+}
+
+// TxFill is TxCount / MaxTx, or 0 if MaxTx is unlimited.
+func (s BuildStats) TxFill() float64 {
+	if s.MaxTx <= 0 {
+		return 0
+	}
+	return float64(s.TxCount) / float64(s.MaxTx)
+}
+
+// GasFill is GasUsed / GasLimit, or 0 if GasLimit is unlimited.
+func (s BuildStats) GasFill() float64 {
+	if s.GasLimit == 0 {
+		return 0
+	}
+	return float64(s.GasUsed) / float64(s.GasLimit)
+}
+
+// BuilderMetrics summarizes every build recorded by a BuilderMeter.
+// Averages are over all builds, empty ones included; fill ratios count
+// as 0 for builds whose limit is unlimited.
+type BuilderMetrics struct {
+	Builds            uint64 `json:"builds"`
+	EmptyBuilds       uint64 `json:"emptyBuilds"`
+	InterruptedBuilds uint64 `json:"interruptedBuilds"`
+
+	LastSelectionMs float64 `json:"lastSelectionMs"`
+	AvgSelectionMs  float64 `json:"avgSelectionMs"`
+	MaxSelectionMs  float64 `json:"maxSelectionMs"`
+
+	LastTxFill  float64 `json:"lastTxFill"`
+	AvgTxFill   float64 `json:"avgTxFill"`
+	LastGasFill float64 `json:"lastGasFill"`
+	AvgGasFill  float64 `json:"avgGasFill"`
+
+	LastFees  uint64 `json:"lastFees"`
+	TotalFees uint64 `json:"totalFees"`
+}
+
+// BuilderMeter aggregates BuildStats for operators tuning GasLimit and
+// the block interval. Install Record as BlockBuilderConfig.OnBuild; the
+// node does this and serves Metrics as block.metrics.
+type BuilderMeter struct {
+	mu sync.Mutex
+	m  BuilderMetrics
+
+	totalSelection time.Duration
+	maxSelection   time.Duration
+	txFill         float64
+	gasFill        float64
+}
+
+// NewBuilderMeter returns an empty meter.
+func NewBuilderMeter() *BuilderMeter {
+	return &BuilderMeter{}
+}
+
+// Record adds one build.
+func (bm *BuilderMeter) Record(s BuildStats) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	bm.m.Builds++
+	if s.TxCount == 0 {
+		bm.m.EmptyBuilds++
+	}
+	if s.Interrupted {
+		bm.m.InterruptedBuilds++
+	}
+	bm.totalSelection += s.SelectionTime
+	bm.maxSelection = max(bm.maxSelection, s.SelectionTime)
+	bm.txFill += s.TxFill()
+	bm.gasFill += s.GasFill()
+
+	n := float64(bm.m.Builds)
+	bm.m.LastSelectionMs = millis(s.SelectionTime)
+	bm.m.AvgSelectionMs = millis(bm.totalSelection) / n
+	bm.m.MaxSelectionMs = millis(bm.maxSelection)
+	bm.m.LastTxFill = s.TxFill()
+	bm.m.AvgTxFill = bm.txFill / n
+	bm.m.LastGasFill = s.GasFill()
+	bm.m.AvgGasFill = bm.gasFill / n
+	bm.m.LastFees = s.Fees
+	bm.m.TotalFees += s.Fees
+}
+
+// Metrics returns the current summary.
+func (bm *BuilderMeter) Metrics() BuilderMetrics {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	return bm.m
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
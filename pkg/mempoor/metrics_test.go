@@ -0,0 +1,57 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBuilderMeterAggregates(t *testing.T) {
+	bm := NewBuilderMeter()
+	bm.Record(BuildStats{SelectionTime: 2 * time.Millisecond, TxCount: 5, MaxTx: 10, GasUsed: 80, GasLimit: 100, Fees: 50})
+	bm.Record(BuildStats{SelectionTime: 4 * time.Millisecond, MaxTx: 10, GasLimit: 100})
+
+	m := bm.Metrics()
+	if m.Builds != 2 || m.EmptyBuilds != 1 {
+		t.Fatalf("expected 2 builds, 1 empty, got %+v", m)
+	}
+	if m.AvgSelectionMs != 3 || m.MaxSelectionMs != 4 || m.LastSelectionMs != 4 {
+		t.Fatalf("unexpected selection latency: %+v", m)
+	}
+	if m.AvgTxFill != 0.25 || m.AvgGasFill != 0.4 || m.LastGasFill != 0 {
+		t.Fatalf("unexpected fill ratios: %+v", m)
+	}
+	if m.TotalFees != 50 || m.LastFees != 0 {
+		t.Fatalf("unexpected fees: %+v", m)
+	}
+}
+
+func TestBlockMetricsRPC(t *testing.T) {
+	n := NewNode(NodeConfig{GasLimit: 100, MaxTxPerBlock: 4})
+	_, _ = n.mempool.Add(newTx("alice", 7, 25))
+	_, _ = n.mempool.Add(newTx("bob", 3, 25))
+
+	if _, err := n.produceBlock(context.Background(), [32]byte{}, 0, time.Time{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := n.produceBlock(context.Background(), [32]byte{}, 1, time.Time{}); err != ErrEmptyBlock {
+		t.Fatalf("expected ErrEmptyBlock, got %v", err)
+	}
+
+	rec := callRPC(n, `{"method":"block.metrics"}`)
+	var resp struct {
+		Result BuilderMetrics `json:"result"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("bad response %d: %v", rec.Code, err)
+	}
+	m := resp.Result
+	if m.Builds != 2 || m.EmptyBuilds != 1 || m.TotalFees != 10 {
+		t.Fatalf("unexpected metrics: %+v", m)
+	}
+	if m.LastTxFill != 0 || m.AvgGasFill != 0.25 {
+		t.Fatalf("unexpected fill ratios: %+v", m)
+	}
+}
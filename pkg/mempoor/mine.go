@@ -0,0 +1,87 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// MaxMineBlocks caps the blocks one debug.mine call may produce.
+const MaxMineBlocks = 100
+
+// On-demand mining semantics (Mine, debug.mine):
+//   - Each block is produced right away, exactly as the block loop would
+//     on a tick: same builder, limits, BuildTimeout, validation, and
+//     signing, serialized with the loop, block.propose, and reorgs.
+//   - Mining stops early when nothing can be selected (the block loop
+//     would skip the tick), unless ProduceEmptyBlocks is set; an empty
+//     pool is a result of no blocks, not an error.
+//   - The block loop's ticker is not reset: a tick may follow a mined
+//     block at once.
+//   - debug.mine is guarded by the AdminToken like the admin.* methods.
+
+// Mine produces up to count blocks immediately (0 = 1) and returns
+// those it produced; see "On-demand mining semantics".
+func (n *Node) Mine(ctx context.Context, count int) ([]*Block, error) {
+	if count <= 0 {
+		count = 1
+	}
+	if count > MaxMineBlocks {
+		return nil, fmt.Errorf("blockbuilder: cannot mine more than %d blocks at once", MaxMineBlocks)
+	}
+
+	var mined []*Block
+	for range count {
+		block, err := n.produceNext(ctx)
+		if err == ErrEmptyBlock {
+			n.oracle.Refresh(n.mempool, nil)
+			break
+		}
+		if err != nil {
+			return mined, err
+		}
+		n.log.logBlock("block mined", block)
+		mined = append(mined, block)
+	}
+	return mined, nil
+}
+
+type mineParams struct {
+	Count int `json:"count"`
+}
+
+type mineResult struct {
+	Blocks []blockDTO `json:"blocks"`
+	Error  string     `json:"error,omitempty"` // why mining stopped early
+}
+
+// rpcDebugMine produces blocks on demand; a build error after some
+// blocks still reports those blocks, with the error.
+func (n *Node) rpcDebugMine(ctx context.Context, w http.ResponseWriter, params json.RawMessage) {
+	var p mineParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			writeRPCError(w, http.StatusBadRequest, "invalid params for debug.mine")
+			return
+		}
+	}
+	if p.Count < 0 || p.Count > MaxMineBlocks {
+		writeRPCError(w, http.StatusBadRequest, fmt.Sprintf("count must be between 0 and %d", MaxMineBlocks))
+		return
+	}
+
+	mined, err := n.Mine(ctx, p.Count)
+	out := mineResult{Blocks: make([]blockDTO, 0, len(mined))}
+	for _, b := range mined {
+		out.Blocks = append(out.Blocks, makeBlockDTO(encodePayloads(b)))
+	}
+	if err != nil {
+		if len(mined) == 0 {
+			writeRPCError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		out.Error = err.Error()
+	}
+	writeRPCResult(w, http.StatusOK, out)
+}
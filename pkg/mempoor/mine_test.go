@@ -0,0 +1,53 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMineProducesPendingTxs(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 2, BlockInterval: time.Second})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	for _, sender := range []string{"alice", "bob", "carol"} {
+		if _, err := n.mempool.Add(newTx(sender, 10, 10)); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+	}
+
+	// Two blocks empty the pool; the third attempt stops mining.
+	mined, err := n.Mine(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	if len(mined) != 2 || mined[1].Header.Height != 2 || n.mempool.Count() != 0 {
+		t.Fatalf("expected 2 blocks emptying the pool, got %d blocks, %d pending", len(mined), n.mempool.Count())
+	}
+	if _, height := n.chainTip(); height != 3 {
+		t.Fatalf("expected the next height to be 3, got %d", height)
+	}
+
+	rec := callRPC(n, `{"method":"debug.mine","params":{"count":1}}`)
+	var resp struct {
+		Result mineResult `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("debug.mine: %d %s", rec.Code, rec.Body)
+	}
+	if len(resp.Result.Blocks) != 0 {
+		t.Fatalf("expected no block from an empty pool, got %d", len(resp.Result.Blocks))
+	}
+
+	if rec := callRPC(n, `{"method":"debug.mine","params":{"count":1000}}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected a count above MaxMineBlocks to be refused, got %d", rec.Code)
+	}
+
+	n.cfg.AdminToken = "secret"
+	if rec := callRPC(n, `{"method":"debug.mine"}`); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected debug.mine to require the admin token, got %d", rec.Code)
+	}
+}
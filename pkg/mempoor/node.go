@@ -2,72 +2,340 @@ package mempoor
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 )
 
 // Node contains the mempool, block builder, chain history, and config.
 // RPC handlers also live as methods on this struct.
 type Node struct {
-	mempool Mempool
-	builder *BlockBuilder
+	mempool Mempool    // the default pool; pools[0].mp
+	pools   []nodePool // default first, then NodeConfig.Pools
+	builder Builder
 
 	blocksMu sync.RWMutex
 	blocks   []*Block
+	byHash   map[[32]byte]uint64 // block hash -> height; see chainindex.go
+	txIndex  map[TxID]txLocation // txs on the chain; see receipt.go
+	store    BlockStore          // nil = in-memory only; see blockstore.go
+
+	// produceMu serializes block production between the block loop and
+	// block.propose, so each height is produced once.
+	produceMu sync.Mutex
+
+	oracle  *FeeOracle
+	meter   *BuilderMeter
+	limits  *liveLimits
+	state   *State   // nil without genesis balances
+	journal *journal // nil without DataDir
+	tracker *txTracker
+	replay  *replayRecorder // nil without ReplayLog
+	key     ed25519.PrivateKey
+	log     nodeLoggers
+	started time.Time // for node.status uptime
+	health  nodeHealth
+	heads   headFeed
+	streams streamSet // /ws and /events connections; see ws.go
+
+	// events records events for /events once a stream opens; see
+	// sse.go.
+	eventsOnce sync.Once
+	events     *eventLog
+
+	// lastCheckpoint is the latest chain checkpoint, read or written;
+	// nil = none. Guarded by produceMu once the node runs. See
+	// checkpoint.go.
+	lastCheckpoint *chainCheckpoint
+
+	peers   *peerSet
+	metrics *nodeMetrics
 
 	cfg NodeConfig
 }
 
 // NewNode creates a fully initialized Node with mempool + builder.
 func NewNode(cfg NodeConfig) *Node {
-	mp := NewMempool()
-	builder := NewBlockBuilder(mp, BlockBuilderConfig{
-		GasLimit:      cfg.GasLimit,
-		MaxTxPerBlock: cfg.MaxTxPerBlock,
-		MinFee:        cfg.MinFee,
-	})
-
-	return &Node{
+	return NewNodeWithBuilder(cfg, nil)
+}
+
+// NewNodeWithBuilder is NewNode with a custom Builder, made by newBuilder
+// from the node's default mempool and its extra pools, in NodeConfig.Pools
+// order. A nil newBuilder means the built-in BlockBuilder.
+//
+// The block limits, Strategy, Packing, Candidates, ProduceEmptyBlocks,
+// Proposer, and ExtraData only configure the built-in builder; a custom
+// one honors whatever it chooses to. So do block.metrics and the
+// constraints recorded in the ReplayLog.
+func NewNodeWithBuilder(cfg NodeConfig, newBuilder func(mp Mempool, pools []NamedPool) Builder) *Node {
+	if cfg.Clock == nil {
+		cfg.Clock = SystemClock
+	}
+
+	tracker := newTxTracker(cfg.StatusCacheSize, cfg.Clock)
+	metrics := newNodeMetrics()
+	observer := Observers(tracker, metrics)
+
+	var jnl *journal
+	if cfg.DataDir != "" {
+		jnl = newJournal(cfg.DataDir, cfg.JournalSync)
+		observer = Observers(observer, jnl)
+	}
+
+	var replay *replayRecorder
+	var hooks []BeforeSelectHook
+	if cfg.ReplayLog != "" {
+		replay = newReplayRecorder(cfg.ReplayLog)
+		observer = Observers(observer, replay)
+		hooks = append(hooks, replay.hook)
+		cfg.BlockSinks = append(cfg.BlockSinks[:len(cfg.BlockSinks):len(cfg.BlockSinks)], replay.sink)
+	}
+
+	var state *State
+	if len(cfg.Genesis.Balances) > 0 {
+		state = NewState(cfg.Genesis.Balances)
+		hooks = append(hooks, state.selectHook)
+	}
+
+	mcfg := MempoolConfig{
+		Priority:          cfg.Priority,
+		MaxTxs:            cfg.MaxMempoolTxs,
+		Overflow:          cfg.Overflow,
+		Eviction:          cfg.Eviction,
+		MaxTxsPerSender:   cfg.MaxTxsPerSender,
+		MaxPayloadBytes:   cfg.MaxPayloadBytes,
+		MinFeeBumpPercent: cfg.MinFeeBumpPercent,
+		MaxOrphans:        cfg.MaxOrphans,
+		OrphanTTL:         cfg.OrphanTTL,
+		Dedup:             cfg.Dedup,
+		DedupWindow:       cfg.DedupWindow,
+		FeeFloor:          cfg.FeeFloor,
+		SenderACL:         cfg.SenderACL,
+		ChainID:           cfg.ChainID,
+		Clock:             cfg.Clock,
+		Observer:          observer,
+	}
+	if state != nil {
+		mcfg.Balances, mcfg.Nonces = state, state
+	}
+	mp := NewMempool(mcfg)
+
+	pools := newNodePools(mp, cfg, mcfg)
+	logs := newNodeLoggers(cfg)
+	meter := NewBuilderMeter()
+	limits := newLiveLimits(cfg)
+	var builder Builder
+	if newBuilder != nil {
+		builder = newBuilder(mp, namedPools(pools))
+	} else {
+		builder = NewBlockBuilder(mp, BlockBuilderConfig{
+			GasLimit:      cfg.GasLimit,
+			MaxTxPerBlock: cfg.MaxTxPerBlock,
+			MinFee:        cfg.MinFee,
+			LaneGasLimits: cfg.LaneGasLimits,
+			BaseFee:       cfg.BaseFee,
+			Strategy:      cfg.Strategy,
+			Packing:       cfg.Packing,
+			Candidates:    cfg.Candidates,
+			Pools:         namedPools(pools),
+			PoolGasLimits: cfg.PoolGasLimits,
+			AllowEmpty:    cfg.ProduceEmptyBlocks,
+			Proposer:      cfg.Proposer,
+			ExtraData:     cfg.ExtraData,
+			ChainID:       cfg.ChainID,
+			BeforeSelect:  hooks,
+			Clock:         cfg.Clock,
+			OnPurge:       logPurged(logs.mempool, limits),
+			OnBuild: func(s BuildStats) {
+				meter.Record(s)
+				metrics.recordBuild(s)
+			},
+		})
+	}
+
+	key := cfg.Key
+	if key == nil {
+		// Replaced by KeyFile's, if any, when the node starts.
+		_, key, _ = ed25519.GenerateKey(rand.Reader)
+	}
+
+	n := &Node{
 		mempool: mp,
+		pools:   pools,
 		builder: builder,
 		blocks:  make([]*Block, 0),
+		byHash:  make(map[[32]byte]uint64),
+		txIndex: make(map[TxID]txLocation),
+		store:   cfg.BlockStore,
+		oracle:  NewFeeOracle(cfg.FeeOracleWindow),
+		meter:   meter,
+		limits:  limits,
+		state:   state,
+		journal: jnl,
+		tracker: tracker,
+		replay:  replay,
+		key:     key,
+		log:     logs,
+		started: cfg.Clock.Now(),
+		peers:   newPeerSet(cfg.Peers),
+		metrics: metrics,
 		cfg:     cfg,
 	}
+	metrics.registerNode(n)
+	return n
+}
+
+// StartNode runs a node with DefaultNodeConfig. It sets up the node,
+// HTTP server, and block production loop. All lifecycle control is
+// driven by ctx.
+func StartNode(ctx context.Context, listenAddr, dataDir string) error {
+	return RunNode(ctx, DefaultNodeConfig(listenAddr, dataDir))
+}
+
+// RunNode is the public entrypoint called from CLI (NodeArgs.Execute):
+// StartNode with a caller-adjusted config.
+func RunNode(ctx context.Context, cfg NodeConfig) error {
+	return NewNode(cfg).run(ctx)
 }
 
-// StartNode is the public entrypoint called from CLI (NodeArgs.Execute).
-// It sets up the node, HTTP server, and block production loop.
-// All lifecycle control is driven by ctx.
-func StartNode(ctx context.Context, listenAddr string) error {
-	cfg := NodeConfig{
+// DefaultNodeConfig returns the settings StartNode uses.
+func DefaultNodeConfig(listenAddr, dataDir string) NodeConfig {
+	return NodeConfig{
 		ListenAddr:    listenAddr,
+		DataDir:       dataDir,
 		BlockInterval: 2 * time.Second,
 		GasLimit:      1_000_000,
 		MaxTxPerBlock: 1000,
 		MinFee:        0,
-	}
+		MaxMempoolTxs: 10_000,
+
+		MaxPayloadBytes: DefaultMaxPayloadBytes,
 
-	node := NewNode(cfg)
-	return node.run(ctx)
+		MinFeeBumpPercent: DefaultMinFeeBumpPercent,
+		MaxOrphans:        1_000,
+		OrphanTTL:         DefaultOrphanTTL,
+	}
 }
 
 func (n *Node) run(ctx context.Context) error {
-	fmt.Printf("🚀 started mempoor node on %s\n", n.cfg.ListenAddr)
+	if err := validatePools(n.cfg.Pools); err != nil {
+		return err
+	}
+	if err := checkExtraData(n.cfg.ExtraData); err != nil {
+		return err
+	}
+	if err := checkExtraData(n.cfg.Genesis.ExtraData); err != nil {
+		return err
+	}
+	if err := checkFillTarget(n.cfg.FillTarget); err != nil {
+		return err
+	}
+	if err := n.loadKey(); err != nil {
+		return err
+	}
+	if n.cfg.Retention.enabled() && n.state != nil {
+		return errRetentionWithAccounts
+	}
+	for _, addr := range n.cfg.Peers {
+		if err := checkPeerAddress(addr); err != nil {
+			return err
+		}
+	}
+
+	// ---- Reload the chain ----
+	closeChain, err := n.openChain()
+	if err != nil {
+		return err
+	}
+	defer closeChain()
+	if err := n.initGenesis(); err != nil {
+		return err
+	}
+	if err := n.loadCheckpoint(); err != nil {
+		return err
+	}
+	if err := n.rebuildState(); err != nil {
+		return err
+	}
+
+	// ---- Reload persisted mempool, then start journaling ----
+	if err := n.loadMempool(); err != nil {
+		return err
+	}
+	if n.journal != nil {
+		if err := n.journal.open(); err != nil {
+			return err
+		}
+		defer n.journal.close()
+
+		// Fold whatever was replayed into a fresh snapshot.
+		if err := n.checkpoint(); err != nil {
+			return err
+		}
+	}
+	if n.replay != nil {
+		var pending []*Tx
+		for _, p := range n.pools {
+			txs, err := p.mp.Snapshot()
+			if err != nil {
+				return err
+			}
+			pending = append(pending, txs...)
+		}
+		if err := n.replay.open(pending); err != nil {
+			return err
+		}
+		defer n.replay.close()
+	}
+
+	n.log.node.Info("started mempoor node", "listen", n.cfg.ListenAddr)
 
 	// ---- Start HTTP server ----
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rpc", n.handleRPC)
+	mux.HandleFunc("/healthz", n.handleHealthz)
+	mux.HandleFunc("/readyz", n.handleReadyz)
+	mux.HandleFunc("/ws", n.handleWS)
+	mux.HandleFunc("/events", n.handleEvents)
+	mux.HandleFunc("/openapi.json", n.handleOpenAPI)
+	mux.Handle("/metrics", n.metrics.handler())
 
 	server := &http.Server{
 		Addr:    n.cfg.ListenAddr,
 		Handler: mux,
 	}
+	server.RegisterOnShutdown(n.streams.stop)
 
-	errCh := make(chan error, 2)
+	errCh := make(chan error, 3)
+
+	// gRPC server goroutine; stopped with the HTTP server
+	if n.cfg.GRPCListenAddr != "" {
+		lis, err := net.Listen("tcp", n.cfg.GRPCListenAddr)
+		if err != nil {
+			return fmt.Errorf("grpc listen: %w", err)
+		}
+		gs := n.newGRPCServer()
+		server.RegisterOnShutdown(gs.GracefulStop)
+		defer gs.Stop()
+		go func() {
+			if err := gs.Serve(lis); err != nil {
+				errCh <- fmt.Errorf("grpc server error: %w", err)
+			}
+		}()
+		n.log.node.Info("serving gRPC", "listen", n.cfg.GRPCListenAddr)
+	}
 
 	// HTTP server goroutine
+	n.health.serving.Store(true)
 	go func() {
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			errCh <- fmt.Errorf("http server error: %w", err)
@@ -75,75 +343,336 @@ func (n *Node) run(ctx context.Context) error {
 	}()
 
 	// ---- Start block production loop ----
+	loopDone := make(chan struct{})
 	go func() {
-		errCh <- n.runBlockLoop(ctx)
+		defer close(loopDone)
+		if err := n.runBlockLoop(ctx); err != nil {
+			errCh <- err
+		}
 	}()
 
+	// ---- Prune old blocks ----
+	if n.cfg.Retention.enabled() {
+		go n.runPruner(ctx)
+	}
+
+	// ---- Poll peers ----
+	go n.runPeers(ctx)
+
+	// ---- Reload the config and block limits on SIGHUP ----
+	if n.cfg.ConfigFile != "" || n.cfg.LimitsFile != "" {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-hup:
+					if n.cfg.ConfigFile != "" {
+						if err := n.reloadConfigFile(); err != nil {
+							n.log.node.Error("config reload failed", "err", err)
+						}
+					}
+					if n.cfg.LimitsFile != "" {
+						if err := n.reloadLimitsFile(); err != nil {
+							n.log.node.Error("limits reload failed", "err", err)
+						}
+					}
+				}
+			}
+		}()
+	}
+
 	// ---- Shutdown on ctx cancel ----
 	select {
 	case <-ctx.Done():
-		_ = server.Shutdown(context.Background())
-		fmt.Println("mempoor node shutting down:", ctx.Err())
-		return nil
+		n.health.serving.Store(false)
+		n.log.node.Info("shutting down", "cause", ctx.Err())
+		return n.shutdown(server, loopDone)
 
 	case err := <-errCh:
+		n.health.serving.Store(false)
 		_ = server.Shutdown(context.Background())
+		if serr := n.checkpoint(); serr != nil {
+			n.log.mempool.Error("persist failed", "err", serr)
+		}
 		return err
 	}
 }
 
-// runBlockLoop executes the block builder loop in a ticker.
-// Only produces blocks when mempool has eligible txs.
+// runBlockLoop executes the block builder loop in a ticker, and also as
+// the pools fill up with a FillTarget; see trigger.go.
+// Only produces blocks when mempool has eligible txs, unless
+// ProduceEmptyBlocks is set.
 func (n *Node) runBlockLoop(ctx context.Context) error {
-	var (
-		height   uint64
-		prevHash [32]byte
-	)
+	n.health.blockLoop.Store(true)
+	defer n.health.blockLoop.Store(false)
 
-	ticker := time.NewTicker(n.cfg.BlockInterval)
+	ticker := time.NewTicker(n.limits.blockInterval())
 	defer ticker.Stop()
 
+	// fill stays nil, and never fires, without a FillTarget.
+	var fill <-chan struct{}
+	if n.cfg.FillTarget > 0 {
+		var stop func()
+		fill, stop = n.watchFill()
+		defer stop()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 
+		case <-n.limits.retick:
+			ticker.Reset(n.limits.blockInterval())
+			continue
+
 		case <-ticker.C:
-			now := time.Now().UTC()
-			block, err := n.builder.BuildBlock(prevHash, height, now)
-			if err == ErrEmptyBlock {
-				continue // No block this round (mempool empty or txs below MinFee)
-			}
-			if err != nil {
-				fmt.Printf("block build error at height %d: %v\n", height, err)
+		case <-fill:
+			if !n.fillReached() {
 				continue
 			}
+			// The ticker is the fallback: count the interval from here.
+			ticker.Reset(n.limits.blockInterval())
+		}
+
+		n.maybeCompactJournal()
+
+		// Nothing can be selected: skip the builder and its lock.
+		if !n.cfg.ProduceEmptyBlocks && n.poolsIdle() {
+			n.oracle.Refresh(n.mempool, nil)
+			continue
+		}
+
+		block, err := n.produceNext(ctx)
+		if err == ErrEmptyBlock {
+			n.oracle.Refresh(n.mempool, nil)
+			continue // No block this round (mempool empty or txs below MinFee)
+		}
+		if err != nil {
+			n.log.builder.Error("block build failed", "err", err)
+			continue
+		}
+
+		n.log.logBlock("block produced", block)
+	}
+}
+
+// produceNext produces the next block on the stored tip, which
+// block.propose may also advance, with selection bounded by ctx and the
+// build timeout.
+func (n *Node) produceNext(ctx context.Context) (*Block, error) {
+	n.produceMu.Lock()
+	defer n.produceMu.Unlock()
+
+	prevHash, height := n.chainTip()
+	bctx, cancel := context.WithTimeout(ctx, n.buildTimeout())
+	defer cancel()
+	block, err := n.produceBlock(bctx, prevHash, height, n.cfg.Clock.Now())
+	if err != nil && err != ErrEmptyBlock {
+		return nil, fmt.Errorf("height %d: %w", height, err)
+	}
+	return block, err
+}
+
+// reserveBlock reserves the next block if the builder is a
+// BlockReserver, and otherwise builds it outright; see builtBlock.
+func (n *Node) reserveBlock(ctx context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, Reservation, error) {
+	if r, ok := n.builder.(BlockReserver); ok {
+		return r.ReserveBlock(ctx, prevHash, height, now)
+	}
+	block, err := n.builder.BuildBlock(ctx, prevHash, height, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return block, builtBlock{n: n, block: block}, nil
+}
 
-			// Store block in memory
-			n.blocksMu.Lock()
-			n.blocks = append(n.blocks, block)
-			n.blocksMu.Unlock()
+// builtBlock is the Reservation for a block from a Builder that cannot
+// reserve: its txs already left the pools, so Commit has nothing to do
+// and Rollback reinserts them into the pools they name (the default pool
+// if that pool is gone).
+type builtBlock struct {
+	n     *Node
+	block *Block
+}
 
-			// Print summary
-			printBlock(block)
+func (r builtBlock) Result() BlockSelectionResult {
+	return BlockSelectionResult{Transactions: r.block.Transactions, GasUsed: r.block.Header.GasUsed, Groups: r.block.Groups}
+}
 
-			// Advance chain tip
-			prevHash = block.Hash()
-			height++
+func (r builtBlock) Commit() error { return nil }
+
+func (r builtBlock) Rollback() error {
+	byPool := make([][]*Tx, len(r.n.pools))
+	for _, tx := range r.block.Transactions {
+		i := 0
+		for j, p := range r.n.pools {
+			if p.name == poolKey(tx.Pool) {
+				i = j
+				break
+			}
 		}
+		byPool[i] = append(byPool[i], tx)
 	}
+
+	var errs []error
+	for i, txs := range byPool {
+		if len(txs) > 0 {
+			errs = append(errs, r.n.pools[i].mp.Reinsert(txs))
+		}
+	}
+	return errors.Join(errs...)
 }
 
-// ---- Helper for stdout block output ----
+// buildTimeout is NodeConfig.BuildTimeout, defaulting to the current
+// block interval.
+func (n *Node) buildTimeout() time.Duration {
+	if n.cfg.BuildTimeout > 0 {
+		return n.cfg.BuildTimeout
+	}
+	return n.limits.blockInterval()
+}
 
-func printBlock(b *Block) {
-	fmt.Printf(
-		"BLOCK height=%d txs=%d gasUsed=%d hash=%x prevHash=%x time=%s\n",
-		b.Header.Height,
-		b.Header.TxCount,
-		b.Header.GasUsed,
-		b.Hash(),
-		b.Header.PrevHash,
-		b.Header.Timestamp.Format(time.RFC3339Nano),
-	)
+// BlockSink receives a newly built block before the node stores it and
+// finalizes its txs; see NodeConfig.BlockSinks.
+type BlockSink func(b *Block) error
+
+// produceBlock builds, publishes, and stores one block as a unit: its txs
+// stay reserved until every BlockSink accepts it, and go back to the
+// mempool (with their original Timestamp) if any sink fails, so a block
+// that was never stored cannot take txs with it.
+func (n *Node) produceBlock(ctx context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, error) {
+	block, res, err := n.reserveBlock(ctx, prevHash, height, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.publishBlock(block, res); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// publishBlock validates block against the tip, hands it to every
+// BlockSink, then stores it and commits res, whose txs it holds; if
+// validation, a sink, or the BlockStore fails, res is rolled back instead.
+func (n *Node) publishBlock(block *Block, res Reservation) error {
+	discard := func(err error) error {
+		if rerr := res.Rollback(); rerr != nil {
+			err = errors.Join(err, fmt.Errorf("rollback: %w", rerr))
+		}
+		return err
+	}
+	if err := Validate(block, n.chainTipBlock(), n.cfg.BaseFee); err != nil {
+		return discard(err)
+	}
+	if n.state != nil {
+		if err := n.state.Check(block, n.cfg.BaseFee); err != nil {
+			return discard(fmt.Errorf("%w: %w", ErrInvalidBlock, err))
+		}
+	}
+	SignBlock(block, n.key)
+	if err := n.storeBlock(block); err != nil {
+		return discard(err)
+	}
+
+	// Finalize the selection.
+	if n.state != nil {
+		if err := n.state.Apply(block, n.cfg.BaseFee); err != nil {
+			n.log.chain.Error("state apply failed", "height", block.Header.Height, "err", err)
+		}
+	}
+	if err := res.Commit(); err != nil {
+		n.log.mempool.Error("block commit failed", "height", block.Header.Height, "err", err)
+	}
+	n.blockCommitted(block, false)
+	return nil
+}
+
+// storeBlock hands a validated block to every BlockSink, then writes it
+// to the BlockStore and makes it the tip at its height, dropping any
+// blocks from that height on. Nothing changes if a sink or the store
+// fails. publishBlock and reorgBlock share it.
+func (n *Node) storeBlock(block *Block) error {
+	for _, sink := range n.cfg.BlockSinks {
+		if err := sink(block); err != nil {
+			return fmt.Errorf("publish block: %w", err)
+		}
+	}
+
+	stored := block
+	if n.cfg.BlockCompression != CompressNone {
+		compressed, err := CompressPayloads(block, n.cfg.BlockCompression)
+		if err != nil {
+			// Keep the block plain; reads handle either.
+			n.log.chain.Error("block compression failed", "height", block.Header.Height, "err", err)
+		} else {
+			stored = compressed
+		}
+	}
+	if n.store != nil {
+		if err := n.store.Put(stored); err != nil {
+			return fmt.Errorf("store block: %w", err)
+		}
+	}
+	n.blocksMu.Lock()
+	n.setChainLocked(int(block.Header.Height), stored)
+	n.blocksMu.Unlock()
+	return nil
+}
+
+// blockCommitted updates everything that follows the tip once block is
+// on the chain and its txs are settled in the pools: replay protection,
+// tx status, the fee oracle, metrics, head subscribers, and checkpoints.
+func (n *Node) blockCommitted(block *Block, reorg bool) {
+	n.dropReplays(block)
+	n.tracker.included(block)
+	n.oracle.Refresh(n.mempool, block)
+	n.metrics.recordBlock(block)
+	n.heads.publish(HeadEvent{Block: block, Reorg: reorg})
+	n.maybeWriteCheckpoint(block)
+}
+
+// chainTip returns the hash and height the next block builds on.
+func (n *Node) chainTip() ([32]byte, uint64) {
+	n.blocksMu.RLock()
+	defer n.blocksMu.RUnlock()
+
+	if len(n.blocks) == 0 {
+		return [32]byte{}, 0
+	}
+	last := n.blocks[len(n.blocks)-1]
+	return last.Hash(), last.Header.Height + 1
+}
+
+// maybeCompactJournal checkpoints once the journal has grown past
+// JournalCompactEvery entries.
+func (n *Node) maybeCompactJournal() {
+	if n.journal == nil {
+		return
+	}
+	limit := n.cfg.JournalCompactEvery
+	if limit <= 0 {
+		limit = DefaultJournalCompactEvery
+	}
+	if n.journal.pending() < limit {
+		return
+	}
+	if err := n.checkpoint(); err != nil {
+		n.log.mempool.Error("checkpoint failed", "err", err)
+	}
+}
+
+// logPurged returns an OnPurge hook that logs each dropped tx.
+func logPurged(log *slog.Logger, limits *liveLimits) func([]*Tx) {
+	return func(purged []*Tx) {
+		l, _ := limits.get()
+		for _, tx := range purged {
+			log.Info("tx purged", "tx", tx.ID, "sender", tx.Sender, "fee", tx.Fee,
+				"reason", "fee below minFee", "minFee", l.MinFee)
+		}
+	}
 }
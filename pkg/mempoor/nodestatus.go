@@ -0,0 +1,87 @@
+package mempoor
+
+import (
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Version is the node's release, reported by node.status. Release
+// builds set it with -ldflags "-X mempoor/pkg/mempoor.Version=v1.2.3".
+var Version = "dev"
+
+// NodeStatus is a snapshot of the node's runtime state, as node.status
+// serves it.
+//
+// Status semantics:
+//   - Height and TipHash are the chain tip's; before genesis both are
+//     zero values.
+//   - PendingTxs and PendingGas add up every pool, scheduled txs
+//     excluded.
+//   - Limits are the live block limits, including changes made by
+//     admin.setBlockLimits or a reload since startup, with their
+//     version.
+//   - Production is the builder's block.metrics.
+type NodeStatus struct {
+	Version   string    `json:"version"`
+	ChainID   string    `json:"chainId,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	Uptime    string    `json:"uptime"` // a Go duration, whole seconds
+
+	Height  uint64 `json:"height"`
+	TipHash string `json:"tipHash"`
+
+	PendingTxs int    `json:"pendingTxs"`
+	PendingGas uint64 `json:"pendingGas"`
+
+	Limits     StatusLimits   `json:"limits"`
+	Production BuilderMetrics `json:"production"`
+}
+
+// StatusLimits are the live block limits in a NodeStatus.
+type StatusLimits struct {
+	BlockInterval string `json:"blockInterval"`
+	GasLimit      uint64 `json:"gasLimit"`
+	MaxTxPerBlock int    `json:"maxTxPerBlock"`
+	MinFee        uint64 `json:"minFee"`
+	Version       uint64 `json:"version"`
+}
+
+// Status returns the node's current NodeStatus; see "Status semantics".
+func (n *Node) Status() NodeStatus {
+	now := n.cfg.Clock.Now()
+	s := NodeStatus{
+		Version:    Version,
+		ChainID:    n.cfg.ChainID,
+		StartedAt:  n.started.UTC(),
+		Uptime:     now.Sub(n.started).Truncate(time.Second).String(),
+		Production: n.meter.Metrics(),
+	}
+
+	n.blocksMu.RLock()
+	if len(n.blocks) > 0 {
+		tip := n.blocks[len(n.blocks)-1]
+		hash := tip.Hash()
+		s.Height, s.TipHash = tip.Header.Height, hex.EncodeToString(hash[:])
+	}
+	n.blocksMu.RUnlock()
+
+	for _, p := range n.pools {
+		s.PendingTxs += p.mp.Count()
+		s.PendingGas += p.mp.PendingGas()
+	}
+
+	limits, version := n.limits.get()
+	s.Limits = StatusLimits{
+		BlockInterval: n.limits.blockInterval().String(),
+		GasLimit:      limits.GasLimit,
+		MaxTxPerBlock: limits.MaxTxPerBlock,
+		MinFee:        limits.MinFee,
+		Version:       version,
+	}
+	return s
+}
+
+func (n *Node) rpcNodeStatus(w http.ResponseWriter) {
+	writeRPCResult(w, http.StatusOK, n.Status())
+}
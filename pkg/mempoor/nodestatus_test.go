@@ -0,0 +1,59 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNodeStatus(t *testing.T) {
+	clock := NewFakeClock(GenesisTime)
+	n := NewNode(NodeConfig{
+		MaxTxPerBlock: 1,
+		GasLimit:      1_000,
+		BlockInterval: time.Second,
+		ChainID:       "testnet-1",
+		Clock:         clock,
+	})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	for _, sender := range []string{"alice", "bob"} {
+		tx := newTx(sender, 10, 100)
+		tx.ChainID = "testnet-1"
+		if _, err := n.mempool.Add(rehash(tx)); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+	}
+	clock.Advance(90 * time.Second)
+	if _, err := n.Mine(context.Background(), 1); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+
+	rec := callRPC(n, `{"method":"node.status"}`)
+	var resp struct {
+		Result NodeStatus `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode node.status: %v: %s", err, rec.Body)
+	}
+	s := resp.Result
+	tipHash, _ := n.chainTip()
+	if s.Height != 1 || s.TipHash != hex.EncodeToString(tipHash[:]) {
+		t.Fatalf("expected the tip at height 1, got %d %s", s.Height, s.TipHash)
+	}
+	if s.PendingTxs != 1 || s.PendingGas != 100 {
+		t.Fatalf("expected 1 pending tx with 100 gas, got %d / %d", s.PendingTxs, s.PendingGas)
+	}
+	if s.Uptime != "1m30s" || s.ChainID != "testnet-1" || s.Version != Version {
+		t.Fatalf("unexpected identity: %+v", s)
+	}
+	if s.Limits.GasLimit != 1_000 || s.Limits.MaxTxPerBlock != 1 || s.Limits.BlockInterval != "1s" {
+		t.Fatalf("unexpected limits: %+v", s.Limits)
+	}
+	if s.Production.Builds != 1 {
+		t.Fatalf("expected 1 build, got %d", s.Production.Builds)
+	}
+}
@@ -0,0 +1,121 @@
+package mempoor
+
+// MempoolObserver receives synchronous callbacks for pool changes, for
+// metrics and logging integrations that want more than the best-effort
+// Subscribe channel but do not want to wrap the whole Mempool.
+//
+// Observer semantics:
+//   - Callbacks run inline while the mempool holds its write lock, so
+//     they see every change, in order, with nothing dropped.
+//   - Callbacks must be fast and must not call back into the mempool
+//     (that would deadlock). Hand work off to a goroutine if needed.
+//   - The *Tx passed in is the pool's own copy: do not modify it.
+//
+// Embed NopObserver to implement only the callbacks you need.
+type MempoolObserver interface {
+	OnAdd(tx *Tx)    // accepted by Add, AddBatch, rollback, or Reinsert
+	OnUpdate(tx *Tx) // replaced by Update or a dedup merge; tx is the new version
+	OnRemove(tx *Tx) // deleted by Remove
+	OnSelect(tx *Tx) // committed to a block
+	OnPurge(tx *Tx)  // dropped during selection for Fee < MinFee
+	OnEvict(tx *Tx)  // dropped for capacity, or orphan expired/evicted
+
+	// OnSchedule reports a tx held until its NotBefore time; OnAdd
+	// follows when it is activated.
+	OnSchedule(tx *Tx)
+}
+
+// NopObserver implements every MempoolObserver callback as a no-op.
+type NopObserver struct{}
+
+func (NopObserver) OnAdd(*Tx)      {}
+func (NopObserver) OnUpdate(*Tx)   {}
+func (NopObserver) OnRemove(*Tx)   {}
+func (NopObserver) OnSelect(*Tx)   {}
+func (NopObserver) OnPurge(*Tx)    {}
+func (NopObserver) OnEvict(*Tx)    {}
+func (NopObserver) OnSchedule(*Tx) {}
+
+// observe dispatches one event to o. TxOrphaned has no observer
+// callback and is only visible via Subscribe.
+func observe(o MempoolObserver, typ MempoolEventType, tx *Tx) {
+	switch typ {
+	case TxAdded:
+		o.OnAdd(tx)
+	case TxUpdated:
+		o.OnUpdate(tx)
+	case TxRemoved:
+		o.OnRemove(tx)
+	case TxSelected:
+		o.OnSelect(tx)
+	case TxPurged:
+		o.OnPurge(tx)
+	case TxEvicted:
+		o.OnEvict(tx)
+	case TxScheduled:
+		o.OnSchedule(tx)
+	}
+}
+
+// Observers fans each callback out to every non-nil observer in order.
+// It returns nil if none are given.
+func Observers(obs ...MempoolObserver) MempoolObserver {
+	var out multiObserver
+	for _, o := range obs {
+		if o != nil {
+			out = append(out, o)
+		}
+	}
+	switch len(out) {
+	case 0:
+		return nil
+	case 1:
+		return out[0]
+	default:
+		return out
+	}
+}
+
+type multiObserver []MempoolObserver
+
+func (m multiObserver) OnAdd(tx *Tx) {
+	for _, o := range m {
+		o.OnAdd(tx)
+	}
+}
+
+func (m multiObserver) OnUpdate(tx *Tx) {
+	for _, o := range m {
+		o.OnUpdate(tx)
+	}
+}
+
+func (m multiObserver) OnRemove(tx *Tx) {
+	for _, o := range m {
+		o.OnRemove(tx)
+	}
+}
+
+func (m multiObserver) OnSelect(tx *Tx) {
+	for _, o := range m {
+		o.OnSelect(tx)
+	}
+}
+
+func (m multiObserver) OnPurge(tx *Tx) {
+	for _, o := range m {
+		o.OnPurge(tx)
+	}
+}
+
+func (m multiObserver) OnEvict(tx *Tx) {
+	for _, o := range m {
+		o.OnEvict(tx)
+	}
+}
+
+func (m multiObserver) OnSchedule(tx *Tx) {
+	for _, o := range m {
+		o.OnSchedule(tx)
+	}
+}
@@ -0,0 +1,85 @@
+package mempoor
+
+import (
+	"reflect"
+	"testing"
+)
+
+// recordingObserver logs callbacks as "kind:sender".
+type recordingObserver struct {
+	calls []string
+}
+
+func (o *recordingObserver) OnAdd(tx *Tx)      { o.calls = append(o.calls, "add:"+tx.Sender) }
+func (o *recordingObserver) OnUpdate(tx *Tx)   { o.calls = append(o.calls, "update:"+tx.Sender) }
+func (o *recordingObserver) OnRemove(tx *Tx)   { o.calls = append(o.calls, "remove:"+tx.Sender) }
+func (o *recordingObserver) OnSelect(tx *Tx)   { o.calls = append(o.calls, "select:"+tx.Sender) }
+func (o *recordingObserver) OnPurge(tx *Tx)    { o.calls = append(o.calls, "purge:"+tx.Sender) }
+func (o *recordingObserver) OnEvict(tx *Tx)    { o.calls = append(o.calls, "evict:"+tx.Sender) }
+func (o *recordingObserver) OnSchedule(tx *Tx) { o.calls = append(o.calls, "schedule:"+tx.Sender) }
+
+func TestObserverSeesLifecycle(t *testing.T) {
+	obs := &recordingObserver{}
+	mp := NewMempool(MempoolConfig{MaxTxs: 2, Observer: obs})
+
+	a := newTx("alice", 1, 10)
+	b := newTx("bob", 50, 10)
+	c := newTx("carol", 100, 10)
+	d := newTx("dan", 60, 10)
+
+	_, _ = mp.Add(a)
+	_, _ = mp.Add(b)
+	_, _ = mp.Add(c) // evicts alice
+	_ = mp.Remove(b.ID)
+	_, _ = mp.Add(d)
+	mp.SelectTransactions(BlockConstraints{MaxTx: 10, MinFee: 70}) // purges dan
+
+	want := []string{
+		"add:alice", "add:bob",
+		"evict:alice", "add:carol",
+		"remove:bob",
+		"add:dan",
+		"purge:dan", "select:carol",
+	}
+	if !reflect.DeepEqual(obs.calls, want) {
+		t.Fatalf("unexpected observer calls:\n got %v\nwant %v", obs.calls, want)
+	}
+}
+
+// addCounter overrides OnAdd only; NopObserver covers the rest.
+type addCounter struct {
+	NopObserver
+	adds int
+}
+
+func (c *addCounter) OnAdd(*Tx) { c.adds++ }
+
+func TestNopObserverEmbedding(t *testing.T) {
+	obs := &addCounter{}
+	mp := NewMempool(MempoolConfig{Observer: obs})
+
+	tx := newTx("alice", 1, 10)
+	_, _ = mp.Add(tx)
+	_ = mp.Remove(tx.ID)
+
+	if obs.adds != 1 {
+		t.Fatalf("expected 1 add, got %d", obs.adds)
+	}
+}
+
+func TestObserversFanOut(t *testing.T) {
+	first, second := &recordingObserver{}, &recordingObserver{}
+	mp := NewMempool(MempoolConfig{Observer: Observers(first, nil, second)})
+
+	tx := newTx("alice", 1, 10)
+	_, _ = mp.Add(tx)
+	_ = mp.Remove(tx.ID)
+
+	want := []string{"add:alice", "remove:alice"}
+	if !reflect.DeepEqual(first.calls, want) || !reflect.DeepEqual(second.calls, want) {
+		t.Fatalf("unexpected calls: %v / %v", first.calls, second.calls)
+	}
+	if Observers(nil) != nil {
+		t.Fatalf("expected nil for no observers")
+	}
+}
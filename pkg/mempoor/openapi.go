@@ -0,0 +1,342 @@
+package mempoor
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// OpenAPI semantics (GET /openapi.json):
+//   - The document is OpenAPI 3.1 and describes POST /rpc. Its request
+//     body is one of the methods' requests, told apart by "method"; the
+//     params and result types are under components.schemas, named after
+//     the Go types.
+//   - "x-rpc-methods" lists every method in rpcMethods order with its
+//     params and result schemas, whether it is an admin method (see
+//     NodeConfig.AdminToken), and, for block.export, the media type of
+//     the stream it answers with instead of a result. pkg/client is
+//     generated from it.
+//   - A refusal is a result holding only "error"; HTTP errors are an
+//     RPCError body with a non-200 status.
+//   - The document is built from the handlers' Go types, so it follows
+//     them; a method missing from rpcMethods is missing from it.
+
+// rpcMethod describes one RPC method for the OpenAPI document.
+type rpcMethod struct {
+	Name    string
+	Summary string // a verb phrase: "adds one tx to a pool"
+	Params  any    // zero value of the params type; nil = none
+	Result  any    // zero value of the result type; nil = Stream
+	Stream  string // media type of a streamed answer
+}
+
+// rpcMethods lists the methods served by serveRPC.
+var rpcMethods = []rpcMethod{
+	{Name: "tx.add", Summary: "adds one tx to a pool", Params: addTxParams{}, Result: addTxResult{}},
+	{Name: "tx.addBatch", Summary: "adds many txs, each succeeding or failing alone", Params: addBatchParams{}, Result: addBatchResult{}},
+	{Name: "tx.addBundle", Summary: "adds txs as one atomic bundle", Params: addBundleParams{}, Result: addBundleResult{}},
+	{Name: "tx.update", Summary: "replaces a pending tx's fee", Params: updateTxParams{}, Result: okResult{}},
+	{Name: "tx.remove", Summary: "removes a pending tx", Params: removeTxParams{}, Result: okResult{}},
+	{Name: "tx.history", Summary: "returns a pending tx and its earlier fees", Params: txHistoryParams{}, Result: txHistoryResult{}},
+	{Name: "tx.status", Summary: "returns the last known lifecycle state of a tx", Params: txStatusParams{}, Result: TxStatus{}},
+	{Name: "tx.get", Summary: "returns a pending tx and its position in the priority order", Params: txGetParams{}, Result: txGetResult{}},
+	{Name: "tx.receipt", Summary: "returns the receipt of an included tx", Params: txReceiptParams{}, Result: Receipt{}},
+	{Name: "tx.scheduled", Summary: "lists txs waiting for their NotBefore", Params: scheduledParams{}, Result: listTxResult{}},
+	{Name: "tx.list", Summary: "lists a pool's pending txs, filtered, sorted and paged", Params: listTxParams{}, Result: listTxResult{}},
+	{Name: "tx.top", Summary: "lists the next txs a block would select", Params: topTxParams{}, Result: listTxResult{}},
+	{Name: "block.list", Summary: "lists the chain's blocks", Params: listBlocksParams{}, Result: listBlocksResult{}},
+	{Name: "block.get", Summary: "returns one block by height or hash", Params: blockGetParams{}, Result: getBlockResult{}},
+	{Name: "block.headers", Summary: "returns headers for light clients", Params: headersParams{}, Result: headersResult{}},
+	{Name: "block.template", Summary: "builds the next block without committing it", Result: getBlockResult{}},
+	{Name: "block.metrics", Summary: "returns the block builder's metrics", Result: BuilderMetrics{}},
+	{Name: "block.propose", Summary: "offers an externally built block for the tip", Params: proposeParams{}, Result: proposeResult{}},
+	{Name: "block.reorg", Summary: "replaces the tip with a competing block", Params: reorgParams{}, Result: reorgResult{}},
+	{Name: "block.export", Summary: "streams the chain as one JSON block per line", Stream: "application/x-ndjson"},
+	{Name: "block.import", Summary: "adopts an exported chain", Params: importParams{}, Result: ImportResult{}},
+	{Name: "node.status", Summary: "returns the node's status", Result: NodeStatus{}},
+	{Name: "peer.add", Summary: "adds a peer and returns the peers", Params: peerParams{}, Result: peerListResult{}},
+	{Name: "peer.remove", Summary: "removes a peer and returns the peers", Params: peerParams{}, Result: peerListResult{}},
+	{Name: "peer.list", Summary: "returns the peers", Result: peerListResult{}},
+	{Name: "debug.mine", Summary: "produces blocks on demand", Params: mineParams{}, Result: mineResult{}},
+	{Name: "account.get", Summary: "returns an account's balance and next nonce", Params: accountGetParams{}, Result: accountResult{}},
+	{Name: "fee.estimate", Summary: "returns the fee oracle's percentiles", Result: FeeEstimate{}},
+	{Name: "fee.floor", Summary: "returns the pool's admission fee floor", Result: feeFloorResult{}},
+	{Name: "admin.setSenderAccess", Summary: "allows, denies or resets a sender", Params: setSenderAccessParams{}, Result: okResult{}},
+	{Name: "admin.senderACL", Summary: "returns the sender allow and deny lists", Result: SenderACL{}},
+	{Name: "admin.purgeSender", Summary: "removes every pending tx of a sender", Params: purgeSenderParams{}, Result: purgeSenderResult{}},
+	{Name: "admin.clear", Summary: "removes every pending tx", Params: clearParams{}, Result: clearResult{}},
+	{Name: "admin.pin", Summary: "pins or unpins a pending tx", Params: pinParams{}, Result: okResult{}},
+	{Name: "admin.pinned", Summary: "lists the pinned txs", Result: pinnedResult{}},
+	{Name: "admin.require", Summary: "requires a tx in the next block, or cancels that", Params: requireParams{}, Result: okResult{}},
+	{Name: "admin.required", Summary: "lists the required txs", Result: requiredResult{}},
+	{Name: "admin.setBlockLimits", Summary: "changes the live block limits", Params: limitsUpdate{}, Result: blockLimitsResult{}},
+	{Name: "admin.blockLimits", Summary: "returns the live block limits", Result: blockLimitsResult{}},
+	{Name: "admin.compact", Summary: "compacts the pool's indexes", Result: okResult{}},
+	{Name: "admin.checkInvariants", Summary: "checks the pool's internal invariants", Result: checkInvariantsResult{}},
+}
+
+// rpcError is an HTTP-level RPC error body, for the OpenAPI document.
+type rpcError struct {
+	Error string `json:"error"`
+}
+
+// schemaNames renames the Go types whose names do not suit the API.
+var schemaNames = map[reflect.Type]string{
+	reflect.TypeFor[blockDTO](): "Block",
+	reflect.TypeFor[okResult](): "OKResult",
+	reflect.TypeFor[rpcError](): "RPCError",
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// schemaRef is the JSON pointer of the named schema.
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// schemaBuilder turns Go types into JSON schemas the way encoding/json
+// encodes them, collecting structs under their names.
+type schemaBuilder struct {
+	schemas map[string]any
+}
+
+// schema returns t's schema; structs become references.
+func (b *schemaBuilder) schema(t reflect.Type) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == rawMessageType:
+		return map[string]any{}
+	case t.Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		// nil encodes as null.
+		s := b.schema(t.Elem())
+		if _, ok := s["$ref"]; ok {
+			return map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+		}
+		if typ, ok := s["type"]; ok {
+			s["type"] = []any{typ, "null"}
+		}
+		return s
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int:
+		return map[string]any{"type": "integer"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer", "format": t.Kind().String()}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": t.Kind().String(), "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		elem := t.Elem()
+		if elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		return map[string]any{"type": "array", "items": b.schema(elem)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+		name, ok := schemaNames[t]
+		if !ok {
+			r, size := utf8.DecodeRuneInString(t.Name())
+			name = string(unicode.ToUpper(r)) + t.Name()[size:]
+		}
+		if _, ok := b.schemas[name]; !ok {
+			b.schemas[name] = nil // recursion guard
+			b.schemas[name] = b.object(t)
+		}
+		return schemaRef(name)
+	default:
+		return map[string]any{}
+	}
+}
+
+// object returns the schema of struct t's JSON object. Fields without
+// omitempty or omitzero are required.
+func (b *schemaBuilder) object(t reflect.Type) map[string]any {
+	var props properties
+	required := make([]string, 0)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		props.names = append(props.names, name)
+		props.schemas = append(props.schemas, b.schema(f.Type))
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+			required = append(required, name)
+		}
+	}
+	return map[string]any{"type": "object", "properties": props, "required": required}
+}
+
+// properties are an object schema's properties in field order, which
+// a map would lose.
+type properties struct {
+	names   []string
+	schemas []map[string]any
+}
+
+func (p properties) MarshalJSON() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, name := range p.names {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		k, _ := json.Marshal(name) // a string cannot fail
+		v, err := json.Marshal(p.schemas[i])
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(buf, k...), ':'), v...)
+	}
+	return append(buf, '}'), nil
+}
+
+// requestSchemaName names method's request schema: tx.addBatch is
+// TxAddBatchRequest.
+func requestSchemaName(method string) string {
+	var sb strings.Builder
+	for part := range strings.SplitSeq(method, ".") {
+		r, size := utf8.DecodeRuneInString(part)
+		sb.WriteRune(unicode.ToUpper(r))
+		sb.WriteString(part[size:])
+	}
+	return sb.String() + "Request"
+}
+
+// OpenAPI returns the node's OpenAPI document, as GET /openapi.json
+// serves it; see "OpenAPI semantics".
+func OpenAPI() []byte {
+	return openAPIDoc()
+}
+
+// openAPIDoc builds the OpenAPI document once.
+var openAPIDoc = sync.OnceValue(func() []byte {
+	b := &schemaBuilder{schemas: make(map[string]any)}
+	requests := make([]any, 0, len(rpcMethods))
+	mapping := make(map[string]any, len(rpcMethods))
+	methods := make([]any, 0, len(rpcMethods))
+	for _, m := range rpcMethods {
+		entry := map[string]any{"name": m.Name, "summary": m.Summary, "admin": isAdminMethod(m.Name)}
+		request := map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"method": map[string]any{"const": m.Name}},
+			"required":   []string{"method"},
+		}
+		if m.Params != nil {
+			params := b.schema(reflect.TypeOf(m.Params))
+			entry["params"] = params
+			request["properties"].(map[string]any)["params"] = params
+		}
+		if m.Result != nil {
+			entry["result"] = b.schema(reflect.TypeOf(m.Result))
+		}
+		if m.Stream != "" {
+			entry["stream"] = m.Stream
+		}
+		methods = append(methods, entry)
+
+		name := requestSchemaName(m.Name)
+		b.schemas[name] = request
+		requests = append(requests, schemaRef(name))
+		mapping[m.Name] = "#/components/schemas/" + name
+	}
+	errorBody := b.schema(reflect.TypeFor[rpcError]())
+	block := b.schema(reflect.TypeFor[blockDTO]())
+
+	doc := map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   "mempoor RPC",
+			"version": Version,
+		},
+		"paths": map[string]any{
+			"/rpc": map[string]any{
+				"post": map[string]any{
+					"operationId": "rpc",
+					"summary":     "Calls one RPC method; see x-rpc-methods for each method's params and result",
+					"requestBody": map[string]any{
+						"required": true,
+						"content": map[string]any{
+							"application/json": map[string]any{
+								"schema": map[string]any{
+									"oneOf":         requests,
+									"discriminator": map[string]any{"propertyName": "method", "mapping": mapping},
+								},
+							},
+						},
+					},
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": "The method's result under \"result\"; a result holding only \"error\" is a refusal",
+							"content": map[string]any{
+								"application/json": map[string]any{
+									"schema": map[string]any{
+										"type":       "object",
+										"properties": map[string]any{"result": map[string]any{}},
+									},
+								},
+								"application/x-ndjson": map[string]any{"schema": block},
+							},
+						},
+						"default": map[string]any{
+							"description": "An HTTP-level error",
+							"content":     map[string]any{"application/json": map[string]any{"schema": errorBody}},
+						},
+					},
+					"security": []any{map[string]any{}, map[string]any{"adminToken": []any{}}},
+				},
+			},
+		},
+		"components": map[string]any{
+			"schemas": b.schemas,
+			"securitySchemes": map[string]any{
+				"adminToken": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"x-rpc-methods": methods,
+	}
+	out, _ := json.MarshalIndent(doc, "", "  ") // only maps, slices and strings
+	return out
+})
+
+// handleOpenAPI serves the OpenAPI document.
+func (n *Node) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(OpenAPI())
+}
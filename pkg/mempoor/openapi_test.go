@@ -0,0 +1,182 @@
+package mempoor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"mempoor/pkg/client"
+)
+
+// servedMethods returns the method names of serveRPC's switch in rpc.go.
+func servedMethods(t *testing.T) []string {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "rpc.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse rpc.go: %v", err)
+	}
+	var names []string
+	ast.Inspect(f, func(node ast.Node) bool {
+		if fn, ok := node.(*ast.FuncDecl); ok {
+			return fn.Name.Name == "serveRPC"
+		}
+		if c, ok := node.(*ast.CaseClause); ok {
+			for _, e := range c.List {
+				if lit, ok := e.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					name, _ := strconv.Unquote(lit.Value)
+					names = append(names, name)
+				}
+			}
+		}
+		return true
+	})
+	return names
+}
+
+func TestOpenAPIListsEveryMethod(t *testing.T) {
+	served := servedMethods(t)
+	if len(served) == 0 {
+		t.Fatalf("found no methods in serveRPC")
+	}
+	listed := make([]string, len(rpcMethods))
+	for i, m := range rpcMethods {
+		listed[i] = m.Name
+	}
+	for _, name := range served {
+		if !slices.Contains(listed, name) {
+			t.Errorf("%s is served but missing from rpcMethods", name)
+		}
+	}
+	for _, name := range listed {
+		if !slices.Contains(served, name) {
+			t.Errorf("%s is in rpcMethods but not served", name)
+		}
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	rec := httptest.NewRecorder()
+	n.handleOpenAPI(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("expected a JSON document, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+		Methods []struct {
+			Name   string          `json:"name"`
+			Admin  bool            `json:"admin"`
+			Params json.RawMessage `json:"params"`
+			Result json.RawMessage `json:"result"`
+			Stream string          `json:"stream"`
+		} `json:"x-rpc-methods"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" || len(doc.Methods) != len(rpcMethods) {
+		t.Fatalf("expected OpenAPI 3.1.0 with %d methods, got %q with %d", len(rpcMethods), doc.OpenAPI, len(doc.Methods))
+	}
+
+	add := doc.Methods[0]
+	if add.Name != "tx.add" || add.Admin || !bytes.Contains(add.Params, []byte(`"#/components/schemas/AddTxParams"`)) {
+		t.Fatalf("unexpected tx.add entry: %+v", add)
+	}
+	for _, m := range doc.Methods {
+		switch {
+		case m.Name == "block.export" && m.Stream != "application/x-ndjson":
+			t.Fatalf("expected block.export streamed, got %+v", m)
+		case m.Name == "admin.clear" && !m.Admin:
+			t.Fatalf("expected admin.clear to be an admin method")
+		}
+	}
+
+	// Schemas follow encoding/json: field order, omitempty, text
+	// marshalers, pointers.
+	var params struct {
+		Properties json.RawMessage `json:"properties"`
+		Required   []string        `json:"required"`
+	}
+	_ = json.Unmarshal(doc.Components.Schemas["AddTxParams"], &params)
+	dec := json.NewDecoder(bytes.NewReader(params.Properties))
+	_, _ = dec.Token()
+	if first, _ := dec.Token(); first != "sender" {
+		t.Fatalf("expected properties in field order, got %v first", first)
+	}
+	if slices.Contains(params.Required, "maxFee") || !slices.Contains(params.Required, "lane") {
+		t.Fatalf("expected omitempty fields optional, got required %v", params.Required)
+	}
+	var props map[string]map[string]any
+	_ = json.Unmarshal(params.Properties, &props)
+	if props["lane"]["type"] != "string" || props["notBefore"]["format"] != "date-time" {
+		t.Fatalf("expected lane a string and notBefore a date-time, got %v and %v", props["lane"], props["notBefore"])
+	}
+	var update struct {
+		Properties map[string]map[string]any `json:"properties"`
+	}
+	_ = json.Unmarshal(doc.Components.Schemas["UpdateTxParams"], &update)
+	if typ, _ := json.Marshal(update.Properties["tip"]["type"]); string(typ) != `["integer","null"]` {
+		t.Fatalf("expected tip nullable, got %s", typ)
+	}
+	if _, ok := doc.Components.Schemas["Block"]; !ok {
+		t.Fatalf("expected blockDTO named Block")
+	}
+}
+
+func TestClientAgainstNode(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10, BlockInterval: time.Second})
+	srv := httptest.NewServer(http.HandlerFunc(n.handleRPC))
+	t.Cleanup(srv.Close)
+	c := client.New(srv.URL)
+	ctx := context.Background()
+
+	added, err := c.TxAdd(ctx, client.AddTxParams{Sender: "alice", Recipient: "bob", Fee: 10, Gas: 5, Lane: "urgent"})
+	if err != nil || added.TxID == "" {
+		t.Fatalf("add: %+v, %v", added, err)
+	}
+	var rpcErr *client.Error
+	if _, err := c.TxUpdate(ctx, client.UpdateTxParams{ID: "nope", Fee: 20}); !errors.As(err, &rpcErr) || rpcErr.Code != http.StatusOK {
+		t.Fatalf("expected a refusal updating an unknown tx, got %v", err)
+	}
+	if _, err := c.TxUpdate(ctx, client.UpdateTxParams{ID: added.TxID, Fee: 20}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	list, err := c.TxList(ctx, client.ListTxParams{Sender: "alice"})
+	if err != nil || list.Total != 1 || list.Transactions[0].Fee != 20 || list.Transactions[0].Lane != "urgent" {
+		t.Fatalf("list: %+v, %v", list, err)
+	}
+	if _, err := c.TxRemove(ctx, client.RemoveTxParams{}); !errors.As(err, &rpcErr) || rpcErr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without an id, got %v", err)
+	}
+
+	if _, err := c.DebugMine(ctx, client.MineParams{Count: 1}); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	got, err := c.BlockGet(ctx, client.BlockGetParams{})
+	if err != nil || len(got.Block.Transactions) != 1 || got.Block.Transactions[0].ID != added.TxID {
+		t.Fatalf("get: %+v, %v", got, err)
+	}
+	stream, err := c.BlockExport(ctx)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	defer stream.Close()
+	var exported client.Block
+	if err := json.NewDecoder(stream).Decode(&exported); err != nil || exported.Hash != got.Block.Hash {
+		t.Fatalf("expected the block exported, got %+v, %v", exported, err)
+	}
+}
@@ -0,0 +1,114 @@
+package mempoor
+
+import (
+	"slices"
+	"sync"
+)
+
+// DefaultFeeOracleWindow is the number of recent blocks a FeeOracle
+// remembers when constructed with a window <= 0.
+const DefaultFeeOracleWindow = 20
+
+// FeePercentiles summarizes a set of fees (nearest-rank percentiles).
+// All fields are zero when Count is zero.
+type FeePercentiles struct {
+	Count int    `json:"count"`
+	P25   uint64 `json:"p25"`
+	P50   uint64 `json:"p50"`
+	P90   uint64 `json:"p90"`
+}
+
+// FeeEstimate is the FeeOracle's current view.
+type FeeEstimate struct {
+	Pending  FeePercentiles `json:"pending"`  // txs waiting in the mempool
+	Included FeePercentiles `json:"included"` // txs in the last Window blocks
+	Window   int            `json:"window"`   // blocks sampled for Included
+}
+
+// FeeOracle suggests fees from what is pending and what recently made it
+// into blocks. It is refreshed by the node once per block tick and read
+// by RPC handlers; estimates are cached so reads are cheap.
+type FeeOracle struct {
+	mu     sync.RWMutex
+	window int
+	recent [][]uint64 // fees per block, oldest first, len <= window
+	est    FeeEstimate
+}
+
+// NewFeeOracle returns an oracle that samples the last window blocks.
+// window <= 0 means DefaultFeeOracleWindow.
+func NewFeeOracle(window int) *FeeOracle {
+	if window <= 0 {
+		window = DefaultFeeOracleWindow
+	}
+	return &FeeOracle{window: window, est: FeeEstimate{Window: window}}
+}
+
+// Refresh recomputes the estimate from the pool's pending txs and, if
+// block is non-nil, records its fees as the newest sample.
+//
+// PERF: O(n log n) over the pending pool per call; called once per
+// block tick, not per request.
+func (o *FeeOracle) Refresh(mp Mempool, block *Block) {
+	var pending []uint64
+	mp.Iterate(func(tx *Tx) bool {
+		pending = append(pending, tx.Fee)
+		return true
+	})
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if block != nil {
+		fees := make([]uint64, len(block.Transactions))
+		for i, tx := range block.Transactions {
+			fees[i] = tx.Fee
+		}
+		o.recent = append(o.recent, fees)
+		if len(o.recent) > o.window {
+			o.recent = o.recent[len(o.recent)-o.window:]
+		}
+	}
+
+	var included []uint64
+	for _, fees := range o.recent {
+		included = append(included, fees...)
+	}
+
+	o.est = FeeEstimate{
+		Pending:  percentiles(pending),
+		Included: percentiles(included),
+		Window:   o.window,
+	}
+}
+
+// Estimate returns the estimate computed by the last Refresh.
+func (o *FeeOracle) Estimate() FeeEstimate {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.est
+}
+
+// percentiles sorts fees in place and summarizes them.
+func percentiles(fees []uint64) FeePercentiles {
+	if len(fees) == 0 {
+		return FeePercentiles{}
+	}
+	slices.Sort(fees)
+	return FeePercentiles{
+		Count: len(fees),
+		P25:   nearestRank(fees, 25),
+		P50:   nearestRank(fees, 50),
+		P90:   nearestRank(fees, 90),
+	}
+}
+
+// nearestRank returns the p-th percentile of sorted (non-empty) fees.
+func nearestRank(sorted []uint64, p int) uint64 {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,43 @@
+package mempoor
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFeeOraclePendingAndIncluded(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	for i := 1; i <= 10; i++ {
+		if _, err := mp.Add(newTx(fmt.Sprintf("s%d", i), uint64(i*10), 10)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	o := NewFeeOracle(2)
+	o.Refresh(mp, nil)
+	est := o.Estimate()
+	want := FeePercentiles{Count: 10, P25: 30, P50: 50, P90: 90}
+	if est.Pending != want {
+		t.Fatalf("pending: got %+v want %+v", est.Pending, want)
+	}
+	if est.Included.Count != 0 {
+		t.Fatalf("expected no included samples yet, got %+v", est.Included)
+	}
+
+	// Three blocks through a window of two: only the last two count.
+	for _, fees := range [][]uint64{{1000}, {1, 2}, {3, 4}} {
+		blk := &Block{}
+		for _, f := range fees {
+			blk.Transactions = append(blk.Transactions, &Tx{Fee: f})
+		}
+		o.Refresh(mp, blk)
+	}
+	est = o.Estimate()
+	want = FeePercentiles{Count: 4, P25: 1, P50: 2, P90: 4}
+	if est.Included != want {
+		t.Fatalf("included: got %+v want %+v", est.Included, want)
+	}
+	if est.Window != 2 {
+		t.Fatalf("expected window 2, got %d", est.Window)
+	}
+}
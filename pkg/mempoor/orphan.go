@@ -0,0 +1,151 @@
+package mempoor
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultOrphanTTL is used when MempoolConfig.OrphanTTL is zero.
+const DefaultOrphanTTL = 10 * time.Minute
+
+// orphan is a tx parked until its dependency becomes pending.
+type orphan struct {
+	tx      *Tx
+	addedAt time.Time
+}
+
+// missingDependency reports whether tx must wait in the orphan area:
+//   - ParentID is set and the parent is not pending, or
+//   - Nonce N > 0, no pending tx from the sender has nonce N-1, and the
+//     mempool has not already selected nonce N-1 (or later) for a block.
+//
+// NOTE: A parent that was already included in a block is not tracked, so
+// a child arriving after its parent was mined waits until it expires.
+//
+// Caller must hold the write lock.
+func (m *mempool) missingDependency(tx *Tx) bool {
+	if tx.ParentID != "" {
+		if _, ok := m.table[tx.ParentID]; !ok {
+			return true
+		}
+	}
+
+	if tx.Nonce == 0 || m.nextNonce[tx.Sender] >= tx.Nonce {
+		return false
+	}
+	for _, rec := range m.senders[tx.Sender] {
+		if rec.tx.Nonce == tx.Nonce-1 {
+			return false
+		}
+	}
+	return true
+}
+
+// addOrphan parks tx, evicting the oldest orphan when the area is full.
+// Returns the IDs of evicted orphans. Caller must hold the write lock.
+func (m *mempool) addOrphan(tx *Tx) []TxID {
+	var evicted []TxID
+	for len(m.orphans) >= m.cfg.MaxOrphans {
+		var oldest *orphan
+		for _, o := range m.orphans {
+			if oldest == nil || o.addedAt.Before(oldest.addedAt) {
+				oldest = o
+			}
+		}
+		delete(m.orphans, oldest.tx.ID)
+		evicted = append(evicted, oldest.tx.ID)
+		m.dropped(TxEvicted, oldest.tx)
+	}
+
+	m.orphans[tx.ID] = &orphan{tx: tx, addedAt: m.cfg.Clock.Now()}
+	m.events.publish(TxOrphaned, tx)
+	return evicted
+}
+
+// expireOrphans drops orphans older than OrphanTTL.
+// Caller must hold the write lock.
+func (m *mempool) expireOrphans(now time.Time) {
+	ttl := m.cfg.OrphanTTL
+	if ttl == 0 {
+		ttl = DefaultOrphanTTL
+	}
+
+	for id, o := range m.orphans {
+		if now.Sub(o.addedAt) > ttl {
+			delete(m.orphans, id)
+			m.dropped(TxEvicted, o.tx)
+		}
+	}
+}
+
+// promoteOrphans moves every orphan whose dependency is now pending into
+// the main heap, repeating until no more can move (promoting nonce N may
+// unblock N+1). Orphans that lose the capacity check are dropped.
+// Returns the IDs evicted along the way. Caller must hold the write lock.
+//
+// PERF: Each pass scans the whole orphan area, which is bounded by
+// MaxOrphans. Index orphans by parent ID / (sender, nonce) if that bound
+// grows large.
+func (m *mempool) promoteOrphans() []TxID {
+	var evicted []TxID
+
+	for promoted := true; promoted; {
+		promoted = false
+
+		// Promote in nonce order so a sender's chain resolves in one pass.
+		ready := make([]*Tx, 0)
+		for _, o := range m.orphans {
+			if !m.missingDependency(o.tx) {
+				ready = append(ready, o.tx)
+			}
+		}
+		sort.Slice(ready, func(i, j int) bool { return ready[i].Nonce < ready[j].Nonce })
+
+		for _, tx := range ready {
+			delete(m.orphans, tx.ID)
+			ev, err := m.admit(tx)
+			if err != nil {
+				evicted = append(evicted, tx.ID)
+				m.dropped(TxEvicted, tx)
+				continue
+			}
+			evicted = append(evicted, ev...)
+			promoted = true
+		}
+	}
+
+	return evicted
+}
+
+// recordSelectedNonce remembers that tx's nonce is taken so later
+// nonces from the same sender are not orphaned.
+// Caller must hold the write lock.
+//
+// NOTE: The map keeps one entry per sender ever selected.
+func (m *mempool) recordSelectedNonce(tx *Tx) {
+	if m.cfg.MaxOrphans == 0 {
+		return
+	}
+	if next := tx.Nonce + 1; next > m.nextNonce[tx.Sender] {
+		m.nextNonce[tx.Sender] = next
+	}
+}
+
+// Orphans returns a copy of the txs currently parked in the orphan area,
+// oldest first.
+func (m *mempool) Orphans() []*Tx {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recs := make([]*orphan, 0, len(m.orphans))
+	for _, o := range m.orphans {
+		recs = append(recs, o)
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].addedAt.Before(recs[j].addedAt) })
+
+	out := make([]*Tx, 0, len(recs))
+	for _, o := range recs {
+		out = append(out, o.tx)
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package mempoor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrphanNonceGapPromotedWhenFilled(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxOrphans: 10})
+
+	n2 := NewUnsignedTx("alice", "bob", "third", 2, 10, 10)
+	n1 := NewUnsignedTx("alice", "bob", "second", 1, 10, 10)
+	n0 := NewUnsignedTx("alice", "bob", "first", 0, 10, 10)
+
+	if _, err := mp.Add(n2); err != ErrTxOrphaned {
+		t.Fatalf("expected ErrTxOrphaned for nonce gap, got %v", err)
+	}
+	if _, err := mp.Add(n1); err != ErrTxOrphaned {
+		t.Fatalf("expected ErrTxOrphaned for nonce gap, got %v", err)
+	}
+	if len(mp.List()) != 0 || len(mp.Orphans()) != 2 {
+		t.Fatalf("expected 2 orphans and empty pool")
+	}
+
+	// Filling nonce 0 must cascade-promote 1 and then 2.
+	if _, err := mp.Add(n0); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	if len(mp.List()) != 3 || len(mp.Orphans()) != 0 {
+		t.Fatalf("expected all 3 txs promoted; pool=%d orphans=%d", len(mp.List()), len(mp.Orphans()))
+	}
+}
+
+func TestOrphanNextNonceAfterSelection(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxOrphans: 10})
+
+	_, _ = mp.Add(NewUnsignedTx("alice", "bob", "first", 0, 10, 10))
+	_ = mp.SelectTransactions(BlockConstraints{MaxTx: 10})
+
+	// Nonce 0 has been selected already, so nonce 1 is not an orphan.
+	if _, err := mp.Add(NewUnsignedTx("alice", "bob", "second", 1, 10, 10)); err != nil {
+		t.Fatalf("expected nonce 1 admitted after nonce 0 was selected, got %v", err)
+	}
+}
+
+func TestOrphanParentID(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxOrphans: 10})
+
+	parent := newTx("alice", 10, 10)
+	child := newTx("carol", 10, 10)
+	child.ParentID = parent.ID
+
+	if _, err := mp.Add(child); err != ErrTxOrphaned {
+		t.Fatalf("expected child orphaned, got %v", err)
+	}
+	if _, err := mp.Add(parent); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	if _, err := mp.Get(child.ID); err != nil {
+		t.Fatalf("expected child promoted once parent arrived")
+	}
+}
+
+func TestOrphanAreaBoundedEvictsOldest(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxOrphans: 1})
+
+	first := NewUnsignedTx("alice", "bob", "a", 5, 10, 10)
+	second := NewUnsignedTx("carol", "bob", "b", 5, 10, 10)
+
+	_, _ = mp.Add(first)
+	evicted, err := mp.Add(second)
+	if err != ErrTxOrphaned {
+		t.Fatalf("expected ErrTxOrphaned, got %v", err)
+	}
+	if len(evicted) != 1 || evicted[0] != first.ID {
+		t.Fatalf("expected oldest orphan evicted, got %v", evicted)
+	}
+	if o := mp.Orphans(); len(o) != 1 || o[0].ID != second.ID {
+		t.Fatalf("expected only second orphan left, got %+v", o)
+	}
+}
+
+func TestOrphanExpiry(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxOrphans: 10, OrphanTTL: time.Millisecond})
+
+	_, _ = mp.Add(NewUnsignedTx("alice", "bob", "a", 5, 10, 10))
+	time.Sleep(5 * time.Millisecond)
+
+	// Any Add sweeps expired orphans.
+	_, _ = mp.Add(newTx("carol", 10, 10))
+
+	if len(mp.Orphans()) != 0 {
+		t.Fatalf("expected expired orphan to be dropped")
+	}
+}
+
+func TestOrphanDisabledAdmitsGaps(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	if _, err := mp.Add(NewUnsignedTx("alice", "bob", "a", 5, 10, 10)); err != nil {
+		t.Fatalf("expected gap admitted with orphans disabled, got %v", err)
+	}
+}
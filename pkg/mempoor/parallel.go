@@ -0,0 +1,86 @@
+package mempoor
+
+// Parallel execution semantics (BlockSelectionResult.Groups, Block.Groups):
+//   - Txs conflict when they touch a common account, as Sender or
+//     Recipient, when one depends on the other (ParentID or DependsOn),
+//     or when they share a bundle. Conflicts are transitive: a group is
+//     a connected component of the conflict graph.
+//   - Each group lists indices into the block's Transactions, ascending;
+//     groups are ordered by their first index. Every tx is in exactly
+//     one group.
+//   - Groups touch disjoint accounts, so an executor may run them in
+//     parallel. Within a group, txs must run in block order.
+//   - Groups are a hint derived from Transactions: they are not hashed
+//     and do not change which txs are selected or in what order.
+//
+// PERF: O(n α(n)) per block, a union-find over the block's txs.
+
+// ParallelGroups partitions txs into groups that touch disjoint
+// accounts. See "Parallel execution semantics".
+func ParallelGroups(txs []*Tx) [][]int {
+	if len(txs) == 0 {
+		return nil
+	}
+
+	parent := make([]int, len(txs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) {
+		parent[find(i)] = find(j)
+	}
+
+	// Each key is owned by the first tx that touched it; later txs
+	// touching it join that tx's group.
+	owner := make(map[string]int)
+	touch := func(key string, i int) {
+		if j, ok := owner[key]; ok {
+			union(i, j)
+			return
+		}
+		owner[key] = i
+	}
+	byID := make(map[TxID]int, len(txs))
+	for i, tx := range txs {
+		byID[tx.ID] = i
+	}
+
+	for i, tx := range txs {
+		touch("a:"+tx.Sender, i)
+		if tx.Recipient != "" {
+			touch("a:"+tx.Recipient, i)
+		}
+		if tx.BundleID != "" {
+			touch("b:"+tx.BundleID, i)
+		}
+		if j, ok := byID[tx.ParentID]; ok && tx.ParentID != "" {
+			union(i, j)
+		}
+		for _, dep := range tx.DependsOn {
+			if j, ok := byID[dep]; ok {
+				union(i, j)
+			}
+		}
+	}
+
+	var groups [][]int
+	slot := make(map[int]int) // root → position in groups
+	for i := range txs {
+		r := find(i)
+		g, ok := slot[r]
+		if !ok {
+			g = len(groups)
+			slot[r] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
@@ -0,0 +1,62 @@
+package mempoor
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParallelGroups(t *testing.T) {
+	mk := func(id, sender, recipient string) *Tx {
+		return &Tx{ID: TxID(id), Sender: sender, Recipient: recipient}
+	}
+	dep := mk("e", "erin", "frank")
+	dep.DependsOn = []TxID{"d"}
+
+	txs := []*Tx{
+		mk("a", "alice", "bob"),
+		mk("b", "carol", "dan"),
+		mk("c", "bob", "zed"), // receives from a's recipient
+		mk("d", "gus", ""),
+		dep,
+		mk("f", "hal", "ivy"),
+	}
+	want := [][]int{{0, 2}, {1}, {3, 4}, {5}}
+	if got := ParallelGroups(txs); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := ParallelGroups(nil); got != nil {
+		t.Fatalf("expected no groups, got %v", got)
+	}
+}
+
+func TestBuildBlockGroups(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	a := NewUnsignedTx("alice", "bob", "", 0, 30, 10)
+	b := NewUnsignedTx("carol", "dan", "", 0, 20, 10)
+	c := NewUnsignedTx("dan", "erin", "", 0, 10, 10)
+	for _, tx := range []*Tx{a, b, c} {
+		if _, err := mp.Add(tx); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+	}
+
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{MaxTxPerBlock: 10})
+	tmpl, err := builder.BuildTemplate(context.Background(), [32]byte{}, 0, time.Unix(1, 0).UTC())
+	if err != nil {
+		t.Fatalf("unexpected template error: %v", err)
+	}
+	blk, err := builder.BuildBlock(context.Background(), [32]byte{}, 0, time.Unix(1, 0).UTC())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]int{{0}, {1, 2}}
+	if !reflect.DeepEqual(blk.Groups, want) || !reflect.DeepEqual(tmpl.Groups, want) {
+		t.Fatalf("expected groups %v, got %v (template %v)", want, blk.Groups, tmpl.Groups)
+	}
+	if tmpl.Hash() != blk.Hash() {
+		t.Fatalf("groups must not change the hash")
+	}
+}
@@ -0,0 +1,303 @@
+package mempoor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPeerInterval is how often the node polls its peers when
+// NodeConfig.PeerInterval is 0.
+const DefaultPeerInterval = 5 * time.Second
+
+var (
+	// ErrInvalidPeer is returned for a peer address that is not
+	// host:port.
+	ErrInvalidPeer = errors.New("blockbuilder: invalid peer address")
+
+	// ErrUnknownPeer is returned when peer.remove names a peer the node
+	// does not have.
+	ErrUnknownPeer = errors.New("blockbuilder: unknown peer")
+
+	// ErrDuplicatePeer is returned when peer.add names a peer the node
+	// already has.
+	ErrDuplicatePeer = errors.New("blockbuilder: peer already added")
+)
+
+// PeerState is a peer's connection state.
+type PeerState string
+
+const (
+	PeerConnecting   PeerState = "connecting"   // added, not polled yet
+	PeerConnected    PeerState = "connected"    // the last poll succeeded
+	PeerDisconnected PeerState = "disconnected" // the last poll failed
+)
+
+// PeerInfo describes one peer, as peer.list serves it.
+//
+// Peer semantics (NodeConfig.Peers, peer.add, peer.remove):
+//   - A peer is another mempoor node, named by the host:port of its RPC
+//     server. The node polls every peer's node.status each PeerInterval
+//     (0 = DefaultPeerInterval), with the interval as the timeout.
+//   - A poll that answers moves the peer to PeerConnected and records
+//     its tip height as LastSeenHeight, with the time in LastSeen; one
+//     that fails moves it to PeerDisconnected with the error in
+//     LastError. The peer stays listed and is polled again next time.
+//   - Sent and Received count RPC requests to the peer and responses
+//     decoded from it; Failures counts failed requests.
+//   - Peers added over RPC are not persisted: a restart starts from
+//     NodeConfig.Peers again.
+//
+// NOTE: Peers are only observed so far; no txs or blocks are exchanged
+// with them.
+type PeerInfo struct {
+	Address        string    `json:"address"`
+	State          PeerState `json:"state"`
+	LastSeenHeight uint64    `json:"lastSeenHeight"`
+	LastSeen       time.Time `json:"lastSeen,omitzero"`
+	LastError      string    `json:"lastError,omitempty"`
+	Sent           uint64    `json:"sent"`
+	Received       uint64    `json:"received"`
+	Failures       uint64    `json:"failures"`
+}
+
+// peerSet is the node's peers by address.
+type peerSet struct {
+	mu    sync.Mutex
+	peers map[string]*PeerInfo
+}
+
+func newPeerSet(addrs []string) *peerSet {
+	s := &peerSet{peers: make(map[string]*PeerInfo, len(addrs))}
+	for _, addr := range addrs {
+		_ = s.add(addr)
+	}
+	return s
+}
+
+// checkPeerAddress rejects anything but a host:port address.
+func checkPeerAddress(addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || port == "" || strings.ContainsAny(host, "/ ") {
+		return fmt.Errorf("%w: %q", ErrInvalidPeer, addr)
+	}
+	return nil
+}
+
+func (s *peerSet) add(addr string) error {
+	if err := checkPeerAddress(addr); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.peers[addr]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicatePeer, addr)
+	}
+	s.peers[addr] = &PeerInfo{Address: addr, State: PeerConnecting}
+	return nil
+}
+
+func (s *peerSet) remove(addr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.peers[addr]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownPeer, addr)
+	}
+	delete(s.peers, addr)
+	return nil
+}
+
+// list returns a copy of every peer, by address.
+func (s *peerSet) list() []PeerInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]PeerInfo, 0, len(s.peers))
+	for _, p := range s.peers {
+		out = append(out, *p)
+	}
+	slices.SortFunc(out, func(a, b PeerInfo) int { return strings.Compare(a.Address, b.Address) })
+	return out
+}
+
+// update applies fn to the peer at addr, if it is still there.
+func (s *peerSet) update(addr string, fn func(p *PeerInfo)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.peers[addr]; ok {
+		fn(p)
+	}
+}
+
+// AddPeer adds a peer by RPC address; see "Peer semantics".
+func (n *Node) AddPeer(addr string) error {
+	if err := n.peers.add(addr); err != nil {
+		return err
+	}
+	n.log.node.Info("peer added", "peer", addr)
+	return nil
+}
+
+// RemovePeer removes the peer at addr.
+func (n *Node) RemovePeer(addr string) error {
+	if err := n.peers.remove(addr); err != nil {
+		return err
+	}
+	n.log.node.Info("peer removed", "peer", addr)
+	return nil
+}
+
+// Peers returns the node's peers, by address.
+func (n *Node) Peers() []PeerInfo {
+	return n.peers.list()
+}
+
+func (n *Node) peerInterval() time.Duration {
+	if n.cfg.PeerInterval > 0 {
+		return n.cfg.PeerInterval
+	}
+	return DefaultPeerInterval
+}
+
+// runPeers polls every peer each PeerInterval until ctx ends.
+func (n *Node) runPeers(ctx context.Context) {
+	ticker := time.NewTicker(n.peerInterval())
+	defer ticker.Stop()
+
+	for {
+		n.pollPeers(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollPeers polls every peer once, concurrently.
+func (n *Node) pollPeers(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, p := range n.peers.list() {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			n.pollPeer(ctx, addr)
+		}(p.Address)
+	}
+	wg.Wait()
+}
+
+// pollPeer asks the peer at addr for its status and records the outcome.
+func (n *Node) pollPeer(ctx context.Context, addr string) {
+	ctx, cancel := context.WithTimeout(ctx, n.peerInterval())
+	defer cancel()
+
+	var st NodeStatus
+	err := n.callPeer(ctx, addr, "node.status", nil, &st)
+	now := n.cfg.Clock.Now()
+	n.peers.update(addr, func(p *PeerInfo) {
+		if err != nil {
+			if p.State != PeerDisconnected {
+				n.log.node.Warn("peer unreachable", "peer", addr, "err", err)
+			}
+			p.State, p.LastError = PeerDisconnected, err.Error()
+			return
+		}
+		if p.State != PeerConnected {
+			n.log.node.Info("peer connected", "peer", addr, "height", st.Height)
+		}
+		p.State, p.LastError = PeerConnected, ""
+		p.LastSeenHeight, p.LastSeen = st.Height, now
+	})
+}
+
+// callPeer makes one RPC call to the peer at addr and decodes its
+// result into out, counting it in the peer's counters.
+func (n *Node) callPeer(ctx context.Context, addr, method string, params, out any) error {
+	n.peers.update(addr, func(p *PeerInfo) { p.Sent++ })
+	err := postRPC(ctx, addr, method, params, out)
+	n.peers.update(addr, func(p *PeerInfo) {
+		if err != nil {
+			p.Failures++
+		} else {
+			p.Received++
+		}
+	})
+	return err
+}
+
+// postRPC sends one RPC request to the node at addr.
+func postRPC(ctx context.Context, addr, method string, params, out any) error {
+	body, err := json.Marshal(struct {
+		Method string `json:"method"`
+		Params any    `json:"params,omitempty"`
+	}{method, params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+addr+"/rpc", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Result json.RawMessage `json:"result"`
+		Error  string          `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("%s: %s", method, resp.Status)
+	}
+	if res.Error != "" {
+		return fmt.Errorf("%s: %s", method, res.Error)
+	}
+	if out != nil {
+		return json.Unmarshal(res.Result, out)
+	}
+	return nil
+}
+
+type peerParams struct {
+	Address string `json:"address"`
+}
+
+type peerListResult struct {
+	Peers []PeerInfo `json:"peers"`
+}
+
+// rpcPeerChange serves peer.add and peer.remove.
+func (n *Node) rpcPeerChange(w http.ResponseWriter, method string, params json.RawMessage) {
+	var p peerParams
+	if err := json.Unmarshal(params, &p); err != nil || p.Address == "" {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for "+method)
+		return
+	}
+
+	var err error
+	if method == "peer.add" {
+		err = n.AddPeer(p.Address)
+	} else {
+		err = n.RemovePeer(p.Address)
+	}
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+	writeRPCResult(w, http.StatusOK, peerListResult{Peers: n.Peers()})
+}
+
+func (n *Node) rpcPeerList(w http.ResponseWriter) {
+	writeRPCResult(w, http.StatusOK, peerListResult{Peers: n.Peers()})
+}
@@ -0,0 +1,104 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// servePeer runs n's RPC on a test server and returns its host:port.
+func servePeer(t *testing.T, n *Node) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(n.handleRPC))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestPeersTrackRemoteTip(t *testing.T) {
+	remote := NewNode(NodeConfig{MaxTxPerBlock: 10, BlockInterval: time.Second})
+	if err := remote.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	_, _ = remote.mempool.Add(newTx("alice", 5, 10))
+	if _, err := remote.Mine(context.Background(), 1); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	addr := servePeer(t, remote)
+
+	n := NewNode(NodeConfig{PeerInterval: time.Second})
+	if err := n.AddPeer(addr); err != nil {
+		t.Fatalf("add peer: %v", err)
+	}
+	if err := n.AddPeer(addr); !errors.Is(err, ErrDuplicatePeer) {
+		t.Fatalf("expected ErrDuplicatePeer, got %v", err)
+	}
+	if err := n.AddPeer("not-an-address"); !errors.Is(err, ErrInvalidPeer) {
+		t.Fatalf("expected ErrInvalidPeer, got %v", err)
+	}
+	if got := n.Peers(); len(got) != 1 || got[0].State != PeerConnecting {
+		t.Fatalf("expected one connecting peer, got %+v", got)
+	}
+
+	n.pollPeers(context.Background())
+	p := n.Peers()[0]
+	if p.State != PeerConnected || p.LastSeenHeight != 1 || p.LastSeen.IsZero() {
+		t.Fatalf("expected a connected peer at height 1, got %+v", p)
+	}
+	if p.Sent != 1 || p.Received != 1 || p.Failures != 0 {
+		t.Fatalf("expected 1 sent and received, got %+v", p)
+	}
+
+	if err := n.RemovePeer(addr); err != nil || len(n.Peers()) != 0 {
+		t.Fatalf("expected the peer removed, got %v, %+v", err, n.Peers())
+	}
+	if err := n.RemovePeer(addr); !errors.Is(err, ErrUnknownPeer) {
+		t.Fatalf("expected ErrUnknownPeer, got %v", err)
+	}
+}
+
+func TestPeerUnreachableIsDisconnected(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	addr := strings.TrimPrefix(srv.URL, "http://")
+	srv.Close()
+
+	n := NewNode(NodeConfig{Peers: []string{addr}, PeerInterval: time.Second})
+	n.pollPeers(context.Background())
+	p := n.Peers()[0]
+	if p.State != PeerDisconnected || p.LastError == "" || p.Failures != 1 {
+		t.Fatalf("expected a disconnected peer with an error, got %+v", p)
+	}
+}
+
+func TestPeerRPC(t *testing.T) {
+	n := NewNode(NodeConfig{AdminToken: "secret"})
+
+	// peer.add is an admin method.
+	if rec := callRPC(n, `{"method":"peer.add","params":{"address":"10.0.0.2:8080"}}`); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without the token, got %d", rec.Code)
+	}
+
+	rec := callRPCWithToken(n, `{"method":"peer.add","params":{"address":"10.0.0.2:8080"}}`, "secret")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	rec = callRPC(n, `{"method":"peer.list"}`)
+	var resp struct {
+		Result peerListResult `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := resp.Result.Peers; len(got) != 1 || got[0].Address != "10.0.0.2:8080" || got[0].State != PeerConnecting {
+		t.Fatalf("expected the added peer, got %+v", got)
+	}
+
+	rec = callRPCWithToken(n, `{"method":"peer.remove","params":{"address":"10.0.0.9:8080"}}`, "secret")
+	if !strings.Contains(rec.Body.String(), ErrUnknownPeer.Error()) {
+		t.Fatalf("expected ErrUnknownPeer, got %s", rec.Body)
+	}
+}
@@ -0,0 +1,148 @@
+package mempoor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// mempoolFile is the name of the mempool dump inside NodeConfig.DataDir.
+const mempoolFile = "mempool.json"
+
+// loadMempool re-seeds the mempool from DataDir: the last snapshot plus
+// any journal entries written after it. A missing DataDir, snapshot, or
+// journal is not an error: the node simply starts with what it finds.
+func (n *Node) loadMempool() error {
+	if n.cfg.DataDir == "" {
+		return nil
+	}
+
+	raw, err := os.ReadFile(filepath.Join(n.cfg.DataDir, mempoolFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("read mempool: %w", err)
+	}
+
+	var txs []*Tx
+	if err == nil {
+		if txs, err = DecodeDumpJSON(raw); err != nil {
+			return fmt.Errorf("decode mempool: %w", err)
+		}
+	}
+
+	if n.journal != nil {
+		byID := make(map[TxID]*Tx, len(txs))
+		for _, tx := range txs {
+			byID[tx.ID] = tx
+		}
+		for _, name := range []string{journalOldFile, journalFile} {
+			if err := replayJournal(filepath.Join(n.cfg.DataDir, name), byID); err != nil {
+				return err
+			}
+		}
+
+		txs = txs[:0]
+		for _, tx := range byID {
+			txs = append(txs, tx)
+		}
+	}
+
+	// Route each tx back to its pool; pools no longer configured fall
+	// back to the default one.
+	byPool := make(map[string][]*Tx, len(n.pools))
+	for _, tx := range txs {
+		if _, err := n.pool(tx.Pool); err != nil {
+			tx.Pool = ""
+		}
+		byPool[poolKey(tx.Pool)] = append(byPool[poolKey(tx.Pool)], tx)
+	}
+
+	for _, p := range n.pools {
+		ptxs := byPool[p.name]
+		// Txs held by a reservation at crash time come back on top of
+		// newer arrivals, which can exceed capacity: keep the best.
+		if p.maxTxs > 0 && len(ptxs) > p.maxTxs {
+			policy := p.mp.Policy()
+			sort.Slice(ptxs, func(i, j int) bool { return policy.Less(ptxs[i], ptxs[j]) })
+			ptxs = ptxs[:p.maxTxs]
+		}
+
+		// Restore keeps Timestamp, so scheduling order survives reload.
+		if err := p.mp.Restore(ptxs); err != nil {
+			return fmt.Errorf("restore mempool %s: %w", p.name, err)
+		}
+	}
+	return nil
+}
+
+// checkpoint folds the journal into a fresh snapshot. The journal is
+// rotated before the snapshot is taken, so every rotated entry is
+// covered by it and can be dropped once the snapshot is on disk.
+// Without a journal this is just storeMempool.
+func (n *Node) checkpoint() error {
+	if n.journal == nil {
+		return n.storeMempool()
+	}
+
+	if err := n.journal.rotate(); err != nil {
+		return err
+	}
+	if err := n.storeMempool(); err != nil {
+		return err
+	}
+	return n.journal.dropRotated()
+}
+
+// storeMempool writes the current mempool to DataDir. The dump is
+// written to a temp file and renamed so a crash mid-write never
+// leaves a truncated file behind.
+func (n *Node) storeMempool() error {
+	if n.cfg.DataDir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(n.cfg.DataDir, 0o755); err != nil {
+		return fmt.Errorf("create data dir: %w", err)
+	}
+
+	raw, err := n.marshalPools()
+	if err != nil {
+		return fmt.Errorf("encode mempool: %w", err)
+	}
+
+	path := filepath.Join(n.cfg.DataDir, mempoolFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("write mempool: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write mempool: %w", err)
+	}
+	return nil
+}
+
+// marshalPools encodes every pool as one canonical Dump; Tx.Pool says
+// where each tx goes on reload.
+func (n *Node) marshalPools() ([]byte, error) {
+	if len(n.pools) == 1 {
+		return n.mempool.MarshalJSON()
+	}
+
+	var all []*Tx
+	for _, p := range n.pools {
+		raw, err := p.mp.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		txs, err := DecodeDumpJSON(raw)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, txs...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return json.Marshal(Dump{Version: DumpVersion, Txs: all})
+}
@@ -0,0 +1,47 @@
+package mempoor
+
+import (
+	"testing"
+)
+
+func TestMempoolPersistRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	n1 := NewNode(NodeConfig{DataDir: dir})
+	tx1 := newTx("alice", 10, 100)
+	tx2 := newTx("carol", 20, 200)
+	_, _ = n1.mempool.Add(tx1)
+	_, _ = n1.mempool.Add(tx2)
+
+	if err := n1.storeMempool(); err != nil {
+		t.Fatalf("unexpected store error: %v", err)
+	}
+
+	n2 := NewNode(NodeConfig{DataDir: dir})
+	if err := n2.loadMempool(); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+
+	if len(n2.mempool.List()) != 2 {
+		t.Fatalf("expected 2 txs after reload, got %d", len(n2.mempool.List()))
+	}
+
+	got, err := n2.mempool.Get(tx1.ID)
+	if err != nil {
+		t.Fatalf("expected tx1 after reload: %v", err)
+	}
+	if got.Fee != tx1.Fee || !got.Timestamp.Equal(tx1.Timestamp) || !got.CreatedAt.Equal(tx1.CreatedAt) {
+		t.Fatalf("reloaded tx differs: got %+v want %+v", got, tx1)
+	}
+}
+
+func TestMempoolLoadMissingFileIsEmpty(t *testing.T) {
+	n := NewNode(NodeConfig{DataDir: t.TempDir()})
+
+	if err := n.loadMempool(); err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if len(n.mempool.List()) != 0 {
+		t.Fatalf("expected empty mempool")
+	}
+}
@@ -0,0 +1,52 @@
+package mempoor
+
+import "sort"
+
+// Pin semantics:
+//   - A pinned tx is never evicted for capacity and never purged for
+//     Fee < MinFee; a low-fee pinned tx is selectable like any other.
+//   - It still obeys gas limits, lane quotas, nonce order, and
+//     dependencies at selection.
+//   - The pin lasts until Unpin, or until the tx is committed or dropped;
+//     a reservation rollback keeps it. Pins are not persisted.
+//   - If every pending tx is pinned, a full pool rejects new txs with
+//     ErrMempoolFull instead of evicting.
+
+// Pin protects a pending tx from eviction and MinFee purges.
+// Strict: ErrTxNotFound if id is not pending.
+func (m *mempool) Pin(id TxID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.table[id]; !ok {
+		return ErrTxNotFound
+	}
+	m.pinned[id] = struct{}{}
+	return nil
+}
+
+// Unpin removes the protection added by Pin. Unpinning a tx that is not
+// pinned is a no-op; ErrTxNotFound only if id is not pending.
+func (m *mempool) Unpin(id TxID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.table[id]; !ok {
+		return ErrTxNotFound
+	}
+	delete(m.pinned, id)
+	return nil
+}
+
+// Pinned returns the IDs of the pinned txs, sorted.
+func (m *mempool) Pinned() []TxID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]TxID, 0, len(m.pinned))
+	for id := range m.pinned {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,87 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPinnedTxSurvivesEviction(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 2})
+
+	cheap := newTx("alice", 1, 10)
+	mid := newTx("carol", 5, 10)
+	_, _ = mp.Add(cheap)
+	_, _ = mp.Add(mid)
+	if err := mp.Pin(cheap.ID); err != nil {
+		t.Fatalf("pin: %v", err)
+	}
+
+	evicted, err := mp.Add(newTx("dan", 50, 10))
+	if err != nil || len(evicted) != 1 || evicted[0] != mid.ID {
+		t.Fatalf("expected the unpinned tx evicted, got %v, %v", evicted, err)
+	}
+
+	// Only pinned or better txs remain: a tx ranking below dan cannot
+	// displace the pinned one.
+	if _, err := mp.Add(newTx("erin", 2, 10)); !errors.Is(err, ErrTxUnderpriced) {
+		t.Fatalf("expected ErrTxUnderpriced, got %v", err)
+	}
+
+	_ = mp.Pin(evictedOrFail(t, mp, "dan"))
+	if _, err := mp.Add(newTx("frank", 100, 10)); !errors.Is(err, ErrMempoolFull) {
+		t.Fatalf("expected ErrMempoolFull with everything pinned, got %v", err)
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("invariants: %v", err)
+	}
+}
+
+// evictedOrFail returns the ID of sender's pending tx.
+func evictedOrFail(t *testing.T, mp Mempool, sender string) TxID {
+	t.Helper()
+	txs := mp.ListBySender(sender)
+	if len(txs) != 1 {
+		t.Fatalf("expected one pending tx from %s, got %d", sender, len(txs))
+	}
+	return txs[0].ID
+}
+
+func TestPinnedTxSkipsMinFeePurge(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	pinned := newTx("alice", 1, 10)
+	dropped := newTx("carol", 1, 10)
+	_, _ = mp.Add(pinned)
+	_, _ = mp.Add(dropped)
+	_ = mp.Pin(pinned.ID)
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 10, MinFee: 5})
+	if len(res.Purged) != 1 || res.Purged[0].ID != dropped.ID {
+		t.Fatalf("expected only the unpinned tx purged, got %v", res.Purged)
+	}
+	if len(res.Transactions) != 1 || res.Transactions[0].ID != pinned.ID {
+		t.Fatalf("expected the pinned tx selected, got %v", res.Transactions)
+	}
+	if len(mp.Pinned()) != 0 {
+		t.Fatalf("pin should be released once the tx is committed")
+	}
+}
+
+func TestPinnedTxObeysGasLimit(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	big := newTx("alice", 1, 1_000)
+	_, _ = mp.Add(big)
+	_ = mp.Pin(big.ID)
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 10, GasLimit: 100})
+	if len(res.Transactions) != 0 {
+		t.Fatalf("pinned tx must still respect the gas limit")
+	}
+	if err := mp.Unpin(big.ID); err != nil {
+		t.Fatalf("unpin: %v", err)
+	}
+	if err := mp.Pin("missing"); !errors.Is(err, ErrTxNotFound) {
+		t.Fatalf("expected ErrTxNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package mempoor
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// PriorityPolicy defines the scheduling order of pending transactions.
+// The mempool heap, eviction, Snapshot, and tx.list's "priority" sort all
+// consult the same policy, so block inclusion order and what operators
+// see in tx.list never diverge.
+//
+// Implementations must be a strict weak ordering and deterministic; end
+// with a TxID comparison to break ties.
+type PriorityPolicy interface {
+	// Less reports whether a should be scheduled before b.
+	Less(a, b *Tx) bool
+}
+
+// PriorityFunc adapts an ordinary function to a PriorityPolicy.
+type PriorityFunc func(a, b *Tx) bool
+
+// Less calls f(a, b).
+func (f PriorityFunc) Less(a, b *Tx) bool { return f(a, b) }
+
+// Built-in policies.
+var (
+	// FeeFirst orders by Fee DESC, Timestamp ASC, ID ASC. Default.
+	FeeFirst PriorityPolicy = PriorityFunc(feeFirst)
+
+	// FeePerGas orders by Fee/Gas DESC, then Timestamp ASC, ID ASC.
+	// A zero-gas tx with a non-zero fee ranks above any priced tx.
+	FeePerGas PriorityPolicy = PriorityFunc(feePerGas)
+
+	// OldestFirst orders by CreatedAt ASC, ID ASC, ignoring fees.
+	OldestFirst PriorityPolicy = PriorityFunc(oldestFirst)
+)
+
+// ParsePriorityPolicy maps "fee", "fee-per-gas", or "oldest" to a
+// built-in policy. An empty name means FeeFirst.
+func ParsePriorityPolicy(name string) (PriorityPolicy, error) {
+	switch name {
+	case "", "fee":
+		return FeeFirst, nil
+	case "fee-per-gas":
+		return FeePerGas, nil
+	case "oldest":
+		return OldestFirst, nil
+	default:
+		return nil, fmt.Errorf("mempool: unknown priority policy %q", name)
+	}
+}
+
+func feeFirst(ti, tj *Tx) bool {
+	// 1) Higher fee first
+	if ti.Fee != tj.Fee {
+		return ti.Fee > tj.Fee
+	}
+
+	// 2) Earlier timestamp first
+	if !ti.Timestamp.Equal(tj.Timestamp) {
+		return ti.Timestamp.Before(tj.Timestamp)
+	}
+
+	// 3) Stable ordering by TxID
+	return ti.ID < tj.ID
+}
+
+func feePerGas(ti, tj *Tx) bool {
+	// Compare ti.Fee/ti.Gas vs tj.Fee/tj.Gas by cross-multiplying in
+	// 128 bits so neither division nor overflow loses precision.
+	hi1, lo1 := bits.Mul64(ti.Fee, tj.Gas)
+	hi2, lo2 := bits.Mul64(tj.Fee, ti.Gas)
+	if hi1 != hi2 {
+		return hi1 > hi2
+	}
+	if lo1 != lo2 {
+		return lo1 > lo2
+	}
+
+	if !ti.Timestamp.Equal(tj.Timestamp) {
+		return ti.Timestamp.Before(tj.Timestamp)
+	}
+	return ti.ID < tj.ID
+}
+
+func oldestFirst(ti, tj *Tx) bool {
+	if !ti.CreatedAt.Equal(tj.CreatedAt) {
+		return ti.CreatedAt.Before(tj.CreatedAt)
+	}
+	return ti.ID < tj.ID
+}
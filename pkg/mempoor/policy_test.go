@@ -0,0 +1,77 @@
+package mempoor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFeePerGasPolicyOrdersByRatio(t *testing.T) {
+	mp := NewMempool(MempoolConfig{Priority: FeePerGas})
+
+	// fee 100 / gas 100 = 1 per gas; fee 50 / gas 10 = 5 per gas.
+	bigFee := newTx("alice", 100, 100)
+	dense := newTx("carol", 50, 10)
+	_, _ = mp.Add(bigFee)
+	_, _ = mp.Add(dense)
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 1})
+	if len(res.Transactions) != 1 || res.Transactions[0].ID != dense.ID {
+		t.Fatalf("expected higher fee-per-gas tx first, got %+v", res.Transactions)
+	}
+}
+
+func TestOldestFirstPolicyIgnoresFee(t *testing.T) {
+	mp := NewMempool(MempoolConfig{Priority: OldestFirst})
+
+	old := newTx("alice", 1, 10)
+	old.CreatedAt = time.Now().UTC().Add(-time.Hour)
+	rich := newTx("carol", 1000, 10)
+	_, _ = mp.Add(rich)
+	_, _ = mp.Add(old)
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 1})
+	if len(res.Transactions) != 1 || res.Transactions[0].ID != old.ID {
+		t.Fatalf("expected oldest tx first, got %+v", res.Transactions)
+	}
+}
+
+func TestPolicySharedByListPageAndSelection(t *testing.T) {
+	policy := PriorityFunc(func(a, b *Tx) bool {
+		// Custom: lowest fee first.
+		if a.Fee != b.Fee {
+			return a.Fee < b.Fee
+		}
+		return a.ID < b.ID
+	})
+	mp := NewMempool(MempoolConfig{Priority: policy})
+
+	for _, fee := range []uint64{30, 10, 20} {
+		_, _ = mp.Add(newTx("alice", fee, 10))
+	}
+
+	page, _ := mp.ListPage(0, 0, SortByPriority)
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 10})
+
+	if len(page) != 3 || len(res.Transactions) != 3 {
+		t.Fatalf("expected 3 txs in both views")
+	}
+	for i := range page {
+		if page[i].ID != res.Transactions[i].ID {
+			t.Fatalf("tx.list order diverges from selection at %d", i)
+		}
+	}
+	if page[0].Fee != 10 {
+		t.Fatalf("expected custom policy to put lowest fee first, got %d", page[0].Fee)
+	}
+}
+
+func TestParsePriorityPolicy(t *testing.T) {
+	for _, name := range []string{"", "fee", "fee-per-gas", "oldest"} {
+		if _, err := ParsePriorityPolicy(name); err != nil {
+			t.Fatalf("unexpected error for %q: %v", name, err)
+		}
+	}
+	if _, err := ParsePriorityPolicy("bogus"); err == nil {
+		t.Fatalf("expected error for unknown policy")
+	}
+}
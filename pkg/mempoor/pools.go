@@ -0,0 +1,177 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultPool names the node's primary mempool, configured by the
+// top-level NodeConfig limits. Tx.Pool "" means DefaultPool.
+const DefaultPool = "default"
+
+// ErrUnknownPool is returned when a tx or RPC names a pool the node does
+// not host.
+var ErrUnknownPool = errors.New("mempool: unknown pool")
+
+// PoolConfig declares an extra named mempool hosted by the node, e.g.
+// "transfers" or "data". Settings not listed here (priority, orphans,
+// dedup, ACL, ...) are shared with the default pool.
+//
+// Pool semantics:
+//   - Each pool is an independent Mempool with its own capacity,
+//     eviction, nonce tracking, and fee floor; dependencies (ParentID,
+//     DependsOn, nonces) only resolve within a pool.
+//   - tx.add and tx.addBatch route by the "pool" param and record it in
+//     Tx.Pool; ID-based RPCs (update, remove, status, ...) search every
+//     pool, since TxIDs do not collide across pools.
+//   - Blocks draw from the default pool first, then the extra pools in
+//     NodeConfig.Pools order, each capped by PoolGasLimits; see
+//     BlockBuilder.ReserveBlock.
+//   - All pools share the node's journal and snapshot. A persisted tx
+//     whose pool is no longer configured is restored to the default pool.
+type PoolConfig struct {
+	Name string
+
+	// MaxTxs caps the pool's pending txs. 0 = unbounded.
+	MaxTxs int
+
+	// MaxPayloadBytes caps tx payloads in this pool. 0 = the node's
+	// MaxPayloadBytes.
+	MaxPayloadBytes int
+}
+
+// NamedPool is a mempool the block builder draws from, besides its
+// primary one.
+type NamedPool struct {
+	Name    string
+	Mempool Mempool
+}
+
+// nodePool is one mempool hosted by a Node.
+type nodePool struct {
+	name       string
+	mp         Mempool
+	maxTxs     int
+	maxPayload int
+}
+
+// newNodePools builds the default pool entry followed by one per
+// PoolConfig. Entries with an empty, reserved, or repeated name are
+// skipped; validatePools reports them when the node starts.
+func newNodePools(mp Mempool, cfg NodeConfig, base MempoolConfig) []nodePool {
+	pools := []nodePool{{name: DefaultPool, mp: mp, maxTxs: cfg.MaxMempoolTxs, maxPayload: cfg.MaxPayloadBytes}}
+	if validatePools(cfg.Pools) != nil {
+		return pools
+	}
+	for _, pc := range cfg.Pools {
+		maxPayload := pc.MaxPayloadBytes
+		if maxPayload == 0 {
+			maxPayload = cfg.MaxPayloadBytes
+		}
+		pcfg := base
+		pcfg.MaxTxs = pc.MaxTxs
+		pcfg.MaxPayloadBytes = maxPayload
+		pools = append(pools, nodePool{name: pc.Name, mp: NewMempool(pcfg), maxTxs: pc.MaxTxs, maxPayload: maxPayload})
+	}
+	return pools
+}
+
+// validatePools checks that every pool has a unique, non-reserved name.
+func validatePools(pools []PoolConfig) error {
+	seen := make(map[string]struct{}, len(pools))
+	for _, pc := range pools {
+		if pc.Name == "" || pc.Name == DefaultPool {
+			return fmt.Errorf("mempool: invalid pool name %q", pc.Name)
+		}
+		if _, dup := seen[pc.Name]; dup {
+			return fmt.Errorf("mempool: duplicate pool name %q", pc.Name)
+		}
+		seen[pc.Name] = struct{}{}
+	}
+	return nil
+}
+
+// poolKey maps Tx.Pool to a pool name: "" means DefaultPool.
+func poolKey(name string) string {
+	if name == "" {
+		return DefaultPool
+	}
+	return name
+}
+
+// pool returns the pool called name; "" means DefaultPool.
+func (n *Node) pool(name string) (*nodePool, error) {
+	name = poolKey(name)
+	for i := range n.pools {
+		if n.pools[i].name == name {
+			return &n.pools[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownPool, name)
+}
+
+// findTx returns the pool holding the pending tx id.
+func (n *Node) findTx(id TxID) (Mempool, *Tx, error) {
+	for _, p := range n.pools {
+		if tx, err := p.mp.Get(id); err == nil {
+			return p.mp, tx, nil
+		}
+	}
+	return nil, nil, ErrTxNotFound
+}
+
+// namedPools returns the pools after the default one, for the block
+// builder.
+func namedPools(pools []nodePool) []NamedPool {
+	named := make([]NamedPool, 0, len(pools)-1)
+	for _, p := range pools[1:] {
+		named = append(named, NamedPool{Name: p.name, Mempool: p.mp})
+	}
+	return named
+}
+
+// removeTx removes id from whichever pool holds it, pending or
+// scheduled.
+func (n *Node) removeTx(id TxID) error {
+	for _, p := range n.pools {
+		if err := p.mp.Remove(id); err != ErrTxNotFound {
+			return err
+		}
+	}
+	return ErrTxNotFound
+}
+
+// poolsIdle reports whether no pool has anything pending or scheduled.
+func (n *Node) poolsIdle() bool {
+	for _, p := range n.pools {
+		if p.mp.Count() > 0 || len(p.mp.ListScheduled()) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// multiReservation spans the reservations taken from several pools for
+// one block.
+type multiReservation struct {
+	parts  []Reservation
+	result BlockSelectionResult
+}
+
+func (r *multiReservation) Result() BlockSelectionResult { return r.result }
+
+func (r *multiReservation) Commit() error {
+	var errs []error
+	for _, p := range r.parts {
+		errs = append(errs, p.Commit())
+	}
+	return errors.Join(errs...)
+}
+
+func (r *multiReservation) Rollback() error {
+	var errs []error
+	for _, p := range r.parts {
+		errs = append(errs, p.Rollback())
+	}
+	return errors.Join(errs...)
+}
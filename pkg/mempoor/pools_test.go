@@ -0,0 +1,149 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuilderSplitsGasAcrossPools(t *testing.T) {
+	main := NewMempool(MempoolConfig{})
+	data := NewMempool(MempoolConfig{})
+	for i := 0; i < 5; i++ {
+		_, _ = main.Add(newTx("alice", 10, 100))
+		_, _ = data.Add(newTx("carol", 50, 100))
+	}
+
+	b := NewBlockBuilder(main, BlockBuilderConfig{
+		GasLimit:      600,
+		MaxTxPerBlock: 100,
+		Pools:         []NamedPool{{Name: "data", Mempool: data}},
+		PoolGasLimits: map[string]uint64{DefaultPool: 400},
+	})
+
+	block, err := b.BuildBlock(context.Background(), [32]byte{}, 0, time.Time{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if block.Header.GasUsed != 600 || len(block.Transactions) != 6 {
+		t.Fatalf("expected a full 600-gas block, got %d gas / %d txs", block.Header.GasUsed, len(block.Transactions))
+	}
+	if main.Count() != 1 || data.Count() != 3 {
+		t.Fatalf("expected 4 txs from default and 2 from data, left %d / %d", main.Count(), data.Count())
+	}
+}
+
+func TestBuilderRollsBackEveryPool(t *testing.T) {
+	main := NewMempool(MempoolConfig{})
+	data := NewMempool(MempoolConfig{})
+	_, _ = main.Add(newTx("alice", 10, 100))
+	_, _ = data.Add(newTx("carol", 10, 100))
+
+	b := NewBlockBuilder(main, BlockBuilderConfig{
+		MaxTxPerBlock: 10,
+		Pools:         []NamedPool{{Name: "data", Mempool: data}},
+	})
+
+	_, res, err := b.ReserveBlock(context.Background(), [32]byte{}, 0, time.Time{})
+	if err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if err := res.Rollback(); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if main.Count() != 1 || data.Count() != 1 {
+		t.Fatalf("rollback should return txs to their pools")
+	}
+	if err := res.Commit(); !errors.Is(err, ErrReservationClosed) {
+		t.Fatalf("expected ErrReservationClosed, got %v", err)
+	}
+}
+
+func callRPC(n *Node, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	n.handleRPC(rec, req)
+	return rec
+}
+
+func TestNodeRoutesTxsByPool(t *testing.T) {
+	n := NewNode(NodeConfig{
+		Pools: []PoolConfig{{Name: "data", MaxTxs: 1, MaxPayloadBytes: 4}},
+	})
+	data, _ := n.pool("data")
+
+	rec := callRPC(n, `{"method":"tx.add","params":{"sender":"alice","recipient":"bob","fee":1,"gas":1,"pool":"data"}}`)
+	if rec.Code != http.StatusOK || data.mp.Count() != 1 || n.mempool.Count() != 0 {
+		t.Fatalf("expected the tx in the data pool, got %d: %s", rec.Code, rec.Body)
+	}
+	if tx := data.mp.List()[0]; tx.Pool != "data" {
+		t.Fatalf("expected Tx.Pool recorded, got %q", tx.Pool)
+	}
+
+	rec = callRPC(n, `{"method":"tx.add","params":{"sender":"carol","recipient":"bob","payload":"toolong","fee":1,"gas":1,"pool":"data"}}`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected the pool's payload limit to apply, got %d", rec.Code)
+	}
+
+	rec = callRPC(n, `{"method":"tx.add","params":{"sender":"alice","recipient":"bob","fee":1,"gas":1,"pool":"nope"}}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown pool, got %d", rec.Code)
+	}
+
+	id := data.mp.List()[0].ID
+	if rec = callRPC(n, `{"method":"tx.remove","params":{"id":"`+string(id)+`"}}`); rec.Code != http.StatusOK || data.mp.Count() != 0 {
+		t.Fatalf("tx.remove should find txs in any pool, got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestValidatePools(t *testing.T) {
+	for _, pools := range [][]PoolConfig{
+		{{Name: ""}},
+		{{Name: DefaultPool}},
+		{{Name: "data"}, {Name: "data"}},
+	} {
+		if err := validatePools(pools); err == nil {
+			t.Fatalf("expected %v to be rejected", pools)
+		}
+	}
+	if err := validatePools([]PoolConfig{{Name: "data"}, {Name: "transfers"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPoolsPersistRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cfg := NodeConfig{DataDir: dir, Pools: []PoolConfig{{Name: "data"}}}
+
+	n1 := NewNode(cfg)
+	data, _ := n1.pool("data")
+	tx := newTx("alice", 10, 100)
+	tx.Pool = "data"
+	_, _ = data.mp.Add(tx)
+	_, _ = n1.mempool.Add(newTx("carol", 20, 200))
+	if err := n1.storeMempool(); err != nil {
+		t.Fatalf("store: %v", err)
+	}
+
+	n2 := NewNode(cfg)
+	if err := n2.loadMempool(); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	data2, _ := n2.pool("data")
+	if data2.mp.Count() != 1 || n2.mempool.Count() != 1 {
+		t.Fatalf("expected one tx per pool, got %d / %d", n2.mempool.Count(), data2.mp.Count())
+	}
+
+	// Without the pool configured, its txs land in the default pool.
+	n3 := NewNode(NodeConfig{DataDir: dir})
+	if err := n3.loadMempool(); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if n3.mempool.Count() != 2 {
+		t.Fatalf("expected both txs in the default pool, got %d", n3.mempool.Count())
+	}
+}
@@ -0,0 +1,164 @@
+package mempoor
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Prometheus metrics semantics (GET /metrics):
+//   - Every node has its own registry, served in the Prometheus text
+//     format beside /rpc; it needs no admin token. Go runtime and
+//     process metrics are included.
+//   - mempoor_txs_{added,updated,removed,selected,purged,evicted}_total
+//     count MempoolObserver events across all pools.
+//   - mempoor_mempool_txs and mempoor_mempool_gas are the pending txs
+//     and their gas across all pools; mempoor_block_height is the tip's.
+//   - mempoor_block_build_duration_seconds observes each build's
+//     selection time, as block.metrics measures it (built-in builder
+//     only); mempoor_block_gas_used observes each block the node
+//     publishes.
+//   - mempoor_rpc_requests_total and mempoor_rpc_request_duration_seconds
+//     are labeled by method and, for the count, HTTP status code.
+//     Unknown methods share the method label "unknown".
+type nodeMetrics struct {
+	reg *prometheus.Registry
+
+	added, updated, removed, selected, purged, evicted prometheus.Counter
+
+	buildDuration prometheus.Histogram
+	gasUsed       prometheus.Histogram
+
+	rpcRequests *prometheus.CounterVec
+	rpcDuration *prometheus.HistogramVec
+}
+
+func newNodeMetrics() *nodeMetrics {
+	txCounter := func(event string) prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "mempoor_txs_" + event + "_total",
+			Help: "Txs " + event + " in the node's mempools.",
+		})
+	}
+	m := &nodeMetrics{
+		reg:      prometheus.NewRegistry(),
+		added:    txCounter("added"),
+		updated:  txCounter("updated"),
+		removed:  txCounter("removed"),
+		selected: txCounter("selected"),
+		purged:   txCounter("purged"),
+		evicted:  txCounter("evicted"),
+		buildDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "mempoor_block_build_duration_seconds",
+			Help:    "Selection time of each block build.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}),
+		gasUsed: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "mempoor_block_gas_used",
+			Help:    "Gas used by each published block.",
+			Buckets: prometheus.ExponentialBuckets(1000, 4, 8),
+		}),
+		rpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mempoor_rpc_requests_total",
+			Help: "RPC requests by method and HTTP status code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mempoor_rpc_request_duration_seconds",
+			Help:    "RPC request latency by method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+	}
+	m.reg.MustRegister(
+		m.added, m.updated, m.removed, m.selected, m.purged, m.evicted,
+		m.buildDuration, m.gasUsed, m.rpcRequests, m.rpcDuration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// registerNode adds the gauges read from n on every scrape.
+func (m *nodeMetrics) registerNode(n *Node) {
+	m.reg.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "mempoor_mempool_txs",
+			Help: "Pending txs across all pools.",
+		}, func() float64 {
+			total := 0
+			for _, p := range n.pools {
+				total += p.mp.Count()
+			}
+			return float64(total)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "mempoor_mempool_gas",
+			Help: "Gas of the pending txs across all pools.",
+		}, func() float64 {
+			var total uint64
+			for _, p := range n.pools {
+				total += p.mp.PendingGas()
+			}
+			return float64(total)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "mempoor_block_height",
+			Help: "Height of the chain tip.",
+		}, func() float64 {
+			_, next := n.chainTip()
+			if next == 0 {
+				return 0
+			}
+			return float64(next - 1)
+		}),
+	)
+}
+
+func (m *nodeMetrics) OnAdd(*Tx)      { m.added.Inc() }
+func (m *nodeMetrics) OnUpdate(*Tx)   { m.updated.Inc() }
+func (m *nodeMetrics) OnRemove(*Tx)   { m.removed.Inc() }
+func (m *nodeMetrics) OnSelect(*Tx)   { m.selected.Inc() }
+func (m *nodeMetrics) OnPurge(*Tx)    { m.purged.Inc() }
+func (m *nodeMetrics) OnEvict(*Tx)    { m.evicted.Inc() }
+func (m *nodeMetrics) OnSchedule(*Tx) {}
+
+// recordBuild is installed, with BuilderMeter.Record, as OnBuild.
+func (m *nodeMetrics) recordBuild(s BuildStats) {
+	m.buildDuration.Observe(s.SelectionTime.Seconds())
+}
+
+// recordBlock observes a block the node published.
+func (m *nodeMetrics) recordBlock(b *Block) {
+	m.gasUsed.Observe(float64(b.Header.GasUsed))
+}
+
+// recordRPC observes one RPC request.
+func (m *nodeMetrics) recordRPC(method string, code int, took time.Duration) {
+	m.rpcRequests.WithLabelValues(method, strconv.Itoa(code)).Inc()
+	m.rpcDuration.WithLabelValues(method).Observe(took.Seconds())
+}
+
+func (m *nodeMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.reg, promhttp.HandlerOpts{})
+}
+
+// statusRecorder remembers the status code written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets block.export stream through the recorder.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
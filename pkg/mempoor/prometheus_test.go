@@ -0,0 +1,47 @@
+package mempoor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 1, BlockInterval: time.Second})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	for _, sender := range []string{"alice", "bob"} {
+		if _, err := n.mempool.Add(newTx(sender, 10, 100)); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+	}
+	if _, err := n.Mine(context.Background(), 1); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	callRPC(n, `{"method":"fee.floor"}`)
+	callRPC(n, `{"method":"no.such"}`)
+
+	rec := httptest.NewRecorder()
+	n.metrics.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"mempoor_txs_added_total 2",
+		"mempoor_txs_selected_total 1",
+		"mempoor_mempool_txs 1",
+		"mempoor_mempool_gas 100",
+		"mempoor_block_height 1",
+		"mempoor_block_build_duration_seconds_count 1",
+		"mempoor_block_gas_used_sum 100",
+		`mempoor_rpc_requests_total{code="200",method="fee.floor"} 1`,
+		`mempoor_rpc_requests_total{code="400",method="unknown"} 1`,
+		`mempoor_rpc_request_duration_seconds_count{method="fee.floor"} 1`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("expected %q in /metrics", want)
+		}
+	}
+}
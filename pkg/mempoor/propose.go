@@ -0,0 +1,301 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidProposal is returned for an externally built block that does
+// not extend the chain tip or cannot be executed from the pool.
+var ErrInvalidProposal = errors.New("blockbuilder: invalid proposal")
+
+// Proposal semantics (block.propose):
+//   - An external builder submits a full block for the next height. Its
+//     prevHash and height must extend the node's chain tip, its
+//     timestamp must be set and not before the tip's, and its extra data
+//     must fit MaxExtraDataBytes.
+//   - Txs are named by ID and taken from the node's own pools, in the
+//     block's order. Each must be pending and executable at its place:
+//     no earlier nonce of its sender left behind, no dependency still
+//     pending, and every bundle whole. The block must have at least one
+//     tx, fit the node's MaxTxPerBlock and GasLimit, and every tx must
+//     cover the node's BaseFee.
+//   - The node recomputes TxCount, GasUsed, Burned, and Tipped itself; a
+//     submitted hash, if any, must match the recomputed one.
+//   - The node previews its own candidate, as block.template does. The
+//     proposal is committed unless the fork choice prefers the candidate
+//     over it (under MostFees: the candidate's TotalFees are strictly
+//     higher); then the node produces its own block at that height.
+//     Either block goes through BlockSinks like one from the block
+//     loop, which is paused meanwhile.
+//
+// NOTE: A proposal skips the builder's hooks and limits other than the
+// ones above (lane quotas, MinFee, Filters, required txs), and replay
+// cannot rebuild it; see "Replay semantics".
+
+// ReserveTxs holds aside exactly the listed txs, in order, like Reserve
+// does for a selection. It fails with ErrInvalidProposal, taking
+// nothing, unless every tx is pending and executable at its place; see
+// "Proposal semantics". Nothing is purged.
+func (m *mempool) ReserveTxs(ids []TxID) (Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.activateDue(m.cfg.Clock.Now())
+
+	taken := make(map[*txRecord]bool, len(ids))
+	recs := make([]*txRecord, 0, len(ids))
+	for _, id := range ids {
+		rec, ok := m.table[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: tx %s is not pending", ErrInvalidProposal, id)
+		}
+		if taken[rec] {
+			return nil, fmt.Errorf("%w: tx %s is listed twice", ErrInvalidProposal, id)
+		}
+		for _, r := range m.senders[rec.tx.Sender] {
+			if taken[r] {
+				continue
+			}
+			if r.tx.Nonce < rec.tx.Nonce {
+				return nil, fmt.Errorf("%w: tx %s comes before nonce %d of %s", ErrInvalidProposal, id, r.tx.Nonce, rec.tx.Sender)
+			}
+			break
+		}
+		if rec.waiting > 0 {
+			return nil, fmt.Errorf("%w: tx %s waits on a pending dependency", ErrInvalidProposal, id)
+		}
+		taken[rec] = true
+		recs = append(recs, rec)
+	}
+	for _, rec := range recs {
+		if b := rec.bundle; b != nil {
+			for _, r := range b.members {
+				if !taken[r] {
+					return nil, fmt.Errorf("%w: bundle %s is incomplete", ErrInvalidProposal, rec.tx.BundleID)
+				}
+			}
+		}
+	}
+
+	var result BlockSelectionResult
+	for _, rec := range recs {
+		m.removeRecord(rec)
+		result.Transactions = append(result.Transactions, rec.tx)
+		result.GasUsed += rec.tx.Gas
+	}
+	result.Groups = ParallelGroups(result.Transactions)
+	res := &reservation{m: m, result: result}
+	m.adjustFeeFloor()
+	return res, nil
+}
+
+// proposalOutcome reports what ProposeBlock committed.
+type proposalOutcome struct {
+	Accepted      bool   // the proposal was committed, not the node's own block
+	Block         *Block // the committed block
+	ProposedFees  uint64 // TotalFees of the proposal
+	CandidateFees uint64 // TotalFees of the node's own candidate; 0 if it had none
+}
+
+// proposeBlock validates an externally built block and commits it, or the
+// node's own block if the fork choice prefers that. Only the header's
+// PrevHash, Height, Timestamp, Proposer, ExtraData, and ChainID and the
+// tx IDs are read from proposed; hash, if not zero, must match the
+// committed proposal. See "Proposal semantics".
+func (n *Node) proposeBlock(ctx context.Context, proposed *Block, hash [32]byte) (proposalOutcome, error) {
+	var out proposalOutcome
+
+	n.produceMu.Lock()
+	defer n.produceMu.Unlock()
+
+	prevHash, height := n.chainTip()
+	limits, _ := n.limits.get()
+	h := proposed.Header
+	switch {
+	case h.ChainID != n.cfg.ChainID:
+		return out, fmt.Errorf("%w: block is for chain %q, node serves %q", ErrInvalidProposal, h.ChainID, n.cfg.ChainID)
+	case h.PrevHash != prevHash || h.Height != height:
+		return out, fmt.Errorf("%w: does not extend the tip at height %d", ErrInvalidProposal, height)
+	case h.Timestamp.IsZero() || h.Timestamp.Before(n.tipTime()):
+		return out, fmt.Errorf("%w: timestamp is before the tip's", ErrInvalidProposal)
+	case len(proposed.Transactions) == 0:
+		return out, fmt.Errorf("%w: no transactions", ErrInvalidProposal)
+	case limits.MaxTxPerBlock > 0 && len(proposed.Transactions) > limits.MaxTxPerBlock:
+		return out, fmt.Errorf("%w: %d txs, max %d", ErrInvalidProposal, len(proposed.Transactions), limits.MaxTxPerBlock)
+	}
+	if err := checkExtraData(h.ExtraData); err != nil {
+		return out, err
+	}
+
+	// Preview the node's own candidate while the pool is untouched.
+	candidate, err := n.builder.BuildTemplate(ctx, prevHash, height, time.Time{})
+	if err != nil && err != ErrEmptyBlock {
+		return out, err
+	}
+	if candidate != nil {
+		out.CandidateFees = candidate.Header.TotalFees
+	}
+
+	block, res, err := n.reserveProposal(proposed, limits.GasLimit)
+	if err != nil {
+		return out, err
+	}
+	if hash != ([32]byte{}) && block.Hash() != hash {
+		_ = res.Rollback()
+		return out, fmt.Errorf("%w: hash does not match its contents", ErrInvalidProposal)
+	}
+	out.ProposedFees = block.Header.TotalFees
+
+	if candidate != nil && n.forkChoice().Prefer([]*Block{block}, []*Block{candidate}) {
+		if err := res.Rollback(); err != nil {
+			return out, err
+		}
+		own, err := n.produceBlock(ctx, prevHash, height, n.cfg.Clock.Now())
+		if err != nil {
+			return out, err
+		}
+		out.Block = own
+		return out, nil
+	}
+
+	if err := n.publishBlock(block, res); err != nil {
+		return out, err
+	}
+	out.Accepted, out.Block = true, block
+	return out, nil
+}
+
+// reserveProposal reserves proposed's txs from the pools holding them,
+// checks them against gasLimit and the base fee, and assembles the block
+// the node would commit for it.
+func (n *Node) reserveProposal(proposed *Block, gasLimit uint64) (*Block, Reservation, error) {
+	ids := make([][]TxID, len(n.pools))
+	for _, tx := range proposed.Transactions {
+		i := -1
+		for j, p := range n.pools {
+			if _, err := p.mp.Get(tx.ID); err == nil {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return nil, nil, fmt.Errorf("%w: tx %s is not pending", ErrInvalidProposal, tx.ID)
+		}
+		ids[i] = append(ids[i], tx.ID)
+	}
+
+	multi := &multiReservation{}
+	byID := make(map[TxID]*Tx, len(proposed.Transactions))
+	for i, p := range n.pools {
+		if len(ids[i]) == 0 {
+			continue
+		}
+		res, err := p.mp.ReserveTxs(ids[i])
+		if err != nil {
+			_ = multi.Rollback()
+			return nil, nil, err
+		}
+		multi.parts = append(multi.parts, res)
+		for _, tx := range res.Result().Transactions {
+			byID[tx.ID] = tx
+		}
+	}
+
+	sel := &multi.result
+	for _, ptx := range proposed.Transactions {
+		tx := byID[ptx.ID]
+		tip, ok := EffectiveTip(tx, n.cfg.BaseFee)
+		if !ok {
+			_ = multi.Rollback()
+			return nil, nil, fmt.Errorf("%w: tx %s cannot cover the base fee", ErrInvalidProposal, tx.ID)
+		}
+		sel.Transactions = append(sel.Transactions, tx)
+		sel.GasUsed += tx.Gas
+		sel.Burned += burnFor(n.cfg.BaseFee, tx.Gas)
+		sel.Tips += tip
+	}
+	if gasLimit > 0 && sel.GasUsed > gasLimit {
+		_ = multi.Rollback()
+		return nil, nil, fmt.Errorf("%w: uses %d gas, limit %d", ErrInvalidProposal, sel.GasUsed, gasLimit)
+	}
+	sel.Groups = ParallelGroups(sel.Transactions)
+
+	h := proposed.Header
+	block := &Block{
+		Header: BlockHeader{
+			Height:    h.Height,
+			PrevHash:  h.PrevHash,
+			Timestamp: h.Timestamp,
+			TxCount:   len(sel.Transactions),
+			GasUsed:   sel.GasUsed,
+			Burned:    sel.Burned,
+			Tipped:    sel.Tips,
+			Proposer:  h.Proposer,
+			ExtraData: h.ExtraData,
+			ChainID:   h.ChainID,
+		},
+		Transactions: sel.Transactions,
+		Groups:       sel.Groups,
+	}
+	block.Header.TotalFees, block.Header.AvgFeePerGas = feeSummary(sel.Burned, sel.Tips, sel.GasUsed)
+	return block, multi, nil
+}
+
+// tipTime returns the timestamp of the last block, or zero.
+func (n *Node) tipTime() time.Time {
+	n.blocksMu.RLock()
+	defer n.blocksMu.RUnlock()
+
+	if len(n.blocks) == 0 {
+		return time.Time{}
+	}
+	return n.blocks[len(n.blocks)-1].Header.Timestamp
+}
+
+// parseProposal turns block.propose params into a block and the hash
+// the proposer claims for it.
+func parseProposal(d blockDTO) (*Block, [32]byte, error) {
+	var hash [32]byte
+	b := &Block{Header: BlockHeader{
+		Height:    d.Height,
+		Timestamp: d.Timestamp,
+		Proposer:  d.Proposer,
+		ChainID:   d.ChainID,
+	}, Transactions: d.Txs}
+
+	if err := decodeHash(d.PrevHash, &b.Header.PrevHash); err != nil {
+		return nil, hash, fmt.Errorf("%w: bad prevHash", ErrInvalidProposal)
+	}
+	if d.Hash != "" {
+		if err := decodeHash(d.Hash, &hash); err != nil {
+			return nil, hash, fmt.Errorf("%w: bad hash", ErrInvalidProposal)
+		}
+	}
+	if d.ExtraData != "" {
+		raw, err := hex.DecodeString(d.ExtraData)
+		if err != nil {
+			return nil, hash, fmt.Errorf("%w: bad extraData", ErrInvalidProposal)
+		}
+		b.Header.ExtraData = raw
+	}
+	for _, tx := range b.Transactions {
+		if tx == nil || tx.ID == "" {
+			return nil, hash, fmt.Errorf("%w: tx without ID", ErrInvalidProposal)
+		}
+	}
+	return b, hash, nil
+}
+
+// decodeHash decodes a 32-byte hex hash into out.
+func decodeHash(s string, out *[32]byte) error {
+	raw, err := hex.DecodeString(s)
+	if err != nil || len(raw) != len(out) {
+		return fmt.Errorf("bad hash %q", s)
+	}
+	copy(out[:], raw)
+	return nil
+}
@@ -0,0 +1,118 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newAuctionNode returns a node whose own greedy block takes big alone,
+// while small1 and small2 together pay more.
+func newAuctionNode(t *testing.T) (n *Node, big, small1, small2 *Tx) {
+	t.Helper()
+	n = NewNode(NodeConfig{GasLimit: 100, MaxTxPerBlock: 10})
+	big = newTx("alice", 50, 100)
+	small1 = newTx("bob", 30, 50)
+	small2 = newTx("carol", 30, 50)
+	for _, tx := range []*Tx{big, small1, small2} {
+		if _, err := n.mempool.Add(tx); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+	}
+	return n, big, small1, small2
+}
+
+func proposal(height uint64, prevHash [32]byte, txs ...*Tx) *Block {
+	return &Block{
+		Header:       BlockHeader{Height: height, PrevHash: prevHash, Timestamp: time.Now().UTC(), Proposer: "ext"},
+		Transactions: txs,
+	}
+}
+
+func TestProposeBlockBeatsOwnCandidate(t *testing.T) {
+	n, big, small1, small2 := newAuctionNode(t)
+
+	out, err := n.proposeBlock(context.Background(), proposal(0, [32]byte{}, small2, small1), [32]byte{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !out.Accepted || out.ProposedFees != 60 || out.CandidateFees != 50 {
+		t.Fatalf("expected the proposal to win 60 to 50, got %+v", out)
+	}
+	if got := out.Block.Transactions; len(got) != 2 || got[0].ID != small2.ID || out.Block.Header.Proposer != "ext" {
+		t.Fatalf("expected the proposed block as submitted, got %+v", out.Block)
+	}
+	if _, height := n.chainTip(); height != 1 {
+		t.Fatalf("expected the tip to advance to height 1, got %d", height)
+	}
+	if _, err := n.mempool.Get(big.ID); err != nil || n.mempool.Count() != 1 {
+		t.Fatalf("expected only the unproposed tx left pending")
+	}
+}
+
+func TestProposeBlockLosesToOwnCandidate(t *testing.T) {
+	n, big, small1, _ := newAuctionNode(t)
+
+	out, err := n.proposeBlock(context.Background(), proposal(0, [32]byte{}, small1), [32]byte{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Accepted || len(out.Block.Transactions) != 1 || out.Block.Transactions[0].ID != big.ID {
+		t.Fatalf("expected the node's own block, got %+v", out)
+	}
+	if _, err := n.mempool.Get(small1.ID); err != nil {
+		t.Fatalf("expected the losing proposal's tx back in the pool: %v", err)
+	}
+}
+
+func TestProposeBlockRejectsInvalid(t *testing.T) {
+	n, _, small1, _ := newAuctionNode(t)
+	next := newTx("bob", 40, 10)
+	next.Nonce = 1
+	_, _ = n.mempool.Add(rehash(next))
+
+	cases := map[string]*Block{
+		"wrong height": proposal(1, [32]byte{}, small1),
+		"not pending":  proposal(0, [32]byte{}, newTx("dan", 99, 10)),
+		"nonce gap":    proposal(0, [32]byte{}, next),
+		"duplicate":    proposal(0, [32]byte{}, small1, small1),
+		"empty":        proposal(0, [32]byte{}),
+	}
+	for name, b := range cases {
+		if _, err := n.proposeBlock(context.Background(), b, [32]byte{}); !errors.Is(err, ErrInvalidProposal) {
+			t.Fatalf("%s: expected ErrInvalidProposal, got %v", name, err)
+		}
+	}
+	if n.mempool.Count() != 4 {
+		t.Fatalf("rejected proposals must not take txs, %d pending", n.mempool.Count())
+	}
+}
+
+func TestBlockProposeRPC(t *testing.T) {
+	n, _, small1, small2 := newAuctionNode(t)
+
+	want := proposal(0, [32]byte{}, small1, small2)
+	want.Header.TxCount, want.Header.GasUsed, want.Header.Tipped = 2, 100, 60
+	hash := want.Hash()
+	body := fmt.Sprintf(`{"method":"block.propose","params":{"block":{"height":0,"prevHash":%q,"timestamp":%q,"proposer":"ext","hash":%q,"transactions":[{"ID":%q},{"ID":%q}]}}}`,
+		hex.EncodeToString(make([]byte, 32)), want.Header.Timestamp.Format(time.RFC3339Nano), hex.EncodeToString(hash[:]), small1.ID, small2.ID)
+
+	rec := callRPC(n, body)
+	var resp struct {
+		Result struct {
+			proposeResult
+			Error string `json:"error"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("bad response %d: %v", rec.Code, err)
+	}
+	if resp.Result.Error != "" || !resp.Result.Accepted || resp.Result.Block.Hash != hex.EncodeToString(hash[:]) {
+		t.Fatalf("expected the proposal accepted under its hash, got %+v", resp.Result)
+	}
+}
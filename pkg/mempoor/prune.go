@@ -0,0 +1,149 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPruneInterval is how often the pruner runs when
+// RetentionPolicy.Interval is 0.
+const DefaultPruneInterval = time.Minute
+
+// errRetentionWithAccounts refuses a retention policy on a node that
+// tracks accounts, which must replay every tx from genesis.
+var errRetentionWithAccounts = errors.New("blockbuilder: block retention cannot be combined with genesis balances")
+
+// RetentionPolicy bounds how many blocks the node keeps in full.
+//
+// Pruning semantics (NodeConfig.Retention):
+//   - A block is kept while it is among the last Blocks blocks or
+//     younger than Age (by header timestamp against the node's clock);
+//     a zero field keeps nothing by itself, and with both zero nothing
+//     is pruned. The genesis block and the tip are never pruned.
+//   - A pruned block keeps its header, signature, and a stub of each tx
+//     holding only its ID and Gas, which is all Hash and Validate read:
+//     the chain still links and validates, in memory and on reload.
+//     Payloads, fees, and groups are gone, and block.get serves the
+//     block with "pruned": true.
+//   - A background pruner runs every Interval (0 = DefaultPruneInterval)
+//     and rewrites the pruned blocks in the BlockStore too, so the store
+//     stops growing with payloads. It is serialized with production.
+//   - Pruned blocks cannot be displaced: a reorg or import that would
+//     replace one is rejected with ErrForkRejected, since its txs could
+//     not go back to the mempool.
+//   - Account tracking replays every tx from genesis, so a node with
+//     genesis balances refuses to start with a retention policy.
+type RetentionPolicy struct {
+	Blocks   int
+	Age      time.Duration
+	Interval time.Duration
+}
+
+func (p RetentionPolicy) enabled() bool {
+	return p.Blocks > 0 || p.Age > 0
+}
+
+// keeps reports whether the policy keeps block b in full on a chain
+// whose tip is at tipHeight.
+func (p RetentionPolicy) keeps(b *Block, tipHeight uint64, now time.Time) bool {
+	if p.Blocks > 0 && b.Header.Height+uint64(p.Blocks) > tipHeight {
+		return true
+	}
+	if p.Age > 0 && now.Sub(b.Header.Timestamp) <= p.Age {
+		return true
+	}
+	return false
+}
+
+// pruneBlock returns b's pruned form; see "Pruning semantics".
+func pruneBlock(b *Block) *Block {
+	out := &Block{
+		Header:       b.Header,
+		Transactions: make([]*Tx, len(b.Transactions)),
+		Signer:       b.Signer,
+		Signature:    b.Signature,
+		Pruned:       true,
+	}
+	for i, tx := range b.Transactions {
+		out.Transactions[i] = &Tx{ID: tx.ID, Gas: tx.Gas}
+	}
+	return out
+}
+
+// runPruner prunes the chain every interval until ctx ends.
+func (n *Node) runPruner(ctx context.Context) {
+	interval := n.cfg.Retention.Interval
+	if interval <= 0 {
+		interval = DefaultPruneInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := n.pruneChain(n.cfg.Clock.Now()); err != nil {
+				n.log.chain.Error("prune failed", "err", err)
+			}
+		}
+	}
+}
+
+// pruneChain prunes every block the retention policy no longer keeps and
+// returns how many it pruned.
+func (n *Node) pruneChain(now time.Time) (int, error) {
+	n.produceMu.Lock()
+	defer n.produceMu.Unlock()
+
+	n.blocksMu.RLock()
+	chain := n.blocks
+	n.blocksMu.RUnlock()
+	if len(chain) < 3 {
+		return 0, nil
+	}
+
+	// Heights and timestamps only grow, so the pruned blocks are a
+	// prefix: find its end, short of the tip.
+	tip := chain[len(chain)-1].Header.Height
+	through := 0
+	for i := 1; i < len(chain)-1 && !n.cfg.Retention.keeps(chain[i], tip, now); i++ {
+		through = i
+	}
+	next := append([]*Block(nil), chain...)
+	pruned := 0
+	for i := 1; i <= through; i++ {
+		if !next[i].Pruned {
+			next[i] = pruneBlock(next[i])
+			pruned++
+		}
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+
+	if n.store != nil {
+		if err := n.store.Prune(next[through].Header.Height); err != nil {
+			return 0, fmt.Errorf("prune store: %w", err)
+		}
+	}
+	n.blocksMu.Lock()
+	n.blocks = next
+	n.blocksMu.Unlock()
+
+	n.log.chain.Info("chain pruned", "through", next[through].Header.Height, "blocks", pruned)
+	return pruned, nil
+}
+
+// checkDisplaceable rejects displacing pruned blocks.
+func checkDisplaceable(displaced []*Block) error {
+	for _, b := range displaced {
+		if b.Pruned {
+			return fmt.Errorf("%w: block %d is pruned", ErrForkRejected, b.Header.Height)
+		}
+	}
+	return nil
+}
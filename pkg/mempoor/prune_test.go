@@ -0,0 +1,80 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPruneChainKeepsHeaders(t *testing.T) {
+	n := NewNode(NodeConfig{DataDir: t.TempDir(), MaxTxPerBlock: 10, Retention: RetentionPolicy{Blocks: 2}})
+	closeChain, err := n.openChain()
+	if err != nil {
+		t.Fatalf("open chain: %v", err)
+	}
+	defer closeChain()
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if _, err := n.mempool.Add(NewUnsignedTx("alice", "bob", "payload", uint64(i), 10, 10)); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+		prevHash, height := n.chainTip()
+		if _, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Duration(i+1)*time.Second)); err != nil {
+			t.Fatalf("produce: %v", err)
+		}
+	}
+	hashes := make([][32]byte, len(n.blocks))
+	for i, b := range n.blocks {
+		hashes[i] = b.Hash()
+	}
+
+	pruned, err := n.pruneChain(GenesisTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if pruned != 2 {
+		t.Fatalf("expected blocks 1 and 2 pruned, got %d", pruned)
+	}
+	for i, b := range n.blocks {
+		if want := i == 1 || i == 2; b.Pruned != want {
+			t.Fatalf("block %d: expected pruned=%v", i, want)
+		}
+		if b.Hash() != hashes[i] {
+			t.Fatalf("block %d: pruning changed the hash", i)
+		}
+	}
+	if n.blocks[1].Transactions[0].Payload != "" {
+		t.Fatalf("expected the payload dropped")
+	}
+
+	stored, err := n.store.Blocks()
+	if err != nil {
+		t.Fatalf("stored blocks: %v", err)
+	}
+	if err := validateChain(stored, 0); err != nil {
+		t.Fatalf("expected the pruned store to validate: %v", err)
+	}
+	if !stored[2].Pruned || stored[3].Pruned {
+		t.Fatalf("expected the store pruned through height 2")
+	}
+
+	if _, err := n.reorgBlock(forkBlock(n.blocks[1], newTx("carol", 500, 10))); !errors.Is(err, ErrForkRejected) {
+		t.Fatalf("expected displacing a pruned block rejected, got %v", err)
+	}
+	if again, _ := n.pruneChain(GenesisTime.Add(time.Hour)); again != 0 {
+		t.Fatalf("expected nothing left to prune, got %d", again)
+	}
+}
+
+func TestRetentionKeepsByAge(t *testing.T) {
+	p := RetentionPolicy{Blocks: 1, Age: time.Minute}
+	now := GenesisTime.Add(time.Hour)
+	young := &Block{Header: BlockHeader{Height: 1, Timestamp: now.Add(-time.Second)}}
+	old := &Block{Header: BlockHeader{Height: 1, Timestamp: now.Add(-time.Hour)}}
+	if !p.keeps(young, 5, now) || p.keeps(old, 5, now) || !p.keeps(old, 1, now) {
+		t.Fatalf("expected a block kept if either window holds it")
+	}
+}
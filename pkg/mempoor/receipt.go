@@ -0,0 +1,109 @@
+package mempoor
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ErrNoReceipt is returned by Receipt for a tx that is not on the chain.
+var ErrNoReceipt = errors.New("mempool: no receipt: tx is not on the chain")
+
+// Receipt confirms a tx's inclusion in the chain.
+//
+// Receipt semantics (Node.Receipt, tx.receipt):
+//   - The node indexes every tx on its chain by ID, with its block
+//     height and position, as blocks are produced, proposed, reorged,
+//     imported, and reloaded; unlike tx.status the index is not
+//     bounded. A tx included twice resolves to its latest block.
+//   - Txs of displaced blocks lose their receipts until a block on the
+//     new chain includes them again.
+//   - Fee is what the tx paid: the burn plus its effective tip under
+//     the node's BaseFee. A pruned block keeps only tx IDs and gas, so
+//     its receipts report Pruned and no fee.
+//   - Confirmations counts the tx's block and every block on top of
+//     it: 1 at the tip.
+type Receipt struct {
+	TxID          TxID    `json:"txID"`
+	Status        TxState `json:"status"` // always TxStateIncluded
+	BlockHeight   uint64  `json:"blockHeight"`
+	BlockHash     string  `json:"blockHash"`
+	Index         int     `json:"index"` // position in the block's transactions
+	Gas           uint64  `json:"gas"`
+	Fee           uint64  `json:"fee,omitempty"`
+	Confirmations uint64  `json:"confirmations"`
+	Pruned        bool    `json:"pruned,omitempty"`
+}
+
+// txLocation is where the tx index finds a tx on the chain.
+type txLocation struct {
+	height uint64
+	index  int
+}
+
+// Receipt returns id's receipt, or ErrNoReceipt; see "Receipt
+// semantics".
+func (n *Node) Receipt(id TxID) (Receipt, error) {
+	n.blocksMu.RLock()
+	defer n.blocksMu.RUnlock()
+
+	loc, ok := n.txIndex[id]
+	if !ok {
+		return Receipt{}, ErrNoReceipt
+	}
+	b := n.blocks[loc.height]
+	tx := b.Transactions[loc.index]
+	hash := b.Hash()
+	r := Receipt{
+		TxID:          id,
+		Status:        TxStateIncluded,
+		BlockHeight:   loc.height,
+		BlockHash:     hex.EncodeToString(hash[:]),
+		Index:         loc.index,
+		Gas:           tx.Gas,
+		Confirmations: uint64(len(n.blocks)) - loc.height,
+		Pruned:        b.Pruned,
+	}
+	if !b.Pruned {
+		tip, _ := EffectiveTip(tx, n.cfg.BaseFee)
+		r.Fee = burnFor(n.cfg.BaseFee, tx.Gas) + tip
+	}
+	return r, nil
+}
+
+// indexTxs adds b's txs to the tx index, or removes them; the caller
+// holds blocksMu for writing.
+func (n *Node) indexTxs(b *Block, add bool) {
+	for i, tx := range b.Transactions {
+		switch {
+		case add:
+			n.txIndex[tx.ID] = txLocation{height: b.Header.Height, index: i}
+		case n.txIndex[tx.ID].height == b.Header.Height:
+			delete(n.txIndex, tx.ID)
+		}
+	}
+}
+
+type txReceiptParams struct {
+	ID string `json:"id"`
+}
+
+func (n *Node) rpcTxReceipt(w http.ResponseWriter, params json.RawMessage) {
+	var p txReceiptParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for tx.receipt")
+		return
+	}
+	if p.ID == "" {
+		writeRPCError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	r, err := n.Receipt(TxID(p.ID))
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+	writeRPCResult(w, http.StatusOK, r)
+}
@@ -0,0 +1,65 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReceipts(t *testing.T) {
+	dir := t.TempDir()
+	n := NewNode(NodeConfig{DataDir: dir, MaxTxPerBlock: 10, BaseFee: 1})
+	closeChain, err := n.openChain()
+	if err != nil {
+		t.Fatalf("open chain: %v", err)
+	}
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+
+	low, high := newTx("alice", 30, 10), newTx("bob", 50, 10)
+	_, _ = n.mempool.Add(low)
+	_, _ = n.mempool.Add(high)
+	if _, err := n.Receipt(low.ID); !errors.Is(err, ErrNoReceipt) {
+		t.Fatalf("expected no receipt for a pending tx, got %v", err)
+	}
+	prevHash, height := n.chainTip()
+	b1, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second))
+	if err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+
+	r, err := n.Receipt(low.ID)
+	if err != nil {
+		t.Fatalf("receipt: %v", err)
+	}
+	hash := b1.Hash()
+	// Legacy txs pay their whole Fee: burn 1*10 plus a tip of the rest.
+	if r.Status != TxStateIncluded || r.BlockHeight != 1 || r.BlockHash != hex.EncodeToString(hash[:]) ||
+		r.Index != 1 || r.Gas != 10 || r.Fee != 30 || r.Confirmations != 1 {
+		t.Fatalf("unexpected receipt: %+v", r)
+	}
+	closeChain()
+
+	// The index is rebuilt from the store, and a reorg moves receipts.
+	n = NewNode(NodeConfig{DataDir: dir, MaxTxPerBlock: 10, BaseFee: 1})
+	closeChain, err = n.openChain()
+	if err != nil {
+		t.Fatalf("reopen chain: %v", err)
+	}
+	defer closeChain()
+	if r, err := n.Receipt(high.ID); err != nil || r.Index != 0 {
+		t.Fatalf("expected the receipt after reload, got %+v, %v", r, err)
+	}
+	if _, err := n.reorgBlock(forkBlockUnder(1, n.blocks[0], newTx("carol", 100, 10), high)); err != nil {
+		t.Fatalf("reorg: %v", err)
+	}
+	if _, err := n.Receipt(low.ID); !errors.Is(err, ErrNoReceipt) {
+		t.Fatalf("expected the displaced tx to lose its receipt, got %v", err)
+	}
+	if r, err := n.Receipt(high.ID); err != nil || r.Index != 1 {
+		t.Fatalf("expected the re-included tx at its new position, got %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,238 @@
+package mempoor
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrForkRejected is returned when a competing block loses the fork
+// choice, or is already on the chain.
+var ErrForkRejected = errors.New("blockbuilder: fork rejected")
+
+// Reorg semantics (block.reorg):
+//   - A competing block names an existing height above genesis and must
+//     be valid on top of the node's block below it, under the live
+//     limits (see validate.go). Validation recomputes its Burned and
+//     Tipped from its txs, so the fork choice weighs what the txs pay,
+//     not what the header claims. Blocks at the tip+1 height extend the
+//     chain through block.propose instead.
+//   - The fork choice (see forkchoice.go) compares it with every block
+//     it would displace: that height and all above it. Under the
+//     default, MostFees, the node switches only if it pays strictly more
+//     TotalFees than the displaced blocks together, so a tie keeps the
+//     current chain.
+//   - On a switch, the block takes the same path as a block the node
+//     publishes: it goes to every BlockSink, then the store replaces the
+//     displaced blocks with it in one write, and the tip moves to it in
+//     one step, so readers see the old chain or the new one. Metrics,
+//     head subscribers, and checkpoints follow the new tip.
+//   - Txs of the displaced blocks that the new block does not hold return
+//     to their pools via Reinsert; the new block's txs are committed in
+//     every pool with CommitExternal, so any pending copies leave.
+//   - Reorgs and block production are serialized, like block.propose.
+//
+// NOTE: The fee oracle and builder metrics keep what they sampled from
+// displaced blocks; a deep reorg skews fee.estimate until the window
+// rolls past it.
+
+// CommitExternal records txs as committed by a block this pool did not
+// build, e.g. one adopted in a reorg: pending copies are removed without
+// touching their dependents, which are released as for a selection, and
+// TxSelected is published for every tx. Scheduled and orphaned copies
+// stay where they are.
+func (m *mempool) CommitExternal(txs []*Tx) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tx := range txs {
+		if rec, ok := m.table[tx.ID]; ok {
+			m.removeRecord(rec)
+		}
+	}
+	m.commitLocked(txs)
+	m.adjustFeeFloor()
+}
+
+// reorgOutcome reports what reorgBlock switched.
+type reorgOutcome struct {
+	Displaced  []*Block // the replaced blocks, in height order
+	Reinserted int      // displaced txs returned to the pools
+}
+
+// reorgBlock replaces the node's block at competing's height, and all
+// above it, with competing if the fork choice prefers it. See "Reorg
+// semantics".
+func (n *Node) reorgBlock(competing *Block) (reorgOutcome, error) {
+	var out reorgOutcome
+
+	n.produceMu.Lock()
+	defer n.produceMu.Unlock()
+
+	height := competing.Header.Height
+	n.blocksMu.RLock()
+	chain := n.blocks
+	n.blocksMu.RUnlock()
+
+	if height == 0 || height >= uint64(len(chain)) || chain[height].Header.Height != height {
+		return out, fmt.Errorf("%w: no block at height %d to replace", ErrInvalidBlock, height)
+	}
+	limits, _ := n.limits.get()
+	if err := ValidateBlock(competing, chain[height-1], n.cfg.BaseFee, limits); err != nil {
+		return out, err
+	}
+	if n.state != nil {
+		parent := NewState(n.cfg.Genesis.Balances)
+		if err := parent.Rebuild(chain[1:height], n.cfg.BaseFee); err != nil {
+			return out, fmt.Errorf("reorg: %w", err)
+		}
+		if err := parent.Check(competing, n.cfg.BaseFee); err != nil {
+			return out, fmt.Errorf("%w: %w", ErrInvalidBlock, err)
+		}
+	}
+	if chain[height].Hash() == competing.Hash() {
+		return out, fmt.Errorf("%w: block %d is already on the chain", ErrForkRejected, height)
+	}
+
+	displaced := chain[height:]
+	if err := checkDisplaceable(displaced); err != nil {
+		return out, err
+	}
+	if !n.forkChoice().Prefer(displaced, []*Block{competing}) {
+		return out, fmt.Errorf("%w: fork choice keeps the current chain at height %d", ErrForkRejected, height)
+	}
+
+	// Collect the displaced txs before anything changes.
+	kept := make(map[TxID]bool, len(competing.Transactions))
+	for _, tx := range competing.Transactions {
+		kept[tx.ID] = true
+	}
+	var orphaned []*Tx
+	for _, b := range displaced {
+		plain, err := DecompressPayloads(b)
+		if err != nil {
+			return out, fmt.Errorf("reorg: block %d: %w", b.Header.Height, err)
+		}
+		for _, tx := range plain.Transactions {
+			if !kept[tx.ID] {
+				orphaned = append(orphaned, tx)
+			}
+		}
+	}
+
+	if err := n.storeBlock(competing); err != nil {
+		return out, err
+	}
+	if err := n.rebuildState(); err != nil {
+		n.log.chain.Error("reorg state rebuild failed", "height", height, "err", err)
+	}
+
+	// Displaced txs go back first, so the new block's commit also
+	// releases anything now waiting on them.
+	if err := n.reinsert(orphaned); err != nil {
+		n.log.mempool.Error("reorg reinsert failed", "height", height, "err", err)
+	}
+	for _, p := range n.pools {
+		p.mp.CommitExternal(competing.Transactions)
+	}
+	n.blockCommitted(competing, true)
+
+	n.log.chain.Info("chain reorganized", "height", height, "displaced", len(displaced),
+		"reinserted", len(orphaned), "tip", fmt.Sprintf("%x", competing.Hash()))
+	out.Displaced, out.Reinserted = displaced, len(orphaned)
+	return out, nil
+}
+
+// parseBlockDTO is the inverse of makeBlockDTO for a complete block, as
+// block.reorg receives it: every field is taken as given, payloads are
+// decoded and decompressed, and a hash, if given, must match.
+func parseBlockDTO(d blockDTO) (*Block, error) {
+	b := &Block{
+		Header: BlockHeader{
+			Height:       d.Height,
+			Timestamp:    d.Timestamp,
+			TxCount:      d.TxCount,
+			GasUsed:      d.GasUsed,
+			Burned:       d.Burned,
+			Tipped:       d.Tipped,
+			TotalFees:    d.TotalFees,
+			AvgFeePerGas: d.AvgFeePerGas,
+			Proposer:     d.Proposer,
+			Extra:        d.Extra,
+			ChainID:      d.ChainID,
+		},
+		Transactions: d.Txs,
+		Groups:       d.Groups,
+		Pruned:       d.Pruned,
+	}
+	if err := decodeHash(d.PrevHash, &b.Header.PrevHash); err != nil {
+		return nil, fmt.Errorf("%w: bad prevHash", ErrInvalidBlock)
+	}
+	var err error
+	if b.Signer, err = hex.DecodeString(d.Signer); err != nil {
+		return nil, fmt.Errorf("%w: bad signer", ErrInvalidBlock)
+	}
+	if b.Signature, err = hex.DecodeString(d.Signature); err != nil {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidBlock)
+	}
+	if d.ExtraData != "" {
+		raw, err := hex.DecodeString(d.ExtraData)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad extraData", ErrInvalidBlock)
+		}
+		b.Header.ExtraData = raw
+	}
+	for _, tx := range b.Transactions {
+		if tx == nil || tx.ID == "" {
+			return nil, fmt.Errorf("%w: tx without ID", ErrInvalidBlock)
+		}
+	}
+
+	if d.Compression != "" {
+		c, err := ParsePayloadCompression(d.Compression)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBlock, err)
+		}
+		b.Compression = c
+		if b, err = decodePayloads(b); err == nil {
+			b, err = DecompressPayloads(b)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBlock, err)
+		}
+	}
+
+	if d.Hash != "" {
+		var hash [32]byte
+		if err := decodeHash(d.Hash, &hash); err != nil {
+			return nil, fmt.Errorf("%w: bad hash", ErrInvalidBlock)
+		}
+		if b.Hash() != hash {
+			return nil, fmt.Errorf("%w: hash does not match its contents", ErrInvalidBlock)
+		}
+	}
+	return b, nil
+}
+
+// reinsert returns txs to the pools they came from, or to the default
+// pool if theirs is no longer configured.
+func (n *Node) reinsert(txs []*Tx) error {
+	byPool := make(map[string][]*Tx, len(n.pools))
+	for _, tx := range txs {
+		p, err := n.pool(tx.Pool)
+		if err != nil {
+			cp := *tx
+			cp.Pool = ""
+			tx, p = &cp, &n.pools[0]
+		}
+		byPool[p.name] = append(byPool[p.name], tx)
+	}
+
+	var errs []error
+	for _, p := range n.pools {
+		if len(byPool[p.name]) > 0 {
+			errs = append(errs, p.mp.Reinsert(byPool[p.name]))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,180 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// forkBlock builds a valid block on parent holding txs, paying their fees
+// as tips.
+func forkBlock(parent *Block, txs ...*Tx) *Block {
+	return forkBlockUnder(0, parent, txs...)
+}
+
+// forkBlockUnder is forkBlock on a chain burning baseFee.
+func forkBlockUnder(baseFee uint64, parent *Block, txs ...*Tx) *Block {
+	b := &Block{
+		Header: BlockHeader{
+			Height:    parent.Header.Height + 1,
+			PrevHash:  parent.Hash(),
+			Timestamp: parent.Header.Timestamp.Add(time.Second),
+			TxCount:   len(txs),
+		},
+		Transactions: txs,
+	}
+	for _, tx := range txs {
+		burned, tip := txFees(tx, baseFee)
+		b.Header.GasUsed += tx.Gas
+		b.Header.Burned += burned
+		b.Header.Tipped += tip
+	}
+	b.Header.TotalFees, b.Header.AvgFeePerGas = feeSummary(b.Header.Burned, b.Header.Tipped, b.Header.GasUsed)
+	return b
+}
+
+func TestReorgSwitchesToHigherFeeBlock(t *testing.T) {
+	n := NewNode(NodeConfig{DataDir: t.TempDir(), MaxTxPerBlock: 10})
+	closeChain, err := n.openChain()
+	if err != nil {
+		t.Fatalf("open chain: %v", err)
+	}
+	defer closeChain()
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+
+	mine, theirs, both := newTx("alice", 5, 10), newTx("bob", 50, 10), newTx("carol", 7, 10)
+	_, _ = n.mempool.Add(mine)
+	prevHash, height := n.chainTip()
+	b1, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second))
+	if err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	_, _ = n.mempool.Add(both)
+
+	// A block paying less than the one it would replace is refused.
+	genesis := n.blocks[0]
+	if _, err := n.reorgBlock(forkBlock(genesis, newTx("dave", 1, 10))); !errors.Is(err, ErrForkRejected) {
+		t.Fatalf("expected ErrForkRejected, got %v", err)
+	}
+
+	competing := forkBlock(genesis, theirs, both)
+	out, err := n.reorgBlock(competing)
+	if err != nil {
+		t.Fatalf("reorg: %v", err)
+	}
+	if len(out.Displaced) != 1 || out.Displaced[0].Hash() != b1.Hash() || out.Reinserted != 1 {
+		t.Fatalf("expected block 1 displaced with one tx reinserted, got %+v", out)
+	}
+	if hash, height := n.chainTip(); hash != competing.Hash() || height != 2 {
+		t.Fatalf("expected the competing block as tip, got height %d", height)
+	}
+	if _, err := n.mempool.Get(mine.ID); err != nil {
+		t.Fatalf("expected the displaced tx back in the pool: %v", err)
+	}
+	if _, err := n.mempool.Get(both.ID); !errors.Is(err, ErrTxNotFound) {
+		t.Fatalf("expected the adopted tx committed, got %v", err)
+	}
+
+	stored, err := n.store.Blocks()
+	if err != nil || len(stored) != 2 || stored[1].Hash() != competing.Hash() {
+		t.Fatalf("expected the store to hold genesis and the new tip, got %d, %v", len(stored), err)
+	}
+	if _, err := n.store.BlockByHash(b1.Hash()); !errors.Is(err, ErrBlockNotFound) {
+		t.Fatalf("expected the displaced block gone from the store, got %v", err)
+	}
+}
+
+func TestReorgDropsBlocksAbove(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	for i, fee := range []uint64{5, 6} {
+		_, _ = n.mempool.Add(newTx("alice", fee, 10))
+		prevHash, height := n.chainTip()
+		if _, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Duration(i+1)*time.Second)); err != nil {
+			t.Fatalf("produce: %v", err)
+		}
+	}
+
+	// The fork choice weighs every displaced block: 5+6 = 11 ties and
+	// keeps the chain, 12 wins.
+	if _, err := n.reorgBlock(forkBlock(n.blocks[0], newTx("bob", 11, 10))); !errors.Is(err, ErrForkRejected) {
+		t.Fatalf("expected a tie to keep the chain, got %v", err)
+	}
+	out, err := n.reorgBlock(forkBlock(n.blocks[0], newTx("bob", 12, 10)))
+	if err != nil || len(out.Displaced) != 2 || out.Reinserted != 2 {
+		t.Fatalf("expected two blocks displaced, got %+v, %v", out, err)
+	}
+	if n.mempool.Count() != 2 {
+		t.Fatalf("expected both displaced txs pending, got %d", n.mempool.Count())
+	}
+	if _, err := n.reorgBlock(forkBlock(n.blocks[1], newTx("carol", 99, 10))); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected the tip+1 height refused, got %v", err)
+	}
+}
+
+func TestReorgRefusesForgedFees(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+	_, _ = n.mempool.Add(newTx("alice", 500, 10))
+	prevHash, height := n.chainTip()
+	b1, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second))
+	if err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+
+	// An empty block claiming a huge tip would win MostFees on its
+	// header alone.
+	forged := forkBlock(n.blocks[0])
+	forged.Header.Tipped = 1 << 40
+	forged.Header.TotalFees, forged.Header.AvgFeePerGas = feeSummary(0, forged.Header.Tipped, 0)
+	if _, err := n.reorgBlock(forged); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected forged fees refused, got %v", err)
+	}
+	if hash, _ := n.chainTip(); hash != b1.Hash() {
+		t.Fatalf("expected block 1 kept")
+	}
+}
+
+func TestReorgUpdatesMetricsAndCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	n, closeChain := startFromDisk(t, NodeConfig{DataDir: dir, MaxTxPerBlock: 10, BlockInterval: time.Second, CheckpointEvery: 1})
+	defer closeChain()
+	_, _ = n.mempool.Add(newTx("alice", 5, 10))
+	if _, err := n.Mine(context.Background(), 1); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+
+	competing := forkBlock(n.blocks[0], newTx("bob", 50, 30))
+	if _, err := n.reorgBlock(competing); err != nil {
+		t.Fatalf("reorg: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	n.metrics.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), "mempoor_block_gas_used_sum 40\n") {
+		t.Fatalf("expected the adopted block's gas recorded")
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, checkpointFile))
+	if err != nil {
+		t.Fatalf("read checkpoint: %v", err)
+	}
+	var cp chainCheckpoint
+	hash := competing.Hash()
+	if err := json.Unmarshal(raw, &cp); err != nil || cp.TipHash != hex.EncodeToString(hash[:]) {
+		t.Fatalf("expected a checkpoint at the new tip, got %+v, %v", cp, err)
+	}
+}
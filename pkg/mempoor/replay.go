@@ -0,0 +1,317 @@
+package mempoor
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNoTimestamp is returned by a Deterministic builder asked to build
+// with a zero timestamp, which would otherwise be read from the clock.
+var ErrNoTimestamp = errors.New("blockbuilder: deterministic build needs an explicit timestamp")
+
+// Replay semantics (NodeConfig.ReplayLog):
+//   - The node appends one JSON line per pool change ("put", "del",
+//     "purge") and one "block" line per produced block, carrying the
+//     block's height, prevHash, timestamp, constraints, and hash. Each
+//     run starts with a "reset" followed by a "put" of every pending tx.
+//   - Replay rebuilds the pool as of each "block" line, restores it into
+//     a fresh mempool (in canonical ID order), builds the block with the
+//     recorded constraints and timestamp, and compares hashes.
+//   - Purges happen during selection, before the block line is written;
+//     replay applies them after the block instead, and the rebuilt
+//     selection purges the same txs on its own.
+//
+// NOTE: Only what BlockConstraints can serialize is replayed: Filters
+// from BeforeSelect hooks, best-of Candidates, AfterAssemble Extra
+// fields, extra Pools, and required txs are not, so blocks built with
+// them can show up as mismatches. So can blocks committed through
+// block.propose or block.reorg, and txs added between selection and
+// publishing.
+type replayEntry struct {
+	Op    string       `json:"op"` // "reset", "put", "del", "purge", or "block"
+	Tx    *Tx          `json:"tx,omitempty"`
+	ID    TxID         `json:"id,omitempty"`
+	Block *replayBlock `json:"block,omitempty"`
+}
+
+// replayBlock records what is needed to build one block again.
+type replayBlock struct {
+	Height      uint64            `json:"height"`
+	PrevHash    string            `json:"prevHash"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Hash        string            `json:"hash"`
+	Proposer    string            `json:"proposer,omitempty"`
+	ExtraData   []byte            `json:"extraData,omitempty"`
+	ChainID     string            `json:"chainId,omitempty"`
+	Constraints replayConstraints `json:"constraints"`
+}
+
+// replayConstraints is the serializable part of BlockConstraints.
+type replayConstraints struct {
+	GasLimit      uint64            `json:"gasLimit"`
+	MaxTx         int               `json:"maxTx"`
+	MinFee        uint64            `json:"minFee"`
+	LaneGasLimits map[Lane]uint64   `json:"laneGasLimits,omitempty"`
+	BaseFee       uint64            `json:"baseFee,omitempty"`
+	Strategy      SelectionStrategy `json:"strategy"`
+	Packing       PackingMode       `json:"packing"`
+	Lookahead     int               `json:"lookahead"`
+}
+
+// replayRecorder writes the replay log. It observes the mempool, records
+// each build's constraints through a BeforeSelect hook, and writes the
+// block line as a BlockSink.
+type replayRecorder struct {
+	NopObserver
+
+	mu   sync.Mutex
+	path string
+	w    *bufio.Writer
+	f    *os.File
+	last replayConstraints
+	err  error
+}
+
+func newReplayRecorder(path string) *replayRecorder {
+	return &replayRecorder{path: path}
+}
+
+// open starts a new run in the log with the txs already pending.
+func (r *replayRecorder) open(pending []*Tx) error {
+	r.mu.Lock()
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		r.mu.Unlock()
+		return fmt.Errorf("open replay log: %w", err)
+	}
+	r.f, r.w = f, bufio.NewWriter(f)
+	r.mu.Unlock()
+
+	r.append(replayEntry{Op: "reset"})
+	for _, tx := range pending {
+		r.append(replayEntry{Op: "put", Tx: tx})
+	}
+	return r.flush()
+}
+
+func (r *replayRecorder) OnAdd(tx *Tx)      { r.append(replayEntry{Op: "put", Tx: tx}) }
+func (r *replayRecorder) OnUpdate(tx *Tx)   { r.append(replayEntry{Op: "put", Tx: tx}) }
+func (r *replayRecorder) OnSchedule(tx *Tx) { r.append(replayEntry{Op: "put", Tx: tx}) }
+func (r *replayRecorder) OnRemove(tx *Tx)   { r.append(replayEntry{Op: "del", ID: tx.ID}) }
+func (r *replayRecorder) OnSelect(tx *Tx)   { r.append(replayEntry{Op: "del", ID: tx.ID}) }
+func (r *replayRecorder) OnEvict(tx *Tx)    { r.append(replayEntry{Op: "del", ID: tx.ID}) }
+func (r *replayRecorder) OnPurge(tx *Tx)    { r.append(replayEntry{Op: "purge", ID: tx.ID}) }
+
+// hook remembers the constraints of the block being built. Install it
+// after every other BeforeSelect hook.
+func (r *replayRecorder) hook(c *BlockConstraints) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.last = replayConstraints{
+		GasLimit:      c.GasLimit,
+		MaxTx:         c.MaxTx,
+		MinFee:        c.MinFee,
+		LaneGasLimits: c.LaneGasLimits,
+		BaseFee:       c.BaseFee,
+		Strategy:      c.Strategy,
+		Packing:       c.Packing,
+		Lookahead:     c.Lookahead,
+	}
+	return nil
+}
+
+// sink writes the block line. A write error fails the block, so the
+// log never misses a block the node stored.
+func (r *replayRecorder) sink(b *Block) error {
+	hash := b.Hash()
+	r.mu.Lock()
+	c := r.last
+	r.mu.Unlock()
+
+	r.append(replayEntry{Op: "block", Block: &replayBlock{
+		Height:      b.Header.Height,
+		PrevHash:    hex.EncodeToString(b.Header.PrevHash[:]),
+		Timestamp:   b.Header.Timestamp,
+		Hash:        hex.EncodeToString(hash[:]),
+		Proposer:    b.Header.Proposer,
+		ChainID:     b.Header.ChainID,
+		ExtraData:   b.Header.ExtraData,
+		Constraints: c,
+	}})
+	return r.flush()
+}
+
+func (r *replayRecorder) append(e replayEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.w == nil || r.err != nil {
+		return
+	}
+	raw, err := json.Marshal(e)
+	if err == nil {
+		_, err = r.w.Write(append(raw, '\n'))
+	}
+	if err != nil {
+		r.err = fmt.Errorf("write replay log: %w", err)
+	}
+}
+
+// flush writes buffered lines out and reports the first write error.
+func (r *replayRecorder) flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	if r.w == nil {
+		return nil
+	}
+	if err := r.w.Flush(); err != nil {
+		r.err = fmt.Errorf("write replay log: %w", err)
+		return r.err
+	}
+	return nil
+}
+
+func (r *replayRecorder) close() error {
+	err := r.flush()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.f != nil {
+		err = errors.Join(err, r.f.Close())
+		r.f, r.w = nil, nil
+	}
+	return err
+}
+
+// ReplayMismatch is a recorded block that replay built differently.
+type ReplayMismatch struct {
+	Height uint64
+	Want   string // recorded hash, hex
+	Got    string // replayed hash, hex; "" if replay built no block
+	Err    error  // build error, if any
+}
+
+// ReplayReport summarizes a Replay run.
+type ReplayReport struct {
+	Blocks     int
+	Mismatches []ReplayMismatch
+}
+
+// Replay re-derives every block in a replay log from the recorded pool
+// state and constraints, with a Deterministic builder over a fresh
+// mempool built from cfg, and reports blocks whose hash differs. See
+// "Replay semantics". cfg.Clock and cfg.Observer are ignored.
+func Replay(r io.Reader, cfg MempoolConfig) (ReplayReport, error) {
+	var report ReplayReport
+	txs := make(map[TxID]*Tx)
+	var purged []TxID
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		var e replayEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return report, fmt.Errorf("decode replay log line %d: %w", line, err)
+		}
+		switch e.Op {
+		case "reset":
+			clear(txs)
+			purged = purged[:0]
+		case "put":
+			if e.Tx == nil {
+				return report, fmt.Errorf("decode replay log line %d: put without tx", line)
+			}
+			txs[e.Tx.ID] = e.Tx
+		case "del":
+			delete(txs, e.ID)
+		case "purge":
+			purged = append(purged, e.ID)
+		case "block":
+			if e.Block == nil {
+				return report, fmt.Errorf("decode replay log line %d: block without header", line)
+			}
+			report.Blocks++
+			if mm, ok := replayBlockFrom(txs, e.Block, cfg); !ok {
+				report.Mismatches = append(report.Mismatches, mm)
+			}
+			for _, id := range purged {
+				delete(txs, id)
+			}
+			purged = purged[:0]
+		default:
+			return report, fmt.Errorf("decode replay log line %d: unknown op %q", line, e.Op)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return report, fmt.Errorf("read replay log: %w", err)
+	}
+	return report, nil
+}
+
+// replayBlockFrom builds rb again from txs and reports whether the hash
+// matches.
+func replayBlockFrom(txs map[TxID]*Tx, rb *replayBlock, cfg MempoolConfig) (ReplayMismatch, bool) {
+	mm := ReplayMismatch{Height: rb.Height, Want: rb.Hash}
+
+	var prevHash [32]byte
+	raw, err := hex.DecodeString(rb.PrevHash)
+	if err != nil || len(raw) != len(prevHash) {
+		mm.Err = fmt.Errorf("bad prevHash %q", rb.PrevHash)
+		return mm, false
+	}
+	copy(prevHash[:], raw)
+
+	pending := make([]*Tx, 0, len(txs))
+	for _, tx := range txs {
+		pending = append(pending, tx)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+
+	cfg.Clock = NewFakeClock(rb.Timestamp)
+	cfg.ChainID = rb.ChainID
+	cfg.Observer = nil
+	mp := NewMempool(cfg)
+	if err := mp.Restore(pending); err != nil {
+		mm.Err = err
+		return mm, false
+	}
+
+	c := rb.Constraints
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{
+		GasLimit:      c.GasLimit,
+		MaxTxPerBlock: c.MaxTx,
+		MinFee:        c.MinFee,
+		LaneGasLimits: c.LaneGasLimits,
+		BaseFee:       c.BaseFee,
+		Strategy:      c.Strategy,
+		Packing:       c.Packing,
+		PackLookahead: c.Lookahead,
+		Proposer:      rb.Proposer,
+		ExtraData:     rb.ExtraData,
+		ChainID:       rb.ChainID,
+		AllowEmpty:    true,
+		Deterministic: true,
+	})
+	block, err := builder.BuildBlock(context.Background(), prevHash, rb.Height, rb.Timestamp)
+	if err != nil {
+		mm.Err = err
+		return mm, false
+	}
+	hash := block.Hash()
+	mm.Got = hex.EncodeToString(hash[:])
+	return mm, mm.Got == mm.Want
+}
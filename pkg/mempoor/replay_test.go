@@ -0,0 +1,80 @@
+package mempoor
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeterministicBuilderNeedsTimestamp(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	_, _ = mp.Add(newTx("alice", 10, 100))
+	builder := NewBlockBuilder(mp, BlockBuilderConfig{MaxTxPerBlock: 10, Deterministic: true})
+
+	if _, err := builder.BuildBlock(context.Background(), [32]byte{}, 0, time.Time{}); !errors.Is(err, ErrNoTimestamp) {
+		t.Fatalf("expected ErrNoTimestamp, got %v", err)
+	}
+	if mp.Count() != 1 {
+		t.Fatalf("a refused build must not select anything")
+	}
+}
+
+func TestReplayRederivesRecordedBlocks(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0).UTC())
+	path := filepath.Join(t.TempDir(), "replay.log")
+	n := NewNode(NodeConfig{
+		MaxTxPerBlock: 2,
+		MinFee:        5,
+		Strategy:      StrategyFairShare,
+		ReplayLog:     path,
+		Clock:         clock,
+	})
+	if err := n.replay.open(nil); err != nil {
+		t.Fatalf("open replay log: %v", err)
+	}
+
+	for i, fee := range []uint64{50, 40, 30, 1} {
+		tx := NewUnsignedTxWithClock(clock, "alice", "bob", "p", uint64(i), fee, 10)
+		_, _ = n.mempool.Add(tx)
+	}
+	_, _ = n.mempool.Add(NewUnsignedTxWithClock(clock, "carol", "bob", "p", 0, 20, 10))
+
+	var prev [32]byte
+	for height := uint64(0); height < 2; height++ {
+		clock.Advance(time.Second)
+		blk, err := n.produceBlock(context.Background(), prev, height, clock.Now())
+		if err != nil {
+			t.Fatalf("block %d: %v", height, err)
+		}
+		prev = blk.Hash()
+	}
+	if err := n.replay.close(); err != nil {
+		t.Fatalf("close replay log: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := Replay(bytes.NewReader(raw), MempoolConfig{})
+	if err != nil {
+		t.Fatalf("unexpected Replay error: %v", err)
+	}
+	if report.Blocks != 2 || len(report.Mismatches) != 0 {
+		t.Fatalf("expected 2 matching blocks, got %+v", report)
+	}
+
+	// A block recorded with a different hash is reported.
+	hash := hex.EncodeToString(prev[:])
+	tampered := strings.Replace(string(raw), hash, strings.Repeat("0", len(hash)), 1)
+	report, err = Replay(strings.NewReader(tampered), MempoolConfig{})
+	if err != nil || len(report.Mismatches) != 1 || report.Mismatches[0].Height != 1 {
+		t.Fatalf("expected a mismatch at height 1, got %+v, %v", report, err)
+	}
+}
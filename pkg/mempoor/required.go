@@ -0,0 +1,97 @@
+package mempoor
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Mandatory inclusion semantics:
+//   - A required tx goes at the top of the next block, in the order the
+//     txs were required, ahead of every lane and regardless of fee,
+//     priority, strategy, lane quota, selection Filter, and MinFee. Only
+//     the block's MaxTx and GasLimit can keep it out.
+//   - Under a BaseFee it burns what it can, min(MaxFee, BaseFee*Gas),
+//     and tips only what is left; a tx that cannot cover the burn tips 0.
+//   - Nonce order and dependencies still hold: a required tx whose sender
+//     has an earlier nonce pending, or that waits on a dependency, is
+//     skipped by the top-of-block pass and competes by priority instead.
+//   - Like a pin, the requirement protects the tx from eviction and
+//     MinFee purges and lasts until Unrequire, or until the tx is
+//     committed or dropped; a reservation rollback keeps it. It is not
+//     persisted or replayed.
+//   - Bundle members cannot be required; the bundle orders as a unit.
+
+// Require marks a pending tx for inclusion at the top of the next block.
+// Requiring it again keeps its place. Strict: ErrTxNotFound if id is not
+// pending, ErrInvalidBundle if it is a bundle member.
+func (m *mempool) Require(id TxID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, ok := m.table[id]
+	if !ok {
+		return ErrTxNotFound
+	}
+	if rec.bundle != nil {
+		return fmt.Errorf("%w: tx %s is in bundle %s", ErrInvalidBundle, id, rec.tx.BundleID)
+	}
+	if _, ok := m.required[id]; !ok {
+		m.requiredSeq++
+		m.required[id] = m.requiredSeq
+	}
+	return nil
+}
+
+// Unrequire removes the requirement added by Require. Unrequiring a tx
+// that is not required is a no-op; ErrTxNotFound only if id is not
+// pending.
+func (m *mempool) Unrequire(id TxID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.table[id]; !ok {
+		return ErrTxNotFound
+	}
+	delete(m.required, id)
+	return nil
+}
+
+// Required returns the IDs of the required txs in the order they were
+// required.
+func (m *mempool) Required() []TxID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recs := m.requiredRecords()
+	ids := make([]TxID, len(recs))
+	for i, rec := range recs {
+		ids[i] = rec.tx.ID
+	}
+	return ids
+}
+
+// requiredRecords returns the records of the required txs in the order
+// they were required. Caller must hold at least the read lock.
+func (m *mempool) requiredRecords() []*txRecord {
+	recs := make([]*txRecord, 0, len(m.required))
+	for id := range m.required {
+		if rec, ok := m.table[id]; ok {
+			recs = append(recs, rec)
+		}
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		return m.required[recs[i].tx.ID] < m.required[recs[j].tx.ID]
+	})
+	return recs
+}
+
+// protected reports whether id is pinned or required, and so exempt
+// from eviction and MinFee purges. Caller must hold at least the read
+// lock.
+func (m *mempool) protected(id TxID) bool {
+	if _, ok := m.pinned[id]; ok {
+		return true
+	}
+	_, ok := m.required[id]
+	return ok
+}
@@ -0,0 +1,65 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRequiredTxsTopTheBlock(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	rich := newTx("alice", 100, 10)
+	gov := newTx("gov", 1, 10)
+	ops := newTx("ops", 2, 10)
+	urgent := newTx("carol", 50, 10)
+	urgent.Lane = LaneUrgent
+	for _, tx := range []*Tx{rich, gov, ops, urgent} {
+		if _, err := mp.Add(tx); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+	}
+	_ = mp.Require(gov.ID)
+	_ = mp.Require(ops.ID)
+	_ = mp.Require(gov.ID) // keeps its place
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 3, MinFee: 5, LaneGasLimits: map[Lane]uint64{LaneNormal: 10}})
+	want := []TxID{gov.ID, ops.ID, urgent.ID}
+	if len(res.Transactions) != len(want) {
+		t.Fatalf("expected %d txs, got %v", len(want), res.Transactions)
+	}
+	for i, id := range want {
+		if res.Transactions[i].ID != id {
+			t.Fatalf("position %d: expected %s, got %s", i, id, res.Transactions[i].ID)
+		}
+	}
+	if len(res.Purged) != 0 {
+		t.Fatalf("expected no purges, got %v", res.Purged)
+	}
+	if len(mp.Required()) != 0 {
+		t.Fatalf("requirements should be released once the txs are committed")
+	}
+}
+
+func TestRequiredTxObeysGasLimit(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	big := newTx("gov", 1, 1_000)
+	small := newTx("alice", 5, 10)
+	_, _ = mp.Add(big)
+	_, _ = mp.Add(small)
+	_ = mp.Require(big.ID)
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 10, GasLimit: 100})
+	if len(res.Transactions) != 1 || res.Transactions[0].ID != small.ID {
+		t.Fatalf("required tx must still respect the gas limit, got %v", res.Transactions)
+	}
+	if ids := mp.Required(); len(ids) != 1 || ids[0] != big.ID {
+		t.Fatalf("expected the requirement kept, got %v", ids)
+	}
+	if err := mp.Unrequire(big.ID); err != nil || len(mp.Required()) != 0 {
+		t.Fatalf("unrequire: %v, %v", err, mp.Required())
+	}
+	if err := mp.Require("missing"); !errors.Is(err, ErrTxNotFound) {
+		t.Fatalf("expected ErrTxNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrReservationClosed is returned when a reservation is committed or
+// rolled back more than once.
+var ErrReservationClosed = errors.New("mempool: reservation already committed or rolled back")
+
+// Reservation holds transactions taken out of the mempool by Reserve
+// until the caller decides their fate. Exactly one of Commit or Rollback
+// should be called; later calls return ErrReservationClosed.
+type Reservation interface {
+	// Result returns the selected transactions and their gas usage.
+	Result() BlockSelectionResult
+
+	// Commit finalizes the selection: the txs are gone for good.
+	Commit() error
+
+	// Rollback returns the txs to the mempool with their original
+	// Timestamp, so they keep their place in line.
+	Rollback() error
+}
+
+// reservation is the mempool's Reservation implementation.
+type reservation struct {
+	m      *mempool
+	result BlockSelectionResult
+
+	once sync.Once
+}
+
+// Reserve selects txs exactly like SelectTransactions but defers the
+// decision: the txs are held out of the pool (invisible to Get, List,
+// and further selection) until Commit or Rollback. Low-fee purges are
+// permanent and happen immediately.
+func (m *mempool) Reserve(c BlockConstraints) Reservation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := &reservation{m: m, result: m.selectLocked(c)}
+	m.adjustFeeFloor()
+	return res
+}
+
+func (r *reservation) Result() BlockSelectionResult { return r.result }
+
+func (r *reservation) Commit() error {
+	closed := true
+	r.once.Do(func() {
+		closed = false
+
+		r.m.mu.Lock()
+		defer r.m.mu.Unlock()
+		r.m.commitLocked(r.result.Transactions)
+	})
+	if closed {
+		return ErrReservationClosed
+	}
+	return nil
+}
+
+func (r *reservation) Rollback() error {
+	closed := true
+	r.once.Do(func() {
+		closed = false
+
+		r.m.mu.Lock()
+		defer r.m.mu.Unlock()
+		r.m.rollbackLocked(r.result.Transactions)
+	})
+	if closed {
+		return ErrReservationClosed
+	}
+	return nil
+}
+
+// commitLocked finalizes selected txs. Caller must hold the write lock.
+func (m *mempool) commitLocked(txs []*Tx) {
+	for _, tx := range txs {
+		m.recordSelectedNonce(tx)
+		m.satisfyDependents(tx.ID)
+		delete(m.pinned, tx.ID)
+		delete(m.required, tx.ID)
+		m.events.publish(TxSelected, tx)
+	}
+
+	// A committed nonce may satisfy orphans that arrived meanwhile.
+	if len(txs) > 0 && len(m.orphans) > 0 {
+		m.promoteOrphans()
+	}
+}
+
+// rollbackLocked re-admits reserved txs. IDs that reappeared while the
+// reservation was open are skipped; txs that no longer fit under the
+// capacity bound are dropped as evicted, along with the rest of their
+// bundle. Caller must hold the write lock.
+func (m *mempool) rollbackLocked(txs []*Tx) {
+	for _, tx := range txs {
+		if _, exists := m.table[tx.ID]; exists {
+			continue
+		}
+		if _, exists := m.orphans[tx.ID]; exists {
+			continue
+		}
+		if _, err := m.admit(tx); err != nil {
+			m.dropped(TxEvicted, tx)
+		}
+	}
+	m.settleBundles(txs, TxEvicted)
+
+	if len(txs) > 0 && len(m.orphans) > 0 {
+		m.promoteOrphans()
+	}
+}
+
+// Reinsert puts back txs from a block that was produced (and committed)
+// but later rejected downstream.
+//
+// Reinsert semantics:
+//   - CreatedAt is kept; Timestamp is refreshed, so reinserted txs queue
+//     behind txs of equal priority that never left (unlike Rollback).
+//   - IDs that are pending or orphaned again are skipped silently.
+//   - Dedup and dependency checks are bypassed: the txs were admitted
+//     once already and their parents are in the same block.
+//   - A tx that no longer fits under MaxTxs is dropped, reported as
+//     TxEvicted, and its error is included in the returned error.
+func (m *mempool) Reinsert(txs []*Tx) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.cfg.Clock.Now()
+	var errs []error
+	for _, tx := range txs {
+		if _, exists := m.table[tx.ID]; exists {
+			continue
+		}
+		if _, exists := m.orphans[tx.ID]; exists {
+			continue
+		}
+
+		if !tx.Lane.valid() {
+			errs = append(errs, fmt.Errorf("reinsert %s: %w", tx.ID, ErrInvalidLane))
+			continue
+		}
+
+		cp := *tx
+		cp.Timestamp = now
+		if _, err := m.admit(&cp); err != nil {
+			m.dropped(TxEvicted, &cp)
+			errs = append(errs, fmt.Errorf("reinsert %s: %w", tx.ID, err))
+		}
+	}
+	if m.settleBundles(txs, TxEvicted) {
+		errs = append(errs, fmt.Errorf("reinsert: %w: bundle did not fit whole", ErrMempoolFull))
+	}
+
+	if len(txs) > 0 && len(m.orphans) > 0 {
+		m.promoteOrphans()
+	}
+	return errors.Join(errs...)
+}
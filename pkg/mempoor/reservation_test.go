@@ -0,0 +1,122 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReserveHoldsTxsUntilCommit(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	tx := newTx("alice", 10, 10)
+	_, _ = mp.Add(tx)
+
+	res := mp.Reserve(BlockConstraints{MaxTx: 10})
+	if len(res.Result().Transactions) != 1 {
+		t.Fatalf("expected 1 reserved tx")
+	}
+
+	// Reserved txs are invisible to the pool and to further selection.
+	if _, err := mp.Get(tx.ID); err != ErrTxNotFound {
+		t.Fatalf("expected reserved tx to be out of the pool")
+	}
+	if again := mp.SelectTransactions(BlockConstraints{MaxTx: 10}); len(again.Transactions) != 0 {
+		t.Fatalf("reserved tx must not be selected twice")
+	}
+
+	if err := res.Commit(); err != nil {
+		t.Fatalf("unexpected Commit error: %v", err)
+	}
+	if err := res.Rollback(); err != ErrReservationClosed {
+		t.Fatalf("expected ErrReservationClosed after Commit, got %v", err)
+	}
+	if len(mp.List()) != 0 {
+		t.Fatalf("expected committed tx gone for good")
+	}
+}
+
+func TestReserveRollbackRestoresTxs(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	tx := newTx("alice", 10, 10)
+	_, _ = mp.Add(tx)
+
+	res := mp.Reserve(BlockConstraints{MaxTx: 10})
+	if err := res.Rollback(); err != nil {
+		t.Fatalf("unexpected Rollback error: %v", err)
+	}
+	if err := res.Commit(); err != ErrReservationClosed {
+		t.Fatalf("expected ErrReservationClosed after Rollback, got %v", err)
+	}
+
+	got, err := mp.Get(tx.ID)
+	if err != nil {
+		t.Fatalf("expected tx back in pool after Rollback: %v", err)
+	}
+	if !got.Timestamp.Equal(tx.Timestamp) {
+		t.Fatalf("expected Rollback to preserve Timestamp")
+	}
+}
+
+func TestReserveRollbackSkipsReappearedIDs(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	tx := newTx("alice", 10, 10)
+	_, _ = mp.Add(tx)
+
+	res := mp.Reserve(BlockConstraints{MaxTx: 10})
+	_, _ = mp.Add(tx) // re-submitted while reserved
+
+	if err := res.Rollback(); err != nil {
+		t.Fatalf("unexpected Rollback error: %v", err)
+	}
+	if len(mp.List()) != 1 {
+		t.Fatalf("expected exactly one copy of the tx, got %d", len(mp.List()))
+	}
+}
+
+func TestReinsertRefreshesTimestamp(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	tx := newTx("alice", 10, 10)
+	tx.Timestamp = time.Unix(1, 0).UTC()
+	tx.CreatedAt = tx.Timestamp
+	_, _ = mp.Add(tx)
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 10})
+	if len(res.Transactions) != 1 {
+		t.Fatalf("expected tx selected")
+	}
+
+	if err := mp.Reinsert(res.Transactions); err != nil {
+		t.Fatalf("unexpected Reinsert error: %v", err)
+	}
+	got, err := mp.Get(tx.ID)
+	if err != nil {
+		t.Fatalf("expected tx back in pool: %v", err)
+	}
+	if !got.CreatedAt.Equal(tx.CreatedAt) {
+		t.Fatalf("expected Reinsert to preserve CreatedAt")
+	}
+	if !got.Timestamp.After(tx.Timestamp) {
+		t.Fatalf("expected Reinsert to refresh Timestamp, got %v", got.Timestamp)
+	}
+}
+
+func TestReinsertSkipsPendingAndReportsOverflow(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxs: 1})
+
+	a := newTx("alice", 10, 10)
+	b := newTx("bob", 5, 10)
+	_, _ = mp.Add(a)
+
+	// a is pending again: skipped. b is underpriced against a: dropped.
+	err := mp.Reinsert([]*Tx{a, b})
+	if !errors.Is(err, ErrTxUnderpriced) {
+		t.Fatalf("expected ErrTxUnderpriced for b, got %v", err)
+	}
+	if len(mp.List()) != 1 {
+		t.Fatalf("expected exactly one pending tx, got %d", len(mp.List()))
+	}
+}
@@ -1,11 +1,14 @@
 package mempoor
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"sort"
+	"strings"
 	"time"
 )
 
@@ -24,46 +27,251 @@ type rpcResponse struct {
 // ---- Method-specific param/result DTOs ----
 
 type addTxParams struct {
-	Sender    string `json:"sender"`
-	Recipient string `json:"recipient"`
-	Payload   string `json:"payload"`
-	Fee       uint64 `json:"fee"`
-	Gas       uint64 `json:"gas"`
+	Sender    string    `json:"sender"`
+	Recipient string    `json:"recipient"`
+	Payload   string    `json:"payload"`
+	Nonce     uint64    `json:"nonce"`
+	Fee       uint64    `json:"fee"`
+	MaxFee    uint64    `json:"maxFee,omitempty"` // dynamic fee; fee defaults to it
+	Tip       uint64    `json:"tip,omitempty"`
+	Gas       uint64    `json:"gas"`
+	Amount    uint64    `json:"amount,omitempty"`
+	ChainID   string    `json:"chainId,omitempty"`
+	ParentID  string    `json:"parentID,omitempty"`
+	DependsOn []string  `json:"dependsOn,omitempty"`
+	NotBefore time.Time `json:"notBefore"`
+	Lane      Lane      `json:"lane"`
+	Pool      string    `json:"pool,omitempty"`
 }
 
 type addTxResult struct {
-	TxID string `json:"txID"`
+	TxID      string   `json:"txID"`
+	Orphan    bool     `json:"orphan,omitempty"`
+	Scheduled bool     `json:"scheduled,omitempty"`
+	Merged    bool     `json:"merged,omitempty"`
+	Evicted   []string `json:"evicted,omitempty"`
+}
+
+type addBatchParams struct {
+	Txs []addTxParams `json:"txs"`
+}
+
+type addBatchItem struct {
+	TxID      string `json:"txID,omitempty"`
+	Orphan    bool   `json:"orphan,omitempty"`
+	Scheduled bool   `json:"scheduled,omitempty"`
+	Merged    bool   `json:"merged,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type addBatchResult struct {
+	Results []addBatchItem `json:"results"`
+}
+
+type addBundleParams struct {
+	BundleID string        `json:"bundleId"` // "" = derived from the tx IDs
+	Pool     string        `json:"pool"`     // "" = DefaultPool
+	Txs      []addTxParams `json:"txs"`
+}
+
+type addBundleResult struct {
+	BundleID string   `json:"bundleId"`
+	TxIDs    []string `json:"txIDs"`
+	Evicted  []string `json:"evicted,omitempty"`
 }
 
 type updateTxParams struct {
-	ID  string `json:"id"`
-	Fee uint64 `json:"fee"`
+	ID  string  `json:"id"`
+	Fee uint64  `json:"fee"`           // new MaxFee for a dynamic-fee tx
+	Tip *uint64 `json:"tip,omitempty"` // dynamic-fee txs only; nil = keep
 }
 
 type removeTxParams struct {
 	ID string `json:"id"`
 }
 
+type txHistoryParams struct {
+	ID string `json:"id"`
+}
+
+type txStatusParams struct {
+	ID string `json:"id"`
+}
+
+type txGetParams struct {
+	ID string `json:"id"`
+}
+
+// txGetResult is a pending tx and where it stands: Position counts the
+// txs ahead of it in its pool's priority order (0 = next), LanePosition
+// those of its own lane. Both are -1 if the tx left the order, e.g.
+// selected into a block, between the lookup and the count.
+type txGetResult struct {
+	Tx           *Tx `json:"tx"`
+	Position     int `json:"position"`
+	LanePosition int `json:"lanePosition"`
+}
+
+type txHistoryResult struct {
+	Current *Tx         `json:"current"`
+	History []TxVersion `json:"history"`
+}
+
 type okResult struct {
 	OK bool `json:"ok"`
 }
 
+type setSenderAccessParams struct {
+	Sender string `json:"sender"`
+	Rule   string `json:"rule"`
+}
+
+type purgeSenderParams struct {
+	Sender string `json:"sender"`
+}
+
+type purgeSenderResult struct {
+	Removed []string `json:"removed"`
+}
+
+type clearParams struct {
+	ReturnTxs bool `json:"returnTxs"`
+}
+
+type clearResult struct {
+	Cleared int   `json:"cleared"`
+	Txs     []*Tx `json:"txs,omitempty"`
+}
+
+type scheduledParams struct {
+	Pool string `json:"pool"`
+}
+
+type pinParams struct {
+	ID    string `json:"id"`
+	Unpin bool   `json:"unpin"`
+}
+
+type pinnedResult struct {
+	Pinned []string `json:"pinned"`
+}
+
+type blockLimitsResult struct {
+	Limits  BlockLimits `json:"limits"`
+	Version uint64      `json:"version"`
+}
+
+type requireParams struct {
+	ID     string `json:"id"`
+	Cancel bool   `json:"cancel"`
+}
+
+type requiredResult struct {
+	Required []string `json:"required"`
+}
+
+type feeFloorResult struct {
+	Floor uint64 `json:"floor"`
+}
+
+type checkInvariantsResult struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// blockGetParams selects a block by Height or by Hash, not both; with
+// neither, block.get returns the block at height 0, if any.
 type blockGetParams struct {
-	Height uint64 `json:"height"`
+	Height      *uint64 `json:"height,omitempty"`
+	Hash        string  `json:"hash,omitempty"` // hex
+	Compression string  `json:"compression"`    // "" = plain payloads
+}
+
+type listBlocksParams struct {
+	Compression string `json:"compression"` // "" = plain payloads
+}
+
+type listTxParams struct {
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+	Sort   string `json:"sort"`
+	Pool   string `json:"pool"` // "" = DefaultPool
+
+	// Optional filters; zero values do not filter.
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+	MinFee    uint64 `json:"minFee"`
+	MaxFee    uint64 `json:"maxFee"`
+	MinGas    uint64 `json:"minGas"`
 }
 
 type listTxResult struct {
 	Transactions []*Tx `json:"transactions"`
+	Total        int   `json:"total"`
+}
+
+type topTxParams struct {
+	N    int    `json:"n"`
+	Pool string `json:"pool"` // "" = DefaultPool
 }
 
 type blockDTO struct {
-	Height    uint64    `json:"height"`
-	PrevHash  string    `json:"prevHash"`
-	Timestamp time.Time `json:"timestamp"`
-	TxCount   int       `json:"txCount"`
-	GasUsed   uint64    `json:"gasUsed"`
-	Hash      string    `json:"hash"`
-	Txs       []*Tx     `json:"transactions"`
+	Height       uint64            `json:"height"`
+	PrevHash     string            `json:"prevHash"`
+	Timestamp    time.Time         `json:"timestamp"`
+	TxCount      int               `json:"txCount"`
+	GasUsed      uint64            `json:"gasUsed"`
+	Hash         string            `json:"hash"`
+	Burned       uint64            `json:"burned,omitempty"`
+	Tipped       uint64            `json:"tipped,omitempty"`
+	TotalFees    uint64            `json:"totalFees"`
+	AvgFeePerGas uint64            `json:"avgFeePerGas"`
+	Proposer     string            `json:"proposer,omitempty"`
+	ExtraData    string            `json:"extraData,omitempty"` // hex
+	Extra        map[string]string `json:"extra,omitempty"`
+	ChainID      string            `json:"chainId,omitempty"`
+	Txs          []*Tx             `json:"transactions"`
+	Groups       [][]int           `json:"groups,omitempty"` // indices into transactions
+
+	// Compression names the codec of the (base64) tx payloads, if any.
+	Compression string `json:"compression,omitempty"`
+
+	Signer    string `json:"signer,omitempty"`    // hex ed25519 public key
+	Signature string `json:"signature,omitempty"` // hex, over hash
+
+	// Pruned: transactions are stubs with only ID and Gas.
+	Pruned bool `json:"pruned,omitempty"`
+}
+
+type proposeParams struct {
+	Block blockDTO `json:"block"`
+}
+
+type proposeResult struct {
+	Accepted      bool     `json:"accepted"` // false: the node's own block won
+	Block         blockDTO `json:"block"`
+	ProposedFees  uint64   `json:"proposedFees"`
+	CandidateFees uint64   `json:"candidateFees"`
+}
+
+type reorgParams struct {
+	Block blockDTO `json:"block"`
+}
+
+type reorgResult struct {
+	Block      blockDTO `json:"block"`     // the new tip
+	Displaced  []string `json:"displaced"` // hashes of the replaced blocks
+	Reinserted int      `json:"reinserted"`
+}
+
+type accountGetParams struct {
+	Address string `json:"address"`
+}
+
+type accountResult struct {
+	Address string `json:"address"`
+	Balance uint64 `json:"balance"`
+	Nonce   uint64 `json:"nonce"` // next unused nonce
 }
 
 type listBlocksResult struct {
@@ -75,33 +283,130 @@ type getBlockResult struct {
 }
 
 // handleRPC is the single HTTP entrypoint for all RPC methods.
-// It should be mounted on POST /rpc in run.go.
+// It should be mounted on POST /rpc in run.go. A JSON array is a batch;
+// see batch.go.
 func (n *Node) handleRPC(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writeRPCError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid JSON request")
+		return
+	}
+	if isBatch(body) {
+		n.handleRPCBatch(w, r, body)
+		return
+	}
 	var req rpcRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.Unmarshal(body, &req); err != nil {
 		writeRPCError(w, http.StatusBadRequest, "invalid JSON request")
 		return
 	}
+	n.serveRPC(w, r, req)
+}
+
+// serveRPC runs one request.
+func (n *Node) serveRPC(w http.ResponseWriter, r *http.Request, req rpcRequest) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+	w = rec
+	method := req.Method // "unknown" for unknown methods, bounding metric labels
+	defer func() {
+		took := time.Since(start)
+		n.metrics.recordRPC(method, rec.code, took)
+		n.log.rpc.Debug("rpc call", "method", req.Method, "code", rec.code, "duration", took)
+	}()
+
+	if isAdminMethod(req.Method) && !n.authorizeAdmin(w, r, req.Method) {
+		return
+	}
 
 	switch req.Method {
 	case "tx.add":
 		n.rpcTxAdd(w, req.Params)
+	case "tx.addBatch":
+		n.rpcTxAddBatch(w, req.Params)
+	case "tx.addBundle":
+		n.rpcTxAddBundle(w, req.Params)
 	case "tx.update":
 		n.rpcTxUpdate(w, req.Params)
 	case "tx.remove":
 		n.rpcTxRemove(w, req.Params)
+	case "tx.history":
+		n.rpcTxHistory(w, req.Params)
+	case "tx.status":
+		n.rpcTxStatus(w, req.Params)
+	case "tx.get":
+		n.rpcTxGet(w, req.Params)
+	case "tx.receipt":
+		n.rpcTxReceipt(w, req.Params)
+	case "tx.scheduled":
+		n.rpcTxScheduled(w, req.Params)
 	case "tx.list":
 		n.rpcTxList(w, req.Params)
+	case "tx.top":
+		n.rpcTxTop(w, req.Params)
 	case "block.list":
 		n.rpcBlockList(w, req.Params)
 	case "block.get":
 		n.rpcBlockGet(w, req.Params)
+	case "block.headers":
+		n.rpcBlockHeaders(w, req.Params)
+	case "block.template":
+		n.rpcBlockTemplate(r.Context(), w)
+	case "block.metrics":
+		n.rpcBlockMetrics(w)
+	case "block.propose":
+		n.rpcBlockPropose(r.Context(), w, req.Params)
+	case "block.reorg":
+		n.rpcBlockReorg(w, req.Params)
+	case "block.export":
+		n.rpcBlockExport(w)
+	case "block.import":
+		n.rpcBlockImport(w, req.Params)
+	case "node.status":
+		n.rpcNodeStatus(w)
+	case "peer.add", "peer.remove":
+		n.rpcPeerChange(w, req.Method, req.Params)
+	case "peer.list":
+		n.rpcPeerList(w)
+	case "debug.mine":
+		n.rpcDebugMine(r.Context(), w, req.Params)
+	case "account.get":
+		n.rpcAccountGet(w, req.Params)
+	case "fee.estimate":
+		n.rpcFeeEstimate(w)
+	case "fee.floor":
+		n.rpcFeeFloor(w)
+	case "admin.setSenderAccess":
+		n.rpcAdminSetSenderAccess(w, req.Params)
+	case "admin.senderACL":
+		n.rpcAdminSenderACL(w)
+	case "admin.purgeSender":
+		n.rpcAdminPurgeSender(w, req.Params)
+	case "admin.clear":
+		n.rpcAdminClear(w, req.Params)
+	case "admin.pin":
+		n.rpcAdminPin(w, req.Params)
+	case "admin.pinned":
+		n.rpcAdminPinned(w)
+	case "admin.require":
+		n.rpcAdminRequire(w, req.Params)
+	case "admin.required":
+		n.rpcAdminRequired(w)
+	case "admin.setBlockLimits":
+		n.rpcAdminSetBlockLimits(w, req.Params)
+	case "admin.blockLimits":
+		n.rpcAdminBlockLimits(w)
+	case "admin.compact":
+		n.rpcAdminCompact(w)
+	case "admin.checkInvariants":
+		n.rpcAdminCheckInvariants(w)
 	default:
+		method = "unknown"
 		writeRPCError(w, http.StatusBadRequest, fmt.Sprintf("unknown method %q", req.Method))
 	}
 }
@@ -119,14 +424,169 @@ func (n *Node) rpcTxAdd(w http.ResponseWriter, params json.RawMessage) {
 		writeRPCError(w, http.StatusBadRequest, "sender and recipient are required")
 		return
 	}
+	pool, err := n.pool(p.Pool)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := CheckPayloadSize(p.Payload, pool.maxPayload); err != nil {
+		writeRPCError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+
+	tx := newRPCTx(n.cfg.Clock, p)
+	evicted, err := pool.mp.Add(tx)
+
+	// A merged duplicate is a success: report the pending tx's ID.
+	var dup *DuplicateError
+	if errors.As(err, &dup) && dup.Merged {
+		writeRPCResult(w, http.StatusOK, addTxResult{TxID: string(dup.Existing), Merged: true})
+		return
+	}
 
-	tx := NewUnsignedTx(p.Sender, p.Recipient, p.Payload, p.Fee, p.Gas)
-	if err := n.mempool.Add(tx); err != nil {
+	orphan := errors.Is(err, ErrTxOrphaned)
+	scheduled := errors.Is(err, ErrTxScheduled)
+	if errors.Is(err, ErrMempoolFull) || errors.Is(err, ErrSenderLimit) {
+		writeRPCError(w, http.StatusTooManyRequests, err.Error())
+		return
+	}
+	if errors.Is(err, ErrPayloadTooLarge) {
+		writeRPCError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	if err != nil && !orphan && !scheduled {
 		writeRPCError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	writeRPCResult(w, http.StatusOK, addTxResult{TxID: string(tx.ID)})
+	res := addTxResult{TxID: string(tx.ID), Orphan: orphan, Scheduled: scheduled}
+	for _, id := range evicted {
+		res.Evicted = append(res.Evicted, string(id))
+	}
+
+	writeRPCResult(w, http.StatusOK, res)
+}
+
+// ---- tx.addBatch ----
+
+func (n *Node) rpcTxAddBatch(w http.ResponseWriter, params json.RawMessage) {
+	var p addBatchParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for tx.addBatch")
+		return
+	}
+
+	// Validate up front so the whole batch is rejected on malformed input,
+	// matching tx.add. Pool-level failures are reported per tx below.
+	txs := make([]*Tx, 0, len(p.Txs))
+	byPool := make(map[*nodePool][]int)
+	for i, tp := range p.Txs {
+		if tp.Sender == "" || tp.Recipient == "" {
+			writeRPCError(w, http.StatusBadRequest, fmt.Sprintf("txs[%d]: sender and recipient are required", i))
+			return
+		}
+		pool, err := n.pool(tp.Pool)
+		if err != nil {
+			writeRPCError(w, http.StatusBadRequest, fmt.Sprintf("txs[%d]: %v", i, err))
+			return
+		}
+		if err := CheckPayloadSize(tp.Payload, pool.maxPayload); err != nil {
+			writeRPCError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("txs[%d]: %v", i, err))
+			return
+		}
+		txs = append(txs, newRPCTx(n.cfg.Clock, tp))
+		byPool[pool] = append(byPool[pool], i)
+	}
+
+	// One AddBatch per pool; errs stays in request order.
+	errs := make([]error, len(txs))
+	for pool, idx := range byPool {
+		batch := make([]*Tx, len(idx))
+		for j, i := range idx {
+			batch[j] = txs[i]
+		}
+		for j, err := range pool.mp.AddBatch(batch) {
+			errs[idx[j]] = err
+		}
+	}
+
+	res := addBatchResult{Results: make([]addBatchItem, len(txs))}
+	for i, err := range errs {
+		item := addBatchItem{TxID: string(txs[i].ID)}
+		var dup *DuplicateError
+		switch {
+		case errors.As(err, &dup) && dup.Merged:
+			item = addBatchItem{TxID: string(dup.Existing), Merged: true}
+		case errors.Is(err, ErrTxOrphaned):
+			item.Orphan = true
+		case errors.Is(err, ErrTxScheduled):
+			item.Scheduled = true
+		case err != nil:
+			item = addBatchItem{Error: err.Error()}
+		}
+		res.Results[i] = item
+	}
+
+	writeRPCResult(w, http.StatusOK, res)
+}
+
+// ---- tx.addBundle ----
+
+func (n *Node) rpcTxAddBundle(w http.ResponseWriter, params json.RawMessage) {
+	var p addBundleParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for tx.addBundle")
+		return
+	}
+
+	if len(p.Txs) == 0 {
+		writeRPCError(w, http.StatusBadRequest, "txs are required")
+		return
+	}
+	pool, err := n.pool(p.Pool)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	txs := make([]*Tx, 0, len(p.Txs))
+	ids := make([]TxID, 0, len(p.Txs))
+	for i, tp := range p.Txs {
+		if tp.Sender == "" || tp.Recipient == "" {
+			writeRPCError(w, http.StatusBadRequest, fmt.Sprintf("txs[%d]: sender and recipient are required", i))
+			return
+		}
+		if err := CheckPayloadSize(tp.Payload, pool.maxPayload); err != nil {
+			writeRPCError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("txs[%d]: %v", i, err))
+			return
+		}
+		tp.Pool = p.Pool
+		tx := newRPCTx(n.cfg.Clock, tp)
+		txs = append(txs, tx)
+		ids = append(ids, tx.ID)
+	}
+	if p.BundleID == "" {
+		p.BundleID = GenerateBundleID(ids)
+	}
+
+	evicted, err := pool.mp.AddBundle(p.BundleID, txs)
+	if errors.Is(err, ErrMempoolFull) || errors.Is(err, ErrSenderLimit) {
+		writeRPCError(w, http.StatusTooManyRequests, err.Error())
+		return
+	}
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res := addBundleResult{BundleID: p.BundleID, TxIDs: make([]string, len(ids))}
+	for i, id := range ids {
+		res.TxIDs[i] = string(id)
+	}
+	for _, id := range evicted {
+		res.Evicted = append(res.Evicted, string(id))
+	}
+	writeRPCResult(w, http.StatusOK, res)
 }
 
 // ---- tx.update ----
@@ -144,24 +604,40 @@ func (n *Node) rpcTxUpdate(w http.ResponseWriter, params json.RawMessage) {
 	}
 
 	// Find existing tx in mempool to preserve immutable fields.
-	// PERF: This is O(n) over List(); acceptable for this project.
-	existing := n.findTxByID(TxID(p.ID))
-	if existing == nil {
-		writeRPCResult(w, http.StatusOK, rpcResponse{Error: ErrTxNotFound.Error()})
+	mp, existing, err := n.findTx(TxID(p.ID))
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
 		return
 	}
 
-	updated := NewTxUpdate(
+	updated := NewTxUpdateWithClock(
+		n.cfg.Clock,
 		existing.ID,
 		existing.Sender,
 		existing.Recipient,
 		existing.Payload,
+		existing.Nonce,
 		p.Fee,
 		existing.Gas,
 		existing.CreatedAt,
 	)
+	updated.ParentID = existing.ParentID
+	updated.DependsOn = existing.DependsOn
+	updated.NotBefore = existing.NotBefore
+	updated.Lane = existing.Lane
+	updated.Pool = existing.Pool
+	updated.ChainID = existing.ChainID
+	updated.Amount = existing.Amount
+	updated.BundleID = existing.BundleID
+	updated.BundleIndex = existing.BundleIndex
+	if existing.MaxFee != 0 {
+		updated.MaxFee, updated.Tip = p.Fee, existing.Tip
+		if p.Tip != nil {
+			updated.Tip = *p.Tip
+		}
+	}
 
-	if err := n.mempool.Update(updated); err != nil {
+	if err := mp.Update(updated); err != nil {
 		writeRPCError(w, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -183,7 +659,7 @@ func (n *Node) rpcTxRemove(w http.ResponseWriter, params json.RawMessage) {
 		return
 	}
 
-	if err := n.mempool.Remove(TxID(p.ID)); err != nil {
+	if err := n.removeTx(TxID(p.ID)); err != nil {
 		if err == ErrTxNotFound {
 			writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
 			return
@@ -195,39 +671,223 @@ func (n *Node) rpcTxRemove(w http.ResponseWriter, params json.RawMessage) {
 	writeRPCResult(w, http.StatusOK, okResult{OK: true})
 }
 
-// ---- tx.list ----
+// ---- tx.history ----
 
-func (n *Node) rpcTxList(w http.ResponseWriter, params json.RawMessage) {
-	// No params expected; ignore any.
-	txs := n.mempool.List()
+func (n *Node) rpcTxHistory(w http.ResponseWriter, params json.RawMessage) {
+	var p txHistoryParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for tx.history")
+		return
+	}
+
+	if p.ID == "" {
+		writeRPCError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	mp, current, err := n.findTx(TxID(p.ID))
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	history, err := mp.GetHistory(TxID(p.ID))
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	writeRPCResult(w, http.StatusOK, txHistoryResult{Current: current, History: history})
+}
 
-	// Sort in priority order: Fee DESC, Timestamp ASC, ID ASC.
-	sort.Slice(txs, func(i, j int) bool {
-		ti := txs[i]
-		tj := txs[j]
+// ---- tx.status ----
 
-		if ti.Fee != tj.Fee {
-			return ti.Fee > tj.Fee
+func (n *Node) rpcTxStatus(w http.ResponseWriter, params json.RawMessage) {
+	var p txStatusParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for tx.status")
+		return
+	}
+
+	if p.ID == "" {
+		writeRPCError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	id := TxID(p.ID)
+	if st, ok := n.tracker.status(id); ok {
+		writeRPCResult(w, http.StatusOK, st)
+		return
+	}
+
+	// Txs restored from disk were never observed; the pool still knows them.
+	if _, tx, err := n.findTx(id); err == nil {
+		writeRPCResult(w, http.StatusOK, TxStatus{ID: id, State: TxStatePending, UpdatedAt: tx.Timestamp})
+		return
+	}
+	// Older ones the tracker forgot may still be on the chain.
+	if r, err := n.Receipt(id); err == nil {
+		writeRPCResult(w, http.StatusOK, TxStatus{ID: id, State: TxStateIncluded, Height: r.BlockHeight})
+		return
+	}
+
+	writeRPCResult(w, http.StatusOK, rpcResponse{Error: ErrTxNotFound.Error()})
+}
+
+// ---- tx.get ----
+
+func (n *Node) rpcTxGet(w http.ResponseWriter, params json.RawMessage) {
+	var p txGetParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for tx.get")
+		return
+	}
+
+	if p.ID == "" {
+		writeRPCError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	mp, tx, err := n.findTx(TxID(p.ID))
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	cp := *tx
+	res := txGetResult{Tx: &cp, Position: -1, LanePosition: -1}
+	ahead, aheadInLane := 0, 0
+	mp.Iterate(func(t *Tx) bool {
+		if t.ID == cp.ID {
+			res.Position, res.LanePosition = ahead, aheadInLane
+			return false
 		}
-		if !ti.Timestamp.Equal(tj.Timestamp) {
-			return ti.Timestamp.Before(tj.Timestamp)
+		ahead++
+		if t.Lane == cp.Lane {
+			aheadInLane++
 		}
-		return ti.ID < tj.ID
+		return true
 	})
 
-	writeRPCResult(w, http.StatusOK, listTxResult{Transactions: txs})
+	writeRPCResult(w, http.StatusOK, res)
+}
+
+// ---- tx.list ----
+
+func (n *Node) rpcTxList(w http.ResponseWriter, params json.RawMessage) {
+	// All params are optional; defaults list the whole pool by priority.
+	var p listTxParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			writeRPCError(w, http.StatusBadRequest, "invalid params for tx.list")
+			return
+		}
+	}
+
+	order, err := ParseSortOrder(p.Sort)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pool, err := n.pool(p.Pool)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := TxFilter{
+		Sender:    p.Sender,
+		Recipient: p.Recipient,
+		MinFee:    p.MinFee,
+		MaxFee:    p.MaxFee,
+		MinGas:    p.MinGas,
+	}
+
+	var (
+		txs   []*Tx
+		total int
+	)
+	if filter == (TxFilter{}) {
+		txs, total = pool.mp.ListPage(p.Offset, p.Limit, order)
+	} else {
+		txs, total = pageTxs(pool.mp.ListFilter(filter), p.Offset, p.Limit, order, pool.mp.Policy())
+	}
+
+	writeRPCResult(w, http.StatusOK, listTxResult{Transactions: txs, Total: total})
+}
+
+// ---- tx.top ----
+
+func (n *Node) rpcTxTop(w http.ResponseWriter, params json.RawMessage) {
+	var p topTxParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for tx.top")
+		return
+	}
+
+	if p.N <= 0 {
+		writeRPCError(w, http.StatusBadRequest, "n must be positive")
+		return
+	}
+	pool, err := n.pool(p.Pool)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	txs := pool.mp.TopN(p.N)
+	writeRPCResult(w, http.StatusOK, listTxResult{Transactions: txs, Total: pool.mp.Count()})
+}
+
+// ---- tx.scheduled ----
+
+func (n *Node) rpcTxScheduled(w http.ResponseWriter, params json.RawMessage) {
+	// All params are optional; the default pool is listed.
+	var p scheduledParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			writeRPCError(w, http.StatusBadRequest, "invalid params for tx.scheduled")
+			return
+		}
+	}
+
+	pool, err := n.pool(p.Pool)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	txs := pool.mp.ListScheduled()
+	writeRPCResult(w, http.StatusOK, listTxResult{Transactions: txs, Total: len(txs)})
 }
 
 // ---- block.list ----
 
 func (n *Node) rpcBlockList(w http.ResponseWriter, params json.RawMessage) {
-	// No params expected; ignore.
+	var p listBlocksParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			writeRPCError(w, http.StatusBadRequest, "invalid params for block.list")
+			return
+		}
+	}
+	codec, err := ParsePayloadCompression(p.Compression)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	n.blocksMu.RLock()
 	defer n.blocksMu.RUnlock()
 
 	dtos := make([]blockDTO, 0, len(n.blocks))
 	for _, b := range n.blocks {
-		dtos = append(dtos, makeBlockDTO(b))
+		served, err := servedBlock(b, codec)
+		if err != nil {
+			writeRPCError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		dtos = append(dtos, makeBlockDTO(served))
 	}
 
 	writeRPCResult(w, http.StatusOK, listBlocksResult{Blocks: dtos})
@@ -241,52 +901,486 @@ func (n *Node) rpcBlockGet(w http.ResponseWriter, params json.RawMessage) {
 		writeRPCError(w, http.StatusBadRequest, "invalid params for block.get")
 		return
 	}
-
-	n.blocksMu.RLock()
-	defer n.blocksMu.RUnlock()
+	codec, err := ParsePayloadCompression(p.Compression)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	var found *Block
-	for _, b := range n.blocks {
-		if b.Header.Height == p.Height {
-			found = b
-			break
+	var ok bool
+	switch {
+	case p.Hash != "" && p.Height != nil:
+		writeRPCError(w, http.StatusBadRequest, "height and hash are mutually exclusive")
+		return
+	case p.Hash != "":
+		var hash [32]byte
+		if err := decodeHash(p.Hash, &hash); err != nil {
+			writeRPCError(w, http.StatusBadRequest, err.Error())
+			return
 		}
+		found, ok = n.blockByHash(hash)
+	case p.Height != nil:
+		found, ok = n.blockAt(*p.Height)
+	default:
+		found, ok = n.blockAt(0)
 	}
-
-	if found == nil {
+	if !ok {
 		writeRPCResult(w, http.StatusOK, rpcResponse{Error: "block not found"})
 		return
 	}
 
-	dto := makeBlockDTO(found)
-	writeRPCResult(w, http.StatusOK, getBlockResult{Block: dto})
+	served, err := servedBlock(found, codec)
+	if err != nil {
+		writeRPCError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeRPCResult(w, http.StatusOK, getBlockResult{Block: makeBlockDTO(served)})
+}
+
+// ---- block.template ----
+
+func (n *Node) rpcBlockTemplate(ctx context.Context, w http.ResponseWriter) {
+	prevHash, height := n.chainTip()
+	block, err := n.builder.BuildTemplate(ctx, prevHash, height, time.Time{})
+	if err == ErrEmptyBlock {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeRPCError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeRPCResult(w, http.StatusOK, getBlockResult{Block: makeBlockDTO(block)})
+}
+
+// ---- block.propose ----
+
+func (n *Node) rpcBlockPropose(ctx context.Context, w http.ResponseWriter, params json.RawMessage) {
+	var p proposeParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for block.propose")
+		return
+	}
+
+	proposed, hash, err := parseProposal(p.Block)
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	out, err := n.proposeBlock(ctx, proposed, hash)
+	if errors.Is(err, ErrInvalidProposal) || errors.Is(err, ErrExtraDataTooLarge) {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeRPCError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeRPCResult(w, http.StatusOK, proposeResult{
+		Accepted:      out.Accepted,
+		Block:         makeBlockDTO(out.Block),
+		ProposedFees:  out.ProposedFees,
+		CandidateFees: out.CandidateFees,
+	})
+}
+
+// ---- block.reorg ----
+
+func (n *Node) rpcBlockReorg(w http.ResponseWriter, params json.RawMessage) {
+	var p reorgParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for block.reorg")
+		return
+	}
+
+	competing, err := parseBlockDTO(p.Block)
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	out, err := n.reorgBlock(competing)
+	if errors.Is(err, ErrInvalidBlock) || errors.Is(err, ErrForkRejected) {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeRPCError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res := reorgResult{Block: makeBlockDTO(competing), Displaced: make([]string, len(out.Displaced)), Reinserted: out.Reinserted}
+	for i, b := range out.Displaced {
+		hash := b.Hash()
+		res.Displaced[i] = hex.EncodeToString(hash[:])
+	}
+	writeRPCResult(w, http.StatusOK, res)
+}
+
+// ---- block.metrics ----
+
+func (n *Node) rpcBlockMetrics(w http.ResponseWriter) {
+	writeRPCResult(w, http.StatusOK, n.meter.Metrics())
+}
+
+// ---- account.get ----
+
+func (n *Node) rpcAccountGet(w http.ResponseWriter, params json.RawMessage) {
+	var p accountGetParams
+	if err := json.Unmarshal(params, &p); err != nil || p.Address == "" {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for account.get")
+		return
+	}
+	if n.state == nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: "accounts are not tracked: no genesis balances"})
+		return
+	}
+
+	writeRPCResult(w, http.StatusOK, accountResult{
+		Address: p.Address,
+		Balance: n.state.Balance(p.Address),
+		Nonce:   n.state.NextNonce(p.Address),
+	})
+}
+
+// ---- fee.estimate ----
+
+func (n *Node) rpcFeeEstimate(w http.ResponseWriter) {
+	writeRPCResult(w, http.StatusOK, n.oracle.Estimate())
+}
+
+// ---- fee.floor ----
+
+func (n *Node) rpcFeeFloor(w http.ResponseWriter) {
+	writeRPCResult(w, http.StatusOK, feeFloorResult{Floor: n.mempool.FeeFloor()})
+}
+
+// ---- admin.setBlockLimits ----
+
+func (n *Node) rpcAdminSetBlockLimits(w http.ResponseWriter, params json.RawMessage) {
+	var u limitsUpdate
+	if err := json.Unmarshal(params, &u); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for admin.setBlockLimits")
+		return
+	}
+
+	limits, version, err := n.setLimits(u, "rpc")
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+	writeRPCResult(w, http.StatusOK, blockLimitsResult{Limits: limits, Version: version})
+}
+
+// ---- admin.blockLimits ----
+
+func (n *Node) rpcAdminBlockLimits(w http.ResponseWriter) {
+	limits, version := n.limits.get()
+	writeRPCResult(w, http.StatusOK, blockLimitsResult{Limits: limits, Version: version})
+}
+
+// ---- admin.compact ----
+
+func (n *Node) rpcAdminCompact(w http.ResponseWriter) {
+	for _, p := range n.pools {
+		p.mp.Compact()
+	}
+	writeRPCResult(w, http.StatusOK, okResult{OK: true})
+}
+
+// ---- admin.checkInvariants ----
+
+func (n *Node) rpcAdminCheckInvariants(w http.ResponseWriter) {
+	// A violation is a finding, not a failed call: report it in the result.
+	for _, p := range n.pools {
+		if err := p.mp.CheckInvariants(); err != nil {
+			msg := err.Error()
+			if p.name != DefaultPool {
+				msg = fmt.Sprintf("pool %s: %s", p.name, msg)
+			}
+			writeRPCResult(w, http.StatusOK, checkInvariantsResult{Error: msg})
+			return
+		}
+	}
+	writeRPCResult(w, http.StatusOK, checkInvariantsResult{OK: true})
+}
+
+// ---- admin.setSenderAccess ----
+
+func (n *Node) rpcAdminSetSenderAccess(w http.ResponseWriter, params json.RawMessage) {
+	var p setSenderAccessParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for admin.setSenderAccess")
+		return
+	}
+
+	if p.Sender == "" {
+		writeRPCError(w, http.StatusBadRequest, "sender is required")
+		return
+	}
+	rule, err := ParseAccessRule(p.Rule)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, pool := range n.pools {
+		pool.mp.SetSenderAccess(p.Sender, rule)
+	}
+	writeRPCResult(w, http.StatusOK, okResult{OK: true})
+}
+
+// ---- admin.senderACL ----
+
+func (n *Node) rpcAdminSenderACL(w http.ResponseWriter) {
+	writeRPCResult(w, http.StatusOK, n.mempool.SenderACL())
+}
+
+// ---- admin.purgeSender ----
+
+func (n *Node) rpcAdminPurgeSender(w http.ResponseWriter, params json.RawMessage) {
+	var p purgeSenderParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for admin.purgeSender")
+		return
+	}
+
+	if p.Sender == "" {
+		writeRPCError(w, http.StatusBadRequest, "sender is required")
+		return
+	}
+
+	res := purgeSenderResult{Removed: make([]string, 0)}
+	for _, pool := range n.pools {
+		for _, id := range pool.mp.PurgeSender(p.Sender) {
+			res.Removed = append(res.Removed, string(id))
+		}
+	}
+	writeRPCResult(w, http.StatusOK, res)
+}
+
+// ---- admin.clear ----
+
+func (n *Node) rpcAdminClear(w http.ResponseWriter, params json.RawMessage) {
+	// All params are optional.
+	var p clearParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			writeRPCError(w, http.StatusBadRequest, "invalid params for admin.clear")
+			return
+		}
+	}
+
+	var txs []*Tx
+	for _, pool := range n.pools {
+		txs = append(txs, pool.mp.Clear()...)
+	}
+	res := clearResult{Cleared: len(txs)}
+	if p.ReturnTxs {
+		res.Txs = txs
+	}
+	writeRPCResult(w, http.StatusOK, res)
+}
+
+// ---- admin.pin ----
+
+func (n *Node) rpcAdminPin(w http.ResponseWriter, params json.RawMessage) {
+	var p pinParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for admin.pin")
+		return
+	}
+
+	if p.ID == "" {
+		writeRPCError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	mp, _, err := n.findTx(TxID(p.ID))
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	pin := mp.Pin
+	if p.Unpin {
+		pin = mp.Unpin
+	}
+	if err := pin(TxID(p.ID)); err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	writeRPCResult(w, http.StatusOK, okResult{OK: true})
+}
+
+// ---- admin.pinned ----
+
+func (n *Node) rpcAdminPinned(w http.ResponseWriter) {
+	res := pinnedResult{Pinned: make([]string, 0)}
+	for _, pool := range n.pools {
+		for _, id := range pool.mp.Pinned() {
+			res.Pinned = append(res.Pinned, string(id))
+		}
+	}
+	writeRPCResult(w, http.StatusOK, res)
+}
+
+// ---- admin.require ----
+
+func (n *Node) rpcAdminRequire(w http.ResponseWriter, params json.RawMessage) {
+	var p requireParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		writeRPCError(w, http.StatusBadRequest, "invalid params for admin.require")
+		return
+	}
+
+	if p.ID == "" {
+		writeRPCError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	mp, _, err := n.findTx(TxID(p.ID))
+	if err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	require := mp.Require
+	if p.Cancel {
+		require = mp.Unrequire
+	}
+	if err := require(TxID(p.ID)); err != nil {
+		writeRPCResult(w, http.StatusOK, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	writeRPCResult(w, http.StatusOK, okResult{OK: true})
+}
+
+// ---- admin.required ----
+
+func (n *Node) rpcAdminRequired(w http.ResponseWriter) {
+	res := requiredResult{Required: make([]string, 0)}
+	for _, pool := range n.pools {
+		for _, id := range pool.mp.Required() {
+			res.Required = append(res.Required, string(id))
+		}
+	}
+	writeRPCResult(w, http.StatusOK, res)
 }
 
 // ---- helpers ----
 
-// findTxByID does a linear scan over mempool.List().
-// PERF: For large mempools, a Get(id) method on Mempool would be better.
-func (n *Node) findTxByID(id TxID) *Tx {
-	txs := n.mempool.List()
-	for _, tx := range txs {
-		if tx.ID == id {
-			return tx
+// newRPCTx builds a tx from tx.add params, stamped with clock.
+func newRPCTx(clock Clock, p addTxParams) *Tx {
+	fee := p.Fee
+	if fee == 0 {
+		fee = p.MaxFee
+	}
+	tx := NewUnsignedTxWithClock(clock, p.Sender, p.Recipient, p.Payload, p.Nonce, fee, p.Gas)
+	if p.Amount != 0 || p.ChainID != "" {
+		tx.Amount, tx.ChainID = p.Amount, p.ChainID
+		tx.ID = GenerateTxID(tx.Sender, tx.Recipient, tx.Payload, tx.Nonce, tx.Amount, tx.ChainID, tx.CreatedAt)
+	}
+	tx.MaxFee = p.MaxFee
+	tx.Tip = p.Tip
+	tx.ParentID = TxID(p.ParentID)
+	tx.DependsOn = txIDs(p.DependsOn)
+	tx.NotBefore = p.NotBefore
+	tx.Lane = p.Lane
+	if p.Pool != DefaultPool {
+		tx.Pool = p.Pool
+	}
+	return tx
+}
+
+// isAdminMethod reports whether method is guarded by the AdminToken:
+// every admin.* and debug.* method, block.export, block.import, and
+// block.reorg, which hand out and replace the chain, and peer.add and
+// peer.remove.
+func isAdminMethod(method string) bool {
+	return strings.HasPrefix(method, "admin.") || strings.HasPrefix(method, "debug.") ||
+		method == "block.export" || method == "block.import" || method == "block.reorg" ||
+		method == "peer.add" || method == "peer.remove"
+}
+
+// needsConfiguredToken reports whether method is refused outright when
+// no AdminToken is set: the methods that empty the pool, replace the
+// chain, change the peers, or change the block limits.
+func needsConfiguredToken(method string) bool {
+	switch method {
+	case "admin.clear", "admin.setBlockLimits", "block.reorg", "block.import", "peer.add", "peer.remove":
+		return true
+	}
+	return false
+}
+
+// authorizeAdmin checks the bearer token for an admin method, writing
+// the error response if the call is refused. Without an AdminToken the
+// methods of needsConfiguredToken are refused; the other admin methods
+// stay open.
+func (n *Node) authorizeAdmin(w http.ResponseWriter, r *http.Request, method string) bool {
+	token := n.cfg.AdminToken
+	if token == "" {
+		if needsConfiguredToken(method) {
+			writeRPCError(w, http.StatusForbidden, method+" requires an admin token to be configured")
+			return false
 		}
+		return true
 	}
-	return nil
+
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+		writeRPCError(w, http.StatusUnauthorized, "invalid or missing admin token")
+		return false
+	}
+	return true
+}
+
+// txIDs converts wire IDs to TxIDs; nil stays nil.
+func txIDs(ids []string) []TxID {
+	if ids == nil {
+		return nil
+	}
+	out := make([]TxID, len(ids))
+	for i, id := range ids {
+		out[i] = TxID(id)
+	}
+	return out
 }
 
 func makeBlockDTO(b *Block) blockDTO {
 	hash := b.Hash()
-	return blockDTO{
-		Height:    b.Header.Height,
-		PrevHash:  hex.EncodeToString(b.Header.PrevHash[:]),
-		Timestamp: b.Header.Timestamp,
-		TxCount:   b.Header.TxCount,
-		GasUsed:   b.Header.GasUsed,
-		Hash:      hex.EncodeToString(hash[:]),
-		Txs:       b.Transactions,
+	dto := blockDTO{
+		Height:       b.Header.Height,
+		PrevHash:     hex.EncodeToString(b.Header.PrevHash[:]),
+		Timestamp:    b.Header.Timestamp,
+		TxCount:      b.Header.TxCount,
+		GasUsed:      b.Header.GasUsed,
+		Hash:         hex.EncodeToString(hash[:]),
+		Burned:       b.Header.Burned,
+		Tipped:       b.Header.Tipped,
+		TotalFees:    b.Header.TotalFees,
+		AvgFeePerGas: b.Header.AvgFeePerGas,
+		Proposer:     b.Header.Proposer,
+		ExtraData:    hex.EncodeToString(b.Header.ExtraData),
+		Extra:        b.Header.Extra,
+		ChainID:      b.Header.ChainID,
+		Txs:          b.Transactions,
+		Groups:       b.Groups,
+		Signer:       hex.EncodeToString(b.Signer),
+		Signature:    hex.EncodeToString(b.Signature),
+		Pruned:       b.Pruned,
+	}
+	if b.Compression != CompressNone {
+		dto.Compression = b.Compression.String()
 	}
+	return dto
 }
 
 func writeRPCResult(w http.ResponseWriter, status int, result any) {
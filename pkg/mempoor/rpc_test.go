@@ -0,0 +1,128 @@
+package mempoor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func callAdmin(n *Node, method, token string) int {
+	return callRPCWithToken(n, `{"method":"`+method+`"}`, token).Code
+}
+
+// callRPCWithToken is callRPC with the admin token as a bearer token.
+func callRPCWithToken(n *Node, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	n.handleRPC(rec, req)
+	return rec
+}
+
+func TestAdminClearRequiresToken(t *testing.T) {
+	open := NewNode(NodeConfig{})
+	if code := callAdmin(open, "admin.clear", ""); code != http.StatusForbidden {
+		t.Fatalf("expected 403 without a configured token, got %d", code)
+	}
+	if code := callAdmin(open, "admin.senderACL", ""); code != http.StatusOK {
+		t.Fatalf("other admin methods stay open without a token, got %d", code)
+	}
+
+	locked := NewNode(NodeConfig{AdminToken: "s3cret"})
+	_, _ = locked.mempool.Add(newTx("alice", 10, 1))
+	if code := callAdmin(locked, "admin.clear", "wrong"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a bad token, got %d", code)
+	}
+	if code := callAdmin(locked, "admin.senderACL", ""); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a missing token, got %d", code)
+	}
+	if code := callAdmin(locked, "admin.clear", "s3cret"); code != http.StatusOK {
+		t.Fatalf("expected 200 with the token, got %d", code)
+	}
+	if locked.mempool.Count() != 0 {
+		t.Fatalf("expected the pool cleared")
+	}
+}
+
+func TestAddRejectsLargePayload(t *testing.T) {
+	n := NewNode(NodeConfig{MaxPayloadBytes: 4})
+
+	body := `{"method":"tx.add","params":{"sender":"alice","recipient":"bob","payload":"too long","fee":1,"gas":1}}`
+	req := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	n.handleRPC(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", rec.Code)
+	}
+	if n.mempool.Count() != 0 {
+		t.Fatalf("oversized tx must not be admitted")
+	}
+}
+
+func TestTxGetReportsPosition(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	urgent := laneTx("alice", LaneUrgent, 1, 1)
+	rich := laneTx("bob", LaneNormal, 50, 1)
+	cheap := laneTx("carol", LaneNormal, 5, 1)
+	for _, tx := range []*Tx{urgent, rich, cheap} {
+		if _, err := n.mempool.Add(tx); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	rec := callRPC(n, `{"method":"tx.get","params":{"id":"`+string(cheap.ID)+`"}}`)
+	var body struct {
+		Result txGetResult `json:"result"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("get: %d %s", rec.Code, rec.Body)
+	}
+	if got := body.Result; got.Tx == nil || got.Tx.ID != cheap.ID || got.Position != 2 || got.LanePosition != 1 {
+		t.Fatalf("expected position 2 and lane position 1, got %+v", got)
+	}
+
+	rec = callRPC(n, `{"method":"tx.get","params":{"id":"nope"}}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), ErrTxNotFound.Error()) {
+		t.Fatalf("expected an unknown tx refused, got %d %s", rec.Code, rec.Body)
+	}
+	if rec = callRPC(n, `{"method":"tx.get","params":{}}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without an id, got %d", rec.Code)
+	}
+}
+
+func TestBlockReorgRequiresToken(t *testing.T) {
+	if code := callAdmin(NewNode(NodeConfig{}), "block.reorg", ""); code != http.StatusForbidden {
+		t.Fatalf("expected 403 without a configured token, got %d", code)
+	}
+	locked := NewNode(NodeConfig{AdminToken: "s3cret"})
+	if code := callAdmin(locked, "block.reorg", ""); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a missing token, got %d", code)
+	}
+	// Past the token check, the missing block is a bad request.
+	if code := callAdmin(locked, "block.reorg", "s3cret"); code != http.StatusBadRequest {
+		t.Fatalf("expected the call through with the token, got %d", code)
+	}
+}
+
+func TestChainAndPeerMethodsRequireConfiguredToken(t *testing.T) {
+	open := NewNode(NodeConfig{})
+	for _, method := range []string{"block.import", "peer.add", "peer.remove", "admin.setBlockLimits"} {
+		if code := callAdmin(open, method, ""); code != http.StatusForbidden {
+			t.Fatalf("expected 403 for %s without a configured token, got %d", method, code)
+		}
+	}
+
+	locked := NewNode(NodeConfig{AdminToken: "s3cret"})
+	if code := callAdmin(locked, "block.import", ""); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a missing token, got %d", code)
+	}
+	// Past the token check, the empty chain is a bad request.
+	if code := callAdmin(locked, "block.import", "s3cret"); code != http.StatusBadRequest {
+		t.Fatalf("expected the call through with the token, got %d", code)
+	}
+}
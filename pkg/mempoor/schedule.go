@@ -0,0 +1,152 @@
+package mempoor
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+	"time"
+)
+
+// DefaultMaxScheduled bounds the pending-activation queue when
+// MempoolConfig.MaxScheduled is 0.
+const DefaultMaxScheduled = 1_000
+
+var (
+	// ErrTxScheduled is returned by Add for a tx whose NotBefore is in
+	// the future. Like ErrTxOrphaned it reports where the tx went, not a
+	// failure.
+	ErrTxScheduled = errors.New("mempool: tx held until its NotBefore time")
+
+	// ErrScheduleFull is returned by Add when the pending-activation
+	// queue is at MaxScheduled.
+	ErrScheduleFull = errors.New("mempool: too many scheduled txs")
+)
+
+// Schedule semantics (Tx.NotBefore):
+//   - A tx with NotBefore in the future passes the usual admission checks
+//     and then waits in a pending-activation queue instead of the heap;
+//     Add returns ErrTxScheduled. Scheduled txs are invisible to Get,
+//     List, and selection, and do not count against MaxTxs.
+//   - Due txs are moved into the heap, in NotBefore order, whenever the
+//     pool is next touched by Add, SelectTransactions, Reserve, or
+//     PeekTransactions. A due tx that loses the capacity check is dropped
+//     and reported as TxEvicted.
+//   - Remove cancels a scheduled tx. The queue is included in dumps, so
+//     scheduled txs survive a restart.
+//   - The queue never evicts: when full, new scheduled txs are rejected
+//     with ErrScheduleFull.
+
+// scheduledTx is the activation-queue element wrapping a Tx.
+type scheduledTx struct {
+	tx    *Tx
+	index int
+}
+
+// scheduleQueue is a min-heap by NotBefore, then ID.
+type scheduleQueue []*scheduledTx
+
+func (q scheduleQueue) Len() int { return len(q) }
+
+func (q scheduleQueue) Less(i, j int) bool {
+	a, b := q[i].tx, q[j].tx
+	if !a.NotBefore.Equal(b.NotBefore) {
+		return a.NotBefore.Before(b.NotBefore)
+	}
+	return a.ID < b.ID
+}
+
+func (q scheduleQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *scheduleQueue) Push(x any) {
+	s := x.(*scheduledTx)
+	s.index = len(*q)
+	*q = append(*q, s)
+}
+
+func (q *scheduleQueue) Pop() any {
+	old := *q
+	n := len(old)
+	s := old[n-1]
+	*q = old[:n-1]
+	s.index = -1
+	return s
+}
+
+// scheduleLocked puts tx into the activation queue.
+// Caller must hold the write lock.
+func (m *mempool) scheduleLocked(tx *Tx) error {
+	max := m.cfg.MaxScheduled
+	if max <= 0 {
+		max = DefaultMaxScheduled
+	}
+	if len(m.schedule) >= max {
+		return ErrScheduleFull
+	}
+
+	s := &scheduledTx{tx: tx}
+	heap.Push(&m.schedule, s)
+	m.scheduled[tx.ID] = s
+	m.events.publish(TxScheduled, tx)
+	return nil
+}
+
+// unscheduleLocked removes id from the activation queue, reporting
+// whether it was there. Caller must hold the write lock.
+func (m *mempool) unscheduleLocked(id TxID) (*Tx, bool) {
+	s, ok := m.scheduled[id]
+	if !ok {
+		return nil, false
+	}
+	heap.Remove(&m.schedule, s.index)
+	delete(m.scheduled, id)
+	return s.tx, true
+}
+
+// activateDue moves every tx whose NotBefore has passed into the heap.
+// Caller must hold the write lock.
+func (m *mempool) activateDue(now time.Time) {
+	activated := false
+	for len(m.schedule) > 0 && !m.schedule[0].tx.NotBefore.After(now) {
+		s := heap.Pop(&m.schedule).(*scheduledTx)
+		delete(m.scheduled, s.tx.ID)
+		if _, err := m.admit(s.tx); err != nil {
+			m.dropped(TxEvicted, s.tx)
+			continue
+		}
+		activated = true
+	}
+
+	// An activated nonce may satisfy waiting orphans.
+	if activated && len(m.orphans) > 0 {
+		m.promoteOrphans()
+	}
+}
+
+// hasDue reports whether any scheduled tx is due at now.
+// Caller must hold at least the read lock.
+func (m *mempool) hasDue(now time.Time) bool {
+	return len(m.schedule) > 0 && !m.schedule[0].tx.NotBefore.After(now)
+}
+
+// ListScheduled returns the txs waiting for their NotBefore time, soonest
+// first.
+func (m *mempool) ListScheduled() []*Tx {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]*Tx, 0, len(m.schedule))
+	for _, s := range m.schedule {
+		out = append(out, s.tx)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].NotBefore.Equal(out[j].NotBefore) {
+			return out[i].NotBefore.Before(out[j].NotBefore)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
@@ -0,0 +1,101 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduledTxActivatesAtNotBefore(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	mp := NewMempool(MempoolConfig{Clock: clock})
+
+	tx := NewUnsignedTxWithClock(clock, "alice", "bob", "payout", 0, 10, 1)
+	tx.NotBefore = clock.Now().Add(time.Minute)
+	if _, err := mp.Add(tx); !errors.Is(err, ErrTxScheduled) {
+		t.Fatalf("expected ErrTxScheduled, got %v", err)
+	}
+	if _, err := mp.Get(tx.ID); !errors.Is(err, ErrTxNotFound) {
+		t.Fatalf("scheduled tx must not be pending yet")
+	}
+	if got := mp.ListScheduled(); len(got) != 1 || got[0].ID != tx.ID {
+		t.Fatalf("expected tx in ListScheduled, got %v", got)
+	}
+	if _, err := mp.Add(tx); !errors.Is(err, ErrTxExists) {
+		t.Fatalf("expected ErrTxExists for a scheduled duplicate, got %v", err)
+	}
+
+	c := BlockConstraints{MaxTx: 10}
+	if res := mp.SelectTransactions(c); len(res.Transactions) != 0 {
+		t.Fatalf("selected a tx before NotBefore")
+	}
+
+	clock.Advance(time.Minute)
+	if peek := mp.PeekTransactions(c); len(peek.Transactions) != 1 {
+		t.Fatalf("expected Peek to see the activated tx, got %v", peek.Transactions)
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("invariants: %v", err)
+	}
+	if res := mp.SelectTransactions(c); len(res.Transactions) != 1 || res.Transactions[0].ID != tx.ID {
+		t.Fatalf("expected the tx selected once due, got %v", res.Transactions)
+	}
+}
+
+func TestRemoveCancelsScheduledTx(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	mp := NewMempool(MempoolConfig{Clock: clock})
+
+	tx := newTx("alice", 10, 1)
+	tx.NotBefore = clock.Now().Add(time.Hour)
+	_, _ = mp.Add(tx)
+
+	if err := mp.Remove(tx.ID); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if len(mp.ListScheduled()) != 0 {
+		t.Fatalf("expected the schedule to be empty")
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("invariants: %v", err)
+	}
+}
+
+func TestScheduleFull(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	mp := NewMempool(MempoolConfig{Clock: clock, MaxScheduled: 1})
+
+	later := clock.Now().Add(time.Hour)
+	a, b := newTx("alice", 10, 1), newTx("carol", 10, 1)
+	a.NotBefore, b.NotBefore = later, later
+	_, _ = mp.Add(a)
+	if _, err := mp.Add(b); !errors.Is(err, ErrScheduleFull) {
+		t.Fatalf("expected ErrScheduleFull, got %v", err)
+	}
+}
+
+func TestScheduledTxsSurviveDump(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	mp := NewMempool(MempoolConfig{Clock: clock})
+
+	tx := newTx("alice", 10, 1)
+	tx.NotBefore = clock.Now().Add(time.Hour)
+	_, _ = mp.Add(tx)
+	_, _ = mp.Add(newTx("carol", 10, 1))
+
+	raw, err := mp.MarshalJSON()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	restored := NewMempool(MempoolConfig{Clock: clock, MaxTxs: 1})
+	if err := restored.UnmarshalJSON(raw); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(restored.List()) != 1 || len(restored.ListScheduled()) != 1 {
+		t.Fatalf("expected 1 pending and 1 scheduled, got %d and %d",
+			len(restored.List()), len(restored.ListScheduled()))
+	}
+	if err := restored.CheckInvariants(); err != nil {
+		t.Fatalf("invariants: %v", err)
+	}
+}
@@ -0,0 +1,439 @@
+package mempoor
+
+import (
+	"container/heap"
+	"fmt"
+	"math/bits"
+)
+
+// SelectionStrategy chooses the order in which a block is filled. The
+// pool's PriorityPolicy still governs eviction and listings; lanes,
+// nonce order, dependencies, and MinFee purges apply under every
+// strategy.
+type SelectionStrategy int
+
+const (
+	// StrategyPriority fills blocks in the pool's PriorityPolicy order:
+	// fee-max under the default FeeFirst. Default.
+	StrategyPriority SelectionStrategy = iota
+
+	// StrategyFIFO fills blocks in arrival order (Timestamp ASC, ID ASC),
+	// ignoring fees.
+	//
+	// PERF: O(n) per lane per block to order the whole lane, instead of
+	// visiting only what is selected.
+	StrategyFIFO
+
+	// StrategyFairShare fills blocks round-robin by sender: each round
+	// admits at most one more tx per sender, in priority order, so no
+	// single sender can monopolize a block while others are waiting.
+	// Rounds run within each lane; a sender's count carries across lanes.
+	StrategyFairShare
+)
+
+func (s SelectionStrategy) String() string {
+	switch s {
+	case StrategyPriority:
+		return "priority"
+	case StrategyFIFO:
+		return "fifo"
+	case StrategyFairShare:
+		return "fair"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSelectionStrategy maps "priority" (or "fee"), "fifo", or "fair" to
+// a SelectionStrategy. An empty name means StrategyPriority.
+func ParseSelectionStrategy(name string) (SelectionStrategy, error) {
+	switch name {
+	case "", "priority", "fee":
+		return StrategyPriority, nil
+	case "fifo":
+		return StrategyFIFO, nil
+	case "fair":
+		return StrategyFairShare, nil
+	default:
+		return 0, fmt.Errorf("mempool: unknown selection strategy %q", name)
+	}
+}
+
+// PackingMode selects how a block is filled from the pool.
+type PackingMode int
+
+const (
+	// PackGreedy takes txs in priority order, skipping any that no
+	// longer fit. Default.
+	PackGreedy PackingMode = iota
+
+	// PackKnapsack is a best-effort fee maximizer for when fee and gas
+	// are anti-correlated and greedy blocks end up under-filled.
+	//
+	// Knapsack semantics:
+	//   - A second plan is built by repeatedly taking, of the next
+	//     Lookahead txs in priority order, the one with the highest
+	//     Fee/Gas. The plan with the higher total fee wins, so the
+	//     block never earns less than PackGreedy's.
+	//   - Nonce order, dependencies, lane order and quotas, and MinFee
+	//     purges apply exactly as in PackGreedy.
+	//   - Block txs are in the order they were picked, not strictly by
+	//     priority.
+	//   - Without a GasLimit there is nothing to pack: same as greedy.
+	//
+	// PERF: Two walks instead of one, the second costing O(Lookahead)
+	// frontier operations per visited tx.
+	PackKnapsack
+)
+
+// DefaultPackLookahead is the PackKnapsack window used when
+// BlockConstraints.Lookahead is 0.
+const DefaultPackLookahead = 32
+
+func (p PackingMode) String() string {
+	switch p {
+	case PackGreedy:
+		return "greedy"
+	case PackKnapsack:
+		return "knapsack"
+	default:
+		return "unknown"
+	}
+}
+
+// selectionPlan is the outcome of planSelection: what to return and
+// which records to take out of the pool.
+type selectionPlan struct {
+	result   BlockSelectionResult
+	selected []*txRecord
+	purged   []*txRecord
+}
+
+// planSelection decides the next block without modifying the pool. With
+// PackKnapsack it also plans a fee-dense block and keeps whichever of the
+// two earns more in fees; see PackingMode.
+func (m *mempool) planSelection(c BlockConstraints) selectionPlan {
+	plan := m.planWalk(c, 1)
+	if c.Packing != PackKnapsack || c.GasLimit == 0 {
+		return plan
+	}
+
+	lookahead := c.Lookahead
+	if lookahead <= 0 {
+		lookahead = DefaultPackLookahead
+	}
+	if dense := m.planWalk(c, lookahead); dense.fees() > plan.fees() {
+		return dense
+	}
+	return plan
+}
+
+// fees is what the plan earns the proposer; see "Fee market semantics".
+func (p *selectionPlan) fees() uint64 { return p.result.Tips }
+
+// planWalk plans one block by walking the pool in priority order. With
+// lookahead > 1, each step considers the next lookahead txs and takes the
+// one with the highest Fee/Gas instead of the first.
+//
+// Each lane heap is walked in priority order through a heapWalk frontier
+// instead of being popped, so txs that are skipped (over the gas limit or
+// lane quota) or parked (nonce-blocked) are never taken out of the heap
+// and never pushed back. Only selected and purged records are removed
+// afterwards, by selectLocked.
+//
+// Nonce semantics match the heap: a parked tx is pushed back onto the
+// frontier as soon as the tx blocking it is selected or purged, so it
+// competes again by priority within the same lane.
+//
+// A closed c.Done ends the walk with the plan built so far.
+//
+// PERF: O((v + s) log v) for v visited txs and s selected ones, plus
+// O(s log n) to remove the selected txs. Skipped txs cost only frontier
+// work, never a heap reinsert. Caller must hold at least the read lock.
+func (m *mempool) planWalk(c BlockConstraints, lookahead int) selectionPlan {
+	var plan selectionPlan
+	if c.MaxTx <= 0 || len(m.table) == 0 {
+		return plan
+	}
+
+	// taken marks records selected or purged by this plan; the sender
+	// index is read "as if" they were already gone.
+	taken := make(map[*txRecord]bool)
+	executable := func(rec *txRecord) bool {
+		for _, r := range m.senders[rec.tx.Sender] {
+			if !taken[r] {
+				return r.tx.Nonce >= rec.tx.Nonce
+			}
+		}
+		return true
+	}
+
+	result := &plan.result
+	parked := make(map[string][]int)  // sender → heap positions in the current lane
+	perSender := make(map[string]int) // selected txs, for StrategyFairShare
+
+	// Required txs go first, held only to the block's size and gas; see
+	// "Mandatory inclusion semantics".
+	for _, rec := range m.requiredRecords() {
+		tx := rec.tx
+		if stopped(c.Done) {
+			return plan
+		}
+		if len(result.Transactions) >= c.MaxTx {
+			break
+		}
+		if !executable(rec) || rec.waiting > 0 ||
+			(c.GasLimit > 0 && result.GasUsed+tx.Gas > c.GasLimit) {
+			continue
+		}
+		maxFee, _ := tx.feeCaps()
+		tip, _ := EffectiveTip(tx, c.BaseFee)
+		taken[rec] = true
+		perSender[tx.Sender]++
+		plan.selected = append(plan.selected, rec)
+		result.Transactions = append(result.Transactions, tx)
+		result.GasUsed += tx.Gas
+		result.Burned += min(burnFor(c.BaseFee, tx.Gas), maxFee)
+		result.Tips += tip
+	}
+
+	for _, lane := range laneOrder {
+		h := m.heapOf(lane)
+		if h.Len() == 0 {
+			continue
+		}
+		quota := c.LaneGasLimits[lane]
+		var laneGas uint64
+
+		// Positions re-pushed after being parked were already expanded.
+		revisit := make(map[int]bool)
+		release := func(sender string, w *heapWalk) {
+			for _, i := range parked[sender] {
+				revisit[i] = true
+				heap.Push(w, i)
+			}
+			delete(parked, sender)
+		}
+
+		w := newHeapWalk(h, c)
+		pop := func() int {
+			i := heap.Pop(w).(int)
+			if !w.flat && !revisit[i] {
+				if l := 2*i + 1; l < h.Len() {
+					heap.Push(w, l)
+				}
+				if r := 2*i + 2; r < h.Len() {
+					heap.Push(w, r)
+				}
+			}
+			delete(revisit, i)
+			return i
+		}
+
+		// Fair share: txs whose sender already has round txs in the block
+		// wait in deferred for the next round.
+		round := 1
+		var deferred []int
+
+		// bundleStep runs steps 1-6 below for a whole bundle, reached
+		// through its member at position i; see bundle.go.
+		bundleStep := func(i int, b *bundle) {
+			members := b.members
+			sender := members[0].tx.Sender // not the member reached, for determinism
+
+			// 1) Purge on the average fee.
+			if !m.bundlePinned(b) && b.key.Fee/uint64(len(members)) < c.MinFee {
+				for _, r := range members {
+					taken[r] = true
+					plan.purged = append(plan.purged, r)
+					result.Purged = append(result.Purged, r.tx)
+				}
+				for _, r := range members {
+					release(r.tx.Sender, w)
+				}
+				return
+			}
+
+			// 2) Every member must be nonce-ready once the ones before it
+			// are in; park on the first sender that blocks.
+			for j, r := range members {
+				if !executable(r) {
+					for _, prev := range members[:j] {
+						delete(taken, prev)
+					}
+					parked[r.tx.Sender] = append(parked[r.tx.Sender], i)
+					return
+				}
+				taken[r] = true
+			}
+			for _, r := range members {
+				delete(taken, r)
+			}
+
+			// 3) Dependencies, filter, and base fee.
+			var tips uint64
+			for _, r := range members {
+				if r.waiting > 0 || (c.Filter != nil && !c.Filter(r.tx)) {
+					return
+				}
+				tip, ok := EffectiveTip(r.tx, c.BaseFee)
+				if !ok {
+					return
+				}
+				tips += tip
+			}
+
+			// 4) Fair share counts the bundle against the sender reached.
+			if c.Strategy == StrategyFairShare && perSender[sender] >= round {
+				deferred = append(deferred, i)
+				return
+			}
+
+			// 5) Block size, gas limit, and lane quota.
+			gas := b.key.Gas
+			if len(result.Transactions)+len(members) > c.MaxTx ||
+				(c.GasLimit > 0 && result.GasUsed+gas > c.GasLimit) ||
+				(quota > 0 && laneGas+gas > quota) {
+				return
+			}
+
+			// 6) Accept every member.
+			perSender[sender]++
+			for _, r := range members {
+				taken[r] = true
+				plan.selected = append(plan.selected, r)
+				result.Transactions = append(result.Transactions, r.tx)
+			}
+			result.GasUsed += gas
+			result.Burned += burnFor(c.BaseFee, gas)
+			result.Tips += tips
+			laneGas += gas
+			for _, r := range members {
+				release(r.tx.Sender, w)
+			}
+		}
+
+		for len(result.Transactions) < c.MaxTx {
+			if stopped(c.Done) {
+				return plan
+			}
+			if w.Len() == 0 {
+				if len(deferred) == 0 {
+					break
+				}
+				round++
+				for _, j := range deferred {
+					revisit[j] = true
+					heap.Push(w, j)
+				}
+				deferred = deferred[:0]
+			}
+
+			i := pop()
+			if lookahead > 1 {
+				// Take the densest of the next lookahead txs; the rest
+				// go back on the frontier, already expanded.
+				window := []int{i}
+				for len(window) < lookahead && w.Len() > 0 {
+					window = append(window, pop())
+				}
+				for _, j := range window[1:] {
+					if denser(h.recs[j].key(), h.recs[i].key()) {
+						i = j
+					}
+				}
+				for _, j := range window {
+					if j != i {
+						revisit[j] = true
+						heap.Push(w, j)
+					}
+				}
+			}
+
+			rec := h.recs[i]
+			tx := rec.tx
+			if taken[rec] {
+				continue // went with its bundle
+			}
+			if rec.bundle != nil {
+				bundleStep(i, rec.bundle)
+				continue
+			}
+
+			// 1) Purge low-fee txs permanently, unless pinned or required.
+			if !m.protected(tx.ID) && tx.Fee < c.MinFee {
+				taken[rec] = true
+				plan.purged = append(plan.purged, rec)
+				result.Purged = append(result.Purged, tx)
+				release(tx.Sender, w)
+				continue
+			}
+
+			// 2) Enforce nonce ordering within the sender.
+			if !executable(rec) {
+				parked[tx.Sender] = append(parked[tx.Sender], i)
+				continue
+			}
+
+			// 3) Skip txs waiting on uncommitted dependencies, rejected
+			// by the caller's filter, or unable to cover the base fee.
+			if rec.waiting > 0 || (c.Filter != nil && !c.Filter(tx)) {
+				continue
+			}
+			tip, ok := EffectiveTip(tx, c.BaseFee)
+			if !ok {
+				continue
+			}
+
+			// 4) Give every sender a turn per round under fair share.
+			if c.Strategy == StrategyFairShare && perSender[tx.Sender] >= round {
+				deferred = append(deferred, i)
+				continue
+			}
+
+			// 5) Enforce block gas limit and lane quota (if any). The tx
+			// simply stays where it is in the heap.
+			if (c.GasLimit > 0 && result.GasUsed+tx.Gas > c.GasLimit) ||
+				(quota > 0 && laneGas+tx.Gas > quota) {
+				continue
+			}
+
+			// 6) Accept the tx.
+			taken[rec] = true
+			perSender[tx.Sender]++
+			plan.selected = append(plan.selected, rec)
+			result.Transactions = append(result.Transactions, tx)
+			result.GasUsed += tx.Gas
+			result.Burned += burnFor(c.BaseFee, tx.Gas)
+			result.Tips += tip
+			laneGas += tx.Gas
+			release(tx.Sender, w)
+		}
+
+		// A tx parked in an already-walked lane waits for the next block.
+		clear(parked)
+	}
+
+	return plan
+}
+
+// stopped reports whether done is closed; a nil done never is.
+func stopped(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// denser reports whether a pays more fee per unit of gas than b. Zero-gas
+// txs are the densest; the comparison is exact (no division).
+func denser(a, b *Tx) bool {
+	ahi, alo := bits.Mul64(a.Fee, b.Gas)
+	bhi, blo := bits.Mul64(b.Fee, a.Gas)
+	if ahi != bhi {
+		return ahi > bhi
+	}
+	return alo > blo
+}
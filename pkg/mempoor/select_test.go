@@ -0,0 +1,169 @@
+package mempoor
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSelectionKeepsSkippedTxsInPlace(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	big := newTx("carol", 100, 900)
+	_, _ = mp.Add(big)
+	for i := 0; i < 20; i++ {
+		_, _ = mp.Add(newTx(fmt.Sprintf("s%d", i), uint64(50-i), 10))
+	}
+
+	m := mp.(*mempool)
+	bigIndex := m.table[big.ID].index
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 5, GasLimit: 100})
+	if len(res.Transactions) != 5 {
+		t.Fatalf("expected 5 txs, got %d", len(res.Transactions))
+	}
+	for _, tx := range res.Transactions {
+		if tx.ID == big.ID {
+			t.Fatalf("over-gas tx must be skipped")
+		}
+	}
+	if bigIndex != 0 || m.table[big.ID].index != 0 {
+		t.Fatalf("skipped top tx should stay at the heap root, was %d now %d", bigIndex, m.table[big.ID].index)
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("invariants after selection: %v", err)
+	}
+}
+
+func TestSelectionRandomizedInvariants(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for round := 0; round < 50; round++ {
+		mp := NewMempool(MempoolConfig{})
+		nonces := make(map[string]uint64)
+		for i := 0; i < 60; i++ {
+			sender := fmt.Sprintf("s%d", rng.Intn(8))
+			tx := NewUnsignedTx(sender, "bob", fmt.Sprint(i), nonces[sender], uint64(rng.Intn(100)), uint64(rng.Intn(50)+1))
+			tx.Lane = Lane(rng.Intn(int(numLanes)))
+			nonces[sender]++
+			_, _ = mp.Add(tx)
+		}
+
+		c := BlockConstraints{
+			MaxTx:         rng.Intn(30) + 1,
+			GasLimit:      uint64(rng.Intn(500) + 50),
+			MinFee:        uint64(rng.Intn(20)),
+			LaneGasLimits: map[Lane]uint64{LaneUrgent: uint64(rng.Intn(200))},
+		}
+		greedy := mp.PeekTransactions(c)
+		c.Packing = PackingMode(round % 2)
+		c.Strategy = SelectionStrategy(round % 3)
+		c.Lookahead = rng.Intn(8)
+		peek := mp.PeekTransactions(c)
+		res := mp.SelectTransactions(c)
+
+		if c.Strategy == StrategyPriority && totalFee(res.Transactions) < totalFee(greedy.Transactions) {
+			t.Fatalf("round %d: %v earned less than greedy", round, c.Packing)
+		}
+
+		if len(peek.Transactions) != len(res.Transactions) || len(peek.Purged) != len(res.Purged) {
+			t.Fatalf("round %d: peek and select disagree", round)
+		}
+		if len(res.Transactions) > c.MaxTx || res.GasUsed > c.GasLimit {
+			t.Fatalf("round %d: constraints violated: %d txs, %d gas", round, len(res.Transactions), res.GasUsed)
+		}
+		last := make(map[string]uint64)
+		seen := make(map[string]bool)
+		for _, tx := range res.Transactions {
+			if tx.Fee < c.MinFee {
+				t.Fatalf("round %d: selected a tx below MinFee", round)
+			}
+			if seen[tx.Sender] && tx.Nonce < last[tx.Sender] {
+				t.Fatalf("round %d: sender %s out of nonce order", round, tx.Sender)
+			}
+			seen[tx.Sender], last[tx.Sender] = true, tx.Nonce
+		}
+		if err := mp.CheckInvariants(); err != nil {
+			t.Fatalf("round %d: %v", round, err)
+		}
+	}
+}
+
+func totalFee(txs []*Tx) uint64 {
+	var total uint64
+	for _, tx := range txs {
+		total += tx.Fee
+	}
+	return total
+}
+
+func TestKnapsackPackingBeatsGreedy(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	big := newTx("whale", 100, 100)
+	_, _ = mp.Add(big)
+	for i := 0; i < 4; i++ {
+		_, _ = mp.Add(newTx(fmt.Sprintf("s%d", i), 40, 25))
+	}
+
+	c := BlockConstraints{MaxTx: 10, GasLimit: 100}
+	if greedy := mp.PeekTransactions(c); totalFee(greedy.Transactions) != 100 {
+		t.Fatalf("expected greedy to take only the whale, got fee %d", totalFee(greedy.Transactions))
+	}
+
+	c.Packing = PackKnapsack
+	res := mp.SelectTransactions(c)
+	if totalFee(res.Transactions) != 160 || res.GasUsed != 100 {
+		t.Fatalf("expected the four small txs (fee 160), got fee %d gas %d", totalFee(res.Transactions), res.GasUsed)
+	}
+	if _, err := mp.Get(big.ID); err != nil {
+		t.Fatalf("the whale should stay pending: %v", err)
+	}
+}
+
+func TestFIFOStrategyIgnoresFees(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	mp := NewMempool(MempoolConfig{Clock: clock})
+
+	first := NewUnsignedTxWithClock(clock, "alice", "bob", "a", 0, 1, 10)
+	clock.Advance(time.Second)
+	second := NewUnsignedTxWithClock(clock, "carol", "bob", "c", 0, 50, 10)
+	clock.Advance(time.Second)
+	third := NewUnsignedTxWithClock(clock, "dan", "bob", "d", 0, 100, 10)
+	for _, tx := range []*Tx{third, first, second} {
+		_, _ = mp.Add(tx)
+	}
+
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 2, Strategy: StrategyFIFO})
+	if len(res.Transactions) != 2 || res.Transactions[0].ID != first.ID || res.Transactions[1].ID != second.ID {
+		t.Fatalf("expected the two oldest txs in arrival order, got %v", res.Transactions)
+	}
+	if _, err := mp.Get(third.ID); err != nil {
+		t.Fatalf("the newest tx should stay pending: %v", err)
+	}
+}
+
+func TestFairShareStrategyInterleavesSenders(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		_, _ = mp.Add(NewUnsignedTx("whale", "bob", fmt.Sprint(nonce), nonce, 100, 10))
+	}
+	_, _ = mp.Add(newTx("minnow", 1, 10))
+
+	c := BlockConstraints{MaxTx: 2}
+	if greedy := mp.PeekTransactions(c); greedy.Transactions[1].Sender != "whale" {
+		t.Fatalf("expected priority order to give the whale both slots")
+	}
+
+	c.Strategy = StrategyFairShare
+	res := mp.SelectTransactions(c)
+	if len(res.Transactions) != 2 || res.Transactions[0].Sender != "whale" || res.Transactions[1].Sender != "minnow" {
+		t.Fatalf("expected one tx per sender, got %v", res.Transactions)
+	}
+
+	// Once every sender has had a turn, the whale gets the rest in order.
+	res = mp.SelectTransactions(BlockConstraints{MaxTx: 10, Strategy: StrategyFairShare})
+	if len(res.Transactions) != 2 || res.Transactions[0].Nonce != 1 || res.Transactions[1].Nonce != 2 {
+		t.Fatalf("expected the whale's remaining nonces, got %v", res.Transactions)
+	}
+}
@@ -0,0 +1,64 @@
+package mempoor
+
+import "errors"
+
+// ErrSenderLimit is returned by Add when the sender already has
+// MaxTxsPerSender txs pending.
+var ErrSenderLimit = errors.New("mempool: too many pending txs from sender")
+
+// Per-sender operations are served from m.senders, the nonce-sorted
+// index kept beside the table, so they cost O(k) in the sender's
+// pending count rather than O(n) in the pool.
+//
+// Sender limit semantics (MaxTxsPerSender > 0):
+//   - Only pending txs count; orphans and reserved txs do not.
+//   - Add fails with ErrSenderLimit once the sender is at the limit,
+//     before any eviction happens.
+//   - Orphans promoted when their dependency arrives are not checked:
+//     they were admitted once already.
+
+// ListBySender returns sender's pending txs in nonce order.
+func (m *mempool) ListBySender(sender string) []*Tx {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recs := m.senders[sender]
+	out := make([]*Tx, len(recs))
+	for i, rec := range recs {
+		out[i] = rec.tx
+	}
+	return out
+}
+
+// PurgeSender removes every pending tx from sender and returns their
+// IDs in nonce order. Each removal is published as TxRemoved. Orphans
+// from sender are left to expire.
+func (m *mempool) PurgeSender(sender string) []TxID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.purgeSenderLocked(sender)
+}
+
+// purgeSenderLocked implements PurgeSender. Caller must hold the write
+// lock.
+func (m *mempool) purgeSenderLocked(sender string) []TxID {
+	// removeRecord edits m.senders[sender] in place; work on a copy.
+	recs := append([]*txRecord(nil), m.senders[sender]...)
+	ids := make([]TxID, 0, len(recs))
+	for _, rec := range recs {
+		if m.table[rec.tx.ID] != rec {
+			continue // dropped along with one of its dependencies
+		}
+		m.removeRecord(rec)
+		m.dropped(TxRemoved, rec.tx)
+		ids = append(ids, rec.tx.ID)
+	}
+	return ids
+}
+
+// senderAtLimit reports whether sender may not add another pending tx.
+// Caller must hold the lock.
+func (m *mempool) senderAtLimit(sender string) bool {
+	return m.cfg.MaxTxsPerSender > 0 && len(m.senders[sender]) >= m.cfg.MaxTxsPerSender
+}
@@ -0,0 +1,86 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestListBySenderNonceOrder(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+
+	for _, nonce := range []uint64{2, 0, 1} {
+		_, _ = mp.Add(NewUnsignedTx("alice", "bob", "p", nonce, 10, 1))
+	}
+	_, _ = mp.Add(newTx("carol", 10, 1))
+
+	txs := mp.ListBySender("alice")
+	if len(txs) != 3 {
+		t.Fatalf("expected 3 txs, got %d", len(txs))
+	}
+	for i, tx := range txs {
+		if tx.Nonce != uint64(i) {
+			t.Fatalf("tx %d has nonce %d", i, tx.Nonce)
+		}
+	}
+	if got := mp.ListBySender("nobody"); len(got) != 0 {
+		t.Fatalf("expected no txs for unknown sender, got %d", len(got))
+	}
+	if got := mp.ListFilter(TxFilter{Sender: "alice", MinFee: 11}); len(got) != 0 {
+		t.Fatalf("filter by sender should still apply other fields, got %d", len(got))
+	}
+}
+
+func TestMaxTxsPerSender(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxTxsPerSender: 2})
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if _, err := mp.Add(NewUnsignedTx("alice", "bob", "p", nonce, 10, 1)); err != nil {
+			t.Fatalf("add nonce %d: %v", nonce, err)
+		}
+	}
+	third := NewUnsignedTx("alice", "bob", "p", 2, 10, 1)
+	if _, err := mp.Add(third); !errors.Is(err, ErrSenderLimit) {
+		t.Fatalf("expected ErrSenderLimit, got %v", err)
+	}
+	if _, err := mp.Add(newTx("carol", 5, 1)); err != nil {
+		t.Fatalf("other senders must be unaffected: %v", err)
+	}
+
+	mp.SelectTransactions(BlockConstraints{MaxTx: 1, MinFee: 0})
+	if _, err := mp.Add(third); err != nil {
+		t.Fatalf("expected room after selection: %v", err)
+	}
+}
+
+func TestPurgeSender(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	events, cancel := mp.Subscribe()
+	defer cancel()
+
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		_, _ = mp.Add(NewUnsignedTx("mallory", "bob", "p", nonce, 10, 1))
+	}
+	keep := newTx("alice", 10, 1)
+	_, _ = mp.Add(keep)
+
+	ids := mp.PurgeSender("mallory")
+	if len(ids) != 3 {
+		t.Fatalf("expected 3 purged, got %d", len(ids))
+	}
+	if got := mp.List(); len(got) != 1 || got[0].ID != keep.ID {
+		t.Fatalf("expected only alice's tx to remain, got %v", got)
+	}
+	if err := mp.CheckInvariants(); err != nil {
+		t.Fatalf("invariants: %v", err)
+	}
+
+	removed := 0
+	for len(events) > 0 {
+		if ev := <-events; ev.Type == TxRemoved {
+			removed++
+		}
+	}
+	if removed != 3 {
+		t.Fatalf("expected 3 TxRemoved events, got %d", removed)
+	}
+}
@@ -0,0 +1,123 @@
+package mempoor
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DumpVersion is the envelope version written by MarshalJSON and
+// GobEncode. Bump it on any incompatible change to Dump or Tx encoding.
+const DumpVersion = 1
+
+// ErrUnsupportedDump is returned when decoding a dump written by a newer
+// format version than this build understands.
+var ErrUnsupportedDump = errors.New("mempool: unsupported dump version")
+
+// Dump is the canonical, versioned serialization of mempool state.
+//
+// Canonical form:
+//   - Txs are sorted by ID, so equal pools produce byte-identical dumps
+//     regardless of priority policy or insertion history.
+//   - Times are normalized to UTC.
+//   - Pending and scheduled txs are included; orphans and reservations
+//     are not.
+type Dump struct {
+	Version int   `json:"version"`
+	Txs     []*Tx `json:"txs"`
+}
+
+// dump builds the canonical Dump of the pending pool.
+func (m *mempool) dump() Dump {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	txs := make([]*Tx, 0, len(m.table)+len(m.schedule))
+	add := func(tx *Tx) {
+		cp := *tx
+		cp.CreatedAt = cp.CreatedAt.UTC()
+		cp.Timestamp = cp.Timestamp.UTC()
+		if !cp.NotBefore.IsZero() {
+			cp.NotBefore = cp.NotBefore.UTC()
+		}
+		txs = append(txs, &cp)
+	}
+	for _, rec := range m.table {
+		add(rec.tx)
+	}
+	for _, s := range m.schedule {
+		add(s.tx)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].ID < txs[j].ID })
+
+	return Dump{Version: DumpVersion, Txs: txs}
+}
+
+// MarshalJSON encodes the pool as a canonical Dump.
+func (m *mempool) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.dump())
+}
+
+// UnmarshalJSON replaces the pool with a dump from MarshalJSON (any
+// version up to DumpVersion, including the bare tx array written before
+// dumps were versioned). Same semantics as Restore.
+func (m *mempool) UnmarshalJSON(raw []byte) error {
+	txs, err := DecodeDumpJSON(raw)
+	if err != nil {
+		return err
+	}
+	return m.Restore(txs)
+}
+
+// GobEncode encodes the pool as a canonical Dump in gob.
+func (m *mempool) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m.dump()); err != nil {
+		return nil, fmt.Errorf("mempool: gob encode: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the pool with a dump from GobEncode.
+func (m *mempool) GobDecode(raw []byte) error {
+	var d Dump
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&d); err != nil {
+		return fmt.Errorf("mempool: gob decode: %w", err)
+	}
+	if err := checkDumpVersion(d.Version); err != nil {
+		return err
+	}
+	return m.Restore(d.Txs)
+}
+
+// DecodeDumpJSON extracts the txs from a JSON dump without touching any
+// pool, for tools that diff or audit dumps. A bare JSON array is read
+// as the unversioned (version 0) format.
+func DecodeDumpJSON(raw []byte) ([]*Tx, error) {
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		var txs []*Tx
+		if err := json.Unmarshal(trimmed, &txs); err != nil {
+			return nil, fmt.Errorf("mempool: decode dump: %w", err)
+		}
+		return txs, nil
+	}
+
+	var d Dump
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return nil, fmt.Errorf("mempool: decode dump: %w", err)
+	}
+	if err := checkDumpVersion(d.Version); err != nil {
+		return nil, err
+	}
+	return d.Txs, nil
+}
+
+func checkDumpVersion(v int) error {
+	if v < 1 || v > DumpVersion {
+		return fmt.Errorf("%w: %d (supported: 1..%d)", ErrUnsupportedDump, v, DumpVersion)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package mempoor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMarshalJSONIsCanonical(t *testing.T) {
+	a := newTx("alice", 10, 10)
+	b := newTx("bob", 20, 10)
+	b.Lane = LaneUrgent
+
+	mp1 := NewMempool(MempoolConfig{})
+	_, _ = mp1.Add(a)
+	_, _ = mp1.Add(b)
+
+	mp2 := NewMempool(MempoolConfig{Priority: OldestFirst})
+	_, _ = mp2.Add(b)
+	_, _ = mp2.Add(a)
+
+	raw1, err := mp1.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	raw2, _ := mp2.MarshalJSON()
+	if !bytes.Equal(raw1, raw2) {
+		t.Fatalf("equal pools must produce identical dumps:\n%s\n%s", raw1, raw2)
+	}
+
+	var d Dump
+	if err := json.Unmarshal(raw1, &d); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if d.Version != DumpVersion || len(d.Txs) != 2 || d.Txs[0].ID > d.Txs[1].ID {
+		t.Fatalf("unexpected dump: %+v", d)
+	}
+
+	mp3 := NewMempool(MempoolConfig{})
+	if err := mp3.UnmarshalJSON(raw1); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	got, err := mp3.Get(b.ID)
+	if err != nil || got.Lane != LaneUrgent || !got.Timestamp.Equal(b.Timestamp) {
+		t.Fatalf("round trip lost fields: %+v err=%v", got, err)
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	mp := NewMempool(MempoolConfig{})
+	tx := newTx("alice", 10, 10)
+	tx.Lane = LaneLow
+	_, _ = mp.Add(tx)
+
+	raw, err := mp.GobEncode()
+	if err != nil {
+		t.Fatalf("GobEncode: %v", err)
+	}
+
+	mp2 := NewMempool(MempoolConfig{})
+	if err := mp2.GobDecode(raw); err != nil {
+		t.Fatalf("GobDecode: %v", err)
+	}
+	got, err := mp2.Get(tx.ID)
+	if err != nil || got.Lane != LaneLow || got.Fee != 10 {
+		t.Fatalf("gob round trip lost fields: %+v err=%v", got, err)
+	}
+}
+
+func TestDecodeDumpJSONVersions(t *testing.T) {
+	legacy, _ := json.Marshal([]*Tx{newTx("alice", 10, 10)})
+	txs, err := DecodeDumpJSON(legacy)
+	if err != nil || len(txs) != 1 {
+		t.Fatalf("expected legacy array accepted, got %v err=%v", txs, err)
+	}
+
+	future := []byte(`{"version": 99, "txs": []}`)
+	if _, err := DecodeDumpJSON(future); !errors.Is(err, ErrUnsupportedDump) {
+		t.Fatalf("expected ErrUnsupportedDump, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package mempoor
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// DefaultShutdownTimeout is how long shutdown waits for in-flight RPCs
+// when NodeConfig.ShutdownTimeout is 0.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// Shutdown semantics (the node's ctx ends):
+//   - The HTTP server stops accepting requests and waits for in-flight
+//     RPCs, up to ShutdownTimeout, then closes whatever is left.
+//   - The block loop finishes the block it is producing, if any, and
+//     stops.
+//   - With DrainOnShutdown, one final block is built from whatever is
+//     pending, including txs the in-flight RPCs added, under the usual
+//     limits and BuildTimeout. Nothing pending means no block.
+//   - The mempool is checkpointed, and the journal, replay log, and
+//     block store are closed, before RunNode returns.
+
+// shutdown runs the steps of "Shutdown semantics" once ctx has ended;
+// loopDone is closed when the block loop has returned.
+func (n *Node) shutdown(server *http.Server, loopDone <-chan struct{}) error {
+	timeout := n.cfg.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	sctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := server.Shutdown(sctx); err != nil {
+		n.log.rpc.Error("shutdown failed", "err", err)
+		_ = server.Close()
+	}
+
+	<-loopDone
+
+	if n.cfg.DrainOnShutdown {
+		block, err := n.produceNext(context.Background())
+		switch {
+		case err == ErrEmptyBlock:
+			n.log.builder.Info("drain found nothing pending")
+		case err != nil:
+			n.log.builder.Error("drain failed", "err", err)
+		default:
+			n.log.logBlock("drain block produced", block)
+		}
+	}
+
+	return n.checkpoint()
+}
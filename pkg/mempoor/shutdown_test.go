@@ -0,0 +1,39 @@
+package mempoor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestShutdownDrainsPendingTxs(t *testing.T) {
+	for _, drain := range []bool{false, true} {
+		n := NewNode(NodeConfig{
+			ListenAddr:      "127.0.0.1:0",
+			BlockInterval:   time.Hour,
+			MaxTxPerBlock:   10,
+			DrainOnShutdown: drain,
+		})
+		tx := newTx("alice", 10, 10)
+		if _, err := n.mempool.Add(tx); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := n.run(ctx); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+
+		want := 1 // genesis
+		if drain {
+			want = 2
+		}
+		if len(n.blocks) != want {
+			t.Fatalf("drain=%v: expected %d blocks, got %d", drain, want, len(n.blocks))
+		}
+		if _, err := n.mempool.Get(tx.ID); (err == nil) == drain {
+			t.Fatalf("drain=%v: expected the tx pending only without a drain", drain)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package mempoor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNodeSignsBlocks(t *testing.T) {
+	n := NewNode(NodeConfig{DataDir: t.TempDir(), MaxTxPerBlock: 10})
+	closeChain, err := n.openChain()
+	if err != nil {
+		t.Fatalf("open chain: %v", err)
+	}
+	defer closeChain()
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+
+	if _, err := n.mempool.Add(newTx("alice", 10, 10)); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	prevHash, height := n.chainTip()
+	block, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second))
+	if err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	if err := VerifyBlock(block); err != nil {
+		t.Fatalf("expected a valid signature, got %v", err)
+	}
+	if !block.Signer.Equal(n.key.Public()) {
+		t.Fatalf("expected the node's key as signer")
+	}
+	stored, err := n.store.Block(1)
+	if err != nil {
+		t.Fatalf("stored block: %v", err)
+	}
+	if err := VerifyBlock(stored); err != nil {
+		t.Fatalf("expected the stored block to keep its signature, got %v", err)
+	}
+
+	tampered := *block
+	tampered.Header.Proposer = "mallory"
+	if err := VerifyBlock(&tampered); !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("expected ErrBadSignature, got %v", err)
+	}
+	if err := Validate(&tampered, n.blocks[0], 0); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected ErrInvalidBlock, got %v", err)
+	}
+	if err := VerifyBlock(n.blocks[0]); !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("expected the genesis block unsigned, got %v", err)
+	}
+}
+
+func TestLoadOrCreateKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "node.key")
+	key, err := LoadOrCreateKey(path)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	again, err := LoadOrCreateKey(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !key.Equal(again) {
+		t.Fatalf("expected the same key on reload")
+	}
+}
@@ -0,0 +1,195 @@
+package mempoor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sseHistory is how many recent events /events keeps for clients
+// resuming with Last-Event-ID.
+const sseHistory = 4096
+
+// sseKeepAlive is how often an idle /events stream gets a comment, so
+// proxies do not time it out.
+const sseKeepAlive = 15 * time.Second
+
+// Server-sent events semantics (GET /events):
+//   - The response is a text/event-stream. Each message has an "id", an
+//     "event" naming its topic (newBlocks, pendingTxs, droppedTxs; see
+//     "WebSocket semantics" for the payloads), and the JSON payload as
+//     "data". ?topics=newBlocks,droppedTxs limits the stream to those
+//     topics; the default is all of them.
+//   - IDs count every event the node has recorded, across topics,
+//     starting at 1; the events of each pool, and of the chain, are
+//     numbered in the order they happened. The node keeps the last
+//     sseHistory of them.
+//   - A client that reconnects with a Last-Event-ID header (or
+//     ?lastEventId=) gets every kept event after that ID first, then
+//     the live stream. If events after it were already discarded, a
+//     "gap" event comes first: {"after": <Last-Event-ID>, "oldest":
+//     <first ID kept>}. Without one, the stream starts from now.
+//   - Events are recorded in memory, from when the first stream opens.
+//     After a restart numbering starts over; a Last-Event-ID beyond the
+//     newest event gets a gap.
+//   - Idle streams get a comment every sseKeepAlive. Shutdown ends every
+//     stream.
+
+// sseEvent is one recorded event.
+type sseEvent struct {
+	ID    uint64
+	Topic string
+	Data  []byte // JSON
+}
+
+// gapEvent is the payload of a "gap" event.
+type gapEvent struct {
+	After  uint64 `json:"after"`
+	Oldest uint64 `json:"oldest"`
+}
+
+// eventLog records the node's events for /events, numbering them.
+type eventLog struct {
+	mu     sync.Mutex
+	last   uint64     // ID of the newest event; 0 = none yet
+	recent []sseEvent // oldest first, at most sseHistory
+	wake   chan struct{}
+}
+
+// eventLog returns the node's event log, subscribing it to every topic
+// the first time.
+func (n *Node) eventLog() *eventLog {
+	n.eventsOnce.Do(func() {
+		l := &eventLog{wake: make(chan struct{})}
+		// The log lives as long as the node: never cancelled.
+		_, _ = n.subscribeTopics([]string{TopicNewBlocks, TopicPendingTxs, TopicDroppedTxs}, l.record)
+		n.events = l
+	})
+	return n.events
+}
+
+// record numbers payload and appends it, waking waiting streams.
+func (l *eventLog) record(topic string, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.last++
+	l.recent = append(l.recent, sseEvent{ID: l.last, Topic: topic, Data: data})
+	if len(l.recent) > sseHistory {
+		l.recent = append(l.recent[:0:0], l.recent[len(l.recent)-sseHistory:]...)
+	}
+	close(l.wake)
+	l.wake = make(chan struct{})
+}
+
+// since returns the kept events after id, and a channel closed when the
+// next event is recorded. If events after id were already discarded, or
+// id is beyond the newest, gapFrom is the ID events start from instead
+// (0 otherwise).
+func (l *eventLog) since(id uint64) (events []sseEvent, gapFrom uint64, wake <-chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	oldest := l.last + 1
+	if len(l.recent) > 0 {
+		oldest = l.recent[0].ID
+	}
+	if id > l.last || id+1 < oldest {
+		return l.recent, oldest, l.wake
+	}
+	return l.recent[id+1-oldest:], 0, l.wake
+}
+
+// lastID returns the ID of the newest event.
+func (l *eventLog) lastID() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.last
+}
+
+// handleEvents streams the node's events; see "Server-sent events
+// semantics".
+func (n *Node) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	topics := map[string]bool{TopicNewBlocks: true, TopicPendingTxs: true, TopicDroppedTxs: true}
+	if raw := r.URL.Query().Get("topics"); raw != "" {
+		want := make(map[string]bool)
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if !topics[t] {
+				http.Error(w, fmt.Sprintf("unknown topic %q", t), http.StatusBadRequest)
+				return
+			}
+			want[t] = true
+		}
+		topics = want
+	}
+
+	log := n.eventLog()
+	cursor := log.lastID()
+	resume := r.Header.Get("Last-Event-ID")
+	if resume == "" {
+		resume = r.URL.Query().Get("lastEventId")
+	}
+	if resume != "" {
+		id, err := strconv.ParseUint(resume, 10, 64)
+		if err != nil {
+			http.Error(w, "bad Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+		cursor = id
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		events, gapFrom, wake := log.since(cursor)
+		if gapFrom != 0 {
+			data, _ := json.Marshal(gapEvent{After: cursor, Oldest: gapFrom})
+			fmt.Fprintf(w, "event: gap\ndata: %s\n\n", data)
+			cursor = gapFrom - 1
+		}
+		for _, ev := range events {
+			if topics[ev.Topic] {
+				fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Topic, ev.Data)
+			}
+			cursor = ev.ID
+		}
+		if gapFrom != 0 || len(events) > 0 {
+			flusher.Flush()
+		}
+
+		select {
+		case <-wake:
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-n.streams.closing():
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package mempoor
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseMessage is one parsed server-sent event.
+type sseMessage struct {
+	id, event, data string
+}
+
+// openEvents opens /events with lastID (if any) and returns a func
+// reading the next message.
+func openEvents(t *testing.T, url, lastID string) func() sseMessage {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			lines <- sc.Text()
+		}
+	}()
+	return func() sseMessage {
+		t.Helper()
+		var msg sseMessage
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					t.Fatalf("stream ended")
+				}
+				switch {
+				case line == "" && msg.event != "":
+					return msg
+				case strings.HasPrefix(line, "id: "):
+					msg.id = strings.TrimPrefix(line, "id: ")
+				case strings.HasPrefix(line, "event: "):
+					msg.event = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					msg.data = strings.TrimPrefix(line, "data: ")
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("no event")
+			}
+		}
+	}
+}
+
+func TestEventsStreamAndResume(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	srv := httptest.NewServer(http.HandlerFunc(n.handleEvents))
+	t.Cleanup(srv.Close) // after the streams' bodies are closed
+
+	next := openEvents(t, srv.URL, "")
+	first := newTx("alice", 10, 5)
+	_, _ = n.mempool.Add(first)
+	if msg := next(); msg.id != "1" || msg.event != TopicPendingTxs || !strings.Contains(msg.data, string(first.ID)) {
+		t.Fatalf("expected event 1 for tx %s, got %+v", first.ID, msg)
+	}
+
+	// Two more while the client is away, then it resumes after 1.
+	_, _ = n.mempool.Add(newTx("bob", 10, 5))
+	_ = n.mempool.Remove(first.ID)
+	for deadline := time.Now().Add(5 * time.Second); n.eventLog().lastID() < 3; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 3 events recorded, got %d", n.eventLog().lastID())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	next = openEvents(t, srv.URL, "1")
+	if msg := next(); msg.id != "2" || msg.event != TopicPendingTxs {
+		t.Fatalf("expected event 2 replayed, got %+v", msg)
+	}
+	if msg := next(); msg.id != "3" || msg.event != TopicDroppedTxs || !strings.Contains(msg.data, `"removed"`) {
+		t.Fatalf("expected event 3 replayed, got %+v", msg)
+	}
+
+	// Only dropped txs on this one.
+	next = openEvents(t, srv.URL+"?topics=droppedTxs", "")
+	late := newTx("carol", 10, 5)
+	_, _ = n.mempool.Add(late)
+	_ = n.mempool.Remove(late.ID)
+	if msg := next(); msg.id != "5" || msg.event != TopicDroppedTxs {
+		t.Fatalf("expected only event 5, got %+v", msg)
+	}
+
+	// A Last-Event-ID from before a restart.
+	next = openEvents(t, srv.URL, "99")
+	if msg := next(); msg.event != "gap" || msg.data != `{"after":99,"oldest":1}` {
+		t.Fatalf("expected a gap, got %+v", msg)
+	}
+	if msg := next(); msg.id != "1" {
+		t.Fatalf("expected the replay to restart at 1, got %+v", msg)
+	}
+
+	resp, _ := http.Get(srv.URL + "?topics=nope")
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown topic, got %d", resp.StatusCode)
+	}
+	resp.Body.Close()
+}
+
+func TestEventLogDiscardsOldest(t *testing.T) {
+	l := &eventLog{wake: make(chan struct{})}
+	for range sseHistory + 5 {
+		l.record(TopicNewBlocks, struct{}{})
+	}
+	events, gapFrom, _ := l.since(2)
+	if gapFrom != 6 || len(events) != sseHistory || events[0].ID != 6 {
+		t.Fatalf("expected a gap to 6 and %d events, got %d from %d", sseHistory, len(events), gapFrom)
+	}
+	events, gapFrom, _ = l.since(sseHistory + 3)
+	if gapFrom != 0 || len(events) != 2 || events[1].ID != sseHistory+5 {
+		t.Fatalf("expected the last 2 events, got %d (gap %d)", len(events), gapFrom)
+	}
+
+	wake := func() <-chan struct{} { _, _, w := l.since(0); return w }()
+	l.record(TopicNewBlocks, struct{}{})
+	select {
+	case <-wake:
+	default:
+		t.Fatalf("expected waiting streams woken")
+	}
+}
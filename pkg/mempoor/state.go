@@ -0,0 +1,327 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"sync"
+)
+
+var (
+	// ErrInsufficientFunds is returned by Add and Update for a tx its
+	// sender cannot pay for, and for blocks that would overdraw an
+	// account.
+	ErrInsufficientFunds = errors.New("mempool: insufficient funds")
+
+	// ErrNonceUsed is returned by Add for a tx whose nonce its sender
+	// already used in an included tx, and for blocks replaying one.
+	ErrNonceUsed = errors.New("mempool: nonce already used")
+)
+
+// BalanceSource reports account balances to the mempool's admission
+// check; see MempoolConfig.Balances.
+type BalanceSource interface {
+	Balance(addr string) uint64
+}
+
+// NonceSource reports the lowest unused nonce per sender to the
+// mempool's admission check; see MempoolConfig.Nonces.
+type NonceSource interface {
+	NextNonce(addr string) uint64
+}
+
+// State tracks account balances and nonces along the chain.
+//
+// Account semantics (NodeConfig.Genesis.Balances, account.get):
+//   - Accounts are tracked once the genesis block allocates balances;
+//     without any, the node checks no funds at all. An address the
+//     genesis does not name starts at 0.
+//   - A tx costs its sender Fee + Amount at most: Fee is what it offers
+//     (MaxFee for a dynamic-fee tx), and Amount moves to Recipient.
+//   - Admission: Add and Update reject a tx with ErrInsufficientFunds
+//     unless the sender's balance covers its cost plus that of every
+//     other pending tx from the sender.
+//   - Selection skips txs whose sender could not pay for them after the
+//     txs picked before them in the block; they stay pending.
+//   - Every block is checked against the state of its parent before it
+//     is published and applied once stored: each tx debits its sender
+//     the base fee burn, its effective tip, and Amount, and credits
+//     Recipient with Amount, in block order. A block that would overdraw
+//     an account is invalid. A reorg recomputes the state from genesis.
+//
+// Replay protection semantics (account nonces):
+//   - Each account's next nonce is one past the highest nonce it used in
+//     an included tx, 0 before any. Nonces may skip ahead but never go
+//     back: a tx below its sender's next nonce is a replay.
+//   - Add rejects a replay with ErrNonceUsed, and a block holding one,
+//     or two txs of a sender with the same nonce, is invalid. Selection
+//     skips such txs, and after each block the node drops pending txs
+//     its senders' new nonces have made replays, as TxRemoved.
+//
+// NOTE: Admission counts the txs pending in the same pool only, not
+// scheduled or orphaned ones nor other pools', and balances can shrink
+// under pending txs after a reorg; selection then leaves such txs
+// waiting for funds in the pool.
+type State struct {
+	mu       sync.RWMutex
+	genesis  map[string]uint64
+	balances map[string]uint64
+	nonces   map[string]uint64 // next unused nonce per sender
+}
+
+// NewState returns a State holding the genesis balances.
+func NewState(genesis map[string]uint64) *State {
+	s := &State{genesis: make(map[string]uint64, len(genesis))}
+	for addr, bal := range genesis {
+		s.genesis[addr] = bal
+	}
+	s.balances = s.copyGenesis()
+	s.nonces = make(map[string]uint64)
+	return s
+}
+
+func (s *State) copyGenesis() map[string]uint64 {
+	out := make(map[string]uint64, len(s.genesis))
+	for addr, bal := range s.genesis {
+		out[addr] = bal
+	}
+	return out
+}
+
+// Balance returns addr's current balance.
+func (s *State) Balance(addr string) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.balances[addr]
+}
+
+// NextNonce returns the lowest nonce addr has not used yet.
+func (s *State) NextNonce(addr string) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.nonces[addr]
+}
+
+// Check reports whether b applies to the current state under baseFee.
+func (s *State) Check(b *Block, baseFee uint64) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, err := s.delta(s.balances, s.nonces, b, baseFee)
+	return err
+}
+
+// Apply applies b under baseFee, or nothing if b is invalid on the
+// current state.
+func (s *State) Apply(b *Block, baseFee uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, err := s.delta(s.balances, s.nonces, b, baseFee)
+	if err != nil {
+		return err
+	}
+	d.merge(s.balances, s.nonces)
+	return nil
+}
+
+// Rebuild resets the state to genesis and applies chain, which must
+// start after the genesis block. Nothing changes if a block fails.
+func (s *State) Rebuild(chain []*Block, baseFee uint64) error {
+	return s.rebuildFrom(s.copyGenesis(), make(map[string]uint64), chain, baseFee)
+}
+
+// snapshot returns copies of the current balances and next nonces.
+func (s *State) snapshot() (balances, nonces map[string]uint64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.balances), maps.Clone(s.nonces)
+}
+
+// rebuildFrom is Rebuild starting from balances and nonces, a snapshot
+// taken at the block before chain. It takes ownership of both maps.
+func (s *State) rebuildFrom(balances, nonces map[string]uint64, chain []*Block, baseFee uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, b := range chain {
+		d, err := s.delta(balances, nonces, b, baseFee)
+		if err != nil {
+			return err
+		}
+		d.merge(balances, nonces)
+	}
+	s.balances, s.nonces = balances, nonces
+	return nil
+}
+
+// stateDelta holds the balances and next nonces a block changes.
+type stateDelta struct {
+	balances map[string]uint64
+	nonces   map[string]uint64
+}
+
+func (d stateDelta) merge(balances, nonces map[string]uint64) {
+	for addr, bal := range d.balances {
+		balances[addr] = bal
+	}
+	for addr, n := range d.nonces {
+		nonces[addr] = n
+	}
+}
+
+// delta returns what b changes, starting from balances and nonces, which
+// it does not modify. Caller must hold the lock.
+func (s *State) delta(balances, nonces map[string]uint64, b *Block, baseFee uint64) (stateDelta, error) {
+	d := stateDelta{balances: make(map[string]uint64), nonces: make(map[string]uint64)}
+	balance := func(addr string) uint64 {
+		if bal, ok := d.balances[addr]; ok {
+			return bal
+		}
+		return balances[addr]
+	}
+	for _, tx := range b.Transactions {
+		next, ok := d.nonces[tx.Sender]
+		if !ok {
+			next = nonces[tx.Sender]
+		}
+		if tx.Nonce < next {
+			return d, fmt.Errorf("%w: tx %s in block %d reuses nonce %d of %s",
+				ErrNonceUsed, tx.ID, b.Header.Height, tx.Nonce, tx.Sender)
+		}
+		d.nonces[tx.Sender] = addCapped(tx.Nonce, 1)
+
+		tip, _ := EffectiveTip(tx, baseFee)
+		cost := addCapped(addCapped(burnFor(baseFee, tx.Gas), tip), tx.Amount)
+		bal := balance(tx.Sender)
+		if bal < cost {
+			return d, fmt.Errorf("%w: %s has %d, tx %s in block %d costs %d",
+				ErrInsufficientFunds, tx.Sender, bal, tx.ID, b.Header.Height, cost)
+		}
+		d.balances[tx.Sender] = bal - cost
+		if tx.Amount > 0 {
+			d.balances[tx.Recipient] = addCapped(balance(tx.Recipient), tx.Amount)
+		}
+	}
+	return d, nil
+}
+
+// txCost is the most tx can cost its sender: Fee + Amount.
+func txCost(tx *Tx) uint64 {
+	return addCapped(tx.Fee, tx.Amount)
+}
+
+// addCapped returns a + b, saturating on overflow.
+func addCapped(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+// selectHook is a BeforeSelectHook that skips replays and txs whose
+// sender cannot pay for them after the txs offered before them in the
+// same block. A tx offered but not selected still counts, so the filter
+// errs towards skipping.
+func (s *State) selectHook(c *BlockConstraints) error {
+	var mu sync.Mutex
+	spent := make(map[string]uint64)
+	nonces := make(map[string]uint64)
+	return FilterHook(func(tx *Tx) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		next, ok := nonces[tx.Sender]
+		if !ok {
+			next = s.NextNonce(tx.Sender)
+		}
+		total := addCapped(spent[tx.Sender], txCost(tx))
+		if tx.Nonce < next || total > s.Balance(tx.Sender) {
+			return false
+		}
+		spent[tx.Sender] = total
+		nonces[tx.Sender] = addCapped(tx.Nonce, 1)
+		return true
+	})(c)
+}
+
+// checkNonce rejects tx if its sender already used its nonce. Caller
+// must hold the lock.
+func (m *mempool) checkNonce(tx *Tx) error {
+	if m.cfg.Nonces == nil {
+		return nil
+	}
+	if next := m.cfg.Nonces.NextNonce(tx.Sender); tx.Nonce < next {
+		return fmt.Errorf("%w: %s is at nonce %d, tx has %d", ErrNonceUsed, tx.Sender, next, tx.Nonce)
+	}
+	return nil
+}
+
+// checkFunds rejects tx unless its sender's balance covers it and the
+// sender's other pending txs; replaced, if not nil, is the version tx
+// replaces. Caller must hold the lock.
+func (m *mempool) checkFunds(tx *Tx, replaced *txRecord) error {
+	if m.cfg.Balances == nil {
+		return nil
+	}
+	total := txCost(tx)
+	for _, rec := range m.senders[tx.Sender] {
+		if rec != replaced {
+			total = addCapped(total, txCost(rec.tx))
+		}
+	}
+	if bal := m.cfg.Balances.Balance(tx.Sender); total > bal {
+		return fmt.Errorf("%w: %s has %d, pending txs need %d", ErrInsufficientFunds, tx.Sender, bal, total)
+	}
+	return nil
+}
+
+// rebuildState recomputes the node's state from its chain, after the
+// genesis block.
+func (n *Node) rebuildState() error {
+	if n.state == nil {
+		return nil
+	}
+	n.blocksMu.RLock()
+	chain := n.blocks
+	n.blocksMu.RUnlock()
+
+	if above, balances, nonces, ok := n.checkpointedState(chain); ok {
+		if err := n.state.rebuildFrom(balances, nonces, above, n.cfg.BaseFee); err != nil {
+			return fmt.Errorf("rebuild state: %w", err)
+		}
+		return nil
+	}
+	if len(chain) > 0 {
+		chain = chain[1:]
+	}
+	if err := n.state.Rebuild(chain, n.cfg.BaseFee); err != nil {
+		return fmt.Errorf("rebuild state: %w", err)
+	}
+	return nil
+}
+
+// dropReplays removes the pending txs that b's senders can no longer use
+// because b took their nonces; see "Replay protection semantics".
+func (n *Node) dropReplays(b *Block) {
+	if n.state == nil {
+		return
+	}
+	senders := make(map[string]bool)
+	for _, tx := range b.Transactions {
+		senders[tx.Sender] = true
+	}
+	for sender := range senders {
+		next := n.state.NextNonce(sender)
+		for _, p := range n.pools {
+			for _, tx := range p.mp.ListFilter(TxFilter{Sender: sender}) {
+				if tx.Nonce < next {
+					_ = p.mp.Remove(tx.ID) // may have gone with a dependency
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package mempoor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdmissionChecksFunds(t *testing.T) {
+	mp := NewMempool(MempoolConfig{Balances: NewState(map[string]uint64{"alice": 100})})
+
+	first := newTx("alice", 60, 10)
+	if _, err := mp.Add(first); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	if _, err := mp.Add(newTx("alice", 50, 10)); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected pending txs to count against the balance, got %v", err)
+	}
+	if _, err := mp.Add(newTx("bob", 1, 10)); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected an unfunded sender rejected, got %v", err)
+	}
+
+	bump := *first
+	bump.Fee = 100
+	if err := mp.Update(&bump); err != nil {
+		t.Fatalf("a replacement only counts once: %v", err)
+	}
+	over := bump
+	over.Fee = 150
+	if err := mp.Update(&over); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+}
+
+func TestNodeAppliesBlocksToState(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10, Genesis: GenesisConfig{Balances: map[string]uint64{"alice": 100}}})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+
+	pay := newTx("alice", 10, 10)
+	pay.Amount = 30
+	if _, err := n.mempool.Add(rehash(pay)); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	prevHash, height := n.chainTip()
+	if _, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second)); err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	if a, b := n.state.Balance("alice"), n.state.Balance("bob"); a != 60 || b != 30 {
+		t.Fatalf("expected alice 60 and bob 30, got %d and %d", a, b)
+	}
+
+	var resp struct {
+		Result accountResult `json:"result"`
+	}
+	if err := json.NewDecoder(callRPC(n, `{"method":"account.get","params":{"address":"bob"}}`).Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Result.Balance != 30 {
+		t.Fatalf("expected account.get to report 30, got %+v", resp.Result)
+	}
+
+	// A competing block 1 that overdraws alice is invalid.
+	greedy := newTx("alice", 10, 10)
+	greedy.Amount = 95
+	if _, err := n.reorgBlock(forkBlock(n.blocks[0], rehash(greedy))); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected ErrInvalidBlock, got %v", err)
+	}
+
+	// One that pays more takes over, and the state follows it.
+	rich := newTx("alice", 50, 10)
+	if _, err := n.reorgBlock(forkBlock(n.blocks[0], rich)); err != nil {
+		t.Fatalf("reorg: %v", err)
+	}
+	if a, b := n.state.Balance("alice"), n.state.Balance("bob"); a != 50 || b != 0 {
+		t.Fatalf("expected alice 50 and bob 0 after the reorg, got %d and %d", a, b)
+	}
+}
+
+func TestNonceReplayProtection(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10, Genesis: GenesisConfig{Balances: map[string]uint64{"alice": 1000}}})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+
+	first := NewUnsignedTx("alice", "bob", "first", 0, 10, 10)
+	if _, err := n.mempool.Add(first); err != nil {
+		t.Fatalf("unexpected Add error: %v", err)
+	}
+	prevHash, height := n.chainTip()
+	if _, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second)); err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	if next := n.state.NextNonce("alice"); next != 1 {
+		t.Fatalf("expected next nonce 1, got %d", next)
+	}
+	if _, err := n.mempool.Add(NewUnsignedTx("alice", "bob", "again", 0, 10, 10)); !errors.Is(err, ErrNonceUsed) {
+		t.Fatalf("expected ErrNonceUsed, got %v", err)
+	}
+
+	// A block reusing nonce 0 on top of the first is invalid.
+	replay := forkBlock(n.blocks[1], NewUnsignedTx("alice", "bob", "again", 0, 10, 10))
+	if err := n.state.Check(replay, n.cfg.BaseFee); !errors.Is(err, ErrNonceUsed) {
+		t.Fatalf("expected ErrNonceUsed, got %v", err)
+	}
+	// So is one using a nonce twice.
+	twice := forkBlock(n.blocks[1],
+		NewUnsignedTx("alice", "bob", "a", 3, 10, 10),
+		NewUnsignedTx("alice", "bob", "b", 3, 10, 10))
+	if err := n.state.Check(twice, n.cfg.BaseFee); !errors.Is(err, ErrNonceUsed) {
+		t.Fatalf("expected ErrNonceUsed, got %v", err)
+	}
+}
+
+func TestNodeDropsReplays(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 1, Genesis: GenesisConfig{Balances: map[string]uint64{"alice": 1000}}})
+	if err := n.initGenesis(); err != nil {
+		t.Fatalf("init genesis: %v", err)
+	}
+
+	// Two txs share nonce 0; the block takes the richer one.
+	rich := NewUnsignedTx("alice", "bob", "rich", 0, 50, 10)
+	poor := NewUnsignedTx("alice", "bob", "poor", 0, 10, 10)
+	for _, tx := range []*Tx{rich, poor} {
+		if _, err := n.mempool.Add(tx); err != nil {
+			t.Fatalf("unexpected Add error: %v", err)
+		}
+	}
+	prevHash, height := n.chainTip()
+	block, err := n.produceBlock(context.Background(), prevHash, height, GenesisTime.Add(time.Second))
+	if err != nil {
+		t.Fatalf("produce: %v", err)
+	}
+	if len(block.Transactions) != 1 || block.Transactions[0].ID != rich.ID {
+		t.Fatalf("expected the block to hold the rich tx, got %+v", block.Transactions)
+	}
+	if _, err := n.mempool.Get(poor.ID); err == nil {
+		t.Fatalf("expected the replayed tx dropped from the pool")
+	}
+}
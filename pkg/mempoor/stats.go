@@ -0,0 +1,86 @@
+package mempoor
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultFeeBuckets are the histogram upper bounds used when
+// MempoolConfig.FeeBuckets is empty.
+var DefaultFeeBuckets = []uint64{1, 10, 100, 1_000, 10_000}
+
+// FeeBucket counts pending txs with fee in (previous UpperBound, UpperBound].
+// The last bucket of a histogram has UpperBound == math.MaxUint64 and
+// catches everything above the configured bounds.
+type FeeBucket struct {
+	UpperBound uint64 `json:"upperBound"`
+	Count      int    `json:"count"`
+}
+
+// MempoolStats summarizes the pending pool for fee estimation and monitoring.
+// Fee fields are zero when the pool is empty.
+type MempoolStats struct {
+	Count     int    `json:"count"`
+	TotalGas  uint64 `json:"totalGas"`
+	TotalFee  uint64 `json:"totalFee"`
+	MinFee    uint64 `json:"minFee"`
+	MaxFee    uint64 `json:"maxFee"`
+	MedianFee uint64 `json:"medianFee"`
+
+	FeeHistogram []FeeBucket `json:"feeHistogram"`
+}
+
+// Stats computes pool statistics under a read lock.
+//
+// Only fees are gathered (one uint64 per tx) — no Tx copies are made.
+// PERF: The median sorts the fee slice, O(n log n). A running
+// order-statistics structure would make this O(1) if Stats becomes hot.
+func (m *mempool) Stats() MempoolStats {
+	bounds := m.cfg.FeeBuckets
+	if len(bounds) == 0 {
+		bounds = DefaultFeeBuckets
+	}
+
+	st := MempoolStats{
+		FeeHistogram: make([]FeeBucket, len(bounds)+1),
+	}
+	for i, b := range bounds {
+		st.FeeHistogram[i].UpperBound = b
+	}
+	st.FeeHistogram[len(bounds)].UpperBound = math.MaxUint64
+
+	m.mu.RLock()
+	fees := make([]uint64, 0, len(m.table))
+	for _, rec := range m.table {
+		tx := rec.tx
+		fees = append(fees, tx.Fee)
+		st.TotalGas += tx.Gas
+		st.TotalFee += tx.Fee
+	}
+	m.mu.RUnlock()
+
+	st.Count = len(fees)
+	if st.Count == 0 {
+		return st
+	}
+
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+
+	st.MinFee = fees[0]
+	st.MaxFee = fees[len(fees)-1]
+
+	mid := len(fees) / 2
+	if len(fees)%2 == 1 {
+		st.MedianFee = fees[mid]
+	} else {
+		lo, hi := fees[mid-1], fees[mid]
+		st.MedianFee = lo + (hi-lo)/2
+	}
+
+	for _, fee := range fees {
+		i := sort.Search(len(bounds), func(i int) bool { return fee <= bounds[i] })
+		st.FeeHistogram[i].Count++
+	}
+
+	return st
+}
@@ -0,0 +1,58 @@
+package mempoor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStatsEmptyPool(t *testing.T) {
+	mp := NewMempool(MempoolConfig{FeeBuckets: []uint64{10}})
+
+	st := mp.Stats()
+	if st.Count != 0 || st.TotalGas != 0 || st.MedianFee != 0 {
+		t.Fatalf("expected zero stats for empty pool, got %+v", st)
+	}
+	if len(st.FeeHistogram) != 2 {
+		t.Fatalf("expected configured bucket plus overflow, got %+v", st.FeeHistogram)
+	}
+}
+
+func TestStatsAggregatesAndHistogram(t *testing.T) {
+	mp := NewMempool(MempoolConfig{FeeBuckets: []uint64{10, 100}})
+
+	for _, fee := range []uint64{5, 10, 50, 200} {
+		_, _ = mp.Add(newTx("alice", fee, 7))
+	}
+
+	st := mp.Stats()
+
+	if st.Count != 4 {
+		t.Fatalf("expected count=4, got %d", st.Count)
+	}
+	if st.TotalGas != 28 {
+		t.Fatalf("expected totalGas=28, got %d", st.TotalGas)
+	}
+	if st.TotalFee != 265 {
+		t.Fatalf("expected totalFee=265, got %d", st.TotalFee)
+	}
+	if st.MinFee != 5 || st.MaxFee != 200 {
+		t.Fatalf("expected min=5 max=200, got %d %d", st.MinFee, st.MaxFee)
+	}
+	if st.MedianFee != 30 {
+		t.Fatalf("expected median=30, got %d", st.MedianFee)
+	}
+
+	want := []FeeBucket{
+		{UpperBound: 10, Count: 2},
+		{UpperBound: 100, Count: 1},
+		{UpperBound: math.MaxUint64, Count: 1},
+	}
+	if len(st.FeeHistogram) != len(want) {
+		t.Fatalf("unexpected histogram: %+v", st.FeeHistogram)
+	}
+	for i := range want {
+		if st.FeeHistogram[i] != want[i] {
+			t.Fatalf("bucket %d: expected %+v, got %+v", i, want[i], st.FeeHistogram[i])
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package mempoor
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultStatusCacheSize is how many txs the node remembers for
+// tx.status when NodeConfig.StatusCacheSize is 0.
+const DefaultStatusCacheSize = 10_000
+
+// TxState is where a tx is in its lifecycle, as seen by the node.
+type TxState int
+
+const (
+	TxStatePending   TxState = iota // in the mempool
+	TxStateSelected                 // taken for a block that is being stored
+	TxStateIncluded                 // in the block at TxStatus.Height
+	TxStateDropped                  // left the pool without a block; see TxStatus.Reason
+	TxStateScheduled                // waiting for its NotBefore time
+)
+
+func (s TxState) String() string {
+	switch s {
+	case TxStatePending:
+		return "pending"
+	case TxStateSelected:
+		return "selected"
+	case TxStateIncluded:
+		return "included"
+	case TxStateDropped:
+		return "dropped"
+	case TxStateScheduled:
+		return "scheduled"
+	default:
+		return "unknown"
+	}
+}
+
+func (s TxState) MarshalText() ([]byte, error) {
+	if s < TxStatePending || s > TxStateScheduled {
+		return nil, fmt.Errorf("mempool: unknown tx state %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// Reasons reported in TxStatus.Reason for TxStateDropped.
+const (
+	DropRemoved = "removed" // tx.remove or PurgeSender
+	DropPurged  = "purged"  // fee below the block MinFee
+	DropEvicted = "evicted" // capacity eviction or orphan expiry
+)
+
+// TxStatus is the last known lifecycle state of a tx.
+type TxStatus struct {
+	ID        TxID      `json:"id"`
+	State     TxState   `json:"state"`
+	Height    uint64    `json:"height,omitempty"` // set when State is TxStateIncluded
+	Reason    string    `json:"reason,omitempty"` // set when State is TxStateDropped
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// txTracker records the lifecycle of recently seen txs for tx.status.
+// It observes the mempool for pending/selected/dropped and is told about
+// inclusion by the block loop.
+//
+// Tracker semantics:
+//   - At most size txs are remembered; the least recently changed one
+//     is forgotten first.
+//   - A rolled-back or reinserted tx goes back to pending.
+//   - Orphans are not tracked until promoted into the pool.
+type txTracker struct {
+	NopObserver
+
+	mu    sync.Mutex
+	clock Clock
+	size  int
+	byID  map[TxID]*list.Element // of *TxStatus
+	order *list.List             // least recently changed at the front
+}
+
+func newTxTracker(size int, clock Clock) *txTracker {
+	if size <= 0 {
+		size = DefaultStatusCacheSize
+	}
+	return &txTracker{
+		clock: clock,
+		size:  size,
+		byID:  make(map[TxID]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (t *txTracker) OnAdd(tx *Tx)      { t.set(TxStatus{ID: tx.ID, State: TxStatePending}) }
+func (t *txTracker) OnUpdate(tx *Tx)   { t.set(TxStatus{ID: tx.ID, State: TxStatePending}) }
+func (t *txTracker) OnSelect(tx *Tx)   { t.set(TxStatus{ID: tx.ID, State: TxStateSelected}) }
+func (t *txTracker) OnSchedule(tx *Tx) { t.set(TxStatus{ID: tx.ID, State: TxStateScheduled}) }
+func (t *txTracker) OnRemove(tx *Tx)   { t.drop(tx, DropRemoved) }
+func (t *txTracker) OnPurge(tx *Tx)    { t.drop(tx, DropPurged) }
+func (t *txTracker) OnEvict(tx *Tx)    { t.drop(tx, DropEvicted) }
+
+func (t *txTracker) drop(tx *Tx, reason string) {
+	t.set(TxStatus{ID: tx.ID, State: TxStateDropped, Reason: reason})
+}
+
+// included marks every tx in b as included at its height.
+func (t *txTracker) included(b *Block) {
+	for _, tx := range b.Transactions {
+		t.set(TxStatus{ID: tx.ID, State: TxStateIncluded, Height: b.Header.Height})
+	}
+}
+
+func (t *txTracker) set(st TxStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	st.UpdatedAt = t.clock.Now()
+	if el, ok := t.byID[st.ID]; ok {
+		*el.Value.(*TxStatus) = st
+		t.order.MoveToBack(el)
+		return
+	}
+
+	t.byID[st.ID] = t.order.PushBack(&st)
+	for t.order.Len() > t.size {
+		oldest := t.order.Front()
+		t.order.Remove(oldest)
+		delete(t.byID, oldest.Value.(*TxStatus).ID)
+	}
+}
+
+// status returns the remembered status of id, if any.
+func (t *txTracker) status(id TxID) (TxStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	el, ok := t.byID[id]
+	if !ok {
+		return TxStatus{}, false
+	}
+	return *el.Value.(*TxStatus), true
+}
@@ -0,0 +1,73 @@
+package mempoor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrackerLifecycle(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+	tr := newTxTracker(0, clock)
+	mp := NewMempool(MempoolConfig{Observer: tr})
+
+	kept := newTx("alice", 50, 10)
+	gone := newTx("bob", 1, 10)
+	cut := newTx("carol", 40, 10)
+	_, _ = mp.Add(kept)
+	_, _ = mp.Add(gone)
+	_, _ = mp.Add(cut)
+
+	if st, _ := tr.status(kept.ID); st.State != TxStatePending {
+		t.Fatalf("expected pending, got %v", st.State)
+	}
+
+	_ = mp.Remove(cut.ID)
+	res := mp.SelectTransactions(BlockConstraints{MaxTx: 10, MinFee: 5})
+	if st, _ := tr.status(kept.ID); st.State != TxStateSelected {
+		t.Fatalf("expected selected, got %v", st.State)
+	}
+
+	clock.Advance(time.Second)
+	tr.included(&Block{Header: BlockHeader{Height: 7}, Transactions: res.Transactions})
+
+	st, ok := tr.status(kept.ID)
+	if !ok || st.State != TxStateIncluded || st.Height != 7 {
+		t.Fatalf("expected included at 7, got %+v", st)
+	}
+	if !st.UpdatedAt.Equal(clock.Now()) {
+		t.Fatalf("expected UpdatedAt from the clock, got %v", st.UpdatedAt)
+	}
+	if st, _ := tr.status(gone.ID); st.State != TxStateDropped || st.Reason != DropPurged {
+		t.Fatalf("expected dropped/purged, got %+v", st)
+	}
+	if st, _ := tr.status(cut.ID); st.State != TxStateDropped || st.Reason != DropRemoved {
+		t.Fatalf("expected dropped/removed, got %+v", st)
+	}
+}
+
+func TestTrackerForgetsLeastRecentlyChanged(t *testing.T) {
+	tr := newTxTracker(2, SystemClock)
+
+	a, b, c := newTx("a", 1, 1), newTx("b", 1, 1), newTx("c", 1, 1)
+	tr.OnAdd(a)
+	tr.OnAdd(b)
+	tr.OnSelect(a) // a is now the most recently changed
+	tr.OnAdd(c)
+
+	if _, ok := tr.status(b.ID); ok {
+		t.Fatalf("expected b to be forgotten")
+	}
+	if _, ok := tr.status(a.ID); !ok {
+		t.Fatalf("expected a to be remembered")
+	}
+}
+
+func TestTxStateMarshalText(t *testing.T) {
+	raw, err := TxStateIncluded.MarshalText()
+	if err != nil || string(raw) != "included" {
+		t.Fatalf("got %q, %v", raw, err)
+	}
+	if _, err := TxState(99).MarshalText(); err == nil {
+		t.Fatalf("expected error for unknown state")
+	}
+}
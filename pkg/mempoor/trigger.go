@@ -0,0 +1,100 @@
+package mempoor
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+// Fill trigger semantics (NodeConfig.FillTarget):
+//   - With FillTarget > 0, the block loop also watches every pool and
+//     produces a block as soon as the pools together hold FillTarget
+//     percent of GasLimit in pending gas, or MaxTxPerBlock pending txs,
+//     whichever comes first. With GasLimit 0 only the tx count counts.
+//   - The BlockInterval ticker stays as the fallback and restarts after
+//     every fill-triggered block, so a quiet pool still gets blocks
+//     every BlockInterval.
+//   - The check runs on each added or updated tx. Pending gas counts
+//     every pending tx, including ones that cannot be selected yet, so a
+//     pool held above the target by such txs builds on every add.
+//
+// NOTE: Pool events are delivered best-effort; if the loop falls behind
+// it misses events, which delays a trigger until the next event or tick
+// but never fires one wrongly, since the fill is re-read each time.
+
+// checkFillTarget validates NodeConfig.FillTarget.
+func checkFillTarget(pct int) error {
+	if pct < 0 || pct > 100 {
+		return fmt.Errorf("mempool: fill target %d%% out of range 0-100", pct)
+	}
+	return nil
+}
+
+// watchFill returns a channel that receives whenever a tx is added to or
+// updated in any of the node's pools, coalescing bursts, and a func that
+// stops watching.
+func (n *Node) watchFill() (<-chan struct{}, func()) {
+	fill := make(chan struct{}, 1)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	cancels := make([]func(), 0, len(n.pools))
+	for _, p := range n.pools {
+		events, cancel := p.mp.Subscribe()
+		cancels = append(cancels, cancel)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				case ev, ok := <-events:
+					if !ok {
+						return
+					}
+					if ev.Type != TxAdded && ev.Type != TxUpdated {
+						continue
+					}
+					select {
+					case fill <- struct{}{}:
+					default: // a check is already due
+					}
+				}
+			}
+		}()
+	}
+
+	stop := func() {
+		close(done)
+		for _, cancel := range cancels {
+			cancel()
+		}
+		wg.Wait()
+	}
+	return fill, stop
+}
+
+// fillReached reports whether the pools have reached the FillTarget; see
+// "Fill trigger semantics".
+func (n *Node) fillReached() bool {
+	var gas uint64
+	var count int
+	for _, p := range n.pools {
+		gas += p.mp.PendingGas()
+		count += p.mp.Count()
+	}
+
+	limits, _ := n.limits.get()
+	if limits.MaxTxPerBlock > 0 && count >= limits.MaxTxPerBlock {
+		return true
+	}
+	if limits.GasLimit == 0 || gas == 0 {
+		return false
+	}
+	// gas*100 >= GasLimit*FillTarget, without overflow.
+	ghi, glo := bits.Mul64(gas, 100)
+	thi, tlo := bits.Mul64(limits.GasLimit, uint64(n.cfg.FillTarget))
+	return ghi > thi || (ghi == thi && glo >= tlo)
+}
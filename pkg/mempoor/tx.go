@@ -3,22 +3,48 @@ package mempoor
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"strconv"
 	"time"
 )
 
+// ErrPayloadTooLarge is returned for a tx whose Payload exceeds the
+// configured MaxPayloadBytes.
+var ErrPayloadTooLarge = errors.New("mempool: payload too large")
+
+// DefaultMaxPayloadBytes is the payload limit used by StartNode.
+const DefaultMaxPayloadBytes = 64 << 10
+
+// CheckPayloadSize returns ErrPayloadTooLarge if payload is longer than
+// max bytes. max <= 0 means unlimited. Callers building txs from
+// untrusted input should check before NewUnsignedTx, which hashes the
+// whole payload.
+func CheckPayloadSize(payload string, max int) error {
+	if max > 0 && len(payload) > max {
+		return fmt.Errorf("%w: %d bytes, limit %d", ErrPayloadTooLarge, len(payload), max)
+	}
+	return nil
+}
+
 // NewUnsignedTx constructs a tx for "add" workflows.
 // TxID is generated based on immutable fields only.
-func NewUnsignedTx(sender, recipient, payload string, fee, gas uint64) *Tx {
-	created := time.Now().UTC()
+func NewUnsignedTx(sender, recipient, payload string, nonce, fee, gas uint64) *Tx {
+	return NewUnsignedTxWithClock(SystemClock, sender, recipient, payload, nonce, fee, gas)
+}
 
-	id := GenerateTxID(sender, recipient, payload, created)
+// NewUnsignedTxWithClock is NewUnsignedTx with CreatedAt read from clock.
+func NewUnsignedTxWithClock(clock Clock, sender, recipient, payload string, nonce, fee, gas uint64) *Tx {
+	created := clock.Now()
+
+	id := GenerateTxID(sender, recipient, payload, nonce, 0, "", created)
 
 	return &Tx{
 		ID:        id,
 		Sender:    sender,
 		Recipient: recipient,
 		Payload:   payload,
+		Nonce:     nonce,
 		Fee:       fee,
 		Gas:       gas,
 		CreatedAt: created,
@@ -29,26 +55,42 @@ func NewUnsignedTx(sender, recipient, payload string, fee, gas uint64) *Tx {
 // NewTxUpdate constructs a tx for update workflows.
 // ID must be supplied; CreatedAt is preserved.
 // Timestamp is refreshed for scheduling.
-func NewTxUpdate(id TxID, sender, recipient, payload string, fee, gas uint64, createdAt time.Time) *Tx {
+func NewTxUpdate(id TxID, sender, recipient, payload string, nonce, fee, gas uint64, createdAt time.Time) *Tx {
+	return NewTxUpdateWithClock(SystemClock, id, sender, recipient, payload, nonce, fee, gas, createdAt)
+}
+
+// NewTxUpdateWithClock is NewTxUpdate with Timestamp read from clock.
+func NewTxUpdateWithClock(clock Clock, id TxID, sender, recipient, payload string, nonce, fee, gas uint64, createdAt time.Time) *Tx {
 	return &Tx{
 		ID:        id,
 		Sender:    sender,
 		Recipient: recipient,
 		Payload:   payload,
+		Nonce:     nonce,
 		Fee:       fee,
 		Gas:       gas,
 		CreatedAt: createdAt,
-		Timestamp: time.Now().UTC(),
+		Timestamp: clock.Now(),
 	}
 }
 
 // GenerateTxID creates a deterministic ID from immutable fields.
 // Fee, Gas, Timestamp DO NOT participate because they may change.
-func GenerateTxID(sender, recipient, payload string, createdAt time.Time) TxID {
+// A non-zero amount and a non-empty chainID are appended, so txs
+// without them keep their IDs; a tx on one network thus has a different
+// ID on another and cannot be replayed there by changing its ChainID.
+func GenerateTxID(sender, recipient, payload string, nonce, amount uint64, chainID string, createdAt time.Time) TxID {
 	raw := sender +
 		"|" + recipient +
 		"|" + payload +
+		"|" + strconv.FormatUint(nonce, 10) +
 		"|" + strconv.FormatInt(createdAt.UnixNano(), 10)
+	if amount != 0 {
+		raw += "|amount=" + strconv.FormatUint(amount, 10)
+	}
+	if chainID != "" {
+		raw += "|chainid=" + strconv.Quote(chainID)
+	}
 
 	hash := sha256.Sum256([]byte(raw))
 	return TxID(hex.EncodeToString(hash[:]))
@@ -1,12 +1,14 @@
 package mempoor
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
 
 func TestNewUnsignedTx_GeneratesID(t *testing.T) {
-	tx := NewUnsignedTx("alice", "bob", "hello", 10, 500)
+	tx := NewUnsignedTx("alice", "bob", "hello", 0, 10, 500)
 
 	if tx.ID == "" {
 		t.Fatalf("expected non-empty tx ID")
@@ -29,8 +31,8 @@ func TestNewUnsignedTx_GeneratesID(t *testing.T) {
 }
 
 func TestNewUnsignedTx_UniqueIDs(t *testing.T) {
-	tx1 := NewUnsignedTx("alice", "bob", "hello", 10, 500)
-	tx2 := NewUnsignedTx("alice", "bob", "hello", 10, 500)
+	tx1 := NewUnsignedTx("alice", "bob", "hello", 0, 10, 500)
+	tx2 := NewUnsignedTx("alice", "bob", "hello", 0, 10, 500)
 
 	if tx1.ID == tx2.ID {
 		t.Fatalf("expected unique IDs; identical creation timestamps are extremely unlikely, but allowed")
@@ -39,9 +41,9 @@ func TestNewUnsignedTx_UniqueIDs(t *testing.T) {
 
 func TestNewTxUpdate_PreservesIDAndCreatedAt(t *testing.T) {
 	origCreated := time.Now().UTC().Add(-1 * time.Minute)
-	id := GenerateTxID("alice", "bob", "msg", origCreated)
+	id := GenerateTxID("alice", "bob", "msg", 0, 0, "", origCreated)
 
-	tx := NewTxUpdate(id, "alice", "bob", "msg", 5, 100, origCreated)
+	tx := NewTxUpdate(id, "alice", "bob", "msg", 0, 5, 100, origCreated)
 
 	if tx.ID != id {
 		t.Fatalf("expected ID to be preserved; got %s", tx.ID)
@@ -59,8 +61,8 @@ func TestNewTxUpdate_PreservesIDAndCreatedAt(t *testing.T) {
 func TestGenerateTxID_Deterministic(t *testing.T) {
 	created := time.Now().UTC()
 
-	id1 := GenerateTxID("a", "b", "p", created)
-	id2 := GenerateTxID("a", "b", "p", created)
+	id1 := GenerateTxID("a", "b", "p", 0, 0, "", created)
+	id2 := GenerateTxID("a", "b", "p", 0, 0, "", created)
 
 	if id1 != id2 {
 		t.Fatalf("expected deterministic IDs")
@@ -71,10 +73,72 @@ func TestGenerateTxID_ChangesWithCreatedAt(t *testing.T) {
 	ts1 := time.Now().UTC()
 	ts2 := ts1.Add(time.Nanosecond)
 
-	id1 := GenerateTxID("a", "b", "p", ts1)
-	id2 := GenerateTxID("a", "b", "p", ts2)
+	id1 := GenerateTxID("a", "b", "p", 0, 0, "", ts1)
+	id2 := GenerateTxID("a", "b", "p", 0, 0, "", ts2)
 
 	if id1 == id2 {
 		t.Fatalf("expected different IDs for different creation times")
 	}
 }
+
+func TestGenerateTxID_ChangesWithNonce(t *testing.T) {
+	created := time.Now().UTC()
+
+	id1 := GenerateTxID("a", "b", "p", 0, 0, "", created)
+	id2 := GenerateTxID("a", "b", "p", 1, 0, "", created)
+
+	if id1 == id2 {
+		t.Fatalf("expected different IDs for different nonces")
+	}
+}
+
+func TestGenerateTxID_ChangesWithAmount(t *testing.T) {
+	created := time.Now().UTC()
+
+	id1 := GenerateTxID("a", "b", "p", 0, 5, "", created)
+	id2 := GenerateTxID("a", "b", "p", 0, 500, "", created)
+
+	if id1 == id2 {
+		t.Fatalf("expected different IDs for different amounts")
+	}
+
+	clock := NewFakeClock(created)
+	tx1 := newRPCTx(clock, addTxParams{Sender: "a", Recipient: "b", Fee: 1, Gas: 1, Amount: 5})
+	tx2 := newRPCTx(clock, addTxParams{Sender: "a", Recipient: "b", Fee: 1, Gas: 1, Amount: 500})
+	if tx1.ID == tx2.ID || tx1.ID != GenerateTxID("a", "b", "", 0, 5, "", created) {
+		t.Fatalf("expected tx.add IDs to commit to the amount")
+	}
+}
+
+func TestGenerateTxID_ChangesWithChainID(t *testing.T) {
+	created := time.Now().UTC()
+
+	if GenerateTxID("a", "b", "p", 0, 0, "mainnet", created) == GenerateTxID("a", "b", "p", 0, 0, "testnet", created) {
+		t.Fatalf("expected different IDs on different chains")
+	}
+
+	clock := NewFakeClock(created)
+	onMain := newRPCTx(clock, addTxParams{Sender: "a", Recipient: "b", Fee: 1, Gas: 1, ChainID: "mainnet"})
+	onTest := newRPCTx(clock, addTxParams{Sender: "a", Recipient: "b", Fee: 1, Gas: 1, ChainID: "testnet"})
+	if onMain.ID == onTest.ID || onMain.ChainID != "mainnet" {
+		t.Fatalf("expected the same tx on two chains to get two IDs")
+	}
+}
+
+func TestMaxPayloadBytes(t *testing.T) {
+	mp := NewMempool(MempoolConfig{MaxPayloadBytes: 8})
+
+	if _, err := mp.Add(NewUnsignedTx("alice", "bob", "12345678", 0, 10, 1)); err != nil {
+		t.Fatalf("payload at the limit should be accepted: %v", err)
+	}
+	big := NewUnsignedTx("carol", "bob", "123456789", 0, 10, 1)
+	if _, err := mp.Add(big); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("expected ErrPayloadTooLarge, got %v", err)
+	}
+	if mp.Count() != 1 {
+		t.Fatalf("oversized tx must not be admitted")
+	}
+	if err := CheckPayloadSize(strings.Repeat("x", 1<<20), 0); err != nil {
+		t.Fatalf("0 means unlimited, got %v", err)
+	}
+}
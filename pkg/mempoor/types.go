@@ -1,19 +1,290 @@
 package mempoor
 
 import (
+	"context"
+	"crypto/ed25519"
 	"errors"
+	"log/slog"
+	"sync"
 	"time"
 )
 
 // NodeConfig holds runtime settings for the node.
 type NodeConfig struct {
 	ListenAddr    string
+	DataDir       string // where the mempool and chain are persisted; "" = in-memory only
 	BlockInterval time.Duration
 	GasLimit      uint64
 	MaxTxPerBlock int
 	MinFee        uint64
+	MaxMempoolTxs int          // 0 = unbounded
+	Overflow      OverflowMode // what tx.add does at MaxMempoolTxs: evict or reject (429)
+
+	// Eviction picks which tx is evicted at MaxMempoolTxs. nil = lowest
+	// priority; see ParseEvictionPolicy for the built-ins.
+	Eviction EvictionPolicy
+
+	// MaxTxsPerSender caps pending txs per sender. 0 = unlimited.
+	MaxTxsPerSender int
+
+	// MaxPayloadBytes caps tx payloads; larger ones are refused with
+	// 413. 0 = unlimited.
+	MaxPayloadBytes int
+
+	// MinFeeBumpPercent is the RBF threshold for tx.update. 0 requires
+	// only a fee raise of 1; DefaultNodeConfig sets
+	// DefaultMinFeeBumpPercent.
+	MinFeeBumpPercent uint64
+
+	MaxOrphans int
+	OrphanTTL  time.Duration
+
+	// Priority is the mempool scheduling policy. nil = FeeFirst.
+	Priority PriorityPolicy
+
+	Dedup       DedupMode
+	DedupWindow time.Duration
+
+	// LaneGasLimits caps per-lane gas in each block. nil = no quotas.
+	LaneGasLimits map[Lane]uint64
+
+	// BaseFee is burned per unit of gas in every block. 0 = no fee
+	// market; see feemarket.go.
+	BaseFee uint64
+
+	// Strategy chooses the block fill order; Packing chooses how gas is
+	// packed. See SelectionStrategy and PackingMode.
+	Strategy SelectionStrategy
+	Packing  PackingMode
+
+	// Candidates, if set, builds every block as the best of several
+	// concurrently previewed candidates; see BlockBuilderConfig.
+	Candidates []BlockCandidate
+
+	// Pools adds named mempools beside DefaultPool; PoolGasLimits caps
+	// per-pool gas in each block, keyed by pool name. See pools.go.
+	Pools         []PoolConfig
+	PoolGasLimits map[string]uint64
+
+	// FeeFloor enables the dynamic admission fee floor. Zero = off.
+	FeeFloor FeeFloorConfig
+
+	// SenderACL is the initial sender allow/deny list.
+	SenderACL SenderACL
+
+	// AdminToken, if set, must be sent as "Authorization: Bearer <token>"
+	// with every admin.* RPC, block.export, block.import, peer.add, and
+	// peer.remove. admin.clear is refused unless it is set.
+	AdminToken string
+
+	// FeeOracleWindow is how many recent blocks fee.estimate samples.
+	// 0 = DefaultFeeOracleWindow.
+	FeeOracleWindow int
+
+	// StatusCacheSize is how many recently seen txs tx.status remembers
+	// after they leave the mempool. 0 = DefaultStatusCacheSize.
+	StatusCacheSize int
+
+	// BlockSinks receive every block before it is stored, e.g. to persist
+	// or broadcast it. If one fails, the block is discarded and its txs
+	// return to the mempool; later sinks are not called.
+	BlockSinks []BlockSink
+
+	// ProduceEmptyBlocks makes the block loop emit a block with zero txs
+	// on ticks where nothing is selected, so height and time keep
+	// advancing, instead of skipping the tick.
+	ProduceEmptyBlocks bool
+
+	// BuildTimeout bounds selection for each block; once it passes, the
+	// block is produced with what was selected so far. See
+	// BlockBuilder.BuildBlock. 0 = BlockInterval.
+	BuildTimeout time.Duration
+
+	// DrainOnShutdown builds one final block from the pending txs when
+	// the node stops; ShutdownTimeout bounds the wait for in-flight RPCs
+	// (0 = DefaultShutdownTimeout). See shutdown.go.
+	DrainOnShutdown bool
+	ShutdownTimeout time.Duration
+
+	// FillTarget, a percentage of GasLimit, also produces a block as soon
+	// as the pools hold that much pending gas or MaxTxPerBlock txs, with
+	// BlockInterval as the fallback. See trigger.go. 0 = ticker only.
+	FillTarget int
+
+	// Proposer and ExtraData are stamped into every block header the
+	// node builds. ExtraData may be at most MaxExtraDataBytes; StartNode
+	// refuses a longer one.
+	Proposer  string
+	ExtraData []byte
+
+	// BlockCompression compresses tx payloads of the blocks the node
+	// stores; reads decompress them. See compress.go. Zero = none.
+	BlockCompression PayloadCompression
+
+	// ChainID names the node's network. It goes into the genesis block
+	// and every block header, and txs must carry it. "" = no chain ID,
+	// and txs must carry none. See chainid.go.
+	ChainID string
+
+	// Genesis describes block 0, which the node creates on its first
+	// start; see genesis.go. Its ChainID is taken from ChainID.
+	Genesis GenesisConfig
+
+	// Key signs every block the node publishes. nil = the key in
+	// KeyFile, created there on first start; nil without a KeyFile = a
+	// fresh key each start. See sign.go.
+	Key     ed25519.PrivateKey
+	KeyFile string
+
+	// Retention bounds how many blocks are kept in full; older ones are
+	// pruned to their headers. Zero = keep everything. See prune.go.
+	Retention RetentionPolicy
+
+	// Peers are other nodes' RPC addresses (host:port) to poll for their
+	// chain tip, every PeerInterval (0 = DefaultPeerInterval); peer.add
+	// and peer.remove change the set at runtime. See peers.go.
+	Peers        []string
+	PeerInterval time.Duration
+
+	// ForkChoice decides between competing blocks for the same height,
+	// in block.reorg and block.propose. nil = MostFees; see
+	// forkchoice.go.
+	ForkChoice ForkChoice
+
+	// BlockStore persists the chain and reloads it on startup. nil with
+	// DataDir = a BoltBlockStore in DataDir; nil without = in-memory
+	// only. See blockstore.go.
+	BlockStore BlockStore
+
+	// Logger receives the node's logs, per component; see log.go. nil =
+	// a LogFormat handler on stdout at LogLevel (zero = text, info).
+	Logger    *slog.Logger
+	LogFormat LogFormat
+	LogLevel  slog.Level
+
+	// ConfigFile, if set, is the config file the node was started from
+	// (see config.go); on SIGHUP the node re-reads it and applies its
+	// runtime-safe settings.
+	ConfigFile string
+
+	// LimitsFile, if set, is a JSON file with any of BlockLimits' fields
+	// that the node re-reads and applies on SIGHUP. See limits.go.
+	LimitsFile string
+
+	// ReplayLog, if set, is a file the node appends a replay log to:
+	// every pool change and every block with its constraints, so Replay
+	// can re-derive the blocks later. See replay.go.
+	ReplayLog string
+
+	// Clock drives tx timestamps, block timestamps, and mempool expiry.
+	// nil = SystemClock.
+	Clock Clock
+
+	// With DataDir set, every pool change is journaled and replayed on
+	// startup. JournalSync fsyncs each entry (durable against power loss,
+	// slower). JournalCompactEvery folds the journal into a snapshot
+	// after that many entries; 0 = DefaultJournalCompactEvery.
+	JournalSync         bool
+	JournalCompactEvery int
+
+	// CheckpointEvery writes a checkpoint of the chain tip, account
+	// state, and mempool to DataDir every that many blocks, so a restart
+	// replays the state from there instead of from genesis. 0 = none.
+	// See checkpoint.go.
+	CheckpointEvery int
+
+	// GRPCListenAddr serves the gRPC API beside the HTTP one; "" = no
+	// gRPC server. See grpc.go.
+	GRPCListenAddr string
 }
 
+// MempoolConfig holds the settings applied by NewMempool.
+type MempoolConfig struct {
+	// Priority decides scheduling order for selection, eviction, and
+	// listings. nil = FeeFirst.
+	Priority PriorityPolicy
+
+	// MaxTxs caps the number of pending transactions. When the pool is
+	// full, Add evicts the lowest-priority tx. 0 = unbounded.
+	MaxTxs int
+
+	// Overflow selects evict-on-full (default) or reject-on-full.
+	Overflow OverflowMode
+
+	// Eviction picks the victim when evicting at MaxTxs. nil = the
+	// lowest-priority tx under Priority.
+	Eviction EvictionPolicy
+
+	// MaxTxsPerSender caps how many txs one sender may have pending.
+	// 0 = unlimited.
+	MaxTxsPerSender int
+
+	// MaxPayloadBytes rejects txs with a larger Payload in Add and
+	// Update. 0 = unlimited.
+	MaxPayloadBytes int
+
+	// MinFeeBumpPercent is the minimum fee increase, in percent of the
+	// old fee, that Update requires for a replacement. Any replacement
+	// must raise the fee by at least 1 regardless. The zero value
+	// requires only that; DefaultMinFeeBumpPercent is not applied here.
+	MinFeeBumpPercent uint64
+
+	// FeeBuckets are the ascending, inclusive upper bounds of the fee
+	// histogram reported by Stats. Empty = DefaultFeeBuckets.
+	FeeBuckets []uint64
+
+	// MaxOrphans bounds the orphan area holding txs whose dependency
+	// (ParentID or previous nonce) is not pending yet. When full, the
+	// oldest orphan is evicted. 0 disables orphan handling entirely.
+	MaxOrphans int
+
+	// OrphanTTL is how long an orphan may wait. 0 = DefaultOrphanTTL.
+	OrphanTTL time.Duration
+
+	// Dedup enables content dedup on (Sender, Recipient, Payload),
+	// independent of TxID. DedupWindow bounds how far apart in CreatedAt
+	// two txs may be to count as duplicates; 0 = any pending tx.
+	Dedup       DedupMode
+	DedupWindow time.Duration
+
+	// MaxScheduled bounds the queue of txs waiting for NotBefore.
+	// 0 = DefaultMaxScheduled.
+	MaxScheduled int
+
+	// MaxHistory bounds how many superseded versions are kept per tx
+	// for GetHistory. 0 = DefaultMaxHistory.
+	MaxHistory int
+
+	// FeeFloor enables a congestion-driven minimum admission fee.
+	// Zero value = disabled.
+	FeeFloor FeeFloorConfig
+
+	// SenderACL is the initial sender allow/deny list. It can be changed
+	// at runtime with SetSenderAccess.
+	SenderACL SenderACL
+
+	// ChainID is the chain ID Add requires of every tx; see chainid.go.
+	ChainID string
+
+	// Balances, if set, makes Add and Update reject txs their sender
+	// cannot pay for; Nonces, if set, makes Add reject txs reusing an
+	// included nonce. See state.go. nil = no check.
+	Balances BalanceSource
+	Nonces   NonceSource
+
+	// Clock supplies the current time for orphan expiry, NotBefore
+	// activation, and Reinsert.
+	// nil = SystemClock.
+	Clock Clock
+
+	// Observer, if set, is called synchronously for every add, remove,
+	// select, purge, and evict. See MempoolObserver.
+	Observer MempoolObserver
+}
+
+// DefaultMinFeeBumpPercent is the RBF threshold used by StartNode.
+const DefaultMinFeeBumpPercent = 10
+
 // BlockHeader contains minimal metadata describing a block.
 type BlockHeader struct {
 	Height    uint64
@@ -22,12 +293,54 @@ type BlockHeader struct {
 
 	TxCount int
 	GasUsed uint64
+
+	// Burned is the base fee burned by the block's txs and Tipped the
+	// effective tips paid to the proposer. Non-zero values are part of
+	// the block hash.
+	Burned uint64
+	Tipped uint64
+
+	// TotalFees is what the block's txs pay, Burned + Tipped, and
+	// AvgFeePerGas is TotalFees / GasUsed rounded down (0 without gas).
+	// Both follow from the fields above and are not hashed on their own.
+	TotalFees    uint64
+	AvgFeePerGas uint64
+
+	// Proposer identifies who built the block, e.g. a node name or an
+	// address. ExtraData is free-form proposer data of at most
+	// MaxExtraDataBytes. Both are part of the block hash when non-empty.
+	Proposer  string
+	ExtraData []byte
+
+	// Extra carries fields set by AfterAssemble hooks, e.g. a merkle
+	// root or a signature. Non-empty Extra is part of the block hash.
+	Extra map[string]string
+
+	// ChainID names the network the block belongs to; see chainid.go.
+	// Part of the block hash when non-empty.
+	ChainID string
 }
 
 // Block wraps a header with its ordered transactions.
 type Block struct {
 	Header       BlockHeader
 	Transactions []*Tx
+
+	// Groups is the parallel execution hint for Transactions; see
+	// parallel.go. It is not part of the hash.
+	Groups [][]int
+
+	// Compression is the codec of the tx payloads; see compress.go.
+	Compression PayloadCompression
+
+	// Signer and Signature are the publishing node's key and its
+	// signature over the hash; see sign.go. Neither is hashed.
+	Signer    ed25519.PublicKey
+	Signature []byte
+
+	// Pruned marks a block whose txs are stubs holding only ID and Gas;
+	// see prune.go.
+	Pruned bool
 }
 
 // BlockConstraints defines limits used by the block builder when
@@ -36,6 +349,31 @@ type BlockConstraints struct {
 	GasLimit uint64 // maximum total gas allowed in the block
 	MaxTx    int    // maximum number of transactions to include
 	MinFee   uint64 // optional minimum fee threshold
+
+	// LaneGasLimits caps the gas each lane may contribute to the block.
+	// A missing or zero entry means only GasLimit applies.
+	LaneGasLimits map[Lane]uint64
+
+	// Packing selects plain priority order (default) or fee-maximizing
+	// packing; Lookahead bounds the latter. 0 = DefaultPackLookahead.
+	Packing   PackingMode
+	Lookahead int
+
+	// Filter, if set, skips txs it returns false for; they stay pending
+	// (and keep blocking their sender's later nonces).
+	Filter func(*Tx) bool
+
+	// Strategy chooses the fill order; see SelectionStrategy.
+	Strategy SelectionStrategy
+
+	// BaseFee is burned per unit of gas by every tx in the block; txs
+	// that cannot cover it wait. 0 = no fee market. See feemarket.go.
+	BaseFee uint64
+
+	// Done, if set, stops selection early once closed, e.g. a build
+	// context's Done channel. The txs accepted and purged so far are
+	// returned as the result.
+	Done <-chan struct{}
 }
 
 // BlockSelectionResult represents the set of transactions chosen
@@ -43,6 +381,19 @@ type BlockConstraints struct {
 type BlockSelectionResult struct {
 	Transactions []*Tx // ordered by priority
 	GasUsed      uint64
+
+	// Burned and Tips split what the selected txs pay into the base fee
+	// burn and the proposer's effective tips; see feemarket.go.
+	Burned uint64
+	Tips   uint64
+
+	// Groups partitions Transactions, by index, into groups touching
+	// disjoint accounts; see parallel.go.
+	Groups [][]int
+
+	// Purged holds txs dropped permanently because Fee < MinFee. They
+	// are gone from the pool even if a reservation is rolled back.
+	Purged []*Tx
 }
 
 // TxID uniquely identifies a transaction.
@@ -57,6 +408,47 @@ type Tx struct {
 	Gas       uint64
 	Payload   string
 
+	// Optional value moved from Sender to Recipient when the tx is
+	// included; part of TxID. See state.go.
+	Amount uint64
+
+	// ChainID must match the chain ID of the mempool admitting the tx;
+	// part of TxID. See chainid.go.
+	ChainID string `json:",omitempty"`
+
+	// Optional dynamic fee: MaxFee is the most the tx pays in total and
+	// Tip the most of it offered to the proposer after the base fee is
+	// burned. 0 = legacy tx paying Fee. See feemarket.go.
+	MaxFee uint64
+	Tip    uint64
+
+	// Per-sender sequence number — part of TxID. A sender's txs are
+	// only selectable in ascending nonce order.
+	Nonce uint64
+
+	// Optional tx that must be pending before this one is admitted.
+	ParentID TxID
+
+	// Optional txs that must be committed to a block before this one is
+	// offered for selection; see deps.go.
+	DependsOn []TxID
+
+	// Optional activation time: the tx is held out of the heap until
+	// then; see schedule.go. Zero = immediately eligible.
+	NotBefore time.Time
+
+	// Priority class; urgent txs are drained before normal and low.
+	Lane Lane
+
+	// Named pool the tx was added to; "" = DefaultPool. See pools.go.
+	Pool string
+
+	// Optional atomic bundle, set by AddBundle: txs sharing a BundleID
+	// are included in one block together, in BundleIndex order, or not
+	// at all. See bundle.go.
+	BundleID    string
+	BundleIndex int
+
 	// Immutable creation timestamp — part of TxID.
 	CreatedAt time.Time
 
@@ -68,8 +460,18 @@ type Tx struct {
 // and node runtime. A concrete mempool implementation must be
 // concurrency-safe internally.
 type Mempool interface {
-	// Add inserts a new transaction into the mempool.
-	Add(tx *Tx) error
+	// Add inserts a new transaction into the mempool. If the mempool
+	// is at capacity, the lowest-priority transactions are evicted to
+	// make room and their IDs are returned.
+	Add(tx *Tx) ([]TxID, error)
+
+	// AddBatch inserts many txs while taking the lock once. The result
+	// holds one entry per input tx: nil on success, else the Add error.
+	AddBatch(txs []*Tx) []error
+
+	// AddBundle inserts txs as one atomic bundle, all or nothing; see
+	// bundle.go. Evicted IDs are returned as for Add.
+	AddBundle(id string, txs []*Tx) ([]TxID, error)
 
 	// Update replaces an existing transaction with the same ID.
 	// If the transaction does not exist, the implementation may
@@ -79,16 +481,153 @@ type Mempool interface {
 	// Remove deletes a transaction by ID.
 	Remove(id TxID) error
 
+	// Get returns the pending transaction with the given ID, or
+	// ErrTxNotFound if it is not in the mempool.
+	Get(id TxID) (*Tx, error)
+
+	// GetHistory returns the fee/timestamp versions a pending tx had
+	// before each replacement, oldest first.
+	GetHistory(id TxID) ([]TxVersion, error)
+
 	// SelectTransactions atomically selects the highest-priority
-	// transactions that satisfy the given constraints.
+	// transactions that satisfy the given constraints. A tx is only
+	// eligible while no tx from the same sender with a lower nonce
+	// is still pending.
 	//
 	// IMPORTANT: This must remove the selected txs from the mempool
 	// as part of the same atomic operation.
 	SelectTransactions(c BlockConstraints) BlockSelectionResult
 
+	// SetSenderAccess puts sender on the allowlist or denylist, or
+	// removes it from both (AccessDefault), for subsequent Adds.
+	SetSenderAccess(sender string, rule AccessRule)
+
+	// SenderACL returns the current sender allow/deny lists.
+	SenderACL() SenderACL
+
+	// FeeFloor returns the current dynamic minimum admission fee, or 0
+	// if the floor is disabled.
+	FeeFloor() uint64
+
+	// Reinsert returns txs from a rejected block to the pool, keeping
+	// CreatedAt but refreshing Timestamp. IDs already pending again are
+	// skipped.
+	Reinsert(txs []*Tx) error
+
+	// PeekTransactions reports what SelectTransactions would return for
+	// c without removing or purging anything.
+	PeekTransactions(c BlockConstraints) BlockSelectionResult
+
+	// Reserve performs the same selection as SelectTransactions but
+	// holds the txs aside until the returned Reservation is committed
+	// or rolled back, so a failed block does not lose them.
+	Reserve(c BlockConstraints) Reservation
+
+	// ReserveTxs holds aside exactly the listed pending txs, in order,
+	// for an externally built block; see propose.go.
+	ReserveTxs(ids []TxID) (Reservation, error)
+
+	// CommitExternal records txs as committed by a block the pool did
+	// not build, removing pending copies; see reorg.go.
+	CommitExternal(txs []*Tx)
+
 	// List returns all transactions currently in the mempool in no
 	// particular order. Primarily for CLI and debugging.
 	List() []*Tx
+
+	// Count returns the number of pending txs without copying the pool.
+	Count() int
+
+	// PendingGas returns the total Gas of the pending txs.
+	PendingGas() uint64
+
+	// ListPage returns a stable page of txs in the requested order along
+	// with the total pending count. limit <= 0 means no limit.
+	ListPage(offset, limit int, order SortOrder) ([]*Tx, int)
+
+	// Iterate calls fn for each pending tx in priority order, without
+	// copying the pool, until fn returns false. fn runs under the read
+	// lock: it must not retain or modify the tx, or call the mempool.
+	Iterate(fn func(*Tx) bool)
+
+	// TopN returns copies of the n highest-priority pending txs without
+	// removing them. Nonce order and block limits are not applied.
+	TopN(n int) []*Tx
+
+	// ListFilter returns the pending txs matching f in no particular order.
+	ListFilter(f TxFilter) []*Tx
+
+	// ListScheduled returns the txs waiting for their NotBefore time,
+	// soonest first.
+	ListScheduled() []*Tx
+
+	// ListBySender returns sender's pending txs in nonce order.
+	ListBySender(sender string) []*Tx
+
+	// PurgeSender removes all of sender's pending txs and returns their
+	// IDs in nonce order.
+	PurgeSender(sender string) []TxID
+
+	// Snapshot atomically captures copies of all pending transactions
+	// in priority order, preserving CreatedAt and Timestamp.
+	Snapshot() ([]*Tx, error)
+
+	// Pin protects a pending tx from eviction and MinFee purges until
+	// Unpin; Pinned lists the protected IDs. See pin.go.
+	Pin(id TxID) error
+	Unpin(id TxID) error
+	Pinned() []TxID
+
+	// Require marks a pending tx for the top of the next block until
+	// Unrequire; Required lists the marked IDs in order. See required.go.
+	Require(id TxID) error
+	Unrequire(id TxID) error
+	Required() []TxID
+
+	// Clear atomically drops every pending, orphaned, and scheduled tx
+	// and returns them.
+	Clear() []*Tx
+
+	// Restore atomically replaces the entire pool with txs, as captured
+	// by Snapshot. Timestamps are kept as-is (unlike a fresh tx.add).
+	// On error the existing pool is left untouched.
+	Restore(txs []*Tx) error
+
+	// MarshalJSON/UnmarshalJSON and GobEncode/GobDecode serialize the
+	// pending pool as a canonical, versioned Dump (sorted by ID, UTC
+	// times). Decoding replaces the pool like Restore.
+	MarshalJSON() ([]byte, error)
+	UnmarshalJSON(raw []byte) error
+	GobEncode() ([]byte, error)
+	GobDecode(raw []byte) error
+
+	// Subscribe returns a channel of pool change events and a cancel
+	// func that unsubscribes and closes the channel. Delivery is
+	// best-effort: slow subscribers miss events instead of blocking.
+	// Restore replaces the pool wholesale and emits no events.
+	Subscribe() (<-chan MempoolEvent, func())
+
+	// Stats returns count, gas, and fee statistics including a fee
+	// histogram bucketed by MempoolConfig.FeeBuckets.
+	Stats() MempoolStats
+
+	// Orphans returns txs parked while waiting for a missing dependency.
+	// They are not part of List, Snapshot, or selection.
+	Orphans() []*Tx
+
+	// Policy returns the PriorityPolicy the mempool orders by, so callers
+	// that sort txs themselves stay consistent with block selection.
+	Policy() PriorityPolicy
+
+	// Compact rebuilds internal storage sized for the current contents,
+	// releasing memory held over from past churn. Pool order and
+	// contents are unchanged.
+	Compact()
+
+	// CheckInvariants verifies table, heap, and index consistency and
+	// returns an error wrapping ErrInvariantViolated on the first
+	// inconsistency found.
+	CheckInvariants() error
 }
 
 // ErrEmptyBlock is returned when the mempool provides no transactions
@@ -96,16 +635,111 @@ type Mempool interface {
 // to skip block production for this tick.
 var ErrEmptyBlock = errors.New("blockbuilder: no transactions selected")
 
+// MaxExtraDataBytes caps BlockHeader.ExtraData.
+const MaxExtraDataBytes = 32
+
+// ErrExtraDataTooLarge is returned when the configured ExtraData exceeds
+// MaxExtraDataBytes.
+var ErrExtraDataTooLarge = errors.New("blockbuilder: extra data too large")
+
 // BlockBuilderConfig specifies the rules used to build blocks.
 type BlockBuilderConfig struct {
 	GasLimit      uint64
 	MaxTxPerBlock int
 	MinFee        uint64
+	LaneGasLimits map[Lane]uint64
+
+	// BaseFee sets BlockConstraints.BaseFee. 0 = no fee market.
+	BaseFee uint64
+
+	// Pools are drawn from after the primary mempool, in order.
+	// PoolGasLimits caps the gas each pool, by name, may contribute to
+	// a block; the primary mempool is DefaultPool. A missing or zero
+	// entry means only GasLimit applies.
+	Pools         []NamedPool
+	PoolGasLimits map[string]uint64
+
+	// Strategy sets BlockConstraints.Strategy; see SelectionStrategy.
+	Strategy SelectionStrategy
+
+	// Packing and PackLookahead set BlockConstraints.Packing and
+	// Lookahead; see PackingMode.
+	Packing       PackingMode
+	PackLookahead int
+
+	// Candidates, if set, are alternative Strategy/Packing combinations
+	// previewed concurrently with the configured ones on every build; the
+	// highest-fee block wins. CandidateWorkers bounds the concurrency.
+	// 0 = GOMAXPROCS.
+	Candidates       []BlockCandidate
+	CandidateWorkers int
+
+	// BeforeSelect and AfterAssemble hooks run, in order, around every
+	// build; see hooks.go.
+	BeforeSelect  []BeforeSelectHook
+	AfterAssemble []AfterAssembleHook
+
+	// Clock stamps blocks built with a zero now. nil = SystemClock.
+	Clock Clock
+
+	// Proposer and ExtraData are copied into every block header.
+	// ExtraData longer than MaxExtraDataBytes fails every build with
+	// ErrExtraDataTooLarge.
+	Proposer  string
+	ExtraData []byte
+
+	// ChainID is copied into every block header; see chainid.go.
+	ChainID string
+
+	// AllowEmpty builds a block with zero txs when nothing is selected,
+	// instead of returning ErrEmptyBlock.
+	AllowEmpty bool
+
+	// Deterministic never reads Clock: a zero now fails with
+	// ErrNoTimestamp. Given the same pool contents (restored in the same
+	// order, e.g. from a canonical Snapshot) and constraints, the block
+	// is then byte-identical on every run. Ties rely on the
+	// PriorityPolicy ending with a TxID comparison, as the built-ins do.
+	// See Replay.
+	Deterministic bool
+
+	// OnPurge, if set, is called with the txs purged for Fee < MinFee
+	// during each build, including builds that end in ErrEmptyBlock.
+	OnPurge func(purged []*Tx)
+
+	// OnBuild, if set, is called after each selection by ReserveBlock
+	// and BuildBlock, including selections that end in ErrEmptyBlock.
+	// See BuilderMeter.
+	OnBuild func(BuildStats)
 }
 
 // BlockBuilder assembles blocks using a mempool and static config.
 // It is pure and stateless: the caller supplies prevHash, height, and timestamp.
+// Only its limits can change, through SetLimits.
 type BlockBuilder struct {
 	mp  Mempool
 	cfg BlockBuilderConfig
+
+	limitsMu sync.RWMutex // guards cfg.GasLimit, MaxTxPerBlock, MinFee
+}
+
+// Builder produces a Node's blocks. *BlockBuilder is the built-in
+// implementation; NewNodeWithBuilder plugs in others, e.g. a randomized
+// selector for fuzzing or a client for an external builder.
+type Builder interface {
+	// BuildBlock builds the next block and takes its txs out of the
+	// pools for good. It returns ErrEmptyBlock when there is nothing to
+	// include, and should stop selecting once ctx ends.
+	BuildBlock(ctx context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, error)
+
+	// BuildTemplate previews the next block without changing any pool.
+	BuildTemplate(ctx context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, error)
+}
+
+// BlockReserver is implemented by Builders that can hold a block's txs
+// until the node has published it; see BlockBuilder.ReserveBlock. For
+// other Builders the node returns the txs of a block it fails to publish
+// with Mempool.Reinsert instead, so they lose their place in line.
+type BlockReserver interface {
+	ReserveBlock(ctx context.Context, prevHash [32]byte, height uint64, now time.Time) (*Block, Reservation, error)
 }
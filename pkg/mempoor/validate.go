@@ -0,0 +1,165 @@
+package mempoor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidBlock is returned by Validate for a block that must not be
+// accepted.
+var ErrInvalidBlock = errors.New("blockbuilder: invalid block")
+
+// ErrTxIDMismatch is returned, wrapped in ErrInvalidBlock, for a block
+// tx whose ID is not the GenerateTxID of its contents.
+var ErrTxIDMismatch = errors.New("blockbuilder: tx ID does not match its contents")
+
+// Validation semantics (Validate, ValidateBlock):
+//   - Linkage: a block with no prev must be a genesis block, at height 0
+//     with a zero PrevHash. Otherwise its height must be prev's plus one,
+//     its PrevHash must equal prev.Hash() recomputed from prev's
+//     contents, its timestamp must not be before prev's, and its ChainID
+//     must be prev's, so a chain never changes networks.
+//   - Consistency: TxCount and GasUsed must match the txs, and no tx may
+//     appear twice. Every tx's ID must be the GenerateTxID of its
+//     contents, as the block hash commits to IDs only; a pruned block's
+//     stubs have no contents and are not checked, and a compressed
+//     block's payloads are decompressed first. Burned and Tipped must be what the txs pay under the
+//     chain's base fee, as selection computes it (see txFees), and
+//     TotalFees and AvgFeePerGas must follow from them. A pruned block's
+//     stubs carry no fees, so its Burned and Tipped are taken as hashed.
+//     ExtraData must fit MaxExtraDataBytes. A signature, if present,
+//     must verify; see sign.go.
+//   - Hash integrity follows from the two: every hashed header field
+//     agrees with the block's contents, and since PrevHash commits to
+//     the parent's recomputed hash, a block altered after its child was
+//     built breaks the link to that child.
+//   - Limits: ValidateBlock also checks the GasLimit and MaxTxPerBlock of
+//     limits; zero values are not checked. MinFee is a selection policy,
+//     not a validity rule, and is ignored.
+//
+// The node validates every block before it is published or stored, and
+// every stored block against its parent when it reloads the chain.
+
+// Validate checks block against its parent prev, nil for a genesis block,
+// on a chain burning baseFee per unit of gas. See "Validation semantics".
+func Validate(block, prev *Block, baseFee uint64) error {
+	return ValidateBlock(block, prev, baseFee, BlockLimits{})
+}
+
+// ValidateBlock is Validate plus the GasLimit and MaxTxPerBlock of
+// limits.
+func ValidateBlock(block, prev *Block, baseFee uint64, limits BlockLimits) error {
+	if block == nil {
+		return fmt.Errorf("%w: nil block", ErrInvalidBlock)
+	}
+	h := block.Header
+
+	if prev == nil {
+		if h.Height != 0 || h.PrevHash != ([32]byte{}) {
+			return fmt.Errorf("%w: block %d has no parent", ErrInvalidBlock, h.Height)
+		}
+	} else {
+		switch {
+		case h.Height != prev.Header.Height+1:
+			return fmt.Errorf("%w: height %d does not follow %d", ErrInvalidBlock, h.Height, prev.Header.Height)
+		case h.PrevHash != prev.Hash():
+			return fmt.Errorf("%w: block %d does not link to its parent's hash", ErrInvalidBlock, h.Height)
+		case h.Timestamp.Before(prev.Header.Timestamp):
+			return fmt.Errorf("%w: block %d is timestamped before its parent", ErrInvalidBlock, h.Height)
+		case h.ChainID != prev.Header.ChainID:
+			return fmt.Errorf("%w: block %d is for chain %q, its parent for %q", ErrInvalidBlock, h.Height, h.ChainID, prev.Header.ChainID)
+		}
+	}
+
+	if h.TxCount != len(block.Transactions) {
+		return fmt.Errorf("%w: txCount %d, block has %d txs", ErrInvalidBlock, h.TxCount, len(block.Transactions))
+	}
+	var gas, burned, tipped uint64
+	seen := make(map[TxID]struct{}, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		if tx == nil {
+			return fmt.Errorf("%w: tx %d is nil", ErrInvalidBlock, i)
+		}
+		if _, dup := seen[tx.ID]; dup {
+			return fmt.Errorf("%w: tx %s appears twice", ErrInvalidBlock, tx.ID)
+		}
+		seen[tx.ID] = struct{}{}
+		gas += tx.Gas
+		burn, tip := txFees(tx, baseFee)
+		burned += burn
+		tipped += tip
+	}
+	if err := checkTxIDs(block); err != nil {
+		return err
+	}
+	if h.GasUsed != gas {
+		return fmt.Errorf("%w: gasUsed %d, txs use %d", ErrInvalidBlock, h.GasUsed, gas)
+	}
+	if !block.Pruned && (h.Burned != burned || h.Tipped != tipped) {
+		return fmt.Errorf("%w: burned %d and tipped %d, txs pay %d and %d",
+			ErrInvalidBlock, h.Burned, h.Tipped, burned, tipped)
+	}
+	if total, perGas := feeSummary(h.Burned, h.Tipped, h.GasUsed); h.TotalFees != total || h.AvgFeePerGas != perGas {
+		return fmt.Errorf("%w: fee summary %d/%d does not match burned %d and tipped %d",
+			ErrInvalidBlock, h.TotalFees, h.AvgFeePerGas, h.Burned, h.Tipped)
+	}
+	if err := checkExtraData(h.ExtraData); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBlock, err)
+	}
+
+	if len(block.Signature) > 0 {
+		if err := VerifyBlock(block); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBlock, err)
+		}
+	}
+
+	if limits.GasLimit > 0 && gas > limits.GasLimit {
+		return fmt.Errorf("%w: gasUsed %d over the gas limit %d", ErrInvalidBlock, gas, limits.GasLimit)
+	}
+	if limits.MaxTxPerBlock > 0 && len(block.Transactions) > limits.MaxTxPerBlock {
+		return fmt.Errorf("%w: %d txs, max %d", ErrInvalidBlock, len(block.Transactions), limits.MaxTxPerBlock)
+	}
+	return nil
+}
+
+// checkTxIDs checks that every tx of block carries the ID of its
+// contents. Pruned blocks are skipped.
+func checkTxIDs(block *Block) error {
+	if block.Pruned {
+		return nil
+	}
+	plain, err := DecompressPayloads(block)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBlock, err)
+	}
+	for _, tx := range plain.Transactions {
+		if tx.ID != GenerateTxID(tx.Sender, tx.Recipient, tx.Payload, tx.Nonce, tx.Amount, tx.ChainID, tx.CreatedAt) {
+			return fmt.Errorf("%w: %w: tx %s", ErrInvalidBlock, ErrTxIDMismatch, tx.ID)
+		}
+	}
+	return nil
+}
+
+// validateChain checks each of blocks against the one before it under
+// baseFee; the first must be a genesis block.
+func validateChain(blocks []*Block, baseFee uint64) error {
+	var prev *Block
+	for _, b := range blocks {
+		if err := Validate(b, prev, baseFee); err != nil {
+			return err
+		}
+		prev = b
+	}
+	return nil
+}
+
+// chainTipBlock returns the node's last block, or nil before genesis.
+func (n *Node) chainTipBlock() *Block {
+	n.blocksMu.RLock()
+	defer n.blocksMu.RUnlock()
+
+	if len(n.blocks) == 0 {
+		return nil
+	}
+	return n.blocks[len(n.blocks)-1]
+}
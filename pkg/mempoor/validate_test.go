@@ -0,0 +1,103 @@
+package mempoor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateBlock(t *testing.T) {
+	genesis := NewGenesisBlock(GenesisConfig{})
+	tx1, tx2 := newTx("alice", 5, 10), newTx("bob", 7, 20)
+	valid := func() *Block {
+		b := &Block{
+			Header: BlockHeader{
+				Height:    1,
+				PrevHash:  genesis.Hash(),
+				Timestamp: GenesisTime.Add(time.Second),
+				TxCount:   2,
+				GasUsed:   30,
+				Tipped:    12,
+			},
+			Transactions: []*Tx{tx1, tx2},
+		}
+		b.Header.TotalFees, b.Header.AvgFeePerGas = feeSummary(0, 12, 30)
+		return b
+	}
+
+	if err := Validate(genesis, nil, 0); err != nil {
+		t.Fatalf("genesis: unexpected error: %v", err)
+	}
+	if err := ValidateBlock(valid(), genesis, 0, BlockLimits{GasLimit: 30, MaxTxPerBlock: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[string]func(b *Block){
+		"height gap":    func(b *Block) { b.Header.Height = 2 },
+		"wrong parent":  func(b *Block) { b.Header.PrevHash[0] ^= 1 },
+		"before parent": func(b *Block) { b.Header.Timestamp = GenesisTime.Add(-time.Second) },
+		"tx count":      func(b *Block) { b.Header.TxCount = 3 },
+		"gas used":      func(b *Block) { b.Header.GasUsed = 29 },
+		"fee summary":   func(b *Block) { b.Header.TotalFees++ },
+		"forged fees": func(b *Block) {
+			b.Header.Tipped = 1 << 40
+			b.Header.TotalFees, b.Header.AvgFeePerGas = feeSummary(0, b.Header.Tipped, b.Header.GasUsed)
+		},
+		"duplicate tx":      func(b *Block) { b.Transactions[1] = tx1; b.Header.GasUsed = 20 },
+		"extra data length": func(b *Block) { b.Header.ExtraData = make([]byte, MaxExtraDataBytes+1) },
+	}
+	for name, mutate := range cases {
+		b := valid()
+		mutate(b)
+		if err := Validate(b, genesis, 0); !errors.Is(err, ErrInvalidBlock) {
+			t.Fatalf("%s: expected ErrInvalidBlock, got %v", name, err)
+		}
+	}
+
+	if err := ValidateBlock(valid(), genesis, 0, BlockLimits{GasLimit: 29}); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected the gas limit enforced, got %v", err)
+	}
+	if err := ValidateBlock(valid(), genesis, 0, BlockLimits{MaxTxPerBlock: 1}); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected MaxTxPerBlock enforced, got %v", err)
+	}
+
+	// The hash commits to tx IDs only, so a tx changed under its ID
+	// keeps the block's hash and is caught by recomputing the ID.
+	altered := valid()
+	redirected := *tx2
+	redirected.Recipient, redirected.Amount = "mallory", 1000
+	altered.Transactions[1] = &redirected
+	if altered.Hash() != valid().Hash() {
+		t.Fatalf("expected the hash unchanged by tx contents")
+	}
+	if err := Validate(altered, genesis, 0); !errors.Is(err, ErrTxIDMismatch) || !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected ErrTxIDMismatch, got %v", err)
+	}
+	compressed, err := CompressPayloads(valid(), CompressGzip)
+	if err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if err := Validate(compressed, genesis, 0); err != nil {
+		t.Fatalf("expected IDs checked against decompressed payloads, got %v", err)
+	}
+
+	// Under a base fee of 1 neither tx covers its burn, so each burns
+	// its whole fee and tips nothing; the all-tip header no longer fits.
+	if err := Validate(valid(), genesis, 1); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected fees checked under the base fee, got %v", err)
+	}
+	burning := valid()
+	burning.Header.Burned, burning.Header.Tipped = 12, 0
+	burning.Header.TotalFees, burning.Header.AvgFeePerGas = feeSummary(12, 0, 30)
+	if err := Validate(burning, genesis, 1); err != nil {
+		t.Fatalf("unexpected error under the base fee: %v", err)
+	}
+
+	// Tampering with a parent breaks the link from its child.
+	child := valid()
+	tampered := *genesis
+	tampered.Header.ExtraData = []byte("rewritten")
+	if err := validateChain([]*Block{&tampered, child}, 0); !errors.Is(err, ErrInvalidBlock) {
+		t.Fatalf("expected a tampered parent to be caught, got %v", err)
+	}
+}
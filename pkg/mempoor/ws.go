@@ -0,0 +1,543 @@
+package mempoor
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Subscription topics, for /ws.
+const (
+	TopicNewBlocks  = "newBlocks"  // every block that joins the chain
+	TopicPendingTxs = "pendingTxs" // txs entering a pool, or replaced in it
+	TopicDroppedTxs = "droppedTxs" // txs leaving a pool without a block
+)
+
+// wsMaxMessage caps the size of one message a /ws client may send.
+const wsMaxMessage = 64 << 10
+
+// WebSocket semantics (GET /ws):
+//   - The client sends JSON requests, {"id", "method", "params"}, and
+//     gets {"id", "result"} or {"id", "error"} back. Methods:
+//     subscribe {"topic"} returns {"subscription"}; unsubscribe
+//     {"subscription"} ends one.
+//   - Each event is pushed as {"subscription", "topic", "result"}:
+//     newBlocks carries {"block", "reorg"} for every block that joins the
+//     chain (see "Head subscription semantics"); pendingTxs carries
+//     {"pool", "event", "tx"} for txs added, updated, orphaned, or
+//     scheduled in any pool; droppedTxs the same for txs removed,
+//     purged, or evicted. Selected txs show up in newBlocks instead.
+//   - Events are pushed as they happen, never blocking the node: a
+//     client more than subscriberBuffer messages behind misses events.
+//   - A connection may hold any number of subscriptions, also to the
+//     same topic; closing it ends them all. Shutdown closes every
+//     connection with 1001 (going away).
+//
+// NOTE: Subscriptions are read-only and need no admin token.
+
+type wsRequest struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type wsResponse struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Result any             `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type wsEvent struct {
+	Subscription string `json:"subscription"`
+	Topic        string `json:"topic"`
+	Result       any    `json:"result"`
+}
+
+type wsSubscribeParams struct {
+	Topic string `json:"topic"`
+}
+
+type wsSubscription struct {
+	Subscription string `json:"subscription"`
+}
+
+// blockEvent is the payload of a newBlocks event.
+type blockEvent struct {
+	Block blockDTO `json:"block"`
+	Reorg bool     `json:"reorg,omitempty"`
+}
+
+// txEvent is the payload of a pendingTxs or droppedTxs event.
+type txEvent struct {
+	Pool  string `json:"pool"`
+	Event string `json:"event"` // MempoolEventType
+	Tx    *Tx    `json:"tx"`
+}
+
+// txTopic returns the topic events of typ belong to, or "".
+func txTopic(typ MempoolEventType) string {
+	switch typ {
+	case TxAdded, TxUpdated, TxOrphaned, TxScheduled:
+		return TopicPendingTxs
+	case TxRemoved, TxPurged, TxEvicted:
+		return TopicDroppedTxs
+	default:
+		return ""
+	}
+}
+
+// subscribeTopics calls send with every event of topics until cancel is
+// called. The events of each pool, and of the chain, arrive in the
+// order they happened.
+func (n *Node) subscribeTopics(topics []string, send func(topic string, payload any)) (cancel func(), err error) {
+	want := make(map[string]bool)
+	for _, topic := range topics {
+		switch topic {
+		case TopicNewBlocks, TopicPendingTxs, TopicDroppedTxs:
+			want[topic] = true
+		default:
+			return nil, fmt.Errorf("unknown topic %q", topic)
+		}
+	}
+
+	var cancels []func()
+	if want[TopicNewBlocks] {
+		heads, cancel := n.SubscribeHeads()
+		cancels = append(cancels, cancel)
+		go func() {
+			for ev := range heads {
+				send(TopicNewBlocks, blockEvent{Block: makeBlockDTO(ev.Block), Reorg: ev.Reorg})
+			}
+		}()
+	}
+	if want[TopicPendingTxs] || want[TopicDroppedTxs] {
+		for _, p := range n.pools {
+			events, cancel := p.mp.Subscribe()
+			cancels = append(cancels, cancel)
+			go func(pool string) {
+				for ev := range events {
+					if topic := txTopic(ev.Type); want[topic] {
+						send(topic, txEvent{Pool: pool, Event: ev.Type.String(), Tx: ev.Tx})
+					}
+				}
+			}(p.name)
+		}
+	}
+	return func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}, nil
+}
+
+// streamSet ends the node's long-lived connections on shutdown, which
+// http.Server.Shutdown neither closes (/ws, hijacked) nor should wait
+// for (/events).
+type streamSet struct {
+	mu   sync.Mutex
+	done chan struct{}
+}
+
+// closing returns a channel closed once the node shuts down.
+func (s *streamSet) closing() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done == nil {
+		s.done = make(chan struct{})
+	}
+	return s.done
+}
+
+// stop closes every stream; it is safe to call twice.
+func (s *streamSet) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done == nil {
+		s.done = make(chan struct{})
+	}
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+}
+
+// WebSocket opcodes and close codes (RFC 6455).
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xA
+
+	wsCloseNormal     = 1000
+	wsCloseGoingAway  = 1001
+	wsCloseProtocol   = 1002
+	wsCloseTooBig     = 1009
+	wsAcceptMagicGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+var (
+	errWSProtocol = errors.New("websocket: protocol error")
+	errWSTooBig   = errors.New("websocket: message too big")
+)
+
+type wsFrame struct {
+	op      byte
+	payload []byte
+}
+
+// wsSession is one /ws connection.
+type wsSession struct {
+	n    *Node
+	out  chan wsFrame
+	done chan struct{} // closed when readLoop returns
+	code uint16        // readLoop's close code, set before done
+
+	// wrote is closed when writeLoop returns.
+	wrote chan struct{}
+
+	mu   sync.Mutex
+	next int
+	subs map[string]func() // subscription ID -> cancel
+}
+
+// handleWS upgrades to a WebSocket and serves subscriptions on it; see
+// "WebSocket semantics".
+func (n *Node) handleWS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !headerHasToken(r.Header, "Connection", "upgrade") || !headerHasToken(r.Header, "Upgrade", "websocket") || key == "" {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		n.log.rpc.Error("websocket hijack failed", "err", err)
+		return
+	}
+	defer conn.Close()
+
+	sum := sha1.Sum([]byte(key + wsAcceptMagicGUID))
+	_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		return
+	}
+
+	s := &wsSession{
+		n:     n,
+		out:   make(chan wsFrame, subscriberBuffer),
+		done:  make(chan struct{}),
+		wrote: make(chan struct{}),
+		subs:  make(map[string]func()),
+	}
+	n.log.rpc.Debug("websocket connected", "remote", r.RemoteAddr)
+
+	go func() {
+		defer close(s.wrote)
+		s.writeLoop(rw.Writer)
+		conn.Close() // unblock readLoop
+	}()
+	s.code = s.readLoop(rw.Reader)
+
+	s.unsubscribeAll()
+	close(s.done)
+	<-s.wrote
+	n.log.rpc.Debug("websocket closed", "remote", r.RemoteAddr, "code", s.code)
+}
+
+// readLoop serves the client's requests until it closes the connection,
+// breaks the protocol, or the node shuts down, and returns the close
+// code to answer with.
+func (s *wsSession) readLoop(br *bufio.Reader) uint16 {
+	for {
+		msg, err := s.readMessage(br)
+		switch {
+		case errors.Is(err, io.EOF):
+			return wsCloseNormal
+		case errors.Is(err, errWSTooBig):
+			return wsCloseTooBig
+		case errors.Is(err, errWSProtocol):
+			return wsCloseProtocol
+		case err != nil:
+			return wsCloseGoingAway
+		}
+
+		var req wsRequest
+		if err := json.Unmarshal(msg, &req); err != nil {
+			s.queue(s.encode(wsResponse{Error: "invalid JSON"}), false)
+			continue
+		}
+		s.queue(s.encode(s.serve(req)), false)
+	}
+}
+
+// serve runs one request.
+func (s *wsSession) serve(req wsRequest) wsResponse {
+	resp := wsResponse{ID: req.ID}
+	switch req.Method {
+	case "subscribe":
+		var p wsSubscribeParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			resp.Error = "invalid params for subscribe"
+			return resp
+		}
+		id, err := s.subscribe(p.Topic)
+		if err != nil {
+			resp.Error = err.Error()
+			return resp
+		}
+		resp.Result = wsSubscription{Subscription: id}
+
+	case "unsubscribe":
+		var p wsSubscription
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			resp.Error = "invalid params for unsubscribe"
+			return resp
+		}
+		if !s.unsubscribe(p.Subscription) {
+			resp.Error = fmt.Sprintf("unknown subscription %q", p.Subscription)
+			return resp
+		}
+		resp.Result = p
+
+	default:
+		resp.Error = fmt.Sprintf("unknown method %q", req.Method)
+	}
+	return resp
+}
+
+func (s *wsSession) subscribe(topic string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.next++
+	id := strconv.Itoa(s.next)
+	cancel, err := s.n.subscribeTopics([]string{topic}, func(topic string, payload any) {
+		s.queue(s.encode(wsEvent{Subscription: id, Topic: topic, Result: payload}), true)
+	})
+	if err != nil {
+		return "", err
+	}
+	s.subs[id] = cancel
+	return id, nil
+}
+
+func (s *wsSession) unsubscribe(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cancel, ok := s.subs[id]
+	if ok {
+		delete(s.subs, id)
+		cancel()
+	}
+	return ok
+}
+
+func (s *wsSession) unsubscribeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, cancel := range s.subs {
+		delete(s.subs, id)
+		cancel()
+	}
+}
+
+// encode returns v as a text frame.
+func (s *wsSession) encode(v any) wsFrame {
+	raw, _ := json.Marshal(v)
+	return wsFrame{op: wsText, payload: raw}
+}
+
+// queue hands f to the writer. Events (drop) are dropped if the client
+// is too far behind; replies wait for room.
+func (s *wsSession) queue(f wsFrame, drop bool) {
+	if drop {
+		select {
+		case s.out <- f:
+		case <-s.done:
+		case <-s.wrote:
+		default: // slow client; drop
+		}
+		return
+	}
+	select {
+	case s.out <- f:
+	case <-s.done:
+	case <-s.wrote:
+	}
+}
+
+// writeLoop writes queued frames, flushing each, until the session
+// ends, then sends the close frame: readLoop's code, or going away on
+// shutdown.
+func (s *wsSession) writeLoop(bw *bufio.Writer) {
+	write := func(f wsFrame) error {
+		if err := writeWSFrame(bw, f.op, f.payload); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+	for {
+		select {
+		case f := <-s.out:
+			if err := write(f); err != nil {
+				return
+			}
+		case <-s.n.streams.closing():
+			_ = write(wsFrame{op: wsClose, payload: binary.BigEndian.AppendUint16(nil, wsCloseGoingAway)})
+			return
+		case <-s.done:
+			_ = write(wsFrame{op: wsClose, payload: binary.BigEndian.AppendUint16(nil, s.code)})
+			return
+		}
+	}
+}
+
+// readMessage returns the next data message, answering pings and
+// skipping pongs along the way. A close frame ends the stream (io.EOF).
+func (s *wsSession) readMessage(br *bufio.Reader) ([]byte, error) {
+	var msg []byte
+	started := false
+	for {
+		fin, op, payload, err := readWSFrame(br)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case wsPing:
+			s.queue(wsFrame{op: wsPong, payload: payload}, true)
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			return nil, io.EOF
+		case wsText, wsBinary:
+			if started {
+				return nil, errWSProtocol
+			}
+			started = true
+		case wsContinuation:
+			if !started {
+				return nil, errWSProtocol
+			}
+		default:
+			return nil, errWSProtocol
+		}
+		if len(msg)+len(payload) > wsMaxMessage {
+			return nil, errWSTooBig
+		}
+		msg = append(msg, payload...)
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+// readWSFrame reads one client frame, which must be masked, and
+// unmasks its payload.
+func readWSFrame(br *bufio.Reader) (fin bool, op byte, payload []byte, err error) {
+	var h [2]byte
+	if _, err := io.ReadFull(br, h[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, op = h[0]&0x80 != 0, h[0]&0x0f
+	if h[0]&0x70 != 0 || h[1]&0x80 == 0 {
+		return false, 0, nil, errWSProtocol // extension bits, or unmasked
+	}
+
+	length := uint64(h[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if op >= wsClose && (!fin || length > 125) {
+		return false, 0, nil, errWSProtocol // control frames are short and whole
+	}
+	if length > wsMaxMessage {
+		return false, 0, nil, errWSTooBig
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(br, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, op, payload, nil
+}
+
+// writeWSFrame writes payload as one unmasked server frame.
+func writeWSFrame(w io.Writer, op byte, payload []byte) error {
+	h := []byte{0x80 | op}
+	switch n := len(payload); {
+	case n <= 125:
+		h = append(h, byte(n))
+	case n <= 0xffff:
+		h = append(h, 126)
+		h = binary.BigEndian.AppendUint16(h, uint16(n))
+	default:
+		h = append(h, 127)
+		h = binary.BigEndian.AppendUint64(h, uint64(n))
+	}
+	if _, err := w.Write(h); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// headerHasToken reports whether the comma-separated header name
+// contains token, ignoring case.
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package mempoor
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wsClient is a bare WebSocket client for tests.
+type wsClient struct {
+	t    *testing.T
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+func dialWS(t *testing.T, n *Node) *wsClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(n.handleWS))
+	t.Cleanup(srv.Close)
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_, _ = conn.Write([]byte("GET /ws HTTP/1.1\r\nHost: test\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n"))
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("expected 101 with the RFC 6455 sample accept key, got %d %q", resp.StatusCode, resp.Header.Get("Sec-WebSocket-Accept"))
+	}
+	return &wsClient{t: t, conn: conn, br: br}
+}
+
+// send writes payload as one masked frame.
+func (c *wsClient) send(op byte, payload []byte) {
+	c.t.Helper()
+	h := []byte{0x80 | op, 0x80 | byte(len(payload))}
+	mask := []byte{1, 2, 3, 4}
+	body := make([]byte, len(payload))
+	for i := range payload {
+		body[i] = payload[i] ^ mask[i%4]
+	}
+	if _, err := c.conn.Write(append(append(h, mask...), body...)); err != nil {
+		c.t.Fatalf("write: %v", err)
+	}
+}
+
+func (c *wsClient) call(req string) {
+	c.send(wsText, []byte(req))
+}
+
+// next reads the next server frame.
+func (c *wsClient) next() (byte, []byte) {
+	c.t.Helper()
+	_ = c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var h [2]byte
+	if _, err := io.ReadFull(c.br, h[:]); err != nil {
+		c.t.Fatalf("read: %v", err)
+	}
+	length := int(h[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			c.t.Fatalf("read: %v", err)
+		}
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		c.t.Fatalf("unexpected huge frame")
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		c.t.Fatalf("read: %v", err)
+	}
+	return h[0] & 0x0f, payload
+}
+
+func (c *wsClient) nextJSON(v any) {
+	c.t.Helper()
+	op, payload := c.next()
+	if op != wsText {
+		c.t.Fatalf("expected a text frame, got op %d", op)
+	}
+	if err := json.Unmarshal(payload, v); err != nil {
+		c.t.Fatalf("decode %s: %v", payload, err)
+	}
+}
+
+func TestWSSubscriptions(t *testing.T) {
+	n := NewNode(NodeConfig{MaxTxPerBlock: 10, BlockInterval: time.Second})
+	c := dialWS(t, n)
+
+	var resp struct {
+		ID     int            `json:"id"`
+		Result wsSubscription `json:"result"`
+		Error  string         `json:"error"`
+	}
+	for i, topic := range []string{TopicPendingTxs, TopicNewBlocks, TopicDroppedTxs} {
+		c.call(`{"id":` + strconv.Itoa(i+1) + `,"method":"subscribe","params":{"topic":"` + topic + `"}}`)
+		c.nextJSON(&resp)
+		if resp.ID != i+1 || resp.Result.Subscription == "" || resp.Error != "" {
+			t.Fatalf("subscribe %s: got %+v", topic, resp)
+		}
+	}
+	c.call(`{"id":9,"method":"subscribe","params":{"topic":"nope"}}`)
+	resp.Error = ""
+	c.nextJSON(&resp)
+	if resp.ID != 9 || resp.Error == "" {
+		t.Fatalf("expected an error for an unknown topic, got %+v", resp)
+	}
+
+	tx := newTx("alice", 10, 5)
+	if _, err := n.mempool.Add(tx); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	var ev struct {
+		Subscription string          `json:"subscription"`
+		Topic        string          `json:"topic"`
+		Result       json.RawMessage `json:"result"`
+	}
+	c.nextJSON(&ev)
+	var pending txEvent
+	_ = json.Unmarshal(ev.Result, &pending)
+	if ev.Topic != TopicPendingTxs || pending.Event != "added" || pending.Pool != DefaultPool || pending.Tx.ID != tx.ID {
+		t.Fatalf("expected tx %s added, got %s %s", tx.ID, ev.Topic, ev.Result)
+	}
+
+	if _, err := n.Mine(context.Background(), 1); err != nil {
+		t.Fatalf("mine: %v", err)
+	}
+	c.nextJSON(&ev)
+	var block blockEvent
+	_ = json.Unmarshal(ev.Result, &block)
+	if ev.Topic != TopicNewBlocks || block.Block.Height != 0 || len(block.Block.Txs) != 1 {
+		t.Fatalf("expected the first block with the tx, got %s %s", ev.Topic, ev.Result)
+	}
+
+	doomed := newTx("bob", 10, 5)
+	_, _ = n.mempool.Add(doomed)
+	c.nextJSON(&ev) // pending
+	if err := n.mempool.Remove(doomed.ID); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	c.nextJSON(&ev)
+	var dropped txEvent
+	_ = json.Unmarshal(ev.Result, &dropped)
+	if ev.Topic != TopicDroppedTxs || dropped.Event != "removed" || dropped.Tx.ID != doomed.ID {
+		t.Fatalf("expected tx %s removed, got %s %s", doomed.ID, ev.Topic, ev.Result)
+	}
+}
+
+func TestWSUnsubscribeAndClose(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	c := dialWS(t, n)
+
+	var resp struct {
+		Result wsSubscription `json:"result"`
+		Error  string         `json:"error"`
+	}
+	c.call(`{"method":"subscribe","params":{"topic":"pendingTxs"}}`)
+	c.nextJSON(&resp)
+	id := resp.Result.Subscription
+	c.call(`{"method":"unsubscribe","params":{"subscription":"` + id + `"}}`)
+	c.nextJSON(&resp)
+	if resp.Error != "" || resp.Result.Subscription != id {
+		t.Fatalf("unsubscribe: got %+v", resp)
+	}
+
+	// Nothing is pushed for the cancelled subscription: the next frame
+	// answers the ping.
+	_, _ = n.mempool.Add(newTx("alice", 10, 5))
+	c.send(wsPing, []byte("hi"))
+	if op, payload := c.next(); op != wsPong || string(payload) != "hi" {
+		t.Fatalf("expected a pong, got op %d %q", op, payload)
+	}
+
+	c.send(wsClose, binary.BigEndian.AppendUint16(nil, wsCloseNormal))
+	if op, payload := c.next(); op != wsClose || binary.BigEndian.Uint16(payload) != wsCloseNormal {
+		t.Fatalf("expected a normal close, got op %d %v", op, payload)
+	}
+}
+
+func TestWSClosesOnShutdown(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	c := dialWS(t, n)
+
+	n.streams.stop()
+	if op, payload := c.next(); op != wsClose || binary.BigEndian.Uint16(payload) != wsCloseGoingAway {
+		t.Fatalf("expected a going-away close, got op %d %v", op, payload)
+	}
+}
+
+func TestWSRequiresUpgrade(t *testing.T) {
+	n := NewNode(NodeConfig{})
+	rec := httptest.NewRecorder()
+	n.handleWS(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without an upgrade, got %d", rec.Code)
+	}
+}
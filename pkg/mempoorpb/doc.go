@@ -0,0 +1,5 @@
+// Package mempoorpb holds the messages and the gRPC client and server
+// stubs of the mempoor gRPC API, generated from mempoor.proto.
+package mempoorpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative mempoor.proto
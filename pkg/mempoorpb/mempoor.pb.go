@@ -0,0 +1,1437 @@
+// The mempoor gRPC API: the JSON-RPC operations of the same names, with
+// typed messages and a block stream. See README.md ("gRPC API").
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: mempoor.proto
+
+package mempoorpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Tx struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sender        string                 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Recipient     string                 `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Fee           uint64                 `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	Gas           uint64                 `protobuf:"varint,5,opt,name=gas,proto3" json:"gas,omitempty"`
+	Payload       string                 `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	Amount        uint64                 `protobuf:"varint,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	ChainId       string                 `protobuf:"bytes,8,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	MaxFee        uint64                 `protobuf:"varint,9,opt,name=max_fee,json=maxFee,proto3" json:"max_fee,omitempty"`
+	Tip           uint64                 `protobuf:"varint,10,opt,name=tip,proto3" json:"tip,omitempty"`
+	Nonce         uint64                 `protobuf:"varint,11,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	ParentId      string                 `protobuf:"bytes,12,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	DependsOn     []string               `protobuf:"bytes,13,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	NotBefore     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	Lane          string                 `protobuf:"bytes,15,opt,name=lane,proto3" json:"lane,omitempty"` // "urgent", "normal", or "low"
+	Pool          string                 `protobuf:"bytes,16,opt,name=pool,proto3" json:"pool,omitempty"`
+	BundleId      string                 `protobuf:"bytes,17,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
+	BundleIndex   int64                  `protobuf:"varint,18,opt,name=bundle_index,json=bundleIndex,proto3" json:"bundle_index,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tx) Reset() {
+	*x = Tx{}
+	mi := &file_mempoor_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tx) ProtoMessage() {}
+
+func (x *Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tx.ProtoReflect.Descriptor instead.
+func (*Tx) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tx) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tx) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *Tx) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *Tx) GetFee() uint64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Tx) GetGas() uint64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+func (x *Tx) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *Tx) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Tx) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *Tx) GetMaxFee() uint64 {
+	if x != nil {
+		return x.MaxFee
+	}
+	return 0
+}
+
+func (x *Tx) GetTip() uint64 {
+	if x != nil {
+		return x.Tip
+	}
+	return 0
+}
+
+func (x *Tx) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Tx) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Tx) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *Tx) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *Tx) GetLane() string {
+	if x != nil {
+		return x.Lane
+	}
+	return ""
+}
+
+func (x *Tx) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *Tx) GetBundleId() string {
+	if x != nil {
+		return x.BundleId
+	}
+	return ""
+}
+
+func (x *Tx) GetBundleIndex() int64 {
+	if x != nil {
+		return x.BundleIndex
+	}
+	return 0
+}
+
+func (x *Tx) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Tx) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type AddTxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sender        string                 `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Payload       string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Nonce         uint64                 `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Fee           uint64                 `protobuf:"varint,5,opt,name=fee,proto3" json:"fee,omitempty"`
+	MaxFee        uint64                 `protobuf:"varint,6,opt,name=max_fee,json=maxFee,proto3" json:"max_fee,omitempty"` // dynamic fee; fee defaults to it
+	Tip           uint64                 `protobuf:"varint,7,opt,name=tip,proto3" json:"tip,omitempty"`
+	Gas           uint64                 `protobuf:"varint,8,opt,name=gas,proto3" json:"gas,omitempty"`
+	Amount        uint64                 `protobuf:"varint,9,opt,name=amount,proto3" json:"amount,omitempty"`
+	ChainId       string                 `protobuf:"bytes,10,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,11,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	DependsOn     []string               `protobuf:"bytes,12,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	NotBefore     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	Lane          string                 `protobuf:"bytes,14,opt,name=lane,proto3" json:"lane,omitempty"` // "" = normal
+	Pool          string                 `protobuf:"bytes,15,opt,name=pool,proto3" json:"pool,omitempty"` // "" = the default pool
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTxRequest) Reset() {
+	*x = AddTxRequest{}
+	mi := &file_mempoor_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTxRequest) ProtoMessage() {}
+
+func (x *AddTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTxRequest.ProtoReflect.Descriptor instead.
+func (*AddTxRequest) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddTxRequest) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *AddTxRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *AddTxRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *AddTxRequest) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *AddTxRequest) GetFee() uint64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *AddTxRequest) GetMaxFee() uint64 {
+	if x != nil {
+		return x.MaxFee
+	}
+	return 0
+}
+
+func (x *AddTxRequest) GetTip() uint64 {
+	if x != nil {
+		return x.Tip
+	}
+	return 0
+}
+
+func (x *AddTxRequest) GetGas() uint64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+func (x *AddTxRequest) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AddTxRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *AddTxRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *AddTxRequest) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *AddTxRequest) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *AddTxRequest) GetLane() string {
+	if x != nil {
+		return x.Lane
+	}
+	return ""
+}
+
+func (x *AddTxRequest) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+type AddTxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Orphan        bool                   `protobuf:"varint,2,opt,name=orphan,proto3" json:"orphan,omitempty"`
+	Scheduled     bool                   `protobuf:"varint,3,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	Merged        bool                   `protobuf:"varint,4,opt,name=merged,proto3" json:"merged,omitempty"`
+	Evicted       []string               `protobuf:"bytes,5,rep,name=evicted,proto3" json:"evicted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTxResponse) Reset() {
+	*x = AddTxResponse{}
+	mi := &file_mempoor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTxResponse) ProtoMessage() {}
+
+func (x *AddTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTxResponse.ProtoReflect.Descriptor instead.
+func (*AddTxResponse) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddTxResponse) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *AddTxResponse) GetOrphan() bool {
+	if x != nil {
+		return x.Orphan
+	}
+	return false
+}
+
+func (x *AddTxResponse) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+func (x *AddTxResponse) GetMerged() bool {
+	if x != nil {
+		return x.Merged
+	}
+	return false
+}
+
+func (x *AddTxResponse) GetEvicted() []string {
+	if x != nil {
+		return x.Evicted
+	}
+	return nil
+}
+
+type UpdateTxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fee           uint64                 `protobuf:"varint,2,opt,name=fee,proto3" json:"fee,omitempty"`       // new max fee for a dynamic-fee tx
+	Tip           *uint64                `protobuf:"varint,3,opt,name=tip,proto3,oneof" json:"tip,omitempty"` // dynamic-fee txs only; unset = keep
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTxRequest) Reset() {
+	*x = UpdateTxRequest{}
+	mi := &file_mempoor_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTxRequest) ProtoMessage() {}
+
+func (x *UpdateTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTxRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTxRequest) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateTxRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTxRequest) GetFee() uint64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *UpdateTxRequest) GetTip() uint64 {
+	if x != nil && x.Tip != nil {
+		return *x.Tip
+	}
+	return 0
+}
+
+type UpdateTxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTxResponse) Reset() {
+	*x = UpdateTxResponse{}
+	mi := &file_mempoor_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTxResponse) ProtoMessage() {}
+
+func (x *UpdateTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTxResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTxResponse) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{4}
+}
+
+type RemoveTxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTxRequest) Reset() {
+	*x = RemoveTxRequest{}
+	mi := &file_mempoor_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTxRequest) ProtoMessage() {}
+
+func (x *RemoveTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTxRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTxRequest) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoveTxRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemoveTxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTxResponse) Reset() {
+	*x = RemoveTxResponse{}
+	mi := &file_mempoor_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTxResponse) ProtoMessage() {}
+
+func (x *RemoveTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTxResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTxResponse) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{6}
+}
+
+type ListTxsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit  int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Sort   string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Pool   string                 `protobuf:"bytes,4,opt,name=pool,proto3" json:"pool,omitempty"` // "" = the default pool
+	// Optional filters; zero values do not filter.
+	Sender        string `protobuf:"bytes,5,opt,name=sender,proto3" json:"sender,omitempty"`
+	Recipient     string `protobuf:"bytes,6,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	MinFee        uint64 `protobuf:"varint,7,opt,name=min_fee,json=minFee,proto3" json:"min_fee,omitempty"`
+	MaxFee        uint64 `protobuf:"varint,8,opt,name=max_fee,json=maxFee,proto3" json:"max_fee,omitempty"`
+	MinGas        uint64 `protobuf:"varint,9,opt,name=min_gas,json=minGas,proto3" json:"min_gas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTxsRequest) Reset() {
+	*x = ListTxsRequest{}
+	mi := &file_mempoor_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTxsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTxsRequest) ProtoMessage() {}
+
+func (x *ListTxsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTxsRequest.ProtoReflect.Descriptor instead.
+func (*ListTxsRequest) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTxsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListTxsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTxsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListTxsRequest) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *ListTxsRequest) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *ListTxsRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *ListTxsRequest) GetMinFee() uint64 {
+	if x != nil {
+		return x.MinFee
+	}
+	return 0
+}
+
+func (x *ListTxsRequest) GetMaxFee() uint64 {
+	if x != nil {
+		return x.MaxFee
+	}
+	return 0
+}
+
+func (x *ListTxsRequest) GetMinGas() uint64 {
+	if x != nil {
+		return x.MinGas
+	}
+	return 0
+}
+
+type ListTxsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Tx                  `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTxsResponse) Reset() {
+	*x = ListTxsResponse{}
+	mi := &file_mempoor_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTxsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTxsResponse) ProtoMessage() {}
+
+func (x *ListTxsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTxsResponse.ProtoReflect.Descriptor instead.
+func (*ListTxsResponse) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListTxsResponse) GetTransactions() []*Tx {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTxsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetBlockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Block:
+	//
+	//	*GetBlockRequest_Height
+	//	*GetBlockRequest_Hash
+	Block         isGetBlockRequest_Block `protobuf_oneof:"block"`
+	Compression   string                  `protobuf:"bytes,3,opt,name=compression,proto3" json:"compression,omitempty"` // "" = plain payloads
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRequest) Reset() {
+	*x = GetBlockRequest{}
+	mi := &file_mempoor_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRequest) ProtoMessage() {}
+
+func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetBlockRequest) GetBlock() isGetBlockRequest_Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *GetBlockRequest) GetHeight() uint64 {
+	if x != nil {
+		if x, ok := x.Block.(*GetBlockRequest_Height); ok {
+			return x.Height
+		}
+	}
+	return 0
+}
+
+func (x *GetBlockRequest) GetHash() string {
+	if x != nil {
+		if x, ok := x.Block.(*GetBlockRequest_Hash); ok {
+			return x.Hash
+		}
+	}
+	return ""
+}
+
+func (x *GetBlockRequest) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+type isGetBlockRequest_Block interface {
+	isGetBlockRequest_Block()
+}
+
+type GetBlockRequest_Height struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3,oneof"`
+}
+
+type GetBlockRequest_Hash struct {
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3,oneof"` // hex
+}
+
+func (*GetBlockRequest_Height) isGetBlockRequest_Block() {}
+
+func (*GetBlockRequest_Hash) isGetBlockRequest_Block() {}
+
+type TxGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Indices       []int64                `protobuf:"varint,1,rep,packed,name=indices,proto3" json:"indices,omitempty"` // into Block.transactions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxGroup) Reset() {
+	*x = TxGroup{}
+	mi := &file_mempoor_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxGroup) ProtoMessage() {}
+
+func (x *TxGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxGroup.ProtoReflect.Descriptor instead.
+func (*TxGroup) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TxGroup) GetIndices() []int64 {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+type Block struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	PrevHash      string                 `protobuf:"bytes,2,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"` // hex
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TxCount       int64                  `protobuf:"varint,4,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	GasUsed       uint64                 `protobuf:"varint,5,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Hash          string                 `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash,omitempty"` // hex
+	Burned        uint64                 `protobuf:"varint,7,opt,name=burned,proto3" json:"burned,omitempty"`
+	Tipped        uint64                 `protobuf:"varint,8,opt,name=tipped,proto3" json:"tipped,omitempty"`
+	TotalFees     uint64                 `protobuf:"varint,9,opt,name=total_fees,json=totalFees,proto3" json:"total_fees,omitempty"`
+	AvgFeePerGas  uint64                 `protobuf:"varint,10,opt,name=avg_fee_per_gas,json=avgFeePerGas,proto3" json:"avg_fee_per_gas,omitempty"`
+	Proposer      string                 `protobuf:"bytes,11,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	ExtraData     []byte                 `protobuf:"bytes,12,opt,name=extra_data,json=extraData,proto3" json:"extra_data,omitempty"`
+	Extra         map[string]string      `protobuf:"bytes,13,rep,name=extra,proto3" json:"extra,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ChainId       string                 `protobuf:"bytes,14,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Transactions  []*Tx                  `protobuf:"bytes,15,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Groups        []*TxGroup             `protobuf:"bytes,16,rep,name=groups,proto3" json:"groups,omitempty"`
+	Compression   string                 `protobuf:"bytes,17,opt,name=compression,proto3" json:"compression,omitempty"` // codec of the payloads, if any
+	Signer        []byte                 `protobuf:"bytes,18,opt,name=signer,proto3" json:"signer,omitempty"`           // ed25519 public key
+	Signature     []byte                 `protobuf:"bytes,19,opt,name=signature,proto3" json:"signature,omitempty"`     // over hash
+	Pruned        bool                   `protobuf:"varint,20,opt,name=pruned,proto3" json:"pruned,omitempty"`          // transactions are stubs with only id and gas
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_mempoor_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Block) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Block) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *Block) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Block) GetTxCount() int64 {
+	if x != nil {
+		return x.TxCount
+	}
+	return 0
+}
+
+func (x *Block) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *Block) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Block) GetBurned() uint64 {
+	if x != nil {
+		return x.Burned
+	}
+	return 0
+}
+
+func (x *Block) GetTipped() uint64 {
+	if x != nil {
+		return x.Tipped
+	}
+	return 0
+}
+
+func (x *Block) GetTotalFees() uint64 {
+	if x != nil {
+		return x.TotalFees
+	}
+	return 0
+}
+
+func (x *Block) GetAvgFeePerGas() uint64 {
+	if x != nil {
+		return x.AvgFeePerGas
+	}
+	return 0
+}
+
+func (x *Block) GetProposer() string {
+	if x != nil {
+		return x.Proposer
+	}
+	return ""
+}
+
+func (x *Block) GetExtraData() []byte {
+	if x != nil {
+		return x.ExtraData
+	}
+	return nil
+}
+
+func (x *Block) GetExtra() map[string]string {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+func (x *Block) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *Block) GetTransactions() []*Tx {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *Block) GetGroups() []*TxGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *Block) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *Block) GetSigner() []byte {
+	if x != nil {
+		return x.Signer
+	}
+	return nil
+}
+
+func (x *Block) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *Block) GetPruned() bool {
+	if x != nil {
+		return x.Pruned
+	}
+	return false
+}
+
+type StreamBlocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromHeight    *uint64                `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3,oneof" json:"from_height,omitempty"` // unset = new blocks only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamBlocksRequest) Reset() {
+	*x = StreamBlocksRequest{}
+	mi := &file_mempoor_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBlocksRequest) ProtoMessage() {}
+
+func (x *StreamBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBlocksRequest.ProtoReflect.Descriptor instead.
+func (*StreamBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StreamBlocksRequest) GetFromHeight() uint64 {
+	if x != nil && x.FromHeight != nil {
+		return *x.FromHeight
+	}
+	return 0
+}
+
+type BlockEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Reorg         bool                   `protobuf:"varint,2,opt,name=reorg,proto3" json:"reorg,omitempty"` // block replaced blocks at or above its height
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockEvent) Reset() {
+	*x = BlockEvent{}
+	mi := &file_mempoor_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockEvent) ProtoMessage() {}
+
+func (x *BlockEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_mempoor_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockEvent.ProtoReflect.Descriptor instead.
+func (*BlockEvent) Descriptor() ([]byte, []int) {
+	return file_mempoor_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BlockEvent) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *BlockEvent) GetReorg() bool {
+	if x != nil {
+		return x.Reorg
+	}
+	return false
+}
+
+var File_mempoor_proto protoreflect.FileDescriptor
+
+const file_mempoor_proto_rawDesc = "" +
+	"\n" +
+	"\rmempoor.proto\x12\n" +
+	"mempoor.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd0\x04\n" +
+	"\x02Tx\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06sender\x18\x02 \x01(\tR\x06sender\x12\x1c\n" +
+	"\trecipient\x18\x03 \x01(\tR\trecipient\x12\x10\n" +
+	"\x03fee\x18\x04 \x01(\x04R\x03fee\x12\x10\n" +
+	"\x03gas\x18\x05 \x01(\x04R\x03gas\x12\x18\n" +
+	"\apayload\x18\x06 \x01(\tR\apayload\x12\x16\n" +
+	"\x06amount\x18\a \x01(\x04R\x06amount\x12\x19\n" +
+	"\bchain_id\x18\b \x01(\tR\achainId\x12\x17\n" +
+	"\amax_fee\x18\t \x01(\x04R\x06maxFee\x12\x10\n" +
+	"\x03tip\x18\n" +
+	" \x01(\x04R\x03tip\x12\x14\n" +
+	"\x05nonce\x18\v \x01(\x04R\x05nonce\x12\x1b\n" +
+	"\tparent_id\x18\f \x01(\tR\bparentId\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\r \x03(\tR\tdependsOn\x129\n" +
+	"\n" +
+	"not_before\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x12\x12\n" +
+	"\x04lane\x18\x0f \x01(\tR\x04lane\x12\x12\n" +
+	"\x04pool\x18\x10 \x01(\tR\x04pool\x12\x1b\n" +
+	"\tbundle_id\x18\x11 \x01(\tR\bbundleId\x12!\n" +
+	"\fbundle_index\x18\x12 \x01(\x03R\vbundleIndex\x129\n" +
+	"\n" +
+	"created_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\ttimestamp\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x95\x03\n" +
+	"\fAddTxRequest\x12\x16\n" +
+	"\x06sender\x18\x01 \x01(\tR\x06sender\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x12\x14\n" +
+	"\x05nonce\x18\x04 \x01(\x04R\x05nonce\x12\x10\n" +
+	"\x03fee\x18\x05 \x01(\x04R\x03fee\x12\x17\n" +
+	"\amax_fee\x18\x06 \x01(\x04R\x06maxFee\x12\x10\n" +
+	"\x03tip\x18\a \x01(\x04R\x03tip\x12\x10\n" +
+	"\x03gas\x18\b \x01(\x04R\x03gas\x12\x16\n" +
+	"\x06amount\x18\t \x01(\x04R\x06amount\x12\x19\n" +
+	"\bchain_id\x18\n" +
+	" \x01(\tR\achainId\x12\x1b\n" +
+	"\tparent_id\x18\v \x01(\tR\bparentId\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\f \x03(\tR\tdependsOn\x129\n" +
+	"\n" +
+	"not_before\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x12\x12\n" +
+	"\x04lane\x18\x0e \x01(\tR\x04lane\x12\x12\n" +
+	"\x04pool\x18\x0f \x01(\tR\x04pool\"\x8c\x01\n" +
+	"\rAddTxResponse\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x16\n" +
+	"\x06orphan\x18\x02 \x01(\bR\x06orphan\x12\x1c\n" +
+	"\tscheduled\x18\x03 \x01(\bR\tscheduled\x12\x16\n" +
+	"\x06merged\x18\x04 \x01(\bR\x06merged\x12\x18\n" +
+	"\aevicted\x18\x05 \x03(\tR\aevicted\"R\n" +
+	"\x0fUpdateTxRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03fee\x18\x02 \x01(\x04R\x03fee\x12\x15\n" +
+	"\x03tip\x18\x03 \x01(\x04H\x00R\x03tip\x88\x01\x01B\x06\n" +
+	"\x04_tip\"\x12\n" +
+	"\x10UpdateTxResponse\"!\n" +
+	"\x0fRemoveTxRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x12\n" +
+	"\x10RemoveTxResponse\"\xe7\x01\n" +
+	"\x0eListTxsRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x12\n" +
+	"\x04pool\x18\x04 \x01(\tR\x04pool\x12\x16\n" +
+	"\x06sender\x18\x05 \x01(\tR\x06sender\x12\x1c\n" +
+	"\trecipient\x18\x06 \x01(\tR\trecipient\x12\x17\n" +
+	"\amin_fee\x18\a \x01(\x04R\x06minFee\x12\x17\n" +
+	"\amax_fee\x18\b \x01(\x04R\x06maxFee\x12\x17\n" +
+	"\amin_gas\x18\t \x01(\x04R\x06minGas\"[\n" +
+	"\x0fListTxsResponse\x122\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x0e.mempoor.v1.TxR\ftransactions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"l\n" +
+	"\x0fGetBlockRequest\x12\x18\n" +
+	"\x06height\x18\x01 \x01(\x04H\x00R\x06height\x12\x14\n" +
+	"\x04hash\x18\x02 \x01(\tH\x00R\x04hash\x12 \n" +
+	"\vcompression\x18\x03 \x01(\tR\vcompressionB\a\n" +
+	"\x05block\"#\n" +
+	"\aTxGroup\x12\x18\n" +
+	"\aindices\x18\x01 \x03(\x03R\aindices\"\xcb\x05\n" +
+	"\x05Block\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x12\x1b\n" +
+	"\tprev_hash\x18\x02 \x01(\tR\bprevHash\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\btx_count\x18\x04 \x01(\x03R\atxCount\x12\x19\n" +
+	"\bgas_used\x18\x05 \x01(\x04R\agasUsed\x12\x12\n" +
+	"\x04hash\x18\x06 \x01(\tR\x04hash\x12\x16\n" +
+	"\x06burned\x18\a \x01(\x04R\x06burned\x12\x16\n" +
+	"\x06tipped\x18\b \x01(\x04R\x06tipped\x12\x1d\n" +
+	"\n" +
+	"total_fees\x18\t \x01(\x04R\ttotalFees\x12%\n" +
+	"\x0favg_fee_per_gas\x18\n" +
+	" \x01(\x04R\favgFeePerGas\x12\x1a\n" +
+	"\bproposer\x18\v \x01(\tR\bproposer\x12\x1d\n" +
+	"\n" +
+	"extra_data\x18\f \x01(\fR\textraData\x122\n" +
+	"\x05extra\x18\r \x03(\v2\x1c.mempoor.v1.Block.ExtraEntryR\x05extra\x12\x19\n" +
+	"\bchain_id\x18\x0e \x01(\tR\achainId\x122\n" +
+	"\ftransactions\x18\x0f \x03(\v2\x0e.mempoor.v1.TxR\ftransactions\x12+\n" +
+	"\x06groups\x18\x10 \x03(\v2\x13.mempoor.v1.TxGroupR\x06groups\x12 \n" +
+	"\vcompression\x18\x11 \x01(\tR\vcompression\x12\x16\n" +
+	"\x06signer\x18\x12 \x01(\fR\x06signer\x12\x1c\n" +
+	"\tsignature\x18\x13 \x01(\fR\tsignature\x12\x16\n" +
+	"\x06pruned\x18\x14 \x01(\bR\x06pruned\x1a8\n" +
+	"\n" +
+	"ExtraEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"K\n" +
+	"\x13StreamBlocksRequest\x12$\n" +
+	"\vfrom_height\x18\x01 \x01(\x04H\x00R\n" +
+	"fromHeight\x88\x01\x01B\x0e\n" +
+	"\f_from_height\"K\n" +
+	"\n" +
+	"BlockEvent\x12'\n" +
+	"\x05block\x18\x01 \x01(\v2\x11.mempoor.v1.BlockR\x05block\x12\x14\n" +
+	"\x05reorg\x18\x02 \x01(\bR\x05reorg2\xa0\x03\n" +
+	"\aMempoor\x12<\n" +
+	"\x05AddTx\x12\x18.mempoor.v1.AddTxRequest\x1a\x19.mempoor.v1.AddTxResponse\x12E\n" +
+	"\bUpdateTx\x12\x1b.mempoor.v1.UpdateTxRequest\x1a\x1c.mempoor.v1.UpdateTxResponse\x12E\n" +
+	"\bRemoveTx\x12\x1b.mempoor.v1.RemoveTxRequest\x1a\x1c.mempoor.v1.RemoveTxResponse\x12B\n" +
+	"\aListTxs\x12\x1a.mempoor.v1.ListTxsRequest\x1a\x1b.mempoor.v1.ListTxsResponse\x12:\n" +
+	"\bGetBlock\x12\x1b.mempoor.v1.GetBlockRequest\x1a\x11.mempoor.v1.Block\x12I\n" +
+	"\fStreamBlocks\x12\x1f.mempoor.v1.StreamBlocksRequest\x1a\x16.mempoor.v1.BlockEvent0\x01B\x17Z\x15mempoor/pkg/mempoorpbb\x06proto3"
+
+var (
+	file_mempoor_proto_rawDescOnce sync.Once
+	file_mempoor_proto_rawDescData []byte
+)
+
+func file_mempoor_proto_rawDescGZIP() []byte {
+	file_mempoor_proto_rawDescOnce.Do(func() {
+		file_mempoor_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mempoor_proto_rawDesc), len(file_mempoor_proto_rawDesc)))
+	})
+	return file_mempoor_proto_rawDescData
+}
+
+var file_mempoor_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_mempoor_proto_goTypes = []any{
+	(*Tx)(nil),                    // 0: mempoor.v1.Tx
+	(*AddTxRequest)(nil),          // 1: mempoor.v1.AddTxRequest
+	(*AddTxResponse)(nil),         // 2: mempoor.v1.AddTxResponse
+	(*UpdateTxRequest)(nil),       // 3: mempoor.v1.UpdateTxRequest
+	(*UpdateTxResponse)(nil),      // 4: mempoor.v1.UpdateTxResponse
+	(*RemoveTxRequest)(nil),       // 5: mempoor.v1.RemoveTxRequest
+	(*RemoveTxResponse)(nil),      // 6: mempoor.v1.RemoveTxResponse
+	(*ListTxsRequest)(nil),        // 7: mempoor.v1.ListTxsRequest
+	(*ListTxsResponse)(nil),       // 8: mempoor.v1.ListTxsResponse
+	(*GetBlockRequest)(nil),       // 9: mempoor.v1.GetBlockRequest
+	(*TxGroup)(nil),               // 10: mempoor.v1.TxGroup
+	(*Block)(nil),                 // 11: mempoor.v1.Block
+	(*StreamBlocksRequest)(nil),   // 12: mempoor.v1.StreamBlocksRequest
+	(*BlockEvent)(nil),            // 13: mempoor.v1.BlockEvent
+	nil,                           // 14: mempoor.v1.Block.ExtraEntry
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_mempoor_proto_depIdxs = []int32{
+	15, // 0: mempoor.v1.Tx.not_before:type_name -> google.protobuf.Timestamp
+	15, // 1: mempoor.v1.Tx.created_at:type_name -> google.protobuf.Timestamp
+	15, // 2: mempoor.v1.Tx.timestamp:type_name -> google.protobuf.Timestamp
+	15, // 3: mempoor.v1.AddTxRequest.not_before:type_name -> google.protobuf.Timestamp
+	0,  // 4: mempoor.v1.ListTxsResponse.transactions:type_name -> mempoor.v1.Tx
+	15, // 5: mempoor.v1.Block.timestamp:type_name -> google.protobuf.Timestamp
+	14, // 6: mempoor.v1.Block.extra:type_name -> mempoor.v1.Block.ExtraEntry
+	0,  // 7: mempoor.v1.Block.transactions:type_name -> mempoor.v1.Tx
+	10, // 8: mempoor.v1.Block.groups:type_name -> mempoor.v1.TxGroup
+	11, // 9: mempoor.v1.BlockEvent.block:type_name -> mempoor.v1.Block
+	1,  // 10: mempoor.v1.Mempoor.AddTx:input_type -> mempoor.v1.AddTxRequest
+	3,  // 11: mempoor.v1.Mempoor.UpdateTx:input_type -> mempoor.v1.UpdateTxRequest
+	5,  // 12: mempoor.v1.Mempoor.RemoveTx:input_type -> mempoor.v1.RemoveTxRequest
+	7,  // 13: mempoor.v1.Mempoor.ListTxs:input_type -> mempoor.v1.ListTxsRequest
+	9,  // 14: mempoor.v1.Mempoor.GetBlock:input_type -> mempoor.v1.GetBlockRequest
+	12, // 15: mempoor.v1.Mempoor.StreamBlocks:input_type -> mempoor.v1.StreamBlocksRequest
+	2,  // 16: mempoor.v1.Mempoor.AddTx:output_type -> mempoor.v1.AddTxResponse
+	4,  // 17: mempoor.v1.Mempoor.UpdateTx:output_type -> mempoor.v1.UpdateTxResponse
+	6,  // 18: mempoor.v1.Mempoor.RemoveTx:output_type -> mempoor.v1.RemoveTxResponse
+	8,  // 19: mempoor.v1.Mempoor.ListTxs:output_type -> mempoor.v1.ListTxsResponse
+	11, // 20: mempoor.v1.Mempoor.GetBlock:output_type -> mempoor.v1.Block
+	13, // 21: mempoor.v1.Mempoor.StreamBlocks:output_type -> mempoor.v1.BlockEvent
+	16, // [16:22] is the sub-list for method output_type
+	10, // [10:16] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_mempoor_proto_init() }
+func file_mempoor_proto_init() {
+	if File_mempoor_proto != nil {
+		return
+	}
+	file_mempoor_proto_msgTypes[3].OneofWrappers = []any{}
+	file_mempoor_proto_msgTypes[9].OneofWrappers = []any{
+		(*GetBlockRequest_Height)(nil),
+		(*GetBlockRequest_Hash)(nil),
+	}
+	file_mempoor_proto_msgTypes[12].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mempoor_proto_rawDesc), len(file_mempoor_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mempoor_proto_goTypes,
+		DependencyIndexes: file_mempoor_proto_depIdxs,
+		MessageInfos:      file_mempoor_proto_msgTypes,
+	}.Build()
+	File_mempoor_proto = out.File
+	file_mempoor_proto_goTypes = nil
+	file_mempoor_proto_depIdxs = nil
+}
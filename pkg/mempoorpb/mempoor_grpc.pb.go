@@ -0,0 +1,332 @@
+// The mempoor gRPC API: the JSON-RPC operations of the same names, with
+// typed messages and a block stream. See README.md ("gRPC API").
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: mempoor.proto
+
+package mempoorpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Mempoor_AddTx_FullMethodName        = "/mempoor.v1.Mempoor/AddTx"
+	Mempoor_UpdateTx_FullMethodName     = "/mempoor.v1.Mempoor/UpdateTx"
+	Mempoor_RemoveTx_FullMethodName     = "/mempoor.v1.Mempoor/RemoveTx"
+	Mempoor_ListTxs_FullMethodName      = "/mempoor.v1.Mempoor/ListTxs"
+	Mempoor_GetBlock_FullMethodName     = "/mempoor.v1.Mempoor/GetBlock"
+	Mempoor_StreamBlocks_FullMethodName = "/mempoor.v1.Mempoor/StreamBlocks"
+)
+
+// MempoorClient is the client API for Mempoor service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MempoorClient interface {
+	// AddTx admits a tx to a pool, as tx.add.
+	AddTx(ctx context.Context, in *AddTxRequest, opts ...grpc.CallOption) (*AddTxResponse, error)
+	// UpdateTx replaces a pending tx's fee, as tx.update.
+	UpdateTx(ctx context.Context, in *UpdateTxRequest, opts ...grpc.CallOption) (*UpdateTxResponse, error)
+	// RemoveTx deletes a pending tx, as tx.remove.
+	RemoveTx(ctx context.Context, in *RemoveTxRequest, opts ...grpc.CallOption) (*RemoveTxResponse, error)
+	// ListTxs pages through a pool, as tx.list.
+	ListTxs(ctx context.Context, in *ListTxsRequest, opts ...grpc.CallOption) (*ListTxsResponse, error)
+	// GetBlock returns a block by height or hash, as block.get.
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// StreamBlocks sends every block that joins the chain, starting with
+	// the stored blocks from from_height if set.
+	StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockEvent], error)
+}
+
+type mempoorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMempoorClient(cc grpc.ClientConnInterface) MempoorClient {
+	return &mempoorClient{cc}
+}
+
+func (c *mempoorClient) AddTx(ctx context.Context, in *AddTxRequest, opts ...grpc.CallOption) (*AddTxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTxResponse)
+	err := c.cc.Invoke(ctx, Mempoor_AddTx_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoorClient) UpdateTx(ctx context.Context, in *UpdateTxRequest, opts ...grpc.CallOption) (*UpdateTxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTxResponse)
+	err := c.cc.Invoke(ctx, Mempoor_UpdateTx_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoorClient) RemoveTx(ctx context.Context, in *RemoveTxRequest, opts ...grpc.CallOption) (*RemoveTxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTxResponse)
+	err := c.cc.Invoke(ctx, Mempoor_RemoveTx_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoorClient) ListTxs(ctx context.Context, in *ListTxsRequest, opts ...grpc.CallOption) (*ListTxsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTxsResponse)
+	err := c.cc.Invoke(ctx, Mempoor_ListTxs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoorClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, Mempoor_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoorClient) StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Mempoor_ServiceDesc.Streams[0], Mempoor_StreamBlocks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamBlocksRequest, BlockEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Mempoor_StreamBlocksClient = grpc.ServerStreamingClient[BlockEvent]
+
+// MempoorServer is the server API for Mempoor service.
+// All implementations must embed UnimplementedMempoorServer
+// for forward compatibility.
+type MempoorServer interface {
+	// AddTx admits a tx to a pool, as tx.add.
+	AddTx(context.Context, *AddTxRequest) (*AddTxResponse, error)
+	// UpdateTx replaces a pending tx's fee, as tx.update.
+	UpdateTx(context.Context, *UpdateTxRequest) (*UpdateTxResponse, error)
+	// RemoveTx deletes a pending tx, as tx.remove.
+	RemoveTx(context.Context, *RemoveTxRequest) (*RemoveTxResponse, error)
+	// ListTxs pages through a pool, as tx.list.
+	ListTxs(context.Context, *ListTxsRequest) (*ListTxsResponse, error)
+	// GetBlock returns a block by height or hash, as block.get.
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	// StreamBlocks sends every block that joins the chain, starting with
+	// the stored blocks from from_height if set.
+	StreamBlocks(*StreamBlocksRequest, grpc.ServerStreamingServer[BlockEvent]) error
+	mustEmbedUnimplementedMempoorServer()
+}
+
+// UnimplementedMempoorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMempoorServer struct{}
+
+func (UnimplementedMempoorServer) AddTx(context.Context, *AddTxRequest) (*AddTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTx not implemented")
+}
+func (UnimplementedMempoorServer) UpdateTx(context.Context, *UpdateTxRequest) (*UpdateTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTx not implemented")
+}
+func (UnimplementedMempoorServer) RemoveTx(context.Context, *RemoveTxRequest) (*RemoveTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTx not implemented")
+}
+func (UnimplementedMempoorServer) ListTxs(context.Context, *ListTxsRequest) (*ListTxsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTxs not implemented")
+}
+func (UnimplementedMempoorServer) GetBlock(context.Context, *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedMempoorServer) StreamBlocks(*StreamBlocksRequest, grpc.ServerStreamingServer[BlockEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBlocks not implemented")
+}
+func (UnimplementedMempoorServer) mustEmbedUnimplementedMempoorServer() {}
+func (UnimplementedMempoorServer) testEmbeddedByValue()                 {}
+
+// UnsafeMempoorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MempoorServer will
+// result in compilation errors.
+type UnsafeMempoorServer interface {
+	mustEmbedUnimplementedMempoorServer()
+}
+
+func RegisterMempoorServer(s grpc.ServiceRegistrar, srv MempoorServer) {
+	// If the following call pancis, it indicates UnimplementedMempoorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Mempoor_ServiceDesc, srv)
+}
+
+func _Mempoor_AddTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoorServer).AddTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mempoor_AddTx_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoorServer).AddTx(ctx, req.(*AddTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mempoor_UpdateTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoorServer).UpdateTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mempoor_UpdateTx_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoorServer).UpdateTx(ctx, req.(*UpdateTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mempoor_RemoveTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoorServer).RemoveTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mempoor_RemoveTx_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoorServer).RemoveTx(ctx, req.(*RemoveTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mempoor_ListTxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTxsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoorServer).ListTxs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mempoor_ListTxs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoorServer).ListTxs(ctx, req.(*ListTxsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mempoor_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoorServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mempoor_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoorServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mempoor_StreamBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MempoorServer).StreamBlocks(m, &grpc.GenericServerStream[StreamBlocksRequest, BlockEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Mempoor_StreamBlocksServer = grpc.ServerStreamingServer[BlockEvent]
+
+// Mempoor_ServiceDesc is the grpc.ServiceDesc for Mempoor service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Mempoor_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mempoor.v1.Mempoor",
+	HandlerType: (*MempoorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddTx",
+			Handler:    _Mempoor_AddTx_Handler,
+		},
+		{
+			MethodName: "UpdateTx",
+			Handler:    _Mempoor_UpdateTx_Handler,
+		},
+		{
+			MethodName: "RemoveTx",
+			Handler:    _Mempoor_RemoveTx_Handler,
+		},
+		{
+			MethodName: "ListTxs",
+			Handler:    _Mempoor_ListTxs_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _Mempoor_GetBlock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBlocks",
+			Handler:       _Mempoor_StreamBlocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mempoor.proto",
+}